@@ -0,0 +1,438 @@
+// Command mockgen writes a mock of an interface that records its calls. It is
+// run by go generate in the service packages, Ex:
+//
+//	go run ../../internal/mockgen -source api.go -package helixmock -out helixmock/mock.go
+//
+// The mock is a Client struct with a Func field per method, called when set,
+// and Calls, CallsTo and Reset methods to inspect the calls made.
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ProjectImportPath is the import path of this repository.
+const ProjectImportPath = "github.com/catsby/go-twitch"
+
+func main() {
+	source := flag.String("source", "api.go", "file declaring the interfaces")
+	iface := flag.String("interface", "API", "interface to mock")
+	pkg := flag.String("package", "", "package name of the mock")
+	out := flag.String("out", "", "file to write the mock to")
+	flag.Parse()
+
+	if *pkg == "" || *out == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	importPath, err := importPathOf(filepath.Dir(*source))
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	src, err := generate(*source, importPath, *iface, *pkg)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(*out), 0755); err != nil {
+		log.Fatal(err)
+	}
+	if err := ioutil.WriteFile(*out, src, 0644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// importPathOf returns the import path of the package in dir, found from its
+// path below the repository root, the first parent holding the internal
+// directory.
+func importPathOf(dir string) (string, error) {
+	abs, err := filepath.Abs(dir)
+	if err != nil {
+		return "", err
+	}
+	for root := abs; root != filepath.Dir(root); root = filepath.Dir(root) {
+		if _, err := os.Stat(filepath.Join(root, "internal", "mockgen")); err == nil {
+			rel, err := filepath.Rel(root, abs)
+			if err != nil {
+				return "", err
+			}
+			return ProjectImportPath + "/" + filepath.ToSlash(rel), nil
+		}
+	}
+	return "", fmt.Errorf("%s is not in the repository", dir)
+}
+
+// method is a method of the mocked interface.
+type method struct {
+	Name      string
+	Interface string
+	Params    []*ast.Field
+	Results   []*ast.Field
+	Variadic  bool
+}
+
+// generate returns the source of a mock of the interface named iface,
+// declared in the file source of the package with the given import path.
+func generate(source, importPath, iface, pkg string) ([]byte, error) {
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, source, nil, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	interfaces := make(map[string]*ast.InterfaceType)
+	for _, decl := range f.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok || gen.Tok != token.TYPE {
+			continue
+		}
+		for _, spec := range gen.Specs {
+			ts := spec.(*ast.TypeSpec)
+			if it, ok := ts.Type.(*ast.InterfaceType); ok {
+				interfaces[ts.Name.Name] = it
+			}
+		}
+	}
+	if interfaces[iface] == nil {
+		return nil, fmt.Errorf("%s: no interface %s", source, iface)
+	}
+
+	var methods []*method
+	var collect func(name string) error
+	collect = func(name string) error {
+		it, ok := interfaces[name]
+		if !ok {
+			return fmt.Errorf("%s: no interface %s", source, name)
+		}
+		for _, field := range it.Methods.List {
+			ft, ok := field.Type.(*ast.FuncType)
+			if !ok {
+				ident, ok := field.Type.(*ast.Ident)
+				if !ok {
+					return fmt.Errorf("%s: cannot mock embedded %T in %s", source, field.Type, name)
+				}
+				if err := collect(ident.Name); err != nil {
+					return err
+				}
+				continue
+			}
+
+			m := &method{Name: field.Names[0].Name, Interface: name}
+			if ft.Params != nil {
+				m.Params = ft.Params.List
+				if n := len(m.Params); n > 0 {
+					_, m.Variadic = m.Params[n-1].Type.(*ast.Ellipsis)
+				}
+			}
+			if ft.Results != nil {
+				m.Results = ft.Results.List
+			}
+			methods = append(methods, m)
+		}
+		return nil
+	}
+	if err := collect(iface); err != nil {
+		return nil, err
+	}
+
+	g := &generator{
+		pkg:     f.Name.Name,
+		imports: make(map[string]string),
+		used:    map[string]bool{importPath: true, "sync": true},
+	}
+	for _, imp := range f.Imports {
+		path, _ := strconv.Unquote(imp.Path.Value)
+		name := path[strings.LastIndex(path, "/")+1:]
+		if imp.Name != nil {
+			name = imp.Name.Name
+		}
+		g.imports[name] = path
+	}
+
+	var body bytes.Buffer
+	g.writeMethods(&body, methods)
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "// Code generated by internal/mockgen from %s. DO NOT EDIT.\n\n", filepath.Base(source))
+	fmt.Fprintf(&buf, "// Package %s provides a mock of %s.%s that records its calls.\n", pkg, g.pkg, iface)
+	fmt.Fprintf(&buf, "package %s\n\n", pkg)
+
+	// Standard library imports come first, as goimports groups them.
+	var std, other []string
+	for path := range g.used {
+		if strings.Contains(strings.SplitN(path, "/", 2)[0], ".") {
+			other = append(other, path)
+		} else {
+			std = append(std, path)
+		}
+	}
+	sort.Strings(std)
+	sort.Strings(other)
+	buf.WriteString("import (\n")
+	for _, path := range std {
+		fmt.Fprintf(&buf, "\t%q\n", path)
+	}
+	if len(std) > 0 && len(other) > 0 {
+		buf.WriteString("\n")
+	}
+	for _, path := range other {
+		fmt.Fprintf(&buf, "\t%q\n", path)
+	}
+	buf.WriteString(")\n\n")
+
+	fmt.Fprintf(&buf, "var _ %s.%s = (*Client)(nil)\n\n", g.pkg, iface)
+	fmt.Fprintf(&buf, `// Call is a call made to a Client.
+type Call struct {
+	Method string
+	Args   []interface{}
+}
+
+// Client is a mock of %[1]s.%[2]s. Each method calls the matching Func field
+// if it is set, and returns zero values otherwise. Every call is recorded.
+type Client struct {
+`, g.pkg, iface)
+	for _, m := range methods {
+		fmt.Fprintf(&buf, "\t%sFunc func%s\n", m.Name, g.signature(m))
+	}
+	buf.WriteString(`
+	mu    sync.Mutex
+	calls []Call
+}
+
+// Calls returns every call made, oldest first.
+func (m *Client) Calls() []Call {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	out := make([]Call, len(m.calls))
+	copy(out, m.calls)
+	return out
+}
+
+// CallsTo returns the calls made to the named method, oldest first.
+func (m *Client) CallsTo(method string) []Call {
+	var out []Call
+	for _, c := range m.Calls() {
+		if c.Method == method {
+			out = append(out, c)
+		}
+	}
+	return out
+}
+
+// Reset forgets the calls made so far.
+func (m *Client) Reset() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.calls = nil
+}
+
+func (m *Client) record(method string, args ...interface{}) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.calls = append(m.calls, Call{Method: method, Args: args})
+}
+`)
+	buf.Write(body.Bytes())
+
+	return format.Source(buf.Bytes())
+}
+
+type generator struct {
+	// pkg is the name of the package declaring the interfaces.
+	pkg string
+
+	// imports maps the names of the source file's imports to their paths,
+	// and used is the set of paths the mock needs.
+	imports map[string]string
+	used    map[string]bool
+}
+
+func (g *generator) writeMethods(w *bytes.Buffer, methods []*method) {
+	for _, m := range methods {
+		var names []string
+		n := 0
+		for _, p := range m.Params {
+			count := len(p.Names)
+			if count == 0 {
+				count = 1
+			}
+			for k := 0; k < count; k++ {
+				if len(p.Names) > 0 && p.Names[k].Name != "_" {
+					names = append(names, p.Names[k].Name)
+				} else {
+					names = append(names, fmt.Sprintf("p%d", n))
+				}
+				n++
+			}
+		}
+
+		args := strings.Join(names, ", ")
+		if m.Variadic {
+			args += "..."
+		}
+
+		var zeros []string
+		for _, r := range m.Results {
+			count := len(r.Names)
+			if count == 0 {
+				count = 1
+			}
+			for k := 0; k < count; k++ {
+				zeros = append(zeros, g.zero(r.Type))
+			}
+		}
+
+		fmt.Fprintf(w, "\n// %s implements %s.%s.\n", m.Name, g.pkg, m.Interface)
+		fmt.Fprintf(w, "func (m *Client) %s%s {\n", m.Name, g.namedSignature(m, names))
+		if len(names) > 0 {
+			fmt.Fprintf(w, "\tm.record(%q, %s)\n", m.Name, strings.Join(names, ", "))
+		} else {
+			fmt.Fprintf(w, "\tm.record(%q)\n", m.Name)
+		}
+		fmt.Fprintf(w, "\tif m.%sFunc != nil {\n", m.Name)
+		if len(zeros) > 0 {
+			fmt.Fprintf(w, "\t\treturn m.%sFunc(%s)\n\t}\n", m.Name, args)
+			fmt.Fprintf(w, "\treturn %s\n", strings.Join(zeros, ", "))
+		} else {
+			fmt.Fprintf(w, "\t\tm.%sFunc(%s)\n\t}\n", m.Name, args)
+		}
+		w.WriteString("}\n")
+	}
+}
+
+// signature returns the parameters and results of a method, for a Func
+// field.
+func (g *generator) signature(m *method) string {
+	return "(" + g.fields(m.Params, nil) + ")" + g.results(m)
+}
+
+// namedSignature returns the parameters, named names, and results of a
+// method.
+func (g *generator) namedSignature(m *method, names []string) string {
+	return "(" + g.fields(m.Params, names) + ")" + g.results(m)
+}
+
+func (g *generator) results(m *method) string {
+	if len(m.Results) == 0 {
+		return ""
+	}
+	r := g.fields(m.Results, nil)
+	if len(m.Results) == 1 && len(m.Results[0].Names) == 0 {
+		return " " + r
+	}
+	return " (" + r + ")"
+}
+
+// fields returns a field list, with the given names or the original ones.
+func (g *generator) fields(list []*ast.Field, names []string) string {
+	var parts []string
+	n := 0
+	for _, f := range list {
+		count := len(f.Names)
+		if count == 0 {
+			count = 1
+		}
+		for k := 0; k < count; k++ {
+			typ := g.typeString(f.Type)
+			switch {
+			case names != nil:
+				parts = append(parts, names[n]+" "+typ)
+			case len(f.Names) > 0:
+				parts = append(parts, f.Names[k].Name+" "+typ)
+			default:
+				parts = append(parts, typ)
+			}
+			n++
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+// typeString returns a type expression, with the names declared in the source
+// package qualified by it.
+func (g *generator) typeString(e ast.Expr) string {
+	switch t := e.(type) {
+	case *ast.Ident:
+		if ast.IsExported(t.Name) {
+			return g.pkg + "." + t.Name
+		}
+		return t.Name
+	case *ast.StarExpr:
+		return "*" + g.typeString(t.X)
+	case *ast.SelectorExpr:
+		name := t.X.(*ast.Ident).Name
+		if path, ok := g.imports[name]; ok {
+			g.used[path] = true
+		}
+		return name + "." + t.Sel.Name
+	case *ast.ArrayType:
+		if t.Len != nil {
+			return "[" + t.Len.(*ast.BasicLit).Value + "]" + g.typeString(t.Elt)
+		}
+		return "[]" + g.typeString(t.Elt)
+	case *ast.MapType:
+		return "map[" + g.typeString(t.Key) + "]" + g.typeString(t.Value)
+	case *ast.Ellipsis:
+		return "..." + g.typeString(t.Elt)
+	case *ast.InterfaceType:
+		return "interface{}"
+	case *ast.ChanType:
+		switch t.Dir {
+		case ast.SEND:
+			return "chan<- " + g.typeString(t.Value)
+		case ast.RECV:
+			return "<-chan " + g.typeString(t.Value)
+		}
+		return "chan " + g.typeString(t.Value)
+	case *ast.FuncType:
+		m := &method{}
+		if t.Params != nil {
+			m.Params = t.Params.List
+		}
+		if t.Results != nil {
+			m.Results = t.Results.List
+		}
+		return "func" + g.signature(m)
+	}
+	panic(fmt.Sprintf("mockgen: unsupported type %T", e))
+}
+
+// zero returns the zero value of a type.
+func (g *generator) zero(e ast.Expr) string {
+	switch t := e.(type) {
+	case *ast.StarExpr, *ast.MapType, *ast.InterfaceType, *ast.ChanType, *ast.FuncType:
+		return "nil"
+	case *ast.ArrayType:
+		if t.Len == nil {
+			return "nil"
+		}
+	case *ast.Ident:
+		switch t.Name {
+		case "error":
+			return "nil"
+		case "string":
+			return `""`
+		case "bool":
+			return "false"
+		case "int", "int8", "int16", "int32", "int64", "uint", "uint8", "uint16",
+			"uint32", "uint64", "uintptr", "float32", "float64", "byte", "rune":
+			return "0"
+		}
+	}
+	return "*new(" + g.typeString(e) + ")"
+}
@@ -0,0 +1,41 @@
+package main
+
+import (
+	"bytes"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+)
+
+// TestGenerate_upToDate fails when a checked in mock is out of date with its
+// interfaces. Run go generate ./... to update them.
+func TestGenerate_upToDate(t *testing.T) {
+	cases := []struct {
+		Source string
+		Pkg    string
+		Mock   string
+	}{
+		{"../../service/helix/api.go", "helixmock", "../../service/helix/helixmock/mock.go"},
+		{"../../service/kraken/api.go", "krakenmock", "../../service/kraken/krakenmock/mock.go"},
+	}
+
+	for _, c := range cases {
+		importPath, err := importPathOf(filepath.Dir(c.Source))
+		if err != nil {
+			t.Fatalf("Error finding the import path of %s: %s", c.Source, err)
+		}
+
+		got, err := generate(c.Source, importPath, "API", c.Pkg)
+		if err != nil {
+			t.Fatalf("Error generating %s: %s", c.Mock, err)
+		}
+
+		want, err := ioutil.ReadFile(c.Mock)
+		if err != nil {
+			t.Fatalf("Error reading %s: %s", c.Mock, err)
+		}
+		if !bytes.Equal(got, want) {
+			t.Errorf("%s is out of date, run go generate ./...", c.Mock)
+		}
+	}
+}
@@ -0,0 +1,195 @@
+// Package chat exposes helpers for working with Twitch chat, which is served
+// over IRC with the IRCv3 message tags extension. Twitch uses tags to attach
+// metadata such as badges, emotes and cheered bits to each message.
+//
+// See:
+//  - https://dev.twitch.tv/docs/irc
+//  - https://ircv3.net/specs/extensions/message-tags
+package chat
+
+import (
+	"errors"
+	"strings"
+)
+
+// ErrEmptyMessage is an error that is returned when parsing a line that has no
+// content.
+var ErrEmptyMessage = errors.New("Empty IRC message")
+
+// ErrInvalidMessage is an error that is returned when parsing a line that
+// contains a CR, LF or NUL character before its end, or whose command is not a
+// word or numeric.
+var ErrInvalidMessage = errors.New("Invalid IRC message")
+
+// ErrMissingCommand is an error that is returned when parsing a line that has
+// tags or a prefix, but no command.
+var ErrMissingCommand = errors.New("Missing IRC command")
+
+// Prefix is the source of a message, in the form nick!user@host. Twitch sends
+// messages from users as `<login>!<login>@<login>.tmi.twitch.tv`, and server
+// messages as `tmi.twitch.tv`.
+type Prefix struct {
+	Name string
+	User string
+	Host string
+}
+
+// String returns the prefix in its wire format, without the leading colon.
+func (p *Prefix) String() string {
+	s := p.Name
+	if p.User != "" {
+		s += "!" + p.User
+	}
+	if p.Host != "" {
+		s += "@" + p.Host
+	}
+	return s
+}
+
+// Message represents a single IRC line.
+type Message struct {
+	// Tags holds the unescaped IRCv3 tags sent with the message.
+	Tags Tags
+
+	// Prefix is the source of the message, nil if the line had none.
+	Prefix *Prefix
+
+	// Command is the IRC command or numeric, Ex: PRIVMSG, ROOMSTATE, 001
+	Command string
+
+	// Params are the command parameters. The trailing parameter, if any, is
+	// the last element.
+	Params []string
+}
+
+// ParseMessage parses a single raw IRC line. Any trailing CR/LF is ignored.
+func ParseMessage(line string) (*Message, error) {
+	line = strings.TrimRight(line, "\r\n")
+	if strings.TrimLeft(line, " ") == "" {
+		return nil, ErrEmptyMessage
+	}
+	if strings.ContainsAny(line, "\r\n\x00") {
+		return nil, ErrInvalidMessage
+	}
+
+	m := new(Message)
+
+	if strings.HasPrefix(line, "@") {
+		var raw string
+		raw, line = splitWord(line[1:])
+		m.Tags = parseTags(raw)
+	}
+
+	if strings.HasPrefix(line, ":") {
+		var raw string
+		raw, line = splitWord(line[1:])
+		m.Prefix = parsePrefix(raw)
+	}
+
+	m.Command, line = splitWord(line)
+	if m.Command == "" {
+		return nil, ErrMissingCommand
+	}
+	if !isCommand(m.Command) {
+		return nil, ErrInvalidMessage
+	}
+
+	for line != "" {
+		if strings.HasPrefix(line, ":") {
+			m.Params = append(m.Params, line[1:])
+			break
+		}
+
+		var p string
+		p, line = splitWord(line)
+		m.Params = append(m.Params, p)
+	}
+
+	return m, nil
+}
+
+// Channel returns the channel the message was sent to, without the leading
+// '#', or an empty string if the first parameter is not a channel.
+func (m *Message) Channel() string {
+	if len(m.Params) == 0 || !strings.HasPrefix(m.Params[0], "#") {
+		return ""
+	}
+	return m.Params[0][1:]
+}
+
+// Text returns the trailing parameter of the message, which is the chat text
+// for PRIVMSG, WHISPER and USERNOTICE messages.
+func (m *Message) Text() string {
+	if len(m.Params) < 2 {
+		return ""
+	}
+	return m.Params[len(m.Params)-1]
+}
+
+// String serializes the message into its wire format, escaping tag values as
+// needed. The returned string does not include the terminating CRLF.
+func (m *Message) String() string {
+	var b strings.Builder
+
+	if len(m.Tags) > 0 {
+		b.WriteByte('@')
+		b.WriteString(m.Tags.String())
+		b.WriteByte(' ')
+	}
+
+	if m.Prefix != nil {
+		b.WriteByte(':')
+		b.WriteString(m.Prefix.String())
+		b.WriteByte(' ')
+	}
+
+	b.WriteString(m.Command)
+
+	for i, p := range m.Params {
+		b.WriteByte(' ')
+		// The last parameter is sent as a trailing parameter if it could not
+		// otherwise be read back as a single parameter.
+		if i == len(m.Params)-1 && (p == "" || strings.HasPrefix(p, ":") || strings.Contains(p, " ")) {
+			b.WriteByte(':')
+		}
+		b.WriteString(p)
+	}
+
+	return b.String()
+}
+
+// splitWord returns the text up to the first space, and the remainder with
+// any leading spaces removed.
+func splitWord(s string) (string, string) {
+	i := strings.IndexByte(s, ' ')
+	if i < 0 {
+		return s, ""
+	}
+	return s[:i], strings.TrimLeft(s[i+1:], " ")
+}
+
+// isCommand reports whether s is a valid command, either letters or a
+// numeric reply.
+func isCommand(s string) bool {
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if !('a' <= c && c <= 'z' || 'A' <= c && c <= 'Z' || '0' <= c && c <= '9') {
+			return false
+		}
+	}
+	return true
+}
+
+func parsePrefix(raw string) *Prefix {
+	p := new(Prefix)
+	if i := strings.IndexByte(raw, '@'); i >= 0 {
+		p.Host = raw[i+1:]
+		raw = raw[:i]
+	}
+	if i := strings.IndexByte(raw, '!'); i >= 0 {
+		p.User = raw[i+1:]
+		raw = raw[:i]
+	}
+	p.Name = raw
+	return p
+}
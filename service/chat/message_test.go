@@ -0,0 +1,213 @@
+package chat
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestMessage_Parse_basic(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		Label    string
+		Line     string
+		Expected *Message
+	}{
+		{
+			Label: "Ping",
+			Line:  "PING :tmi.twitch.tv\r\n",
+			Expected: &Message{
+				Command: "PING",
+				Params:  []string{"tmi.twitch.tv"},
+			},
+		},
+		{
+			Label: "Privmsg",
+			Line:  ":ronni!ronni@ronni.tmi.twitch.tv PRIVMSG #dallas :Kappa Keepo Kappa",
+			Expected: &Message{
+				Prefix: &Prefix{
+					Name: "ronni",
+					User: "ronni",
+					Host: "ronni.tmi.twitch.tv",
+				},
+				Command: "PRIVMSG",
+				Params:  []string{"#dallas", "Kappa Keepo Kappa"},
+			},
+		},
+		{
+			Label: "Tags",
+			Line:  `@display-name=Ronni;system-msg=ronni\shas\ssubscribed\:\sthanks\\ :tmi.twitch.tv USERNOTICE #dallas`,
+			Expected: &Message{
+				Tags: Tags{
+					"display-name": "Ronni",
+					"system-msg":   `ronni has subscribed; thanks\`,
+				},
+				Prefix:  &Prefix{Name: "tmi.twitch.tv"},
+				Command: "USERNOTICE",
+				Params:  []string{"#dallas"},
+			},
+		},
+		{
+			Label: "Numeric",
+			Line:  ":tmi.twitch.tv 001 ronni :Welcome, GLHF!",
+			Expected: &Message{
+				Prefix:  &Prefix{Name: "tmi.twitch.tv"},
+				Command: "001",
+				Params:  []string{"ronni", "Welcome, GLHF!"},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Label, func(t *testing.T) {
+			m, err := ParseMessage(tc.Line)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !reflect.DeepEqual(m, tc.Expected) {
+				t.Fatalf("Error in matching message, got: \n%#v\n\nexpected:\n%#v\n\n", m, tc.Expected)
+			}
+		})
+	}
+}
+
+func TestMessage_Parse_errors(t *testing.T) {
+	t.Parallel()
+
+	if _, err := ParseMessage("\r\n"); err != ErrEmptyMessage {
+		t.Fatalf("Expected ErrEmptyMessage, got (%v)", err)
+	}
+
+	if _, err := ParseMessage("@badges= :tmi.twitch.tv"); err != ErrMissingCommand {
+		t.Fatalf("Expected ErrMissingCommand, got (%v)", err)
+	}
+}
+
+func TestMessage_String(t *testing.T) {
+	t.Parallel()
+
+	m := &Message{
+		Tags: Tags{
+			"reply-parent-msg-id": "b34ccfc7-4977-403a-8a94-33c6bac34fb8",
+			"client-nonce":        "a b;c",
+		},
+		Command: "PRIVMSG",
+		Params:  []string{"#dallas", ":) hello"},
+	}
+
+	expected := `@client-nonce=a\sb\:c;reply-parent-msg-id=b34ccfc7-4977-403a-8a94-33c6bac34fb8 PRIVMSG #dallas ::) hello`
+	if m.String() != expected {
+		t.Fatalf("Expected (%s), got (%s)", expected, m.String())
+	}
+}
+
+func TestTags_Twitch(t *testing.T) {
+	t.Parallel()
+
+	line := `@badge-info=subscriber/14;badges=broadcaster/1,subscriber/12,glhf-pledge/1;bits=100;emotes=25:7-11,19-23/1902:13-17;msg-id=highlighted-message;reply-parent-display-name=Ronni;reply-parent-msg-body=hi\sthere;reply-parent-msg-id=abc;reply-parent-user-id=1337;reply-parent-user-login=ronni;tmi-sent-ts=1507246572675 :ronni!ronni@ronni.tmi.twitch.tv PRIVMSG #dallas :héllo😀 Kappa Keepo Kappa`
+	m, err := ParseMessage(line)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expectedBadges := Badges{"broadcaster": "1", "subscriber": "12", "glhf-pledge": "1"}
+	if !reflect.DeepEqual(m.Tags.Badges(), expectedBadges) {
+		t.Fatalf("Bad badges: %#v", m.Tags.Badges())
+	}
+
+	if m.Tags.BadgeInfo()["subscriber"] != "14" {
+		t.Fatalf("Bad badge-info: %#v", m.Tags.BadgeInfo())
+	}
+
+	if m.Tags.Bits() != 100 {
+		t.Fatalf("Expected (100) bits, got (%d)", m.Tags.Bits())
+	}
+
+	if m.Tags.MsgId() != "highlighted-message" {
+		t.Fatalf("Bad msg-id: %q", m.Tags.MsgId())
+	}
+
+	if !m.Tags.SentAt().Equal(time.Unix(1507246572, 675000000)) {
+		t.Fatalf("Bad tmi-sent-ts: %s", m.Tags.SentAt())
+	}
+
+	expectedParent := &ReplyParent{
+		MsgId:       "abc",
+		UserId:      "1337",
+		UserLogin:   "ronni",
+		DisplayName: "Ronni",
+		Body:        "hi there",
+	}
+	if !reflect.DeepEqual(m.Tags.ReplyParent(), expectedParent) {
+		t.Fatalf("Bad reply parent: %#v", m.Tags.ReplyParent())
+	}
+
+	emotes, err := m.Tags.Emotes(m.Text())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	names := []string{"Kappa", "Keepo", "Kappa"}
+	ids := []string{"25", "1902", "25"}
+	if len(emotes) != len(names) {
+		t.Fatalf("Expected (%d) emotes, got (%d)", len(names), len(emotes))
+	}
+	for i, e := range emotes {
+		if e.Name != names[i] || e.Id != ids[i] {
+			t.Fatalf("Emote (%d) mismatch: %#v", i, e)
+		}
+		if m.Text()[e.Start:e.End] != e.Name {
+			t.Fatalf("Emote (%d) offsets do not slice the text: %#v", i, e)
+		}
+	}
+}
+
+func TestTags_Emotes_outOfBounds(t *testing.T) {
+	t.Parallel()
+
+	tags := Tags{"emotes": "25:0-5"}
+	if _, err := tags.Emotes("Kappa"); err == nil {
+		t.Fatal("Expected an error for an emote range past the end of the text")
+	}
+}
+
+func FuzzParseMessage(f *testing.F) {
+	f.Add("PING :tmi.twitch.tv")
+	f.Add(":ronni!ronni@ronni.tmi.twitch.tv PRIVMSG #dallas :Kappa Keepo Kappa")
+	f.Add(`@badges=subscriber/12;emotes=25:0-4;system-msg=a\sb\:c\\ :tmi.twitch.tv USERNOTICE #dallas :Kappa`)
+	f.Add("@a=\\ :b c d :")
+
+	f.Fuzz(func(t *testing.T, line string) {
+		m, err := ParseMessage(line)
+		if err != nil {
+			return
+		}
+
+		// Serializing a parsed message and parsing it again must be stable.
+		s := m.String()
+		m2, err := ParseMessage(s)
+		if err != nil {
+			t.Fatalf("Failed to reparse %q (from %q): %s", s, line, err)
+		}
+		if m2.String() != s {
+			t.Fatalf("Round trip mismatch:\n%q\n%q", s, m2.String())
+		}
+
+		// Decoding Twitch tags must never panic, and emotes must slice the
+		// text they were decoded against.
+		m.Tags.Badges()
+		m.Tags.BadgeInfo()
+		m.Tags.SentAt()
+		emotes, err := m.Tags.Emotes(m.Text())
+		if err != nil {
+			return
+		}
+		for _, e := range emotes {
+			if m.Text()[e.Start:e.End] != e.Name {
+				t.Fatalf("Emote offsets do not slice the text: %#v", e)
+			}
+		}
+	})
+}
@@ -0,0 +1,58 @@
+package chat
+
+import (
+	"strconv"
+	"time"
+)
+
+// RoomState holds the chat settings of a channel, as sent by Twitch in
+// ROOMSTATE messages.
+// See https://dev.twitch.tv/docs/irc/tags#roomstate-tags
+type RoomState struct {
+	RoomId string
+
+	// Slow is the minimum time between messages from users that are not
+	// moderators. Zero when slow mode is off.
+	Slow time.Duration
+
+	// FollowersOnly reports whether only followers may chat, and
+	// FollowersOnlyDuration how long they must have followed for.
+	FollowersOnly         bool
+	FollowersOnlyDuration time.Duration
+
+	EmoteOnly bool
+	SubsOnly  bool
+	R9K       bool
+}
+
+// Update applies the tags of a ROOMSTATE message. Twitch sends every tag when
+// joining a channel, but only the changed tag afterwards, so tags that are
+// missing are left untouched.
+func (rs *RoomState) Update(tags Tags) {
+	if v, ok := tags["room-id"]; ok {
+		rs.RoomId = v
+	}
+	if v, ok := tags["slow"]; ok {
+		n, _ := strconv.Atoi(v)
+		rs.Slow = time.Duration(n) * time.Second
+	}
+	if v, ok := tags["followers-only"]; ok {
+		// -1 disables followers-only mode, otherwise the value is the
+		// number of minutes a user must have followed for.
+		n, err := strconv.Atoi(v)
+		rs.FollowersOnly = err == nil && n >= 0
+		rs.FollowersOnlyDuration = 0
+		if rs.FollowersOnly {
+			rs.FollowersOnlyDuration = time.Duration(n) * time.Minute
+		}
+	}
+	if v, ok := tags["emote-only"]; ok {
+		rs.EmoteOnly = v == "1"
+	}
+	if v, ok := tags["subs-only"]; ok {
+		rs.SubsOnly = v == "1"
+	}
+	if v, ok := tags["r9k"]; ok {
+		rs.R9K = v == "1"
+	}
+}
@@ -0,0 +1,417 @@
+package chat
+
+import (
+	"errors"
+	"io"
+	"sync"
+	"time"
+)
+
+// ErrQueueFull is an error that is returned by Sender.Send when the message
+// was dropped because the send queue is full.
+var ErrQueueFull = errors.New("Send queue is full")
+
+// ErrSenderClosed is an error that is returned by Sender.Send after the
+// Sender has been closed.
+var ErrSenderClosed = errors.New("Sender is closed")
+
+// Role is the standing of the bot's account in a channel, which determines how
+// many messages Twitch allows it to send.
+type Role int
+
+const (
+	// RoleRegular is an account that is neither a moderator nor the
+	// broadcaster of the channel.
+	RoleRegular Role = iota
+
+	// RoleModerator is a moderator or the broadcaster of the channel.
+	RoleModerator
+
+	// RoleVerified is a verified bot account.
+	RoleVerified
+)
+
+// RateLimit is the number of messages allowed within a period.
+type RateLimit struct {
+	Messages int
+	Period   time.Duration
+}
+
+// DefaultRateLimits are the chat limits Twitch enforces for each Role.
+// Exceeding them results in the account being muted globally.
+// See https://dev.twitch.tv/docs/irc#rate-limits
+var DefaultRateLimits = map[Role]RateLimit{
+	RoleRegular:   {Messages: 20, Period: 30 * time.Second},
+	RoleModerator: {Messages: 100, Period: 30 * time.Second},
+	RoleVerified:  {Messages: 7500, Period: 30 * time.Second},
+}
+
+// Policy is what a Sender does with messages it cannot send right away.
+type Policy int
+
+const (
+	// PolicyBlock makes Send wait until there is room in the queue.
+	PolicyBlock Policy = iota
+
+	// PolicyDrop makes Send discard the message and return ErrQueueFull when
+	// the queue is full.
+	PolicyDrop
+
+	// PolicyCoalesce replaces a message still waiting in the queue for the
+	// same channel with the newer one. When there is none and the queue is
+	// full, the message is dropped as with PolicyDrop.
+	PolicyCoalesce
+)
+
+// SenderConfig holds the configuration options for a Sender.
+type SenderConfig struct {
+	// Role is the role used for channels the Sender has no USERSTATE for.
+	// Verified bots should set RoleVerified. Default: RoleRegular.
+	Role Role
+
+	// Policy is applied when a message cannot be sent right away.
+	// Default: PolicyBlock.
+	Policy Policy
+
+	// QueueSize is the maximum number of messages waiting to be sent.
+	// Default: 100.
+	QueueSize int
+
+	// RateLimits overrides DefaultRateLimits for the given roles.
+	RateLimits map[Role]RateLimit
+}
+
+// SenderStats is a snapshot of a Sender's queue.
+type SenderStats struct {
+	// QueueDepth is the number of messages waiting to be sent, and
+	// MaxQueueDepth the highest it has been.
+	QueueDepth    int
+	MaxQueueDepth int
+
+	Sent      int
+	Dropped   int
+	Coalesced int
+}
+
+// Sender is a per-connection outgoing message queue that keeps a bot within
+// Twitch's chat rate limits. Messages are written in order for each channel,
+// while messages to a channel that is waiting out slow mode do not hold up
+// other channels.
+type Sender struct {
+	w      io.Writer
+	policy Policy
+	size   int
+	role   Role
+
+	mu      sync.Mutex
+	space   *sync.Cond
+	wake    chan struct{}
+	done    chan struct{}
+	closed  bool
+	err     error
+	queue   []*Message
+	windows map[Role]*window
+	rooms   map[string]*RoomState
+	last    map[string]time.Time
+	stats   SenderStats
+
+	// mods holds the channels the bot is a moderator or the broadcaster of.
+	// Only those are exempt from slow mode, whatever the rate-limit window.
+	mods map[string]bool
+}
+
+// NewSender creates a Sender writing to w, which is usually the connection to
+// Twitch's chat servers. A nil config uses the defaults.
+func NewSender(w io.Writer, config *SenderConfig) *Sender {
+	if config == nil {
+		config = &SenderConfig{}
+	}
+
+	s := &Sender{
+		w:       w,
+		policy:  config.Policy,
+		size:    config.QueueSize,
+		role:    config.Role,
+		wake:    make(chan struct{}, 1),
+		done:    make(chan struct{}),
+		windows: make(map[Role]*window),
+		mods:    make(map[string]bool),
+		rooms:   make(map[string]*RoomState),
+		last:    make(map[string]time.Time),
+	}
+	if s.size <= 0 {
+		s.size = 100
+	}
+	s.space = sync.NewCond(&s.mu)
+
+	for r, l := range DefaultRateLimits {
+		if o, ok := config.RateLimits[r]; ok {
+			l = o
+		}
+		s.windows[r] = newWindow(l)
+	}
+
+	go s.run()
+
+	return s
+}
+
+// Send queues a message, usually a PRIVMSG, to be written once the rate
+// limits allow it.
+func (s *Sender) Send(m *Message) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for {
+		if s.closed {
+			return ErrSenderClosed
+		}
+		if s.err != nil {
+			return s.err
+		}
+
+		if s.policy == PolicyCoalesce {
+			ch := m.Channel()
+			for i, q := range s.queue {
+				if q.Channel() == ch {
+					s.queue[i] = m
+					s.stats.Coalesced++
+					return nil
+				}
+			}
+		}
+
+		if len(s.queue) < s.size {
+			break
+		}
+
+		if s.policy != PolicyBlock {
+			s.stats.Dropped++
+			return ErrQueueFull
+		}
+		s.space.Wait()
+	}
+
+	s.queue = append(s.queue, m)
+	if len(s.queue) > s.stats.MaxQueueDepth {
+		s.stats.MaxQueueDepth = len(s.queue)
+	}
+	s.notify()
+
+	return nil
+}
+
+// Observe updates the Sender from a message received from Twitch. ROOMSTATE
+// messages track slow and followers-only mode, and USERSTATE messages track
+// the bot's role in each channel. Other messages are ignored.
+func (s *Sender) Observe(m *Message) {
+	ch := m.Channel()
+	if ch == "" {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	switch m.Command {
+	case "ROOMSTATE":
+		rs, ok := s.rooms[ch]
+		if !ok {
+			rs = new(RoomState)
+			s.rooms[ch] = rs
+		}
+		rs.Update(m.Tags)
+	case "USERSTATE":
+		b := m.Tags.Badges()
+		_, broadcaster := b["broadcaster"]
+		if m.Tags["mod"] == "1" || broadcaster {
+			s.mods[ch] = true
+		} else {
+			delete(s.mods, ch)
+		}
+	default:
+		return
+	}
+	s.notify()
+}
+
+// RoomState returns a copy of the last known state of the channel, or nil if
+// no ROOMSTATE has been observed for it.
+func (s *Sender) RoomState(channel string) *RoomState {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	rs, ok := s.rooms[channel]
+	if !ok {
+		return nil
+	}
+	c := *rs
+	return &c
+}
+
+// Stats returns a snapshot of the queue metrics.
+func (s *Sender) Stats() SenderStats {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	st := s.stats
+	st.QueueDepth = len(s.queue)
+	return st
+}
+
+// Close stops the Sender. Messages still in the queue are discarded, and any
+// blocked Send calls return ErrSenderClosed.
+func (s *Sender) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed {
+		return nil
+	}
+	s.closed = true
+	s.queue = nil
+	close(s.done)
+	s.space.Broadcast()
+
+	return nil
+}
+
+// roleFor returns the role whose rate limit applies to a channel: the larger
+// of the configured role and moderator, if the bot is one in the channel.
+// Must be called with s.mu held.
+func (s *Sender) roleFor(ch string) Role {
+	if s.mods[ch] && RoleModerator > s.role {
+		return RoleModerator
+	}
+	return s.role
+}
+
+// notify wakes the run loop. Must be called with s.mu held.
+func (s *Sender) notify() {
+	select {
+	case s.wake <- struct{}{}:
+	default:
+	}
+}
+
+// next removes and returns the first message that may be sent now, with the
+// role whose rate limit it counts against. If there is none, it returns how
+// long to wait before checking again, or zero if the queue is empty. Must be
+// called with s.mu held.
+func (s *Sender) next(now time.Time) (*Message, Role, time.Duration) {
+	var wait time.Duration
+	blocked := make(map[string]bool)
+
+	for i, m := range s.queue {
+		ch := m.Channel()
+		if blocked[ch] {
+			// Keep messages to the same channel in order.
+			continue
+		}
+
+		role := s.roleFor(ch)
+		d := s.windows[role].wait(now)
+		if rs, ok := s.rooms[ch]; ok && rs.Slow > 0 && !s.mods[ch] {
+			if t := s.last[ch].Add(rs.Slow).Sub(now); t > d {
+				d = t
+			}
+		}
+
+		if d <= 0 {
+			s.queue = append(s.queue[:i], s.queue[i+1:]...)
+			return m, role, 0
+		}
+
+		blocked[ch] = true
+		if wait == 0 || d < wait {
+			wait = d
+		}
+	}
+
+	return nil, 0, wait
+}
+
+func (s *Sender) run() {
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+
+	for {
+		s.mu.Lock()
+		m, role, wait := s.next(time.Now())
+		s.mu.Unlock()
+
+		if m != nil {
+			_, err := io.WriteString(s.w, m.String()+"\r\n")
+
+			// The send is counted once the write returns, so the times
+			// in the window are never earlier than the message left.
+			// Only run calls next, so nothing is sent in between.
+			s.mu.Lock()
+			now := time.Now()
+			s.windows[role].add(now)
+			s.last[m.Channel()] = now
+			if err != nil {
+				s.err = err
+				s.queue = nil
+			} else {
+				s.stats.Sent++
+			}
+			s.space.Broadcast()
+			s.mu.Unlock()
+
+			if err != nil {
+				return
+			}
+			continue
+		}
+
+		var tick <-chan time.Time
+		if wait > 0 {
+			if !timer.Stop() {
+				select {
+				case <-timer.C:
+				default:
+				}
+			}
+			timer.Reset(wait)
+			tick = timer.C
+		}
+
+		select {
+		case <-s.done:
+			return
+		case <-s.wake:
+		case <-tick:
+		}
+	}
+}
+
+// window is a sliding window of the times of the last sends. A message may
+// be sent once fewer than Messages sends are within the last Period, so no
+// Period ever holds more than Messages sends.
+type window struct {
+	limit RateLimit
+	sent  []time.Time // oldest first, at most limit.Messages
+}
+
+func newWindow(l RateLimit) *window {
+	return &window{limit: l}
+}
+
+// wait returns how long until a message may be sent, or a value of zero or
+// less if it may be sent now.
+func (w *window) wait(now time.Time) time.Duration {
+	if len(w.sent) == 0 || len(w.sent) < w.limit.Messages {
+		return 0
+	}
+	return w.sent[0].Add(w.limit.Period).Sub(now)
+}
+
+// add records a send, forgetting the oldest one once the window is full.
+func (w *window) add(t time.Time) {
+	if len(w.sent) > 0 && len(w.sent) >= w.limit.Messages {
+		copy(w.sent, w.sent[1:])
+		w.sent = w.sent[:len(w.sent)-1]
+	}
+	w.sent = append(w.sent, t)
+}
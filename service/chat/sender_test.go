@@ -0,0 +1,269 @@
+package chat
+
+import (
+	"bytes"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// lineRecorder is an io.Writer that records each write with the time it was
+// made.
+type lineRecorder struct {
+	mu    sync.Mutex
+	lines []string
+	times []time.Time
+}
+
+func (r *lineRecorder) Write(p []byte) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.lines = append(r.lines, string(bytes.TrimRight(p, "\r\n")))
+	r.times = append(r.times, time.Now())
+	return len(p), nil
+}
+
+func (r *lineRecorder) wait(t *testing.T, n int) ([]string, []time.Time) {
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		r.mu.Lock()
+		if len(r.lines) >= n {
+			defer r.mu.Unlock()
+			return r.lines, r.times
+		}
+		r.mu.Unlock()
+		time.Sleep(5 * time.Millisecond)
+	}
+	t.Fatalf("Timed out waiting for (%d) lines", n)
+	return nil, nil
+}
+
+func privmsg(ch, text string) *Message {
+	return &Message{Command: "PRIVMSG", Params: []string{"#" + ch, text}}
+}
+
+func TestSender_RateLimit(t *testing.T) {
+	t.Parallel()
+
+	rec := new(lineRecorder)
+	s := NewSender(rec, &SenderConfig{
+		RateLimits: map[Role]RateLimit{
+			RoleRegular:   {Messages: 2, Period: 200 * time.Millisecond},
+			RoleModerator: {Messages: 10, Period: 200 * time.Millisecond},
+		},
+	})
+	defer s.Close()
+
+	start := time.Now()
+	for i := 0; i < 4; i++ {
+		if err := s.Send(privmsg("dallas", "hi")); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	_, times := rec.wait(t, 4)
+	// Two messages go out right away, the next two once the first two are
+	// a full period old.
+	if d := times[1].Sub(start); d > 90*time.Millisecond {
+		t.Fatalf("Expected the first two messages to be sent right away, took %s", d)
+	}
+	for i := 2; i < 4; i++ {
+		if d := times[i].Sub(times[i-2]); d < 200*time.Millisecond {
+			t.Fatalf("Expected message (%d) to wait out the period, sent after %s", i, d)
+		}
+	}
+
+	// USERSTATE with the moderator badge moves the channel to the larger
+	// moderator limit.
+	s.Observe(&Message{
+		Tags:    Tags{"mod": "1"},
+		Command: "USERSTATE",
+		Params:  []string{"#dallas"},
+	})
+	start = time.Now()
+	for i := 0; i < 5; i++ {
+		if err := s.Send(privmsg("dallas", "hi")); err != nil {
+			t.Fatal(err)
+		}
+	}
+	_, times = rec.wait(t, 9)
+	if d := times[8].Sub(start); d > 90*time.Millisecond {
+		t.Fatalf("Expected moderator messages to be sent right away, took %s", d)
+	}
+}
+
+func TestSender_RateLimit_window(t *testing.T) {
+	t.Parallel()
+
+	limit := RateLimit{Messages: 5, Period: 100 * time.Millisecond}
+	rec := new(lineRecorder)
+	s := NewSender(rec, &SenderConfig{
+		RateLimits: map[Role]RateLimit{RoleRegular: limit},
+	})
+	defer s.Close()
+
+	const n = 23
+	for i := 0; i < n; i++ {
+		if err := s.Send(privmsg("dallas", "hi")); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// No Period-length window may hold more than Messages sends, so each
+	// send must be at least a Period after the one Messages before it.
+	_, times := rec.wait(t, n)
+	for i := limit.Messages; i < n; i++ {
+		if d := times[i].Sub(times[i-limit.Messages]); d < limit.Period {
+			t.Fatalf("Expected at most (%d) messages per %s, messages (%d) and (%d) were sent %s apart", limit.Messages, limit.Period, i-limit.Messages, i, d)
+		}
+	}
+}
+
+func TestSender_SlowMode(t *testing.T) {
+	t.Parallel()
+
+	rec := new(lineRecorder)
+	s := NewSender(rec, nil)
+	defer s.Close()
+
+	s.Observe(&Message{
+		Tags:    Tags{"room-id": "1337", "slow": "1", "followers-only": "10"},
+		Command: "ROOMSTATE",
+		Params:  []string{"#dallas"},
+	})
+
+	rs := s.RoomState("dallas")
+	if rs == nil || rs.Slow != time.Second || !rs.FollowersOnly || rs.FollowersOnlyDuration != 10*time.Minute {
+		t.Fatalf("Bad room state: %#v", rs)
+	}
+
+	s.Send(privmsg("dallas", "one"))
+	s.Send(privmsg("dallas", "two"))
+	s.Send(privmsg("ronni", "three"))
+
+	// The slowed channel must not hold up the other one.
+	lines, times := rec.wait(t, 3)
+	expected := []string{"PRIVMSG #dallas one", "PRIVMSG #ronni three", "PRIVMSG #dallas two"}
+	if strings.Join(lines, "\n") != strings.Join(expected, "\n") {
+		t.Fatalf("Bad order, got:\n%s", strings.Join(lines, "\n"))
+	}
+	if d := times[2].Sub(times[0]); d < 900*time.Millisecond {
+		t.Fatalf("Expected slow mode to delay the second message, sent after %s", d)
+	}
+
+	// A partial ROOMSTATE only changes the tags it carries.
+	s.Observe(&Message{
+		Tags:    Tags{"slow": "0"},
+		Command: "ROOMSTATE",
+		Params:  []string{"#dallas"},
+	})
+	rs = s.RoomState("dallas")
+	if rs.Slow != 0 || !rs.FollowersOnly || rs.RoomId != "1337" {
+		t.Fatalf("Bad room state after update: %#v", rs)
+	}
+}
+
+func TestSender_SlowMode_verified(t *testing.T) {
+	t.Parallel()
+
+	rec := new(lineRecorder)
+	s := NewSender(rec, &SenderConfig{Role: RoleVerified})
+	defer s.Close()
+
+	for _, ch := range []string{"dallas", "ronni"} {
+		s.Observe(&Message{
+			Tags:    Tags{"slow": "1"},
+			Command: "ROOMSTATE",
+			Params:  []string{"#" + ch},
+		})
+	}
+	// The bot moderates ronni, but not dallas.
+	s.Observe(&Message{
+		Tags:    Tags{"mod": "1"},
+		Command: "USERSTATE",
+		Params:  []string{"#ronni"},
+	})
+
+	start := time.Now()
+	s.Send(privmsg("ronni", "one"))
+	s.Send(privmsg("ronni", "two"))
+	lines, times := rec.wait(t, 2)
+	if lines[1] != "PRIVMSG #ronni two" {
+		t.Fatalf("Bad order, got:\n%s", strings.Join(lines, "\n"))
+	}
+	if d := times[1].Sub(start); d > 500*time.Millisecond {
+		t.Fatalf("Expected a verified moderator to skip slow mode, sent after %s", d)
+	}
+
+	// A verified bot that is not a moderator still waits out slow mode.
+	start = time.Now()
+	s.Send(privmsg("dallas", "one"))
+	s.Send(privmsg("dallas", "two"))
+	_, times = rec.wait(t, 4)
+	if d := times[3].Sub(start); d < 900*time.Millisecond {
+		t.Fatalf("Expected slow mode to delay a verified non-moderator, sent after %s", d)
+	}
+}
+
+func TestSender_Policies(t *testing.T) {
+	t.Parallel()
+
+	limits := map[Role]RateLimit{
+		RoleRegular: {Messages: 1, Period: time.Hour},
+	}
+
+	// Drop
+	rec := new(lineRecorder)
+	s := NewSender(rec, &SenderConfig{Policy: PolicyDrop, QueueSize: 1, RateLimits: limits})
+	s.Send(privmsg("dallas", "sent"))
+	rec.wait(t, 1)
+	s.Send(privmsg("dallas", "queued"))
+	if err := s.Send(privmsg("dallas", "dropped")); err != ErrQueueFull {
+		t.Fatalf("Expected ErrQueueFull, got (%v)", err)
+	}
+	st := s.Stats()
+	if st.QueueDepth != 1 || st.MaxQueueDepth != 1 || st.Sent != 1 || st.Dropped != 1 {
+		t.Fatalf("Bad stats: %#v", st)
+	}
+	s.Close()
+
+	// Coalesce
+	rec = new(lineRecorder)
+	s = NewSender(rec, &SenderConfig{Policy: PolicyCoalesce, RateLimits: limits})
+	s.Send(privmsg("dallas", "sent"))
+	rec.wait(t, 1)
+	s.Send(privmsg("dallas", "old"))
+	s.Send(privmsg("ronni", "other"))
+	s.Send(privmsg("dallas", "new"))
+	st = s.Stats()
+	if st.QueueDepth != 2 || st.Coalesced != 1 {
+		t.Fatalf("Bad stats: %#v", st)
+	}
+	s.mu.Lock()
+	if s.queue[0].Text() != "new" {
+		t.Fatalf("Expected the queued message to be replaced, got (%s)", s.queue[0].Text())
+	}
+	s.mu.Unlock()
+	s.Close()
+
+	// Block
+	rec = new(lineRecorder)
+	s = NewSender(rec, &SenderConfig{QueueSize: 1, RateLimits: limits})
+	s.Send(privmsg("dallas", "sent"))
+	rec.wait(t, 1)
+	s.Send(privmsg("dallas", "queued"))
+	errCh := make(chan error)
+	go func() {
+		errCh <- s.Send(privmsg("dallas", "blocked"))
+	}()
+	select {
+	case err := <-errCh:
+		t.Fatalf("Expected Send to block, returned (%v)", err)
+	case <-time.After(50 * time.Millisecond):
+	}
+	s.Close()
+	if err := <-errCh; err != ErrSenderClosed {
+		t.Fatalf("Expected ErrSenderClosed, got (%v)", err)
+	}
+}
@@ -0,0 +1,254 @@
+package chat
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// Tags holds IRCv3 message tags. Values are stored unescaped.
+type Tags map[string]string
+
+// tagEscapes maps characters that cannot appear raw in a tag value to their
+// escaped form.
+// See https://ircv3.net/specs/extensions/message-tags#escaping-values
+var tagEscapes = map[byte]byte{
+	';':  ':',
+	' ':  's',
+	'\\': '\\',
+	'\r': 'r',
+	'\n': 'n',
+}
+
+var tagUnescapes = map[byte]byte{
+	':':  ';',
+	's':  ' ',
+	'\\': '\\',
+	'r':  '\r',
+	'n':  '\n',
+}
+
+func parseTags(raw string) Tags {
+	tags := make(Tags)
+	for _, kv := range strings.Split(raw, ";") {
+		var k, v string
+		if i := strings.IndexByte(kv, '='); i >= 0 {
+			k, v = kv[:i], unescapeTagValue(kv[i+1:])
+		} else {
+			k = kv
+		}
+		if k == "" {
+			continue
+		}
+		tags[k] = v
+	}
+	return tags
+}
+
+// unescapeTagValue reverses the IRCv3 tag escaping. Unknown escapes drop the
+// backslash, and a trailing lone backslash is removed.
+func unescapeTagValue(s string) string {
+	if strings.IndexByte(s, '\\') < 0 {
+		return s
+	}
+
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if c != '\\' {
+			b.WriteByte(c)
+			continue
+		}
+		i++
+		if i == len(s) {
+			break
+		}
+		if u, ok := tagUnescapes[s[i]]; ok {
+			b.WriteByte(u)
+		} else {
+			b.WriteByte(s[i])
+		}
+	}
+	return b.String()
+}
+
+func escapeTagValue(s string) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		if e, ok := tagEscapes[s[i]]; ok {
+			b.WriteByte('\\')
+			b.WriteByte(e)
+			continue
+		}
+		b.WriteByte(s[i])
+	}
+	return b.String()
+}
+
+// String serializes the tags, sorted by key, without the leading '@'.
+func (t Tags) String() string {
+	keys := make([]string, 0, len(t))
+	for k := range t {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	parts := make([]string, 0, len(keys))
+	for _, k := range keys {
+		parts = append(parts, k+"="+escapeTagValue(t[k]))
+	}
+	return strings.Join(parts, ";")
+}
+
+// Badges maps a badge set ID to the badge version ID, Ex: "subscriber" to
+// "12". Both are strings, matching the IDs returned by helix.
+type Badges map[string]string
+
+func parseBadges(s string) Badges {
+	if s == "" {
+		return nil
+	}
+	b := make(Badges)
+	for _, badge := range strings.Split(s, ",") {
+		if i := strings.IndexByte(badge, '/'); i >= 0 {
+			b[badge[:i]] = badge[i+1:]
+		} else if badge != "" {
+			b[badge] = ""
+		}
+	}
+	return b
+}
+
+// Badges returns the badges displayed next to the sender's name.
+func (t Tags) Badges() Badges {
+	return parseBadges(t["badges"])
+}
+
+// BadgeInfo returns the metadata for the sender's badges. For the "subscriber"
+// badge, this is the exact number of months subscribed.
+func (t Tags) BadgeInfo() Badges {
+	return parseBadges(t["badge-info"])
+}
+
+// Emote is a single use of an emote in a chat message.
+type Emote struct {
+	// Id is the emote ID, as used by helix.
+	Id string
+
+	// Start and End are the byte offsets of the emote in the message text,
+	// with End being exclusive, so that text[Start:End] is the emote name.
+	// Twitch sends positions as inclusive code point indices; they are
+	// converted here so multi-byte UTF-8 text can be sliced directly.
+	Start int
+	End   int
+
+	// Name is the emote text as it appears in the message.
+	Name string
+}
+
+// Emotes decodes the emotes tag against the given message text. The returned
+// emotes are sorted by their position in the text.
+func (t Tags) Emotes(text string) ([]*Emote, error) {
+	raw := t["emotes"]
+	if raw == "" {
+		return nil, nil
+	}
+
+	// Build a lookup from code point index to byte offset. The extra entry
+	// allows an emote to end on the final rune.
+	offsets := make([]int, 0, utf8.RuneCountInString(text)+1)
+	for i := range text {
+		offsets = append(offsets, i)
+	}
+	offsets = append(offsets, len(text))
+
+	var emotes []*Emote
+	for _, e := range strings.Split(raw, "/") {
+		i := strings.IndexByte(e, ':')
+		if i < 0 {
+			return nil, fmt.Errorf("Invalid emote %q", e)
+		}
+		id := e[:i]
+		for _, r := range strings.Split(e[i+1:], ",") {
+			j := strings.IndexByte(r, '-')
+			if j < 0 {
+				return nil, fmt.Errorf("Invalid emote range %q", r)
+			}
+			start, err := strconv.Atoi(r[:j])
+			if err != nil {
+				return nil, fmt.Errorf("Invalid emote range %q: %s", r, err)
+			}
+			end, err := strconv.Atoi(r[j+1:])
+			if err != nil {
+				return nil, fmt.Errorf("Invalid emote range %q: %s", r, err)
+			}
+			if start < 0 || end < start || end+1 >= len(offsets) {
+				return nil, fmt.Errorf("Emote range %q out of bounds", r)
+			}
+
+			em := &Emote{
+				Id:    id,
+				Start: offsets[start],
+				End:   offsets[end+1],
+			}
+			em.Name = text[em.Start:em.End]
+			emotes = append(emotes, em)
+		}
+	}
+
+	sort.Slice(emotes, func(i, j int) bool {
+		return emotes[i].Start < emotes[j].Start
+	})
+
+	return emotes, nil
+}
+
+// Bits returns the number of bits cheered with the message, or zero.
+func (t Tags) Bits() int {
+	n, _ := strconv.Atoi(t["bits"])
+	return n
+}
+
+// MsgId returns the msg-id tag, which identifies the kind of NOTICE or
+// USERNOTICE, Ex: "sub", "raid", "msg_ratelimit".
+func (t Tags) MsgId() string {
+	return t["msg-id"]
+}
+
+// SentAt returns the tmi-sent-ts tag, the time Twitch's servers received the
+// message. The zero time is returned if the tag is missing or invalid.
+func (t Tags) SentAt() time.Time {
+	ms, err := strconv.ParseInt(t["tmi-sent-ts"], 10, 64)
+	if err != nil {
+		return time.Time{}
+	}
+	return time.UnixMilli(ms)
+}
+
+// ReplyParent describes the message a chat message is replying to.
+type ReplyParent struct {
+	MsgId       string
+	UserId      string
+	UserLogin   string
+	DisplayName string
+	Body        string
+}
+
+// ReplyParent returns the parent of a reply, or nil if the message is not a
+// reply.
+func (t Tags) ReplyParent() *ReplyParent {
+	id, ok := t["reply-parent-msg-id"]
+	if !ok {
+		return nil
+	}
+	return &ReplyParent{
+		MsgId:       id,
+		UserId:      t["reply-parent-user-id"],
+		UserLogin:   t["reply-parent-user-login"],
+		DisplayName: t["reply-parent-display-name"],
+		Body:        t["reply-parent-msg-body"],
+	}
+}
@@ -0,0 +1,178 @@
+package helix
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/catsby/go-twitch/twitch"
+)
+
+// MaxCommercialLength is the longest commercial Twitch runs. StartCommercial
+// rejects longer lengths.
+const MaxCommercialLength = 180 * time.Second
+
+// Commercial is the result of starting a commercial.
+type Commercial struct {
+	// Length is how long the commercial runs, which may be shorter than
+	// requested.
+	Length  time.Duration `mapstructure:"length"`
+	Message string        `mapstructure:"message"`
+
+	// RetryAfter is how long until another commercial can be started.
+	RetryAfter time.Duration `mapstructure:"retry_after"`
+}
+
+// StartCommercialInput is the input to the StartCommercial function.
+type StartCommercialInput struct {
+	BroadcasterId string
+
+	// Length is sent in whole seconds, and at most MaxCommercialLength.
+	// Twitch recommends a multiple of 30 seconds.
+	Length time.Duration
+}
+
+// StartCommercialOutput is the output of the StartCommercial function.
+type StartCommercialOutput struct {
+	Commercials []*Commercial `mapstructure:"data"`
+}
+
+// StartCommercial runs a commercial on a live channel.
+// Scope: channel:edit:commercial
+// See:
+//  - https://dev.twitch.tv/docs/api/reference#start-commercial
+func (k *Client) StartCommercial(i *StartCommercialInput) (*StartCommercialOutput, error) {
+	if i == nil || i.BroadcasterId == "" {
+		return nil, fmt.Errorf("[ERR] No BroadcasterId for StartCommercial")
+	}
+	if i.Length < time.Second || i.Length > MaxCommercialLength {
+		return nil, fmt.Errorf("[ERR] Length %s for StartCommercial must be between 1s and %s", i.Length, MaxCommercialLength)
+	}
+
+	body := map[string]interface{}{
+		"broadcaster_id": i.BroadcasterId,
+		"length":         int(i.Length / time.Second),
+	}
+
+	resp, err := k.PostJSON("/channels/commercial", body, &twitch.RequestOptions{Endpoint: "StartCommercial", Input: i})
+	if err != nil {
+		return nil, err
+	}
+
+	var o StartCommercialOutput
+	if err := twitch.DecodeJSON(&o, resp.Body); err != nil {
+		return nil, err
+	}
+
+	return &o, nil
+}
+
+// AdSchedule is when a channel's ads run. Twitch returns its times as
+// RFC3339 strings or Unix timestamps; both are decoded.
+type AdSchedule struct {
+	// NextAdAt is the zero time when no ad is scheduled or the channel is
+	// not live.
+	NextAdAt time.Time `mapstructure:"next_ad_at"`
+	LastAdAt time.Time `mapstructure:"last_ad_at"`
+
+	// Duration is the length of the next ad.
+	Duration time.Duration `mapstructure:"duration"`
+
+	// PrerollFreeTime is how long viewers who join will not see a preroll
+	// ad.
+	PrerollFreeTime time.Duration `mapstructure:"preroll_free_time"`
+
+	// SnoozeCount is the number of snoozes left, and SnoozeRefreshAt when
+	// another is added.
+	SnoozeCount     int       `mapstructure:"snooze_count"`
+	SnoozeRefreshAt time.Time `mapstructure:"snooze_refresh_at"`
+}
+
+// GetAdScheduleInput is the input to the GetAdSchedule function.
+type GetAdScheduleInput struct {
+	BroadcasterId string
+}
+
+// GetAdScheduleOutput is the output of the GetAdSchedule function.
+type GetAdScheduleOutput struct {
+	Schedules []*AdSchedule `mapstructure:"data"`
+}
+
+// GetAdSchedule returns when a channel's next ad runs, and its snoozes.
+// Scope: channel:read:ads
+// See:
+//  - https://dev.twitch.tv/docs/api/reference#get-ad-schedule
+func (k *Client) GetAdSchedule(i *GetAdScheduleInput) (*GetAdScheduleOutput, error) {
+	if i == nil || i.BroadcasterId == "" {
+		return nil, fmt.Errorf("[ERR] No BroadcasterId for GetAdSchedule")
+	}
+
+	ro := &twitch.RequestOptions{
+		Params: map[string]string{
+			"broadcaster_id": i.BroadcasterId,
+		},
+		Endpoint: "GetAdSchedule",
+		Input:    i,
+	}
+
+	resp, err := k.Get("/channels/ads", ro)
+	if err != nil {
+		return nil, err
+	}
+
+	var o GetAdScheduleOutput
+	if err := twitch.DecodeJSON(&o, resp.Body); err != nil {
+		return nil, err
+	}
+
+	return &o, nil
+}
+
+// AdSnooze is the result of snoozing the next ad.
+type AdSnooze struct {
+	SnoozeCount     int       `mapstructure:"snooze_count"`
+	SnoozeRefreshAt time.Time `mapstructure:"snooze_refresh_at"`
+
+	// NextAdAt is when the snoozed ad now runs.
+	NextAdAt time.Time `mapstructure:"next_ad_at"`
+}
+
+// SnoozeNextAdInput is the input to the SnoozeNextAd function.
+type SnoozeNextAdInput struct {
+	BroadcasterId string
+}
+
+// SnoozeNextAdOutput is the output of the SnoozeNextAd function.
+type SnoozeNextAdOutput struct {
+	Snoozes []*AdSnooze `mapstructure:"data"`
+}
+
+// SnoozeNextAd pushes the next scheduled ad back by 5 minutes, using one of
+// the channel's snoozes.
+// Scope: channel:manage:ads
+// See:
+//  - https://dev.twitch.tv/docs/api/reference#snooze-next-ad
+func (k *Client) SnoozeNextAd(i *SnoozeNextAdInput) (*SnoozeNextAdOutput, error) {
+	if i == nil || i.BroadcasterId == "" {
+		return nil, fmt.Errorf("[ERR] No BroadcasterId for SnoozeNextAd")
+	}
+
+	ro := &twitch.RequestOptions{
+		Params: map[string]string{
+			"broadcaster_id": i.BroadcasterId,
+		},
+		Endpoint: "SnoozeNextAd",
+		Input:    i,
+	}
+
+	resp, err := k.Post("/channels/ads/schedule/snooze", ro)
+	if err != nil {
+		return nil, err
+	}
+
+	var o SnoozeNextAdOutput
+	if err := twitch.DecodeJSON(&o, resp.Body); err != nil {
+		return nil, err
+	}
+
+	return &o, nil
+}
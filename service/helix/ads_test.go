@@ -0,0 +1,78 @@
+package helix
+
+import (
+	"testing"
+	"time"
+)
+
+func TestAds_StartCommercial(t *testing.T) {
+	t.Parallel()
+
+	var err error
+	var output *StartCommercialOutput
+	recordHelix(t, "ads/commercial", func(c *Client) {
+		output, err = c.StartCommercial(&StartCommercialInput{
+			BroadcasterId: "41245072",
+			Length:        time.Minute,
+		})
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c := output.Commercials[0]
+	if c.Length != time.Minute || c.RetryAfter != 8*time.Minute {
+		t.Fatalf("Bad commercial: %#v", c)
+	}
+
+	_, err = (&Client{}).StartCommercial(&StartCommercialInput{
+		BroadcasterId: "41245072",
+		Length:        4 * time.Minute,
+	})
+	if err == nil {
+		t.Fatalf("Expected an error for a 4m commercial")
+	}
+}
+
+func TestAds_GetAdSchedule(t *testing.T) {
+	t.Parallel()
+
+	var err error
+	var output *GetAdScheduleOutput
+	recordHelix(t, "ads/schedule", func(c *Client) {
+		output, err = c.GetAdSchedule(&GetAdScheduleInput{
+			BroadcasterId: "123",
+		})
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s := output.Schedules[0]
+	if s.Duration != time.Minute || s.PrerollFreeTime != 90*time.Second || s.SnoozeCount != 1 {
+		t.Fatalf("Bad ad schedule: %#v", s)
+	}
+	if !s.NextAdAt.Equal(time.Date(2023, 8, 1, 23, 8, 18, 0, time.UTC)) {
+		t.Fatalf("Bad next ad: %s", s.NextAdAt)
+	}
+}
+
+func TestAds_SnoozeNextAd(t *testing.T) {
+	t.Parallel()
+
+	var err error
+	var output *SnoozeNextAdOutput
+	recordHelix(t, "ads/snooze", func(c *Client) {
+		output, err = c.SnoozeNextAd(&SnoozeNextAdInput{
+			BroadcasterId: "123",
+		})
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s := output.Snoozes[0]
+	if s.SnoozeCount != 1 || s.SnoozeRefreshAt.Sub(s.NextAdAt) != 55*time.Minute {
+		t.Fatalf("Bad snooze: %#v", s)
+	}
+}
@@ -0,0 +1,172 @@
+package helix
+
+//go:generate go run ../../internal/mockgen -source api.go -package helixmock -out helixmock/mock.go
+
+// The interfaces below group the methods of Client by the resource they act
+// on, so code using the client can depend on the smallest interface it needs
+// and be tested against a fake, such as the mocks in the helixmock package.
+// API combines them all.
+
+// AdsAPI runs commercials and manages the ad schedule.
+type AdsAPI interface {
+	StartCommercial(i *StartCommercialInput) (*StartCommercialOutput, error)
+	GetAdSchedule(i *GetAdScheduleInput) (*GetAdScheduleOutput, error)
+	SnoozeNextAd(i *SnoozeNextAdInput) (*SnoozeNextAdOutput, error)
+}
+
+// AutoModAPI checks messages against AutoMod and manages its settings.
+type AutoModAPI interface {
+	CheckAutoModStatus(i *CheckAutoModStatusInput) (*CheckAutoModStatusOutput, error)
+	ManageHeldAutoModMessage(i *ManageHeldAutoModMessageInput) error
+	GetAutoModSettings(i *GetAutoModSettingsInput) (*GetAutoModSettingsOutput, error)
+	UpdateAutoModSettings(i *UpdateAutoModSettingsInput) (*UpdateAutoModSettingsOutput, error)
+}
+
+// BitsAPI reads Bits leaderboards and cheermotes.
+type BitsAPI interface {
+	GetBitsLeaderboard(i *GetBitsLeaderboardInput) (*GetBitsLeaderboardOutput, error)
+	GetCheermotes(i *GetCheermotesInput) (*GetCheermotesOutput, error)
+}
+
+// ChannelPointsAPI manages custom Channel Points rewards and redemptions.
+type ChannelPointsAPI interface {
+	CreateCustomReward(i *CreateCustomRewardInput) (*CustomRewardOutput, error)
+	UpdateCustomReward(i *UpdateCustomRewardInput) (*CustomRewardOutput, error)
+	DeleteCustomReward(i *DeleteCustomRewardInput) error
+	GetCustomReward(i *GetCustomRewardInput) (*CustomRewardOutput, error)
+	GetCustomRewardRedemption(i *GetCustomRewardRedemptionInput) (*GetCustomRewardRedemptionOutput, error)
+	UpdateRedemptionStatus(i *UpdateRedemptionStatusInput) (*UpdateRedemptionStatusOutput, error)
+}
+
+// ChannelsAPI reads a channel's editors and manages its VIPs.
+type ChannelsAPI interface {
+	GetChannelEditors(i *GetChannelEditorsInput) (*GetChannelEditorsOutput, error)
+	GetVIPs(i *GetVIPsInput) (*GetVIPsOutput, error)
+	AddChannelVIP(i *AddChannelVIPInput) error
+	RemoveChannelVIP(i *RemoveChannelVIPInput) error
+	SyncVIPs(i *SyncVIPsInput) (*VIPsDiff, error)
+}
+
+// ChatAPI sends chat messages, manages chat settings and reads chatters,
+// badges and emotes.
+type ChatAPI interface {
+	GetChatSettings(i *GetChatSettingsInput) (*GetChatSettingsOutput, error)
+	UpdateChatSettings(i *UpdateChatSettingsInput) (*UpdateChatSettingsOutput, error)
+	SendChatAnnouncement(i *SendChatAnnouncementInput) error
+	SendShoutout(i *SendShoutoutInput) error
+	SendChatMessage(i *SendChatMessageInput) (*SendChatMessageOutput, error)
+	GetUserChatColor(i *GetUserChatColorInput) (*GetUserChatColorOutput, error)
+	UpdateUserChatColor(i *UpdateUserChatColorInput) error
+	GetChatters(i *GetChattersInput) (*GetChattersOutput, error)
+	GetChannelChatBadges(i *GetChannelChatBadgesInput) (*ChatBadgesOutput, error)
+	GetGlobalChatBadges() (*ChatBadgesOutput, error)
+	GetChannelEmotes(i *GetChannelEmotesInput) (*EmotesOutput, error)
+	GetGlobalEmotes() (*EmotesOutput, error)
+	GetEmoteSets(i *GetEmoteSetsInput) (*EmotesOutput, error)
+	GetUserEmotes(i *GetUserEmotesInput) (*EmotesOutput, error)
+}
+
+// GamesAPI reads games.
+type GamesAPI interface {
+	GetGames(i *GetGamesInput) (*GetGamesOutput, error)
+}
+
+// HypeTrainAPI reads hype train events.
+type HypeTrainAPI interface {
+	GetHypeTrainEvents(i *GetHypeTrainEventsInput) (*GetHypeTrainEventsOutput, error)
+}
+
+// ModerationAPI bans users, manages moderators and blocked terms.
+type ModerationAPI interface {
+	BanUser(i *BanUserInput) (*BanUserOutput, error)
+	UnbanUser(i *UnbanUserInput) error
+	GetBannedUsers(i *GetBannedUsersInput) (*GetBannedUsersOutput, error)
+	GetModerators(i *GetModeratorsInput) (*GetModeratorsOutput, error)
+	AddChannelModerator(i *AddChannelModeratorInput) error
+	RemoveChannelModerator(i *RemoveChannelModeratorInput) error
+	GetBlockedTerms(i *GetBlockedTermsInput) (*GetBlockedTermsOutput, error)
+	AddBlockedTerm(i *AddBlockedTermInput) (*AddBlockedTermOutput, error)
+	RemoveBlockedTerm(i *RemoveBlockedTermInput) error
+	SyncBlockedTerms(i *SyncBlockedTermsInput) (*BlockedTermsDiff, error)
+}
+
+// PollsAPI runs polls.
+type PollsAPI interface {
+	CreatePoll(i *CreatePollInput) (*PollsOutput, error)
+	EndPoll(i *EndPollInput) (*PollsOutput, error)
+	GetPolls(i *GetPollsInput) (*PollsOutput, error)
+	WaitPoll(i *WaitPollInput) (*Poll, error)
+}
+
+// PredictionsAPI runs predictions.
+type PredictionsAPI interface {
+	CreatePrediction(i *CreatePredictionInput) (*PredictionsOutput, error)
+	EndPrediction(i *EndPredictionInput) (*PredictionsOutput, error)
+	GetPredictions(i *GetPredictionsInput) (*PredictionsOutput, error)
+	WaitPrediction(i *WaitPredictionInput) (*Prediction, error)
+}
+
+// RaidsAPI starts and cancels raids.
+type RaidsAPI interface {
+	StartRaid(i *StartRaidInput) (*StartRaidOutput, error)
+	CancelRaid(i *CancelRaidInput) error
+}
+
+// ScheduleAPI manages a channel's stream schedule.
+type ScheduleAPI interface {
+	GetChannelStreamSchedule(i *GetChannelStreamScheduleInput) (*GetChannelStreamScheduleOutput, error)
+	CreateScheduleSegment(i *CreateScheduleSegmentInput) (*CreateScheduleSegmentOutput, error)
+	UpdateScheduleSegment(i *UpdateScheduleSegmentInput) (*UpdateScheduleSegmentOutput, error)
+	DeleteScheduleSegment(i *DeleteScheduleSegmentInput) error
+	UpdateScheduleSettings(i *UpdateScheduleSettingsInput) error
+}
+
+// StreamsAPI reads live streams and manages stream markers.
+type StreamsAPI interface {
+	GetStreams(i *GetStreamsInput) (*GetStreamsOutput, error)
+	CreateStreamMarker(i *CreateStreamMarkerInput) (*CreateStreamMarkerOutput, error)
+	GetStreamMarkers(i *GetStreamMarkersInput) (*GetStreamMarkersOutput, error)
+}
+
+// SubscriptionsAPI reads subscriptions.
+type SubscriptionsAPI interface {
+	GetBroadcasterSubscriptions(i *GetBroadcasterSubscriptionsInput) (*GetBroadcasterSubscriptionsOutput, error)
+	CheckUserSubscription(i *CheckUserSubscriptionInput) (*CheckUserSubscriptionOutput, error)
+}
+
+// TeamsAPI reads teams and their members.
+type TeamsAPI interface {
+	GetTeams(i *GetTeamsInput) (*GetTeamsOutput, error)
+	GetChannelTeams(i *GetChannelTeamsInput) (*GetChannelTeamsOutput, error)
+	GetTeamMembers(i *GetTeamMembersInput) (*GetTeamMembersOutput, error)
+}
+
+// UsersAPI reads users.
+type UsersAPI interface {
+	GetUsers(i *GetUsersInput) (*GetUsersOutput, error)
+}
+
+// API is every resource of the Helix API.
+type API interface {
+	AdsAPI
+	AutoModAPI
+	BitsAPI
+	ChannelPointsAPI
+	ChannelsAPI
+	ChatAPI
+	GamesAPI
+	HypeTrainAPI
+	ModerationAPI
+	PollsAPI
+	PredictionsAPI
+	RaidsAPI
+	ScheduleAPI
+	StreamsAPI
+	SubscriptionsAPI
+	TeamsAPI
+	UsersAPI
+}
+
+// Client must implement every interface; a method added to an interface and
+// not the client, or changed on only one of them, fails to compile here.
+var _ API = (*Client)(nil)
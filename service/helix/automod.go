@@ -0,0 +1,265 @@
+package helix
+
+import (
+	"fmt"
+
+	"github.com/catsby/go-twitch/twitch"
+)
+
+// MaxAutoModBatch is the number of messages Twitch accepts in a single
+// CheckAutoModStatus request.
+const MaxAutoModBatch = 100
+
+// AutoModMessage is a message to check against AutoMod.
+type AutoModMessage struct {
+	// MsgId is a caller defined ID used to match the message to its result.
+	MsgId   string `json:"msg_id"`
+	MsgText string `json:"msg_text"`
+}
+
+// AutoModStatus is whether a checked message would be held by AutoMod.
+type AutoModStatus struct {
+	MsgId       string `mapstructure:"msg_id"`
+	IsPermitted bool   `mapstructure:"is_permitted"`
+}
+
+// CheckAutoModStatusInput is the input to the CheckAutoModStatus function.
+type CheckAutoModStatusInput struct {
+	BroadcasterId string
+
+	// Messages are sent in batches of MaxAutoModBatch.
+	Messages []*AutoModMessage
+}
+
+// CheckAutoModStatusOutput is the output of the CheckAutoModStatus function.
+type CheckAutoModStatusOutput struct {
+	Statuses []*AutoModStatus `mapstructure:"data"`
+}
+
+// CheckAutoModStatus checks whether AutoMod would flag the given messages.
+// Any number of messages may be given; they are sent in as many requests as
+// needed, and the results are returned in the same order.
+// Scope: moderation:read
+// See:
+//  - https://dev.twitch.tv/docs/api/reference#check-automod-status
+func (k *Client) CheckAutoModStatus(i *CheckAutoModStatusInput) (*CheckAutoModStatusOutput, error) {
+	if i == nil || i.BroadcasterId == "" {
+		return nil, fmt.Errorf("[ERR] No BroadcasterId for CheckAutoModStatus")
+	}
+
+	ro := &twitch.RequestOptions{
+		Params: map[string]string{
+			"broadcaster_id": i.BroadcasterId,
+		},
+		Endpoint: "CheckAutoModStatus",
+		Input:    i,
+	}
+
+	var out CheckAutoModStatusOutput
+	for start := 0; start < len(i.Messages); start += MaxAutoModBatch {
+		end := start + MaxAutoModBatch
+		if end > len(i.Messages) {
+			end = len(i.Messages)
+		}
+
+		body := map[string]interface{}{
+			"data": i.Messages[start:end],
+		}
+		resp, err := k.PostJSON("/moderation/enforcements/status", body, ro)
+		if err != nil {
+			return nil, err
+		}
+
+		var o CheckAutoModStatusOutput
+		if err := twitch.DecodeJSON(&o, resp.Body); err != nil {
+			return nil, err
+		}
+		out.Statuses = append(out.Statuses, o.Statuses...)
+	}
+
+	return &out, nil
+}
+
+// AutoModAction is what to do with a message held by AutoMod.
+type AutoModAction string
+
+const (
+	AutoModActionAllow AutoModAction = "ALLOW"
+	AutoModActionDeny  AutoModAction = "DENY"
+)
+
+// ManageHeldAutoModMessageInput is the input to the ManageHeldAutoModMessage
+// function.
+type ManageHeldAutoModMessageInput struct {
+	// UserId is the moderator approving or denying the message.
+	UserId string
+	MsgId  string
+	Action AutoModAction
+}
+
+// ManageHeldAutoModMessage approves or denies a message held by AutoMod.
+// Scope: moderator:manage:automod
+// See:
+//  - https://dev.twitch.tv/docs/api/reference#manage-held-automod-messages
+func (k *Client) ManageHeldAutoModMessage(i *ManageHeldAutoModMessageInput) error {
+	if i == nil || i.UserId == "" || i.MsgId == "" || i.Action == "" {
+		return fmt.Errorf("[ERR] No UserId, MsgId or Action for ManageHeldAutoModMessage")
+	}
+
+	body := map[string]string{
+		"user_id": i.UserId,
+		"msg_id":  i.MsgId,
+		"action":  string(i.Action),
+	}
+
+	resp, err := k.PostJSON("/moderation/automod/message", body, &twitch.RequestOptions{Endpoint: "ManageHeldAutoModMessage", Input: i})
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+
+	return nil
+}
+
+// AutoModSettings are the AutoMod levels of a channel. Each level is from 0
+// (no filtering) to 4 (most filtering).
+type AutoModSettings struct {
+	BroadcasterId string `mapstructure:"broadcaster_id"`
+	ModeratorId   string `mapstructure:"moderator_id"`
+
+	// OverallLevel is set when the channel uses a single level for every
+	// category, and nil when the categories are set individually.
+	OverallLevel *int `mapstructure:"overall_level"`
+
+	Disability              int `mapstructure:"disability"`
+	Aggression              int `mapstructure:"aggression"`
+	SexualitySexOrGender    int `mapstructure:"sexuality_sex_or_gender"`
+	Misogyny                int `mapstructure:"misogyny"`
+	Bullying                int `mapstructure:"bullying"`
+	Swearing                int `mapstructure:"swearing"`
+	RaceEthnicityOrReligion int `mapstructure:"race_ethnicity_or_religion"`
+	SexBasedTerms           int `mapstructure:"sex_based_terms"`
+}
+
+// GetAutoModSettingsInput is the input to the GetAutoModSettings function.
+type GetAutoModSettingsInput struct {
+	BroadcasterId string
+	ModeratorId   string
+}
+
+// GetAutoModSettingsOutput is the output of the GetAutoModSettings function.
+type GetAutoModSettingsOutput struct {
+	Settings []*AutoModSettings `mapstructure:"data"`
+}
+
+// GetAutoModSettings returns the AutoMod settings of a channel.
+// Scope: moderator:read:automod_settings
+// See:
+//  - https://dev.twitch.tv/docs/api/reference#get-automod-settings
+func (k *Client) GetAutoModSettings(i *GetAutoModSettingsInput) (*GetAutoModSettingsOutput, error) {
+	if i == nil || i.BroadcasterId == "" || i.ModeratorId == "" {
+		return nil, fmt.Errorf("[ERR] No BroadcasterId or ModeratorId for GetAutoModSettings")
+	}
+
+	ro := &twitch.RequestOptions{
+		Params: map[string]string{
+			"broadcaster_id": i.BroadcasterId,
+			"moderator_id":   i.ModeratorId,
+		},
+		Endpoint: "GetAutoModSettings",
+		Input:    i,
+	}
+
+	resp, err := k.Get("/moderation/automod/settings", ro)
+	if err != nil {
+		return nil, err
+	}
+
+	var o GetAutoModSettingsOutput
+	if err := twitch.DecodeJSON(&o, resp.Body); err != nil {
+		return nil, err
+	}
+
+	return &o, nil
+}
+
+// UpdateAutoModSettingsInput is the input to the UpdateAutoModSettings
+// function. Twitch replaces the settings as a whole, so either OverallLevel is
+// set, or every category level is sent.
+type UpdateAutoModSettingsInput struct {
+	BroadcasterId string
+	ModeratorId   string
+
+	// OverallLevel sets every category from a single level. When set, the
+	// category levels below are ignored.
+	OverallLevel *int
+
+	Disability              int
+	Aggression              int
+	SexualitySexOrGender    int
+	Misogyny                int
+	Bullying                int
+	Swearing                int
+	RaceEthnicityOrReligion int
+	SexBasedTerms           int
+}
+
+// UpdateAutoModSettingsOutput is the output of the UpdateAutoModSettings
+// function.
+type UpdateAutoModSettingsOutput struct {
+	Settings []*AutoModSettings `mapstructure:"data"`
+}
+
+// UpdateAutoModSettings replaces the AutoMod settings of a channel.
+// Scope: moderator:manage:automod_settings
+// See:
+//  - https://dev.twitch.tv/docs/api/reference#update-automod-settings
+func (k *Client) UpdateAutoModSettings(i *UpdateAutoModSettingsInput) (*UpdateAutoModSettingsOutput, error) {
+	if i == nil || i.BroadcasterId == "" || i.ModeratorId == "" {
+		return nil, fmt.Errorf("[ERR] No BroadcasterId or ModeratorId for UpdateAutoModSettings")
+	}
+
+	var body map[string]int
+	if i.OverallLevel != nil {
+		body = map[string]int{
+			"overall_level": *i.OverallLevel,
+		}
+	} else {
+		body = map[string]int{
+			"disability":                 i.Disability,
+			"aggression":                 i.Aggression,
+			"sexuality_sex_or_gender":    i.SexualitySexOrGender,
+			"misogyny":                   i.Misogyny,
+			"bullying":                   i.Bullying,
+			"swearing":                   i.Swearing,
+			"race_ethnicity_or_religion": i.RaceEthnicityOrReligion,
+			"sex_based_terms":            i.SexBasedTerms,
+		}
+	}
+	for name, level := range body {
+		if level < 0 || level > 4 {
+			return nil, fmt.Errorf("[ERR] Invalid AutoMod level %d for %s, must be 0-4", level, name)
+		}
+	}
+
+	ro := &twitch.RequestOptions{
+		Params: map[string]string{
+			"broadcaster_id": i.BroadcasterId,
+			"moderator_id":   i.ModeratorId,
+		},
+		Endpoint: "UpdateAutoModSettings",
+		Input:    i,
+	}
+
+	resp, err := k.PutJSON("/moderation/automod/settings", body, ro)
+	if err != nil {
+		return nil, err
+	}
+
+	var o UpdateAutoModSettingsOutput
+	if err := twitch.DecodeJSON(&o, resp.Body); err != nil {
+		return nil, err
+	}
+
+	return &o, nil
+}
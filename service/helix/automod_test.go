@@ -0,0 +1,110 @@
+package helix
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestAutoMod_CheckAutoModStatus_batched(t *testing.T) {
+	t.Parallel()
+
+	var messages []*AutoModMessage
+	for i := 0; i < MaxAutoModBatch+1; i++ {
+		messages = append(messages, &AutoModMessage{
+			MsgId:   fmt.Sprintf("msg%d", i),
+			MsgText: fmt.Sprintf("hello %d", i),
+		})
+	}
+
+	var err error
+	var output *CheckAutoModStatusOutput
+	recordHelix(t, "automod/check_status_batched", func(c *Client) {
+		output, err = c.CheckAutoModStatus(&CheckAutoModStatusInput{
+			BroadcasterId: "12345",
+			Messages:      messages,
+		})
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(output.Statuses) != len(messages) {
+		t.Fatalf("Expected (%d) statuses, got (%d)", len(messages), len(output.Statuses))
+	}
+
+	for i, s := range output.Statuses {
+		if s.MsgId != messages[i].MsgId {
+			t.Fatalf("Status (%d) out of order: %s", i, s.MsgId)
+		}
+	}
+
+	if output.Statuses[100].IsPermitted {
+		t.Fatalf("Expected the last message to be held")
+	}
+}
+
+func TestAutoMod_ManageHeldAutoModMessage(t *testing.T) {
+	t.Parallel()
+
+	var err error
+	recordHelix(t, "automod/manage_held", func(c *Client) {
+		err = c.ManageHeldAutoModMessage(&ManageHeldAutoModMessageInput{
+			UserId: "9327994",
+			MsgId:  "836013710",
+			Action: AutoModActionAllow,
+		})
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestAutoMod_Settings(t *testing.T) {
+	t.Parallel()
+
+	var err error
+	var output *GetAutoModSettingsOutput
+	recordHelix(t, "automod/get_settings", func(c *Client) {
+		output, err = c.GetAutoModSettings(&GetAutoModSettingsInput{
+			BroadcasterId: "1234",
+			ModeratorId:   "5678",
+		})
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(output.Settings) != 1 || output.Settings[0].OverallLevel != nil {
+		t.Fatalf("Expected per category settings, got %#v", output.Settings)
+	}
+
+	level := 3
+	var updated *UpdateAutoModSettingsOutput
+	recordHelix(t, "automod/update_settings_overall", func(c *Client) {
+		updated, err = c.UpdateAutoModSettings(&UpdateAutoModSettingsInput{
+			BroadcasterId: "1234",
+			ModeratorId:   "5678",
+			OverallLevel:  &level,
+		})
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s := updated.Settings[0]
+	if s.OverallLevel == nil || *s.OverallLevel != 3 || s.Bullying != 2 {
+		t.Fatalf("Bad updated settings: %#v", s)
+	}
+
+	level = 5
+	recordHelix(t, "automod/update_settings_overall", func(c *Client) {
+		_, err = c.UpdateAutoModSettings(&UpdateAutoModSettingsInput{
+			BroadcasterId: "1234",
+			ModeratorId:   "5678",
+			OverallLevel:  &level,
+		})
+	})
+	if err == nil {
+		t.Fatalf("Expected an error for an out of range level")
+	}
+}
@@ -0,0 +1,163 @@
+package helix
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/catsby/go-twitch/twitch"
+)
+
+// BadgeVersion is one version of a chat badge, Ex: the 6 month version of the
+// subscriber badge.
+type BadgeVersion struct {
+	Id         string `mapstructure:"id"`
+	ImageUrl1x string `mapstructure:"image_url_1x"`
+	ImageUrl2x string `mapstructure:"image_url_2x"`
+	ImageUrl4x string `mapstructure:"image_url_4x"`
+
+	Title       string `mapstructure:"title"`
+	Description string `mapstructure:"description"`
+
+	// ClickAction is what happens when the badge is clicked, Ex:
+	// subscribe_to_channel, and ClickUrl is set for visit_url.
+	ClickAction string `mapstructure:"click_action"`
+	ClickUrl    string `mapstructure:"click_url"`
+}
+
+// BadgeSet is a chat badge and its versions, Ex: subscriber.
+type BadgeSet struct {
+	SetId    string          `mapstructure:"set_id"`
+	Versions []*BadgeVersion `mapstructure:"versions"`
+}
+
+// Version returns the version with the given ID, or nil.
+func (s *BadgeSet) Version(id string) *BadgeVersion {
+	for _, v := range s.Versions {
+		if v.Id == id {
+			return v
+		}
+	}
+	return nil
+}
+
+// ChatBadgesOutput is the output of the functions returning chat badges.
+type ChatBadgesOutput struct {
+	BadgeSets []*BadgeSet `mapstructure:"data"`
+}
+
+// GetChannelChatBadgesInput is the input to the GetChannelChatBadges function.
+type GetChannelChatBadgesInput struct {
+	BroadcasterId string
+}
+
+// GetChannelChatBadges returns the custom chat badges of a channel, Ex: its
+// subscriber and Bits badges.
+// See:
+//  - https://dev.twitch.tv/docs/api/reference#get-channel-chat-badges
+func (k *Client) GetChannelChatBadges(i *GetChannelChatBadgesInput) (*ChatBadgesOutput, error) {
+	if i == nil || i.BroadcasterId == "" {
+		return nil, fmt.Errorf("[ERR] No BroadcasterId for GetChannelChatBadges")
+	}
+
+	ro := &twitch.RequestOptions{
+		Params: map[string]string{
+			"broadcaster_id": i.BroadcasterId,
+		},
+		Endpoint: "GetChannelChatBadges",
+		Input:    i,
+	}
+
+	return k.getChatBadges("/chat/badges", ro)
+}
+
+// GetGlobalChatBadges returns the chat badges available in every channel.
+// See:
+//  - https://dev.twitch.tv/docs/api/reference#get-global-chat-badges
+func (k *Client) GetGlobalChatBadges() (*ChatBadgesOutput, error) {
+	return k.getChatBadges("/chat/badges/global", nil)
+}
+
+func (k *Client) getChatBadges(p string, ro *twitch.RequestOptions) (*ChatBadgesOutput, error) {
+	resp, err := k.Get(p, ro)
+	if err != nil {
+		return nil, err
+	}
+
+	var o ChatBadgesOutput
+	if err := twitch.DecodeJSON(&o, resp.Body); err != nil {
+		return nil, err
+	}
+
+	return &o, nil
+}
+
+// BadgeCache caches the global chat badges and those of a channel, keyed by
+// set ID, so the badges tag of a chat message can be rendered. It is safe for
+// concurrent use.
+type BadgeCache struct {
+	client        *Client
+	broadcasterId string
+
+	mu   sync.Mutex
+	sets map[string]map[string]*BadgeVersion
+}
+
+// NewBadgeCache returns an empty BadgeCache for the given channel. The badges
+// are requested on first use.
+func NewBadgeCache(c *Client, broadcasterId string) *BadgeCache {
+	return &BadgeCache{
+		client:        c,
+		broadcasterId: broadcasterId,
+	}
+}
+
+// Badge returns the given version of a badge set, Ex: ("subscriber", "6"), or
+// nil if there is no such badge. A channel's badge versions take precedence
+// over the global ones.
+func (c *BadgeCache) Badge(setId, version string) (*BadgeVersion, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.sets == nil {
+		if err := c.fetch(); err != nil {
+			return nil, err
+		}
+	}
+	return c.sets[setId][version], nil
+}
+
+// Refresh requests the badges again, Ex: after a broadcaster changes their
+// subscriber badges.
+func (c *BadgeCache) Refresh() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.fetch()
+}
+
+func (c *BadgeCache) fetch() error {
+	global, err := c.client.GetGlobalChatBadges()
+	if err != nil {
+		return err
+	}
+
+	channel, err := c.client.GetChannelChatBadges(&GetChannelChatBadgesInput{
+		BroadcasterId: c.broadcasterId,
+	})
+	if err != nil {
+		return err
+	}
+
+	sets := make(map[string]map[string]*BadgeVersion)
+	for _, o := range []*ChatBadgesOutput{global, channel} {
+		for _, s := range o.BadgeSets {
+			if sets[s.SetId] == nil {
+				sets[s.SetId] = make(map[string]*BadgeVersion)
+			}
+			for _, v := range s.Versions {
+				sets[s.SetId][v.Id] = v
+			}
+		}
+	}
+	c.sets = sets
+	return nil
+}
@@ -0,0 +1,81 @@
+package helix
+
+import "testing"
+
+func TestBadges_GetGlobalChatBadges(t *testing.T) {
+	t.Parallel()
+
+	var err error
+	var output *ChatBadgesOutput
+	recordHelix(t, "badges/global", func(c *Client) {
+		output, err = c.GetGlobalChatBadges()
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(output.BadgeSets) != 2 {
+		t.Fatalf("Expected (2) badge sets, got (%d)", len(output.BadgeSets))
+	}
+	if v := output.BadgeSets[0].Version("1"); v == nil || v.Title != "moderator" {
+		t.Fatalf("Bad moderator badge: %#v", v)
+	}
+}
+
+func TestBadges_GetChannelChatBadges(t *testing.T) {
+	t.Parallel()
+
+	var err error
+	var output *ChatBadgesOutput
+	recordHelix(t, "badges/channel", func(c *Client) {
+		output, err = c.GetChannelChatBadges(&GetChannelChatBadgesInput{
+			BroadcasterId: "135093069",
+		})
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s := output.BadgeSets[0]
+	if s.SetId != "subscriber" || len(s.Versions) != 2 {
+		t.Fatalf("Bad badge set: %#v", s)
+	}
+	if v := s.Version("6"); v == nil || v.ClickAction != "subscribe_to_channel" || v.ClickUrl != "" {
+		t.Fatalf("Bad 6 month badge: %#v", v)
+	}
+	if s.Version("12") != nil {
+		t.Fatalf("Expected no 12 month badge")
+	}
+}
+
+func TestBadgeCache(t *testing.T) {
+	t.Parallel()
+
+	recordHelix(t, "badges/cache", func(c *Client) {
+		cache := NewBadgeCache(c, "135093069")
+
+		v, err := cache.Badge("subscriber", "0")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if v == nil || v.Title != "Subscriber" {
+			t.Fatalf("Expected the channel badge to override the global one, got %#v", v)
+		}
+
+		v, err = cache.Badge("moderator", "1")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if v == nil || v.Title != "moderator" {
+			t.Fatalf("Expected the global moderator badge, got %#v", v)
+		}
+
+		if v, _ := cache.Badge("vip", "1"); v != nil {
+			t.Fatalf("Expected no vip badge, got %#v", v)
+		}
+
+		if err := cache.Refresh(); err != nil {
+			t.Fatal(err)
+		}
+	})
+}
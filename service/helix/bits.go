@@ -0,0 +1,211 @@
+package helix
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/catsby/go-twitch/twitch"
+)
+
+// BitsLeaderboardPeriod is the period a Bits leaderboard covers.
+type BitsLeaderboardPeriod string
+
+const (
+	BitsLeaderboardDay   BitsLeaderboardPeriod = "day"
+	BitsLeaderboardWeek  BitsLeaderboardPeriod = "week"
+	BitsLeaderboardMonth BitsLeaderboardPeriod = "month"
+	BitsLeaderboardYear  BitsLeaderboardPeriod = "year"
+	BitsLeaderboardAll   BitsLeaderboardPeriod = "all"
+)
+
+// BitsLeader is a user on a Bits leaderboard.
+type BitsLeader struct {
+	UserId    string `mapstructure:"user_id"`
+	UserLogin string `mapstructure:"user_login"`
+	UserName  string `mapstructure:"user_name"`
+	Rank      int    `mapstructure:"rank"`
+
+	// Score is the number of Bits cheered in the period.
+	Score int `mapstructure:"score"`
+}
+
+// DateRange is the period covered by a Bits leaderboard.
+type DateRange struct {
+	StartedAt time.Time `mapstructure:"started_at"`
+	EndedAt   time.Time `mapstructure:"ended_at"`
+}
+
+// GetBitsLeaderboardInput is the input to the GetBitsLeaderboard function.
+type GetBitsLeaderboardInput struct {
+	// Count is the number of leaders to return. Default: 10. Maximum: 100.
+	Count int
+
+	// Period defaults to BitsLeaderboardAll.
+	Period BitsLeaderboardPeriod
+
+	// StartedAt is a time in the period, Ex: any time in the week for
+	// BitsLeaderboardWeek. It is ignored for BitsLeaderboardAll.
+	StartedAt time.Time
+
+	// UserId returns the leaderboard around the user's rank.
+	UserId string
+}
+
+// GetBitsLeaderboardOutput is the output of the GetBitsLeaderboard function.
+type GetBitsLeaderboardOutput struct {
+	Leaders []*BitsLeader `mapstructure:"data"`
+
+	// DateRange is the zero value for BitsLeaderboardAll.
+	DateRange *DateRange `mapstructure:"date_range"`
+	Total     int        `mapstructure:"total"`
+}
+
+// GetBitsLeaderboard returns the users who cheered the most Bits in the
+// channel the access token belongs to.
+// Scope: bits:read
+// See:
+//  - https://dev.twitch.tv/docs/api/reference#get-bits-leaderboard
+func (k *Client) GetBitsLeaderboard(i *GetBitsLeaderboardInput) (*GetBitsLeaderboardOutput, error) {
+	if i == nil {
+		i = new(GetBitsLeaderboardInput)
+	}
+	if i.Count < 0 || i.Count > 100 {
+		return nil, fmt.Errorf("[ERR] Invalid Count %d for GetBitsLeaderboard, must be 1-100", i.Count)
+	}
+
+	ro := &twitch.RequestOptions{
+		Params:   map[string]string{},
+		Endpoint: "GetBitsLeaderboard",
+		Input:    i,
+	}
+	if i.Count > 0 {
+		ro.Params["count"] = strconv.Itoa(i.Count)
+	}
+	if i.Period != "" {
+		ro.Params["period"] = string(i.Period)
+	}
+	if !i.StartedAt.IsZero() {
+		ro.Params["started_at"] = i.StartedAt.UTC().Format(time.RFC3339)
+	}
+	if i.UserId != "" {
+		ro.Params["user_id"] = i.UserId
+	}
+
+	resp, err := k.Get("/bits/leaderboard", ro)
+	if err != nil {
+		return nil, err
+	}
+
+	var o GetBitsLeaderboardOutput
+	if err := twitch.DecodeJSON(&o, resp.Body); err != nil {
+		return nil, err
+	}
+
+	return &o, nil
+}
+
+// CheermoteImageSet are the image URLs of a cheermote tier for one theme,
+// keyed by scale: "1", "1.5", "2", "3" and "4".
+type CheermoteImageSet struct {
+	Animated map[string]string `mapstructure:"animated"`
+	Static   map[string]string `mapstructure:"static"`
+}
+
+// CheermoteImages are the image URLs of a cheermote tier.
+type CheermoteImages struct {
+	Dark  *CheermoteImageSet `mapstructure:"dark"`
+	Light *CheermoteImageSet `mapstructure:"light"`
+}
+
+// CheermoteTier is the image and color used for cheers of at least MinBits.
+type CheermoteTier struct {
+	Id      string           `mapstructure:"id"`
+	MinBits int              `mapstructure:"min_bits"`
+	Color   string           `mapstructure:"color"`
+	Images  *CheermoteImages `mapstructure:"images"`
+
+	CanCheer       bool `mapstructure:"can_cheer"`
+	ShowInBitsCard bool `mapstructure:"show_in_bits_card"`
+}
+
+// Image returns the URL of the tier's image for the given theme and scale,
+// Ex: (EmoteThemeDark, true, "1.5"), or an empty string if there is none.
+func (t *CheermoteTier) Image(theme EmoteTheme, animated bool, scale string) string {
+	if t.Images == nil {
+		return ""
+	}
+
+	set := t.Images.Light
+	if theme == EmoteThemeDark {
+		set = t.Images.Dark
+	}
+	if set == nil {
+		return ""
+	}
+
+	if animated {
+		return set.Animated[scale]
+	}
+	return set.Static[scale]
+}
+
+// Cheermote is an emote used to cheer Bits, Ex: Cheer100.
+type Cheermote struct {
+	Prefix string           `mapstructure:"prefix"`
+	Tiers  []*CheermoteTier `mapstructure:"tiers"`
+
+	// Type is global_first_party, global_third_party, channel_custom,
+	// display_only or sponsored.
+	Type         string    `mapstructure:"type"`
+	Order        int       `mapstructure:"order"`
+	LastUpdated  time.Time `mapstructure:"last_updated"`
+	IsCharitable bool      `mapstructure:"is_charitable"`
+}
+
+// Tier returns the tier used for a cheer of the given number of Bits, or nil
+// if the cheer is below every tier.
+func (c *Cheermote) Tier(bits int) *CheermoteTier {
+	var tier *CheermoteTier
+	for _, t := range c.Tiers {
+		if t.MinBits <= bits && (tier == nil || t.MinBits > tier.MinBits) {
+			tier = t
+		}
+	}
+	return tier
+}
+
+// GetCheermotesInput is the input to the GetCheermotes function.
+type GetCheermotesInput struct {
+	// BroadcasterId also returns the channel's custom cheermotes.
+	BroadcasterId string
+}
+
+// GetCheermotesOutput is the output of the GetCheermotes function.
+type GetCheermotesOutput struct {
+	Cheermotes []*Cheermote `mapstructure:"data"`
+}
+
+// GetCheermotes returns the cheermotes available globally, or in a channel.
+// See:
+//  - https://dev.twitch.tv/docs/api/reference#get-cheermotes
+func (k *Client) GetCheermotes(i *GetCheermotesInput) (*GetCheermotesOutput, error) {
+	ro := &twitch.RequestOptions{Endpoint: "GetCheermotes", Input: i}
+	if i != nil && i.BroadcasterId != "" {
+		ro.Params = map[string]string{
+			"broadcaster_id": i.BroadcasterId,
+		}
+	}
+
+	resp, err := k.Get("/bits/cheermotes", ro)
+	if err != nil {
+		return nil, err
+	}
+
+	var o GetCheermotesOutput
+	if err := twitch.DecodeJSON(&o, resp.Body); err != nil {
+		return nil, err
+	}
+
+	return &o, nil
+}
@@ -0,0 +1,93 @@
+package helix
+
+import (
+	"testing"
+	"time"
+)
+
+func TestBits_GetBitsLeaderboard(t *testing.T) {
+	t.Parallel()
+
+	var err error
+	var output *GetBitsLeaderboardOutput
+	recordHelix(t, "bits/leaderboard", func(c *Client) {
+		output, err = c.GetBitsLeaderboard(&GetBitsLeaderboardInput{
+			Count:     2,
+			Period:    BitsLeaderboardWeek,
+			StartedAt: time.Date(2018, 2, 5, 8, 0, 0, 0, time.UTC),
+		})
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(output.Leaders) != 2 || output.Leaders[0].Score != 12543 || output.Leaders[1].Rank != 2 {
+		t.Fatalf("Bad leaders: %#v", output.Leaders)
+	}
+	if d := output.DateRange.EndedAt.Sub(output.DateRange.StartedAt); d != 7*24*time.Hour {
+		t.Fatalf("Expected a week long date range, got (%s)", d)
+	}
+}
+
+func TestBits_GetBitsLeaderboard_all(t *testing.T) {
+	t.Parallel()
+
+	var err error
+	var output *GetBitsLeaderboardOutput
+	recordHelix(t, "bits/leaderboard_all", func(c *Client) {
+		output, err = c.GetBitsLeaderboard(nil)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if output.Total != 0 || !output.DateRange.StartedAt.IsZero() {
+		t.Fatalf("Bad leaderboard: %#v", output)
+	}
+}
+
+func TestBits_GetCheermotes(t *testing.T) {
+	t.Parallel()
+
+	var err error
+	var output *GetCheermotesOutput
+	recordHelix(t, "bits/cheermotes", func(c *Client) {
+		output, err = c.GetCheermotes(&GetCheermotesInput{
+			BroadcasterId: "41245072",
+		})
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c := output.Cheermotes[0]
+	if c.Prefix != "Cheer" || len(c.Tiers) != 3 {
+		t.Fatalf("Bad cheermote: %#v", c)
+	}
+
+	cases := []struct {
+		bits    int
+		minBits int
+	}{
+		{1, 1},
+		{99, 1},
+		{100, 100},
+		{5000, 1000},
+	}
+	for _, tc := range cases {
+		if tier := c.Tier(tc.bits); tier == nil || tier.MinBits != tc.minBits {
+			t.Errorf("Expected tier (%d) for (%d) bits, got %#v", tc.minBits, tc.bits, tier)
+		}
+	}
+	if c.Tier(0) != nil {
+		t.Fatalf("Expected no tier for (0) bits")
+	}
+
+	expected := "https://d3aqoihi2n8ty8.cloudfront.net/actions/cheer/dark/animated/100/1.5.gif"
+	if u := c.Tier(250).Image(EmoteThemeDark, true, "1.5"); u != expected {
+		t.Fatalf("Expected image (%s), got (%s)", expected, u)
+	}
+	if u := c.Tier(1).Image(EmoteThemeLight, false, "5"); u != "" {
+		t.Fatalf("Expected no image for an unknown scale, got (%s)", u)
+	}
+}
@@ -0,0 +1,291 @@
+package helix
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/catsby/go-twitch/twitch"
+	yaml "gopkg.in/yaml.v2"
+)
+
+// BlockedTerm is a word or phrase that is blocked from a channel's chat.
+type BlockedTerm struct {
+	BroadcasterId string    `mapstructure:"broadcaster_id"`
+	ModeratorId   string    `mapstructure:"moderator_id"`
+	Id            string    `mapstructure:"id"`
+	Text          string    `mapstructure:"text"`
+	CreatedAt     time.Time `mapstructure:"created_at"`
+	UpdatedAt     time.Time `mapstructure:"updated_at"`
+
+	// ExpiresAt is the zero time for terms that do not expire.
+	ExpiresAt time.Time `mapstructure:"expires_at"`
+}
+
+// GetBlockedTermsInput is the input to the GetBlockedTerms function.
+type GetBlockedTermsInput struct {
+	BroadcasterId string
+	ModeratorId   string
+
+	// Maximum number of objects to return. Default: 20. Maximum: 100.
+	First int
+	After string
+}
+
+// GetBlockedTermsOutput is the output of the GetBlockedTerms function.
+type GetBlockedTermsOutput struct {
+	BlockedTerms []*BlockedTerm `mapstructure:"data"`
+	Pagination   *Pagination    `mapstructure:"pagination"`
+}
+
+// GetBlockedTerms returns a page of the terms blocked in a channel.
+// Scope: moderator:read:blocked_terms
+// See:
+//  - https://dev.twitch.tv/docs/api/reference#get-blocked-terms
+func (k *Client) GetBlockedTerms(i *GetBlockedTermsInput) (*GetBlockedTermsOutput, error) {
+	if i == nil || i.BroadcasterId == "" || i.ModeratorId == "" {
+		return nil, fmt.Errorf("[ERR] No BroadcasterId or ModeratorId for GetBlockedTerms")
+	}
+
+	ro := &twitch.RequestOptions{
+		Params: map[string]string{
+			"broadcaster_id": i.BroadcasterId,
+			"moderator_id":   i.ModeratorId,
+		},
+		Endpoint: "GetBlockedTerms",
+		Input:    i,
+	}
+	setPageParams(ro, i.First, i.After, "")
+
+	resp, err := k.Get("/moderation/blocked_terms", ro)
+	if err != nil {
+		return nil, err
+	}
+
+	var o GetBlockedTermsOutput
+	if err := twitch.DecodeJSON(&o, resp.Body); err != nil {
+		return nil, err
+	}
+
+	return &o, nil
+}
+
+// AddBlockedTermInput is the input to the AddBlockedTerm function.
+type AddBlockedTermInput struct {
+	BroadcasterId string
+	ModeratorId   string
+
+	// Text is the term to block. It may contain * as a wildcard.
+	// Minimum: 2 characters. Maximum: 500 characters.
+	Text string
+}
+
+// AddBlockedTermOutput is the output of the AddBlockedTerm function.
+type AddBlockedTermOutput struct {
+	BlockedTerms []*BlockedTerm `mapstructure:"data"`
+}
+
+// AddBlockedTerm blocks a term in a channel's chat.
+// Scope: moderator:manage:blocked_terms
+// See:
+//  - https://dev.twitch.tv/docs/api/reference#add-blocked-term
+func (k *Client) AddBlockedTerm(i *AddBlockedTermInput) (*AddBlockedTermOutput, error) {
+	if i == nil || i.BroadcasterId == "" || i.ModeratorId == "" || i.Text == "" {
+		return nil, fmt.Errorf("[ERR] No BroadcasterId, ModeratorId or Text for AddBlockedTerm")
+	}
+
+	ro := &twitch.RequestOptions{
+		Params: map[string]string{
+			"broadcaster_id": i.BroadcasterId,
+			"moderator_id":   i.ModeratorId,
+		},
+		Endpoint: "AddBlockedTerm",
+		Input:    i,
+	}
+
+	resp, err := k.PostJSON("/moderation/blocked_terms", map[string]string{"text": i.Text}, ro)
+	if err != nil {
+		return nil, err
+	}
+
+	var o AddBlockedTermOutput
+	if err := twitch.DecodeJSON(&o, resp.Body); err != nil {
+		return nil, err
+	}
+
+	return &o, nil
+}
+
+// RemoveBlockedTermInput is the input to the RemoveBlockedTerm function.
+type RemoveBlockedTermInput struct {
+	BroadcasterId string
+	ModeratorId   string
+
+	// Id is the ID of the blocked term, not its text.
+	Id string
+}
+
+// RemoveBlockedTerm unblocks a term in a channel's chat.
+// Scope: moderator:manage:blocked_terms
+// See:
+//  - https://dev.twitch.tv/docs/api/reference#remove-blocked-term
+func (k *Client) RemoveBlockedTerm(i *RemoveBlockedTermInput) error {
+	if i == nil || i.BroadcasterId == "" || i.ModeratorId == "" || i.Id == "" {
+		return fmt.Errorf("[ERR] No BroadcasterId, ModeratorId or Id for RemoveBlockedTerm")
+	}
+
+	ro := &twitch.RequestOptions{
+		Params: map[string]string{
+			"broadcaster_id": i.BroadcasterId,
+			"moderator_id":   i.ModeratorId,
+			"id":             i.Id,
+		},
+		Endpoint: "RemoveBlockedTerm",
+		Input:    i,
+	}
+
+	resp, err := k.Delete("/moderation/blocked_terms", ro)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+
+	return nil
+}
+
+// ReadBlockedTerms reads a YAML list of terms, Ex:
+//
+//   - "badword"
+//   - "bad phrase*"
+func ReadBlockedTerms(r io.Reader) ([]string, error) {
+	b, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var terms []string
+	if err := yaml.Unmarshal(b, &terms); err != nil {
+		return nil, err
+	}
+
+	return terms, nil
+}
+
+// BlockedTermsDiff is the change needed to make a channel's blocked terms
+// match a desired list.
+type BlockedTermsDiff struct {
+	// Add are the desired terms that are not blocked yet.
+	Add []string
+
+	// Remove are the blocked terms that are not in the desired list.
+	Remove []*BlockedTerm
+}
+
+// Empty reports whether the diff has no changes.
+func (d *BlockedTermsDiff) Empty() bool {
+	return len(d.Add) == 0 && len(d.Remove) == 0
+}
+
+// DiffBlockedTerms compares the current blocked terms to a desired list.
+// Twitch matches terms case-insensitively, so they are compared that way,
+// ignoring surrounding whitespace and duplicates.
+func DiffBlockedTerms(current []*BlockedTerm, desired []string) *BlockedTermsDiff {
+	want := make(map[string]string)
+	for _, t := range desired {
+		t = strings.TrimSpace(t)
+		if t != "" {
+			want[strings.ToLower(t)] = t
+		}
+	}
+
+	d := new(BlockedTermsDiff)
+	have := make(map[string]bool)
+	for _, bt := range current {
+		key := strings.ToLower(strings.TrimSpace(bt.Text))
+		if _, ok := want[key]; !ok || have[key] {
+			d.Remove = append(d.Remove, bt)
+		}
+		have[key] = true
+	}
+
+	for key, t := range want {
+		if !have[key] {
+			d.Add = append(d.Add, t)
+		}
+	}
+	sort.Strings(d.Add)
+
+	return d
+}
+
+// SyncBlockedTermsInput is the input to the SyncBlockedTerms function.
+type SyncBlockedTermsInput struct {
+	BroadcasterId string
+	ModeratorId   string
+
+	// Terms is the desired list of blocked terms, Ex: from ReadBlockedTerms.
+	Terms []string
+
+	// DryRun returns the diff without applying it.
+	DryRun bool
+}
+
+// SyncBlockedTerms makes a channel's blocked terms match Terms, adding and
+// removing terms as needed, and returns the changes made. Every page of the
+// current terms is read first.
+// Scope: moderator:manage:blocked_terms
+func (k *Client) SyncBlockedTerms(i *SyncBlockedTermsInput) (*BlockedTermsDiff, error) {
+	if i == nil || i.BroadcasterId == "" || i.ModeratorId == "" {
+		return nil, fmt.Errorf("[ERR] No BroadcasterId or ModeratorId for SyncBlockedTerms")
+	}
+
+	var current []*BlockedTerm
+	get := &GetBlockedTermsInput{
+		BroadcasterId: i.BroadcasterId,
+		ModeratorId:   i.ModeratorId,
+		First:         100,
+	}
+	for {
+		o, err := k.GetBlockedTerms(get)
+		if err != nil {
+			return nil, err
+		}
+		current = append(current, o.BlockedTerms...)
+		if o.Pagination == nil || o.Pagination.Cursor == "" {
+			break
+		}
+		get.After = o.Pagination.Cursor
+	}
+
+	d := DiffBlockedTerms(current, i.Terms)
+	if i.DryRun {
+		return d, nil
+	}
+
+	for _, t := range d.Add {
+		_, err := k.AddBlockedTerm(&AddBlockedTermInput{
+			BroadcasterId: i.BroadcasterId,
+			ModeratorId:   i.ModeratorId,
+			Text:          t,
+		})
+		if err != nil {
+			return d, err
+		}
+	}
+
+	for _, bt := range d.Remove {
+		err := k.RemoveBlockedTerm(&RemoveBlockedTermInput{
+			BroadcasterId: i.BroadcasterId,
+			ModeratorId:   i.ModeratorId,
+			Id:            bt.Id,
+		})
+		if err != nil {
+			return d, err
+		}
+	}
+
+	return d, nil
+}
@@ -0,0 +1,96 @@
+package helix
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestBlockedTerms_Get(t *testing.T) {
+	t.Parallel()
+
+	var err error
+	var output *GetBlockedTermsOutput
+	recordHelix(t, "blocked_terms/get", func(c *Client) {
+		output, err = c.GetBlockedTerms(&GetBlockedTermsInput{
+			BroadcasterId: "1234",
+			ModeratorId:   "5678",
+			First:         10,
+		})
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(output.BlockedTerms) != 1 {
+		t.Fatalf("Expected (1) term, got (%d)", len(output.BlockedTerms))
+	}
+	if output.BlockedTerms[0].Text != "A phrase I'm not fond of" {
+		t.Fatalf("Bad term: %q", output.BlockedTerms[0].Text)
+	}
+	if !output.BlockedTerms[0].ExpiresAt.IsZero() {
+		t.Fatalf("Expected term to never expire")
+	}
+}
+
+func TestBlockedTerms_Diff(t *testing.T) {
+	t.Parallel()
+
+	terms, err := ReadBlockedTerms(strings.NewReader(`
+- "Keep Me"
+- keep me
+- "new term*"
+- "  "
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	current := []*BlockedTerm{
+		{Id: "1", Text: "keep me"},
+		{Id: "2", Text: "remove me"},
+		{Id: "3", Text: "KEEP ME"},
+	}
+
+	d := DiffBlockedTerms(current, terms)
+	if !reflect.DeepEqual(d.Add, []string{"new term*"}) {
+		t.Fatalf("Bad additions: %#v", d.Add)
+	}
+
+	// The duplicate of "keep me" is removed along with the unwanted term.
+	var removed []string
+	for _, bt := range d.Remove {
+		removed = append(removed, bt.Id)
+	}
+	if !reflect.DeepEqual(removed, []string{"2", "3"}) {
+		t.Fatalf("Bad removals: %#v", removed)
+	}
+
+	if !DiffBlockedTerms(current[:1], []string{"Keep me"}).Empty() {
+		t.Fatalf("Expected an empty diff")
+	}
+}
+
+func TestBlockedTerms_Sync(t *testing.T) {
+	t.Parallel()
+
+	var err error
+	var d *BlockedTermsDiff
+	recordHelix(t, "blocked_terms/sync", func(c *Client) {
+		d, err = c.SyncBlockedTerms(&SyncBlockedTermsInput{
+			BroadcasterId: "1234",
+			ModeratorId:   "5678",
+			Terms:         []string{"keep me", "new term*"},
+		})
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(d.Add) != 1 || d.Add[0] != "new term*" {
+		t.Fatalf("Bad additions: %#v", d.Add)
+	}
+	if len(d.Remove) != 1 || d.Remove[0].Id != "term-2" {
+		t.Fatalf("Bad removals: %#v", d.Remove)
+	}
+}
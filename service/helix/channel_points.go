@@ -0,0 +1,587 @@
+package helix
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/catsby/go-twitch/twitch"
+)
+
+// MaxRedemptionBatch is the number of redemptions Twitch accepts in a single
+// GetCustomRewardRedemption or UpdateRedemptionStatus request.
+const MaxRedemptionBatch = 50
+
+// MaxRewardIds is the number of reward Ids Twitch accepts in a single
+// GetCustomReward request.
+const MaxRewardIds = 50
+
+// MinGlobalCooldown and MaxGlobalCooldown bound the GlobalCooldown of a
+// reward. A GlobalCooldown of zero disables it.
+const (
+	MinGlobalCooldown = time.Second
+	MaxGlobalCooldown = 7 * 24 * time.Hour
+)
+
+// RewardImage are the URLs of a custom reward's image.
+type RewardImage struct {
+	Url1x string `mapstructure:"url_1x"`
+	Url2x string `mapstructure:"url_2x"`
+	Url4x string `mapstructure:"url_4x"`
+}
+
+// MaxPerStreamSetting limits how many times a reward can be redeemed per
+// stream.
+type MaxPerStreamSetting struct {
+	IsEnabled    bool `mapstructure:"is_enabled"`
+	MaxPerStream int  `mapstructure:"max_per_stream"`
+}
+
+// MaxPerUserPerStreamSetting limits how many times each user can redeem a
+// reward per stream.
+type MaxPerUserPerStreamSetting struct {
+	IsEnabled           bool `mapstructure:"is_enabled"`
+	MaxPerUserPerStream int  `mapstructure:"max_per_user_per_stream"`
+}
+
+// GlobalCooldownSetting is how long a reward is unavailable after it is
+// redeemed.
+type GlobalCooldownSetting struct {
+	IsEnabled             bool `mapstructure:"is_enabled"`
+	GlobalCooldownSeconds int  `mapstructure:"global_cooldown_seconds"`
+}
+
+// Cooldown returns the cooldown as a time.Duration.
+func (s *GlobalCooldownSetting) Cooldown() time.Duration {
+	return time.Duration(s.GlobalCooldownSeconds) * time.Second
+}
+
+// CustomReward is a Channel Points reward of a channel.
+type CustomReward struct {
+	BroadcasterId    string `mapstructure:"broadcaster_id"`
+	BroadcasterLogin string `mapstructure:"broadcaster_login"`
+	BroadcasterName  string `mapstructure:"broadcaster_name"`
+
+	Id     string `mapstructure:"id"`
+	Title  string `mapstructure:"title"`
+	Prompt string `mapstructure:"prompt"`
+	Cost   int    `mapstructure:"cost"`
+
+	// Image is nil when the reward uses DefaultImage.
+	Image           *RewardImage `mapstructure:"image"`
+	DefaultImage    *RewardImage `mapstructure:"default_image"`
+	BackgroundColor string       `mapstructure:"background_color"`
+
+	IsEnabled           bool `mapstructure:"is_enabled"`
+	IsUserInputRequired bool `mapstructure:"is_user_input_required"`
+	IsPaused            bool `mapstructure:"is_paused"`
+	IsInStock           bool `mapstructure:"is_in_stock"`
+
+	MaxPerStreamSetting        *MaxPerStreamSetting        `mapstructure:"max_per_stream_setting"`
+	MaxPerUserPerStreamSetting *MaxPerUserPerStreamSetting `mapstructure:"max_per_user_per_stream_setting"`
+	GlobalCooldownSetting      *GlobalCooldownSetting      `mapstructure:"global_cooldown_setting"`
+
+	// ShouldRedemptionsSkipRequestQueue marks redemptions as FULFILLED
+	// right away, so they cannot be refunded.
+	ShouldRedemptionsSkipRequestQueue bool `mapstructure:"should_redemptions_skip_request_queue"`
+
+	// RedemptionsRedeemedCurrentStream is nil when the channel is offline
+	// or the reward has no MaxPerStreamSetting.
+	RedemptionsRedeemedCurrentStream *int `mapstructure:"redemptions_redeemed_current_stream"`
+
+	// CooldownExpiresAt is the zero time when the reward is not in cooldown.
+	CooldownExpiresAt time.Time `mapstructure:"cooldown_expires_at"`
+}
+
+// CustomRewardOutput is the output of the functions returning custom rewards.
+type CustomRewardOutput struct {
+	Rewards []*CustomReward `mapstructure:"data"`
+}
+
+// customRewardBody is the body of CreateCustomReward and UpdateCustomReward.
+type customRewardBody struct {
+	Title                             *string `json:"title,omitempty"`
+	Prompt                            *string `json:"prompt,omitempty"`
+	Cost                              *int    `json:"cost,omitempty"`
+	BackgroundColor                   *string `json:"background_color,omitempty"`
+	IsEnabled                         *bool   `json:"is_enabled,omitempty"`
+	IsUserInputRequired               *bool   `json:"is_user_input_required,omitempty"`
+	IsMaxPerStreamEnabled             *bool   `json:"is_max_per_stream_enabled,omitempty"`
+	MaxPerStream                      *int    `json:"max_per_stream,omitempty"`
+	IsMaxPerUserPerStreamEnabled      *bool   `json:"is_max_per_user_per_stream_enabled,omitempty"`
+	MaxPerUserPerStream               *int    `json:"max_per_user_per_stream,omitempty"`
+	IsGlobalCooldownEnabled           *bool   `json:"is_global_cooldown_enabled,omitempty"`
+	GlobalCooldownSeconds             *int    `json:"global_cooldown_seconds,omitempty"`
+	IsPaused                          *bool   `json:"is_paused,omitempty"`
+	ShouldRedemptionsSkipRequestQueue *bool   `json:"should_redemptions_skip_request_queue,omitempty"`
+}
+
+// setLimits sets the limit fields of the body. A limit of zero disables it.
+func (b *customRewardBody) setLimits(maxPerStream, maxPerUserPerStream *int, cooldown *time.Duration) error {
+	if maxPerStream != nil {
+		if *maxPerStream < 0 {
+			return fmt.Errorf("[ERR] Invalid MaxPerStream %d, must not be negative", *maxPerStream)
+		}
+		b.IsMaxPerStreamEnabled = twitch.Bool(*maxPerStream > 0)
+		if *maxPerStream > 0 {
+			b.MaxPerStream = maxPerStream
+		}
+	}
+
+	if maxPerUserPerStream != nil {
+		if *maxPerUserPerStream < 0 {
+			return fmt.Errorf("[ERR] Invalid MaxPerUserPerStream %d, must not be negative", *maxPerUserPerStream)
+		}
+		b.IsMaxPerUserPerStreamEnabled = twitch.Bool(*maxPerUserPerStream > 0)
+		if *maxPerUserPerStream > 0 {
+			b.MaxPerUserPerStream = maxPerUserPerStream
+		}
+	}
+
+	if cooldown != nil {
+		if *cooldown != 0 && (*cooldown < MinGlobalCooldown || *cooldown > MaxGlobalCooldown) {
+			return fmt.Errorf("[ERR] Invalid GlobalCooldown %s, must be zero or between %s and %s", *cooldown, MinGlobalCooldown, MaxGlobalCooldown)
+		}
+		seconds := int(*cooldown / time.Second)
+		b.IsGlobalCooldownEnabled = twitch.Bool(seconds > 0)
+		if seconds > 0 {
+			b.GlobalCooldownSeconds = &seconds
+		}
+	}
+
+	return nil
+}
+
+// CreateCustomRewardInput is the input to the CreateCustomReward function.
+type CreateCustomRewardInput struct {
+	BroadcasterId string
+
+	// Title must be unique in the channel. Maximum: 45 characters.
+	Title string
+	Cost  int
+
+	// Prompt is shown to the user. Maximum: 200 characters.
+	Prompt string
+
+	// BackgroundColor is a hex color, Ex: "#9146FF".
+	BackgroundColor string
+
+	// IsDisabled creates the reward without making it available.
+	IsDisabled bool
+
+	IsUserInputRequired bool
+
+	// MaxPerStream, MaxPerUserPerStream and GlobalCooldown are not enforced
+	// when zero. GlobalCooldown is sent in whole seconds, between
+	// MinGlobalCooldown and MaxGlobalCooldown.
+	MaxPerStream        int
+	MaxPerUserPerStream int
+	GlobalCooldown      time.Duration
+
+	ShouldRedemptionsSkipRequestQueue bool
+}
+
+// CreateCustomReward creates a Channel Points reward. Only rewards created
+// with the same client ID can be updated or have their redemptions managed.
+// Scope: channel:manage:redemptions
+// See:
+//  - https://dev.twitch.tv/docs/api/reference#create-custom-rewards
+func (k *Client) CreateCustomReward(i *CreateCustomRewardInput) (*CustomRewardOutput, error) {
+	if i == nil || i.BroadcasterId == "" || i.Title == "" {
+		return nil, fmt.Errorf("[ERR] No BroadcasterId or Title for CreateCustomReward")
+	}
+	if i.Cost < 1 {
+		return nil, fmt.Errorf("[ERR] Invalid Cost %d for CreateCustomReward, must be at least 1", i.Cost)
+	}
+
+	body := &customRewardBody{
+		Title: &i.Title,
+		Cost:  &i.Cost,
+	}
+	if i.Prompt != "" {
+		body.Prompt = &i.Prompt
+	}
+	if i.BackgroundColor != "" {
+		body.BackgroundColor = &i.BackgroundColor
+	}
+	if i.IsDisabled {
+		body.IsEnabled = twitch.Bool(false)
+	}
+	if i.IsUserInputRequired {
+		body.IsUserInputRequired = twitch.Bool(true)
+	}
+	if i.ShouldRedemptionsSkipRequestQueue {
+		body.ShouldRedemptionsSkipRequestQueue = twitch.Bool(true)
+	}
+
+	// Limits left at zero are not sent, leaving them disabled.
+	var maxPerStream, maxPerUserPerStream *int
+	var cooldown *time.Duration
+	if i.MaxPerStream != 0 {
+		maxPerStream = &i.MaxPerStream
+	}
+	if i.MaxPerUserPerStream != 0 {
+		maxPerUserPerStream = &i.MaxPerUserPerStream
+	}
+	if i.GlobalCooldown != 0 {
+		cooldown = &i.GlobalCooldown
+	}
+	if err := body.setLimits(maxPerStream, maxPerUserPerStream, cooldown); err != nil {
+		return nil, err
+	}
+
+	ro := &twitch.RequestOptions{
+		Params: map[string]string{
+			"broadcaster_id": i.BroadcasterId,
+		},
+		Endpoint: "CreateCustomReward",
+		Input:    i,
+	}
+
+	resp, err := k.PostJSON("/channel_points/custom_rewards", body, ro)
+	if err != nil {
+		return nil, err
+	}
+
+	var o CustomRewardOutput
+	if err := twitch.DecodeJSON(&o, resp.Body); err != nil {
+		return nil, err
+	}
+
+	return &o, nil
+}
+
+// UpdateCustomRewardInput is the input to the UpdateCustomReward function.
+// Only the fields that are set are changed; use the twitch package helpers,
+// Ex: twitch.Int, to set them.
+type UpdateCustomRewardInput struct {
+	BroadcasterId string
+	Id            string
+
+	Title           *string
+	Prompt          *string
+	Cost            *int
+	BackgroundColor *string
+
+	IsEnabled           *bool
+	IsPaused            *bool
+	IsUserInputRequired *bool
+
+	// Setting MaxPerStream, MaxPerUserPerStream or GlobalCooldown to zero
+	// disables the limit.
+	MaxPerStream        *int
+	MaxPerUserPerStream *int
+	GlobalCooldown      *time.Duration
+
+	ShouldRedemptionsSkipRequestQueue *bool
+}
+
+// UpdateCustomReward changes a Channel Points reward created by the same
+// client ID.
+// Scope: channel:manage:redemptions
+// See:
+//  - https://dev.twitch.tv/docs/api/reference#update-custom-reward
+func (k *Client) UpdateCustomReward(i *UpdateCustomRewardInput) (*CustomRewardOutput, error) {
+	if i == nil || i.BroadcasterId == "" || i.Id == "" {
+		return nil, fmt.Errorf("[ERR] No BroadcasterId or Id for UpdateCustomReward")
+	}
+	if i.Cost != nil && *i.Cost < 1 {
+		return nil, fmt.Errorf("[ERR] Invalid Cost %d for UpdateCustomReward, must be at least 1", *i.Cost)
+	}
+
+	body := &customRewardBody{
+		Title:                             i.Title,
+		Prompt:                            i.Prompt,
+		Cost:                              i.Cost,
+		BackgroundColor:                   i.BackgroundColor,
+		IsEnabled:                         i.IsEnabled,
+		IsPaused:                          i.IsPaused,
+		IsUserInputRequired:               i.IsUserInputRequired,
+		ShouldRedemptionsSkipRequestQueue: i.ShouldRedemptionsSkipRequestQueue,
+	}
+	if err := body.setLimits(i.MaxPerStream, i.MaxPerUserPerStream, i.GlobalCooldown); err != nil {
+		return nil, err
+	}
+	if *body == (customRewardBody{}) {
+		return nil, fmt.Errorf("[ERR] No fields to change for UpdateCustomReward")
+	}
+
+	ro := &twitch.RequestOptions{
+		Params: map[string]string{
+			"broadcaster_id": i.BroadcasterId,
+			"id":             i.Id,
+		},
+		Endpoint: "UpdateCustomReward",
+		Input:    i,
+	}
+
+	resp, err := k.PatchJSON("/channel_points/custom_rewards", body, ro)
+	if err != nil {
+		return nil, err
+	}
+
+	var o CustomRewardOutput
+	if err := twitch.DecodeJSON(&o, resp.Body); err != nil {
+		return nil, err
+	}
+
+	return &o, nil
+}
+
+// DeleteCustomRewardInput is the input to the DeleteCustomReward function.
+type DeleteCustomRewardInput struct {
+	BroadcasterId string
+	Id            string
+}
+
+// DeleteCustomReward deletes a Channel Points reward created by the same
+// client ID. Unfulfilled redemptions of the reward are fulfilled.
+// Scope: channel:manage:redemptions
+// See:
+//  - https://dev.twitch.tv/docs/api/reference#delete-custom-reward
+func (k *Client) DeleteCustomReward(i *DeleteCustomRewardInput) error {
+	if i == nil || i.BroadcasterId == "" || i.Id == "" {
+		return fmt.Errorf("[ERR] No BroadcasterId or Id for DeleteCustomReward")
+	}
+
+	ro := &twitch.RequestOptions{
+		Params: map[string]string{
+			"broadcaster_id": i.BroadcasterId,
+			"id":             i.Id,
+		},
+		Endpoint: "DeleteCustomReward",
+		Input:    i,
+	}
+
+	resp, err := k.Delete("/channel_points/custom_rewards", ro)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+
+	return nil
+}
+
+// GetCustomRewardInput is the input to the GetCustomReward function.
+type GetCustomRewardInput struct {
+	BroadcasterId string
+
+	// Ids filters the list to the given rewards. Maximum: MaxRewardIds.
+	Ids []string
+
+	// OnlyManageableRewards filters the list to the rewards created by the
+	// same client ID.
+	OnlyManageableRewards bool
+}
+
+// GetCustomReward returns the Channel Points rewards of a channel.
+// Scope: channel:read:redemptions
+// See:
+//  - https://dev.twitch.tv/docs/api/reference#get-custom-reward
+func (k *Client) GetCustomReward(i *GetCustomRewardInput) (*CustomRewardOutput, error) {
+	if i == nil || i.BroadcasterId == "" {
+		return nil, fmt.Errorf("[ERR] No BroadcasterId for GetCustomReward")
+	}
+	if len(i.Ids) > MaxRewardIds {
+		return nil, fmt.Errorf("[ERR] Too many Ids for GetCustomReward, maximum is %d", MaxRewardIds)
+	}
+
+	ro := &twitch.RequestOptions{
+		Params: map[string]string{
+			"broadcaster_id": i.BroadcasterId,
+		},
+		Endpoint: "GetCustomReward",
+		Input:    i,
+	}
+	if len(i.Ids) > 0 {
+		ro.Params["id"] = strings.Join(i.Ids, ",")
+	}
+	if i.OnlyManageableRewards {
+		ro.Params["only_manageable_rewards"] = strconv.FormatBool(true)
+	}
+
+	resp, err := k.Get("/channel_points/custom_rewards", ro)
+	if err != nil {
+		return nil, err
+	}
+
+	var o CustomRewardOutput
+	if err := twitch.DecodeJSON(&o, resp.Body); err != nil {
+		return nil, err
+	}
+
+	return &o, nil
+}
+
+// RedemptionStatus is the state of a redemption.
+type RedemptionStatus string
+
+const (
+	RedemptionStatusUnfulfilled RedemptionStatus = "UNFULFILLED"
+	RedemptionStatusFulfilled   RedemptionStatus = "FULFILLED"
+
+	// RedemptionStatusCanceled refunds the user's Channel Points.
+	RedemptionStatusCanceled RedemptionStatus = "CANCELED"
+)
+
+// RedemptionReward is the reward that was redeemed, as it was at the time.
+type RedemptionReward struct {
+	Id     string `mapstructure:"id"`
+	Title  string `mapstructure:"title"`
+	Prompt string `mapstructure:"prompt"`
+	Cost   int    `mapstructure:"cost"`
+}
+
+// Redemption is a user redeeming a Channel Points reward.
+type Redemption struct {
+	BroadcasterId    string `mapstructure:"broadcaster_id"`
+	BroadcasterLogin string `mapstructure:"broadcaster_login"`
+	BroadcasterName  string `mapstructure:"broadcaster_name"`
+
+	Id        string `mapstructure:"id"`
+	UserId    string `mapstructure:"user_id"`
+	UserLogin string `mapstructure:"user_login"`
+	UserName  string `mapstructure:"user_name"`
+
+	// UserInput is empty unless the reward requires it.
+	UserInput  string            `mapstructure:"user_input"`
+	Status     RedemptionStatus  `mapstructure:"status"`
+	RedeemedAt time.Time         `mapstructure:"redeemed_at"`
+	Reward     *RedemptionReward `mapstructure:"reward"`
+}
+
+// GetCustomRewardRedemptionInput is the input to the GetCustomRewardRedemption
+// function.
+type GetCustomRewardRedemptionInput struct {
+	BroadcasterId string
+	RewardId      string
+
+	// Either Status or Ids must be set. Ids is at most MaxRedemptionBatch.
+	Status RedemptionStatus
+	Ids    []string
+
+	// Sort is OLDEST or NEWEST. Default: OLDEST.
+	Sort string
+
+	// Maximum number of objects to return. Default: 20. Maximum: 50.
+	First int
+	After string
+}
+
+// GetCustomRewardRedemptionOutput is the output of the
+// GetCustomRewardRedemption function.
+type GetCustomRewardRedemptionOutput struct {
+	Redemptions []*Redemption `mapstructure:"data"`
+	Pagination  *Pagination   `mapstructure:"pagination"`
+}
+
+// GetCustomRewardRedemption returns a page of the redemptions of a reward
+// created by the same client ID.
+// Scope: channel:read:redemptions
+// See:
+//  - https://dev.twitch.tv/docs/api/reference#get-custom-reward-redemption
+func (k *Client) GetCustomRewardRedemption(i *GetCustomRewardRedemptionInput) (*GetCustomRewardRedemptionOutput, error) {
+	if i == nil || i.BroadcasterId == "" || i.RewardId == "" {
+		return nil, fmt.Errorf("[ERR] No BroadcasterId or RewardId for GetCustomRewardRedemption")
+	}
+	if i.Status == "" && len(i.Ids) == 0 {
+		return nil, fmt.Errorf("[ERR] No Status or Ids for GetCustomRewardRedemption")
+	}
+	if len(i.Ids) > MaxRedemptionBatch {
+		return nil, fmt.Errorf("[ERR] Too many Ids for GetCustomRewardRedemption, maximum is %d", MaxRedemptionBatch)
+	}
+
+	ro := &twitch.RequestOptions{
+		Params: map[string]string{
+			"broadcaster_id": i.BroadcasterId,
+			"reward_id":      i.RewardId,
+		},
+		Endpoint: "GetCustomRewardRedemption",
+		Input:    i,
+	}
+	if i.Status != "" {
+		ro.Params["status"] = string(i.Status)
+	}
+	if len(i.Ids) > 0 {
+		ro.Params["id"] = strings.Join(i.Ids, ",")
+	}
+	if i.Sort != "" {
+		ro.Params["sort"] = i.Sort
+	}
+	setPageParams(ro, i.First, i.After, "")
+
+	resp, err := k.Get("/channel_points/custom_rewards/redemptions", ro)
+	if err != nil {
+		return nil, err
+	}
+
+	var o GetCustomRewardRedemptionOutput
+	if err := twitch.DecodeJSON(&o, resp.Body); err != nil {
+		return nil, err
+	}
+
+	return &o, nil
+}
+
+// UpdateRedemptionStatusInput is the input to the UpdateRedemptionStatus
+// function.
+type UpdateRedemptionStatusInput struct {
+	BroadcasterId string
+	RewardId      string
+
+	// Ids of the redemptions to update. Maximum: MaxRedemptionBatch.
+	Ids []string
+
+	// Status is RedemptionStatusFulfilled or RedemptionStatusCanceled.
+	Status RedemptionStatus
+}
+
+// UpdateRedemptionStatusOutput is the output of the UpdateRedemptionStatus
+// function.
+type UpdateRedemptionStatusOutput struct {
+	Redemptions []*Redemption `mapstructure:"data"`
+}
+
+// UpdateRedemptionStatus fulfills or cancels unfulfilled redemptions.
+// Canceling refunds the Channel Points, Ex: when the redeemed action failed.
+// Scope: channel:manage:redemptions
+// See:
+//  - https://dev.twitch.tv/docs/api/reference#update-redemption-status
+func (k *Client) UpdateRedemptionStatus(i *UpdateRedemptionStatusInput) (*UpdateRedemptionStatusOutput, error) {
+	if i == nil || i.BroadcasterId == "" || i.RewardId == "" || len(i.Ids) == 0 {
+		return nil, fmt.Errorf("[ERR] No BroadcasterId, RewardId or Ids for UpdateRedemptionStatus")
+	}
+	if len(i.Ids) > MaxRedemptionBatch {
+		return nil, fmt.Errorf("[ERR] Too many Ids for UpdateRedemptionStatus, maximum is %d", MaxRedemptionBatch)
+	}
+	if i.Status != RedemptionStatusFulfilled && i.Status != RedemptionStatusCanceled {
+		return nil, fmt.Errorf("[ERR] Invalid Status %q for UpdateRedemptionStatus, must be FULFILLED or CANCELED", i.Status)
+	}
+
+	ro := &twitch.RequestOptions{
+		Params: map[string]string{
+			"broadcaster_id": i.BroadcasterId,
+			"reward_id":      i.RewardId,
+			"id":             strings.Join(i.Ids, ","),
+		},
+		Endpoint: "UpdateRedemptionStatus",
+		Input:    i,
+	}
+
+	body := map[string]string{
+		"status": string(i.Status),
+	}
+
+	resp, err := k.PatchJSON("/channel_points/custom_rewards/redemptions", body, ro)
+	if err != nil {
+		return nil, err
+	}
+
+	var o UpdateRedemptionStatusOutput
+	if err := twitch.DecodeJSON(&o, resp.Body); err != nil {
+		return nil, err
+	}
+
+	return &o, nil
+}
@@ -0,0 +1,257 @@
+package helix
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/catsby/go-twitch/twitch"
+)
+
+func TestChannelPoints_CreateCustomReward(t *testing.T) {
+	t.Parallel()
+
+	var err error
+	var output *CustomRewardOutput
+	recordHelix(t, "channel_points/create", func(c *Client) {
+		output, err = c.CreateCustomReward(&CreateCustomRewardInput{
+			BroadcasterId:       "274637212",
+			Title:               "game analysis",
+			Cost:                50000,
+			MaxPerUserPerStream: 1,
+			GlobalCooldown:      5 * time.Minute,
+		})
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	r := output.Rewards[0]
+	if r.MaxPerStreamSetting.IsEnabled {
+		t.Fatalf("Expected no max per stream, got %#v", r.MaxPerStreamSetting)
+	}
+	if s := r.MaxPerUserPerStreamSetting; !s.IsEnabled || s.MaxPerUserPerStream != 1 {
+		t.Fatalf("Bad max per user per stream: %#v", s)
+	}
+	if s := r.GlobalCooldownSetting; !s.IsEnabled || s.Cooldown() != 5*time.Minute {
+		t.Fatalf("Bad global cooldown: %#v", s)
+	}
+	if r.Image != nil || r.DefaultImage == nil {
+		t.Fatalf("Expected only a default image")
+	}
+	if r.RedemptionsRedeemedCurrentStream != nil || !r.CooldownExpiresAt.IsZero() {
+		t.Fatalf("Expected no redemptions or cooldown, got %#v", r)
+	}
+
+	_, err = (&Client{}).CreateCustomReward(&CreateCustomRewardInput{
+		BroadcasterId: "274637212",
+		Title:         "free",
+	})
+	if err == nil {
+		t.Fatalf("Expected an error for a reward with no cost")
+	}
+}
+
+func TestChannelPoints_UpdateCustomReward(t *testing.T) {
+	t.Parallel()
+
+	var err error
+	var output *CustomRewardOutput
+	recordHelix(t, "channel_points/update", func(c *Client) {
+		output, err = c.UpdateCustomReward(&UpdateCustomRewardInput{
+			BroadcasterId:  "274637212",
+			Id:             "afaa7e34-6b17-49f0-a19a-d1e76eaaf673",
+			IsPaused:       twitch.Bool(true),
+			MaxPerStream:   twitch.Int(10),
+			GlobalCooldown: twitch.Duration(0),
+		})
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	r := output.Rewards[0]
+	if !r.IsPaused || r.MaxPerStreamSetting.MaxPerStream != 10 {
+		t.Fatalf("Bad updated reward: %#v", r)
+	}
+	if r.RedemptionsRedeemedCurrentStream == nil || *r.RedemptionsRedeemedCurrentStream != 3 {
+		t.Fatalf("Expected (3) redemptions this stream")
+	}
+	if r.CooldownExpiresAt.IsZero() {
+		t.Fatalf("Expected a cooldown expiry")
+	}
+
+	_, err = (&Client{}).UpdateCustomReward(&UpdateCustomRewardInput{
+		BroadcasterId: "274637212",
+		Id:            "afaa7e34-6b17-49f0-a19a-d1e76eaaf673",
+	})
+	if err == nil {
+		t.Fatalf("Expected an error when no fields are set")
+	}
+}
+
+func TestCustomRewardBody_setLimits(t *testing.T) {
+	cases := []struct {
+		maxPerStream *int
+		cooldown     *time.Duration
+		expected     string
+		err          bool
+	}{
+		{nil, nil, `{}`, false},
+		{twitch.Int(5), nil, `{"is_max_per_stream_enabled":true,"max_per_stream":5}`, false},
+		{twitch.Int(0), nil, `{"is_max_per_stream_enabled":false}`, false},
+		{nil, twitch.Duration(90 * time.Second), `{"is_global_cooldown_enabled":true,"global_cooldown_seconds":90}`, false},
+		{nil, twitch.Duration(0), `{"is_global_cooldown_enabled":false}`, false},
+		{twitch.Int(-1), nil, ``, true},
+		{nil, twitch.Duration(8 * 24 * time.Hour), ``, true},
+		{nil, twitch.Duration(500 * time.Millisecond), ``, true},
+		{nil, twitch.Duration(-time.Second), ``, true},
+	}
+
+	for _, tc := range cases {
+		var b customRewardBody
+		err := b.setLimits(tc.maxPerStream, nil, tc.cooldown)
+		if tc.err {
+			if err == nil {
+				t.Errorf("Expected an error for %v, %v", tc.maxPerStream, tc.cooldown)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		out, err := json.Marshal(b)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(out) != tc.expected {
+			t.Errorf("Expected (%s), got (%s)", tc.expected, out)
+		}
+	}
+}
+
+func TestChannelPoints_DeleteCustomReward(t *testing.T) {
+	t.Parallel()
+
+	var err error
+	recordHelix(t, "channel_points/delete", func(c *Client) {
+		err = c.DeleteCustomReward(&DeleteCustomRewardInput{
+			BroadcasterId: "274637212",
+			Id:            "afaa7e34-6b17-49f0-a19a-d1e76eaaf673",
+		})
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestChannelPoints_GetCustomReward(t *testing.T) {
+	t.Parallel()
+
+	var err error
+	var output *CustomRewardOutput
+	recordHelix(t, "channel_points/get", func(c *Client) {
+		output, err = c.GetCustomReward(&GetCustomRewardInput{
+			BroadcasterId:         "274637212",
+			OnlyManageableRewards: true,
+		})
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(output.Rewards) != 2 {
+		t.Fatalf("Expected (2) rewards, got (%d)", len(output.Rewards))
+	}
+	if r := output.Rewards[1]; r.Title != "hydrate" || r.Image == nil || r.Image.Url4x == "" {
+		t.Fatalf("Bad reward: %#v", r)
+	}
+
+	_, err = (&Client{}).GetCustomReward(&GetCustomRewardInput{
+		BroadcasterId: "274637212",
+		Ids:           make([]string, MaxRewardIds+1),
+	})
+	if err == nil {
+		t.Fatalf("Expected an error for more than %d Ids", MaxRewardIds)
+	}
+}
+
+func TestChannelPoints_GetCustomRewardRedemption(t *testing.T) {
+	t.Parallel()
+
+	var err error
+	var output *GetCustomRewardRedemptionOutput
+	recordHelix(t, "channel_points/get_redemptions", func(c *Client) {
+		output, err = c.GetCustomRewardRedemption(&GetCustomRewardRedemptionInput{
+			BroadcasterId: "274637212",
+			RewardId:      "92af127c-7326-4483-a52b-b0da0be61c01",
+			Status:        RedemptionStatusUnfulfilled,
+			Sort:          "NEWEST",
+			First:         2,
+		})
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(output.Redemptions) != 2 {
+		t.Fatalf("Expected (2) redemptions, got (%d)", len(output.Redemptions))
+	}
+	r := output.Redemptions[0]
+	if r.Status != RedemptionStatusUnfulfilled || r.UserInput != "play a song" || r.Reward.Cost != 50000 {
+		t.Fatalf("Bad redemption: %#v", r)
+	}
+	if output.Pagination == nil || output.Pagination.Cursor == "" {
+		t.Fatalf("Expected a pagination cursor")
+	}
+
+	_, err = (&Client{}).GetCustomRewardRedemption(&GetCustomRewardRedemptionInput{
+		BroadcasterId: "274637212",
+		RewardId:      "92af127c-7326-4483-a52b-b0da0be61c01",
+	})
+	if err == nil {
+		t.Fatalf("Expected an error with no Status or Ids")
+	}
+}
+
+func TestChannelPoints_UpdateRedemptionStatus(t *testing.T) {
+	t.Parallel()
+
+	var err error
+	var output *UpdateRedemptionStatusOutput
+	recordHelix(t, "channel_points/update_redemptions", func(c *Client) {
+		output, err = c.UpdateRedemptionStatus(&UpdateRedemptionStatusInput{
+			BroadcasterId: "274637212",
+			RewardId:      "92af127c-7326-4483-a52b-b0da0be61c01",
+			Ids: []string{
+				"17fa2df1-ad76-4804-bfa5-a40ef63efe63",
+				"d3ef9b8b-0b5a-4b5b-9a4e-b8a1c4a1e1f2",
+			},
+			Status: RedemptionStatusCanceled,
+		})
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, r := range output.Redemptions {
+		if r.Status != RedemptionStatusCanceled {
+			t.Fatalf("Expected the redemption to be canceled, got (%s)", r.Status)
+		}
+	}
+
+	ids := make([]string, MaxRedemptionBatch+1)
+	for n := range ids {
+		ids[n] = "id"
+	}
+	_, err = (&Client{}).UpdateRedemptionStatus(&UpdateRedemptionStatusInput{
+		BroadcasterId: "274637212",
+		RewardId:      "92af127c-7326-4483-a52b-b0da0be61c01",
+		Ids:           ids,
+		Status:        RedemptionStatusFulfilled,
+	})
+	if err == nil {
+		t.Fatalf("Expected an error for more than %d Ids", MaxRedemptionBatch)
+	}
+}
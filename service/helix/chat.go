@@ -0,0 +1,446 @@
+package helix
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/catsby/go-twitch/twitch"
+)
+
+// ChatSettings are the chat modes of a channel.
+type ChatSettings struct {
+	BroadcasterId string `mapstructure:"broadcaster_id"`
+
+	// SlowModeWaitTime is in seconds, and nil when slow mode is off.
+	SlowMode         bool `mapstructure:"slow_mode"`
+	SlowModeWaitTime *int `mapstructure:"slow_mode_wait_time"`
+
+	// FollowerModeDuration is in minutes, and nil when follower mode is off.
+	FollowerMode         bool `mapstructure:"follower_mode"`
+	FollowerModeDuration *int `mapstructure:"follower_mode_duration"`
+
+	SubscriberMode bool `mapstructure:"subscriber_mode"`
+	EmoteMode      bool `mapstructure:"emote_mode"`
+	UniqueChatMode bool `mapstructure:"unique_chat_mode"`
+
+	// The non-moderator chat delay is only returned when the request is
+	// made with a ModeratorId. NonModeratorChatDelayDuration is in seconds.
+	ModeratorId                   string `mapstructure:"moderator_id"`
+	NonModeratorChatDelay         bool   `mapstructure:"non_moderator_chat_delay"`
+	NonModeratorChatDelayDuration *int   `mapstructure:"non_moderator_chat_delay_duration"`
+}
+
+// GetChatSettingsInput is the input to the GetChatSettings function.
+type GetChatSettingsInput struct {
+	BroadcasterId string
+
+	// ModeratorId is optional, and needed to read the non-moderator chat
+	// delay.
+	ModeratorId string
+}
+
+// GetChatSettingsOutput is the output of the GetChatSettings function.
+type GetChatSettingsOutput struct {
+	Settings []*ChatSettings `mapstructure:"data"`
+}
+
+// GetChatSettings returns the chat settings of a channel.
+// Scope: moderator:read:chat_settings, only needed with a ModeratorId
+// See:
+//  - https://dev.twitch.tv/docs/api/reference#get-chat-settings
+func (k *Client) GetChatSettings(i *GetChatSettingsInput) (*GetChatSettingsOutput, error) {
+	if i == nil || i.BroadcasterId == "" {
+		return nil, fmt.Errorf("[ERR] No BroadcasterId for GetChatSettings")
+	}
+
+	ro := &twitch.RequestOptions{
+		Params: map[string]string{
+			"broadcaster_id": i.BroadcasterId,
+		},
+		Endpoint: "GetChatSettings",
+		Input:    i,
+	}
+	if i.ModeratorId != "" {
+		ro.Params["moderator_id"] = i.ModeratorId
+	}
+
+	resp, err := k.Get("/chat/settings", ro)
+	if err != nil {
+		return nil, err
+	}
+
+	var o GetChatSettingsOutput
+	if err := twitch.DecodeJSON(&o, resp.Body); err != nil {
+		return nil, err
+	}
+
+	return &o, nil
+}
+
+// UpdateChatSettingsInput is the input to the UpdateChatSettings function.
+// Only the fields that are set are sent, so settings can be changed one at a
+// time; use twitch.Bool and twitch.Int to set them.
+type UpdateChatSettingsInput struct {
+	BroadcasterId string `json:"-"`
+	ModeratorId   string `json:"-"`
+
+	// SlowModeWaitTime is in seconds. Minimum: 3. Maximum: 120. Default: 30.
+	SlowMode         *bool `json:"slow_mode,omitempty"`
+	SlowModeWaitTime *int  `json:"slow_mode_wait_time,omitempty"`
+
+	// FollowerModeDuration is in minutes. Maximum: 129600 (3 months).
+	// Default: 0.
+	FollowerMode         *bool `json:"follower_mode,omitempty"`
+	FollowerModeDuration *int  `json:"follower_mode_duration,omitempty"`
+
+	SubscriberMode *bool `json:"subscriber_mode,omitempty"`
+	EmoteMode      *bool `json:"emote_mode,omitempty"`
+	UniqueChatMode *bool `json:"unique_chat_mode,omitempty"`
+
+	// NonModeratorChatDelayDuration is in seconds. Valid values: 2, 4, 6.
+	NonModeratorChatDelay         *bool `json:"non_moderator_chat_delay,omitempty"`
+	NonModeratorChatDelayDuration *int  `json:"non_moderator_chat_delay_duration,omitempty"`
+}
+
+// UpdateChatSettingsOutput is the output of the UpdateChatSettings function.
+type UpdateChatSettingsOutput struct {
+	Settings []*ChatSettings `mapstructure:"data"`
+}
+
+// UpdateChatSettings changes the chat settings that are set in the input,
+// leaving the others untouched.
+// Scope: moderator:manage:chat_settings
+// See:
+//  - https://dev.twitch.tv/docs/api/reference#update-chat-settings
+func (k *Client) UpdateChatSettings(i *UpdateChatSettingsInput) (*UpdateChatSettingsOutput, error) {
+	if i == nil || i.BroadcasterId == "" || i.ModeratorId == "" {
+		return nil, fmt.Errorf("[ERR] No BroadcasterId or ModeratorId for UpdateChatSettings")
+	}
+
+	if i.SlowMode == nil && i.SlowModeWaitTime == nil &&
+		i.FollowerMode == nil && i.FollowerModeDuration == nil &&
+		i.SubscriberMode == nil && i.EmoteMode == nil && i.UniqueChatMode == nil &&
+		i.NonModeratorChatDelay == nil && i.NonModeratorChatDelayDuration == nil {
+		return nil, fmt.Errorf("[ERR] No settings to change for UpdateChatSettings")
+	}
+
+	ro := &twitch.RequestOptions{
+		Params: map[string]string{
+			"broadcaster_id": i.BroadcasterId,
+			"moderator_id":   i.ModeratorId,
+		},
+		Endpoint: "UpdateChatSettings",
+		Input:    i,
+	}
+
+	resp, err := k.PatchJSON("/chat/settings", i, ro)
+	if err != nil {
+		return nil, err
+	}
+
+	var o UpdateChatSettingsOutput
+	if err := twitch.DecodeJSON(&o, resp.Body); err != nil {
+		return nil, err
+	}
+
+	return &o, nil
+}
+
+// AnnouncementColor is the color used to highlight an announcement.
+type AnnouncementColor string
+
+const (
+	// AnnouncementColorPrimary uses the channel's accent color. Default.
+	AnnouncementColorPrimary AnnouncementColor = "primary"
+
+	AnnouncementColorBlue   AnnouncementColor = "blue"
+	AnnouncementColorGreen  AnnouncementColor = "green"
+	AnnouncementColorOrange AnnouncementColor = "orange"
+	AnnouncementColorPurple AnnouncementColor = "purple"
+)
+
+// valid reports whether c is one of the AnnouncementColor constants, or
+// empty for the default.
+func (c AnnouncementColor) valid() bool {
+	switch c {
+	case "", AnnouncementColorPrimary, AnnouncementColorBlue, AnnouncementColorGreen,
+		AnnouncementColorOrange, AnnouncementColorPurple:
+		return true
+	}
+	return false
+}
+
+// SendChatAnnouncementInput is the input to the SendChatAnnouncement function.
+type SendChatAnnouncementInput struct {
+	BroadcasterId string
+	ModeratorId   string
+
+	// Message is the announcement. Maximum: 500 characters.
+	Message string
+	Color   AnnouncementColor
+}
+
+// announcementBody is the JSON body of SendChatAnnouncement. An empty Color
+// is left out, so Twitch uses its default.
+type announcementBody struct {
+	Message string            `json:"message"`
+	Color   AnnouncementColor `json:"color,omitempty"`
+}
+
+// SendChatAnnouncement sends an announcement to a channel's chat.
+// Scope: moderator:manage:announcements
+// See:
+//  - https://dev.twitch.tv/docs/api/reference#send-chat-announcement
+func (k *Client) SendChatAnnouncement(i *SendChatAnnouncementInput) error {
+	if i == nil || i.BroadcasterId == "" || i.ModeratorId == "" || i.Message == "" {
+		return fmt.Errorf("[ERR] No BroadcasterId, ModeratorId or Message for SendChatAnnouncement")
+	}
+	if !i.Color.valid() {
+		return fmt.Errorf("[ERR] Unknown announcement Color: %q", i.Color)
+	}
+
+	ro := &twitch.RequestOptions{
+		Params: map[string]string{
+			"broadcaster_id": i.BroadcasterId,
+			"moderator_id":   i.ModeratorId,
+		},
+		Endpoint: "SendChatAnnouncement",
+		Input:    i,
+	}
+
+	body := &announcementBody{Message: i.Message, Color: i.Color}
+	resp, err := k.PostJSON("/chat/announcements", body, ro)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+
+	return nil
+}
+
+// SendShoutoutInput is the input to the SendShoutout function.
+type SendShoutoutInput struct {
+	// FromBroadcasterId is the channel sending the shoutout, and
+	// ToBroadcasterId the channel being shouted out.
+	FromBroadcasterId string
+	ToBroadcasterId   string
+	ModeratorId       string
+}
+
+// SendShoutout sends a Shoutout to another broadcaster. The broadcaster must
+// be live, and Twitch limits how often Shoutouts can be sent.
+// Scope: moderator:manage:shoutouts
+// See:
+//  - https://dev.twitch.tv/docs/api/reference#send-a-shoutout
+func (k *Client) SendShoutout(i *SendShoutoutInput) error {
+	if i == nil || i.FromBroadcasterId == "" || i.ToBroadcasterId == "" || i.ModeratorId == "" {
+		return fmt.Errorf("[ERR] No FromBroadcasterId, ToBroadcasterId or ModeratorId for SendShoutout")
+	}
+
+	ro := &twitch.RequestOptions{
+		Params: map[string]string{
+			"from_broadcaster_id": i.FromBroadcasterId,
+			"to_broadcaster_id":   i.ToBroadcasterId,
+			"moderator_id":        i.ModeratorId,
+		},
+		Endpoint: "SendShoutout",
+		Input:    i,
+	}
+
+	resp, err := k.Post("/chat/shoutouts", ro)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+
+	return nil
+}
+
+// SendChatMessageInput is the input to the SendChatMessage function.
+type SendChatMessageInput struct {
+	BroadcasterId string `json:"broadcaster_id"`
+
+	// SenderId must match the user the access token belongs to.
+	SenderId string `json:"sender_id"`
+
+	// Message may include emotes by name. Maximum: 500 characters.
+	Message string `json:"message"`
+
+	// ReplyParentMessageId sends the message as a reply.
+	ReplyParentMessageId string `json:"reply_parent_message_id,omitempty"`
+}
+
+// SentChatMessage is the result of sending a chat message.
+type SentChatMessage struct {
+	MessageId string `mapstructure:"message_id"`
+
+	// IsSent is false when Twitch dropped the message, with the reason in
+	// DropReason.
+	IsSent     bool        `mapstructure:"is_sent"`
+	DropReason *DropReason `mapstructure:"drop_reason"`
+}
+
+// DropReason is why a chat message was not sent, Ex: msg_duplicate.
+type DropReason struct {
+	Code    string `mapstructure:"code"`
+	Message string `mapstructure:"message"`
+}
+
+// SendChatMessageOutput is the output of the SendChatMessage function.
+type SendChatMessageOutput struct {
+	Messages []*SentChatMessage `mapstructure:"data"`
+}
+
+// SendChatMessage sends a message to a channel's chat through the API, for
+// bots that do not keep an IRC connection open.
+// Scope: user:write:chat
+// See:
+//  - https://dev.twitch.tv/docs/api/reference#send-chat-message
+func (k *Client) SendChatMessage(i *SendChatMessageInput) (*SendChatMessageOutput, error) {
+	if i == nil || i.BroadcasterId == "" || i.SenderId == "" || i.Message == "" {
+		return nil, fmt.Errorf("[ERR] No BroadcasterId, SenderId or Message for SendChatMessage")
+	}
+
+	resp, err := k.PostJSON("/chat/messages", i, &twitch.RequestOptions{Endpoint: "SendChatMessage", Input: i})
+	if err != nil {
+		return nil, err
+	}
+
+	var o SendChatMessageOutput
+	if err := twitch.DecodeJSON(&o, resp.Body); err != nil {
+		return nil, err
+	}
+
+	return &o, nil
+}
+
+// Named chat colors anyone can use. Turbo and Prime users may also use any hex
+// color, Ex: "#9146FF".
+const (
+	ChatColorBlue        = "blue"
+	ChatColorBlueViolet  = "blue_violet"
+	ChatColorCadetBlue   = "cadet_blue"
+	ChatColorChocolate   = "chocolate"
+	ChatColorCoral       = "coral"
+	ChatColorDodgerBlue  = "dodger_blue"
+	ChatColorFirebrick   = "firebrick"
+	ChatColorGoldenRod   = "golden_rod"
+	ChatColorGreen       = "green"
+	ChatColorHotPink     = "hot_pink"
+	ChatColorOrangeRed   = "orange_red"
+	ChatColorRed         = "red"
+	ChatColorSeaGreen    = "sea_green"
+	ChatColorSpringGreen = "spring_green"
+	ChatColorYellowGreen = "yellow_green"
+)
+
+var chatColors = map[string]bool{
+	ChatColorBlue:        true,
+	ChatColorBlueViolet:  true,
+	ChatColorCadetBlue:   true,
+	ChatColorChocolate:   true,
+	ChatColorCoral:       true,
+	ChatColorDodgerBlue:  true,
+	ChatColorFirebrick:   true,
+	ChatColorGoldenRod:   true,
+	ChatColorGreen:       true,
+	ChatColorHotPink:     true,
+	ChatColorOrangeRed:   true,
+	ChatColorRed:         true,
+	ChatColorSeaGreen:    true,
+	ChatColorSpringGreen: true,
+	ChatColorYellowGreen: true,
+}
+
+var hexColorRe = regexp.MustCompile(`^#[0-9a-fA-F]{6}$`)
+
+// UserChatColor is the color of a user's name in chat.
+type UserChatColor struct {
+	UserId    string `mapstructure:"user_id"`
+	UserLogin string `mapstructure:"user_login"`
+	UserName  string `mapstructure:"user_name"`
+
+	// Color is a hex color, Ex: "#9146FF", and empty if the user never set
+	// one.
+	Color string `mapstructure:"color"`
+}
+
+// GetUserChatColorInput is the input to the GetUserChatColor function.
+type GetUserChatColorInput struct {
+	// UserIds to get the colors of. Maximum: 100.
+	UserIds []string
+}
+
+// GetUserChatColorOutput is the output of the GetUserChatColor function.
+type GetUserChatColorOutput struct {
+	Colors []*UserChatColor `mapstructure:"data"`
+}
+
+// GetUserChatColor returns the chat colors of the given users.
+// See:
+//  - https://dev.twitch.tv/docs/api/reference#get-user-chat-color
+func (k *Client) GetUserChatColor(i *GetUserChatColorInput) (*GetUserChatColorOutput, error) {
+	if i == nil || len(i.UserIds) == 0 {
+		return nil, fmt.Errorf("[ERR] No UserIds for GetUserChatColor")
+	}
+
+	ro := &twitch.RequestOptions{
+		Params: map[string]string{
+			"user_id": strings.Join(i.UserIds, ","),
+		},
+		Endpoint: "GetUserChatColor",
+		Input:    i,
+	}
+
+	resp, err := k.Get("/chat/color", ro)
+	if err != nil {
+		return nil, err
+	}
+
+	var o GetUserChatColorOutput
+	if err := twitch.DecodeJSON(&o, resp.Body); err != nil {
+		return nil, err
+	}
+
+	return &o, nil
+}
+
+// UpdateUserChatColorInput is the input to the UpdateUserChatColor function.
+type UpdateUserChatColorInput struct {
+	UserId string
+
+	// Color is one of the named ChatColor constants, or a hex color, Ex:
+	// "#9146FF", for Turbo and Prime users.
+	Color string
+}
+
+// UpdateUserChatColor changes the color of a user's name in chat.
+// Scope: user:manage:chat_color
+// See:
+//  - https://dev.twitch.tv/docs/api/reference#update-user-chat-color
+func (k *Client) UpdateUserChatColor(i *UpdateUserChatColorInput) error {
+	if i == nil || i.UserId == "" || i.Color == "" {
+		return fmt.Errorf("[ERR] No UserId or Color for UpdateUserChatColor")
+	}
+	if !chatColors[i.Color] && !hexColorRe.MatchString(i.Color) {
+		return fmt.Errorf("[ERR] Invalid Color %q for UpdateUserChatColor, must be a named color or #RRGGBB", i.Color)
+	}
+
+	ro := &twitch.RequestOptions{
+		Params: map[string]string{
+			"user_id": i.UserId,
+			"color":   i.Color,
+		},
+		Endpoint: "UpdateUserChatColor",
+		Input:    i,
+	}
+
+	resp, err := k.Put("/chat/color", ro)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+
+	return nil
+}
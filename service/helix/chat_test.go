@@ -0,0 +1,244 @@
+package helix
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/catsby/go-twitch/twitch"
+)
+
+func TestChat_GetChatSettings(t *testing.T) {
+	t.Parallel()
+
+	var err error
+	var output *GetChatSettingsOutput
+	recordHelix(t, "chat/get_settings", func(c *Client) {
+		output, err = c.GetChatSettings(&GetChatSettingsInput{
+			BroadcasterId: "713936733",
+			ModeratorId:   "713936733",
+		})
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s := output.Settings[0]
+	if s.SlowMode || s.SlowModeWaitTime != nil {
+		t.Fatalf("Expected slow mode to be off, got %#v", s)
+	}
+	if !s.FollowerMode || s.FollowerModeDuration == nil || *s.FollowerModeDuration != 0 {
+		t.Fatalf("Expected follower mode with no duration, got %#v", s)
+	}
+	if s.NonModeratorChatDelayDuration == nil || *s.NonModeratorChatDelayDuration != 4 {
+		t.Fatalf("Expected a 4s chat delay, got %#v", s)
+	}
+}
+
+func TestChat_UpdateChatSettings(t *testing.T) {
+	t.Parallel()
+
+	var err error
+	var output *UpdateChatSettingsOutput
+	recordHelix(t, "chat/update_settings", func(c *Client) {
+		output, err = c.UpdateChatSettings(&UpdateChatSettingsInput{
+			BroadcasterId:    "1234",
+			ModeratorId:      "5678",
+			SlowMode:         twitch.Bool(true),
+			SlowModeWaitTime: twitch.Int(10),
+			FollowerMode:     twitch.Bool(false),
+		})
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s := output.Settings[0]
+	if !s.SlowMode || *s.SlowModeWaitTime != 10 || s.FollowerMode {
+		t.Fatalf("Bad updated settings: %#v", s)
+	}
+
+	_, err = (&Client{}).UpdateChatSettings(&UpdateChatSettingsInput{
+		BroadcasterId: "1234",
+		ModeratorId:   "5678",
+	})
+	if err == nil {
+		t.Fatalf("Expected an error when no settings are set")
+	}
+}
+
+func TestChat_SendChatAnnouncement(t *testing.T) {
+	t.Parallel()
+
+	var err error
+	recordHelix(t, "chat/announcement", func(c *Client) {
+		err = c.SendChatAnnouncement(&SendChatAnnouncementInput{
+			BroadcasterId: "11111",
+			ModeratorId:   "44444",
+			Message:       "Hello chat!",
+			Color:         AnnouncementColorPurple,
+		})
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestUpdateChatSettingsInput_body(t *testing.T) {
+	cases := []struct {
+		input    *UpdateChatSettingsInput
+		expected string
+	}{
+		{&UpdateChatSettingsInput{BroadcasterId: "1234", ModeratorId: "5678"}, `{}`},
+		{&UpdateChatSettingsInput{SlowMode: twitch.Bool(false)}, `{"slow_mode":false}`},
+		{
+			&UpdateChatSettingsInput{FollowerMode: twitch.Bool(true), FollowerModeDuration: twitch.Int(0)},
+			`{"follower_mode":true,"follower_mode_duration":0}`,
+		},
+		{
+			&UpdateChatSettingsInput{EmoteMode: twitch.Bool(true), NonModeratorChatDelayDuration: twitch.Int(4)},
+			`{"emote_mode":true,"non_moderator_chat_delay_duration":4}`,
+		},
+	}
+
+	for _, tc := range cases {
+		b, err := json.Marshal(tc.input)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(b) != tc.expected {
+			t.Errorf("Expected body %s, got: %s", tc.expected, b)
+		}
+	}
+}
+
+func TestAnnouncementBody(t *testing.T) {
+	cases := []struct {
+		body     *announcementBody
+		expected string
+	}{
+		{&announcementBody{Message: "Hello chat!"}, `{"message":"Hello chat!"}`},
+		{&announcementBody{Message: "Hello chat!", Color: AnnouncementColorBlue}, `{"message":"Hello chat!","color":"blue"}`},
+	}
+
+	for _, tc := range cases {
+		b, err := json.Marshal(tc.body)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(b) != tc.expected {
+			t.Errorf("Expected body %s, got: %s", tc.expected, b)
+		}
+	}
+}
+
+func TestChat_SendChatAnnouncement_color(t *testing.T) {
+	err := (&Client{}).SendChatAnnouncement(&SendChatAnnouncementInput{
+		BroadcasterId: "11111",
+		ModeratorId:   "44444",
+		Message:       "Hello chat!",
+		Color:         "red",
+	})
+	if err == nil {
+		t.Fatal("Expected an error for an unknown Color")
+	}
+}
+
+func TestChat_SendShoutout(t *testing.T) {
+	t.Parallel()
+
+	var err error
+	recordHelix(t, "chat/shoutout", func(c *Client) {
+		err = c.SendShoutout(&SendShoutoutInput{
+			FromBroadcasterId: "12345",
+			ToBroadcasterId:   "626262",
+			ModeratorId:       "98765",
+		})
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestChat_SendChatMessage(t *testing.T) {
+	t.Parallel()
+
+	input := &SendChatMessageInput{
+		BroadcasterId: "12826",
+		SenderId:      "141981764",
+		Message:       "Hello, world! twitchdevHype",
+	}
+
+	var err error
+	var sent, dropped *SendChatMessageOutput
+	recordHelix(t, "chat/send_message", func(c *Client) {
+		sent, err = c.SendChatMessage(input)
+		if err != nil {
+			return
+		}
+		dropped, err = c.SendChatMessage(input)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !sent.Messages[0].IsSent || sent.Messages[0].MessageId != "abc-123-def" {
+		t.Fatalf("Expected the message to be sent, got %#v", sent.Messages[0])
+	}
+
+	m := dropped.Messages[0]
+	if m.IsSent || m.DropReason == nil || m.DropReason.Code != "msg_duplicate" {
+		t.Fatalf("Expected the message to be dropped, got %#v", m)
+	}
+}
+
+func TestChat_GetUserChatColor(t *testing.T) {
+	t.Parallel()
+
+	var err error
+	var output *GetUserChatColorOutput
+	recordHelix(t, "chat/get_color", func(c *Client) {
+		output, err = c.GetUserChatColor(&GetUserChatColorInput{
+			UserIds: []string{"11111", "44444"},
+		})
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(output.Colors) != 2 {
+		t.Fatalf("Expected (2) colors, got (%d)", len(output.Colors))
+	}
+	if output.Colors[0].Color != "#9146FF" || output.Colors[1].Color != "" {
+		t.Fatalf("Bad colors: %#v, %#v", output.Colors[0], output.Colors[1])
+	}
+}
+
+func TestChat_UpdateUserChatColor(t *testing.T) {
+	t.Parallel()
+
+	var err error
+	recordHelix(t, "chat/update_color", func(c *Client) {
+		for _, color := range []string{ChatColorBlue, "#9146FF"} {
+			err = c.UpdateUserChatColor(&UpdateUserChatColorInput{
+				UserId: "123",
+				Color:  color,
+			})
+			if err != nil {
+				return
+			}
+		}
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, color := range []string{"Blue", "#12345", "9146FF", "#GGGGGG"} {
+		err = (&Client{}).UpdateUserChatColor(&UpdateUserChatColorInput{
+			UserId: "123",
+			Color:  color,
+		})
+		if err == nil {
+			t.Fatalf("Expected an error for color %q", color)
+		}
+	}
+}
@@ -0,0 +1,106 @@
+package helix
+
+import (
+	"fmt"
+
+	"github.com/catsby/go-twitch/twitch"
+)
+
+// Chatter is a user connected to a channel's chat.
+type Chatter struct {
+	UserId    string `mapstructure:"user_id"`
+	UserLogin string `mapstructure:"user_login"`
+	UserName  string `mapstructure:"user_name"`
+}
+
+// GetChattersInput is the input to the GetChatters function.
+type GetChattersInput struct {
+	BroadcasterId string
+	ModeratorId   string
+
+	// Maximum number of objects to return. Default: 100. Maximum: 1000.
+	First int
+	After string
+}
+
+// GetChattersOutput is the output of the GetChatters function.
+type GetChattersOutput struct {
+	Chatters   []*Chatter  `mapstructure:"data"`
+	Pagination *Pagination `mapstructure:"pagination"`
+
+	// Total is the number of users in chat, which may be more than the
+	// Chatters that can be listed.
+	Total int `mapstructure:"total"`
+}
+
+// GetChatters returns a page of the users connected to a channel's chat.
+// Scope: moderator:read:chatters
+// See:
+//  - https://dev.twitch.tv/docs/api/reference#get-chatters
+func (k *Client) GetChatters(i *GetChattersInput) (*GetChattersOutput, error) {
+	if i == nil || i.BroadcasterId == "" || i.ModeratorId == "" {
+		return nil, fmt.Errorf("[ERR] No BroadcasterId or ModeratorId for GetChatters")
+	}
+
+	ro := &twitch.RequestOptions{
+		Params: map[string]string{
+			"broadcaster_id": i.BroadcasterId,
+			"moderator_id":   i.ModeratorId,
+		},
+		Endpoint: "GetChatters",
+		Input:    i,
+	}
+	setPageParams(ro, i.First, i.After, "")
+
+	resp, err := k.Get("/chat/chatters", ro)
+	if err != nil {
+		return nil, err
+	}
+
+	var o GetChattersOutput
+	if err := twitch.DecodeJSON(&o, resp.Body); err != nil {
+		return nil, err
+	}
+
+	return &o, nil
+}
+
+// ChattersDiff is the change between two snapshots of a channel's chatters.
+type ChattersDiff struct {
+	// Joined are in the current snapshot but not the previous one.
+	Joined []*Chatter
+
+	// Left are in the previous snapshot but not the current one.
+	Left []*Chatter
+}
+
+// DiffChatters compares two snapshots of a channel's chatters, Ex: taken a
+// minute apart, matching chatters by UserId. Chatters are returned in the
+// order of the snapshot they come from.
+func DiffChatters(previous, current []*Chatter) *ChattersDiff {
+	before := make(map[string]bool, len(previous))
+	for _, c := range previous {
+		before[c.UserId] = true
+	}
+
+	now := make(map[string]bool, len(current))
+	for _, c := range current {
+		now[c.UserId] = true
+	}
+
+	d := new(ChattersDiff)
+	for _, c := range current {
+		if !before[c.UserId] {
+			d.Joined = append(d.Joined, c)
+			before[c.UserId] = true
+		}
+	}
+	for _, c := range previous {
+		if !now[c.UserId] {
+			d.Left = append(d.Left, c)
+			now[c.UserId] = true
+		}
+	}
+
+	return d
+}
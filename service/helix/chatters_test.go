@@ -0,0 +1,88 @@
+package helix
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestChatters_GetChatters(t *testing.T) {
+	t.Parallel()
+
+	var err error
+	var output *GetChattersOutput
+	recordHelix(t, "chatters/get_chatters", func(c *Client) {
+		output, err = c.GetChatters(&GetChattersInput{
+			BroadcasterId: "123456",
+			ModeratorId:   "654321",
+			First:         2,
+		})
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(output.Chatters) != 2 {
+		t.Fatalf("Expected (2) chatters, got (%d)", len(output.Chatters))
+	}
+	if output.Total != 8 {
+		t.Fatalf("Expected a total of (8), got (%d)", output.Total)
+	}
+	if output.Pagination == nil || output.Pagination.Cursor == "" {
+		t.Fatalf("Expected a pagination cursor")
+	}
+}
+
+func TestDiffChatters(t *testing.T) {
+	chatter := func(id string) *Chatter {
+		return &Chatter{UserId: id}
+	}
+	ids := func(cs []*Chatter) []string {
+		var out []string
+		for _, c := range cs {
+			out = append(out, c.UserId)
+		}
+		return out
+	}
+
+	cases := []struct {
+		name         string
+		previous     []*Chatter
+		current      []*Chatter
+		joined, left []string
+	}{
+		{
+			name:    "first snapshot",
+			current: []*Chatter{chatter("1"), chatter("2")},
+			joined:  []string{"1", "2"},
+		},
+		{
+			name:     "unchanged",
+			previous: []*Chatter{chatter("1"), chatter("2")},
+			current:  []*Chatter{chatter("2"), chatter("1")},
+		},
+		{
+			name:     "joins and leaves",
+			previous: []*Chatter{chatter("1"), chatter("2"), chatter("3")},
+			current:  []*Chatter{chatter("4"), chatter("2"), chatter("5")},
+			joined:   []string{"4", "5"},
+			left:     []string{"1", "3"},
+		},
+		{
+			name:     "duplicates across pages",
+			previous: []*Chatter{chatter("1"), chatter("1")},
+			current:  []*Chatter{chatter("2"), chatter("2")},
+			joined:   []string{"2"},
+			left:     []string{"1"},
+		},
+	}
+
+	for _, tc := range cases {
+		d := DiffChatters(tc.previous, tc.current)
+		if got := ids(d.Joined); !reflect.DeepEqual(got, tc.joined) {
+			t.Errorf("%s: expected joined %v, got %v", tc.name, tc.joined, got)
+		}
+		if got := ids(d.Left); !reflect.DeepEqual(got, tc.left) {
+			t.Errorf("%s: expected left %v, got %v", tc.name, tc.left, got)
+		}
+	}
+}
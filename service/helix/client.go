@@ -6,17 +6,10 @@
 package helix
 
 import (
-	"bytes"
-	"fmt"
-	"log"
-	"net/http"
-	"net/url"
+	"context"
 	"os"
-	"strings"
 
-	"github.com/ajg/form"
 	twitch "github.com/catsby/go-twitch/twitch"
-	cleanhttp "github.com/hashicorp/go-cleanhttp"
 )
 
 // AccessTokenEnvVar is the name of the environment variable where the Twitch API
@@ -32,15 +25,13 @@ const ClientIdEnvVar = "TWITCH_CLIENT_ID"
 const ClientSecretEnvVar = "TWITCH_CLIENT_SECRET"
 
 // AccessTokenHeader is the name of the header that contains the Twitch API key.
-const AccessTokenHeader = "Authorization"
-const ClientIdHeader = "Client-ID"
+const AccessTokenHeader = twitch.AccessTokenHeader
+const ClientIdHeader = twitch.ClientIdHeader
 
 type Client struct {
-	Config *twitch.Config
-
-	// HTTPClient is the HTTP client to use. If one is not provided, a default
-	// client will be used.
-	httpClient *http.Client
+	// Client is the transport shared with the other service clients, and
+	// provides the Request functions.
+	*twitch.Client
 
 	// accessToken is the Twitch API key to authenticate requests.
 	accessToken string
@@ -52,10 +43,6 @@ type Client struct {
 	clientId string
 
 	clientSecret string
-
-	// url is the parsed URL from Address
-	// ??
-	url *url.URL
 }
 
 // type Client twitch.Config
@@ -86,7 +73,9 @@ func DefaultClient(config *twitch.Config) (*Client, error) {
 
 	client, err := NewClient(config)
 	if err != nil {
-		log.Printf("Failed to make default helix client: %s", err)
+		if config.Logger != nil {
+			config.Logger.Warn("Failed to make default helix client", "error", err)
+		}
 		return nil, err
 	}
 
@@ -100,120 +89,36 @@ func DefaultClient(config *twitch.Config) (*Client, error) {
 // Creating an access token is not yet supported by this libary
 // TODO: Support creating an access token
 func NewClient(config *twitch.Config) (*Client, error) {
-	if config.AccessToken == "" {
-		return nil, fmt.Errorf("Access Token not specified")
-	}
-
-	if config.HTTPClient == nil {
-		config.HTTPClient = cleanhttp.DefaultClient()
+	core, err := twitch.NewClient(config, service)
+	if err != nil {
+		return nil, err
 	}
 
 	c := &Client{
+		Client:       core,
 		accessToken:  config.AccessToken,
 		clientId:     config.ClientId,
-		httpClient:   config.HTTPClient,
 		clientSecret: config.ClientSecret,
 	}
 
-	u, err := url.Parse(config.Endpoint)
-	if err != nil {
-		return nil, err
-	}
-
-	c.url = u
-
 	return c, nil
 }
 
-func (c *Client) Get(p string, ro *twitch.RequestOptions) (*http.Response, error) {
-	return c.Request("GET", p, ro)
-}
-
-// Head issues an HTTP HEAD request.
-func (c *Client) Head(p string, ro *twitch.RequestOptions) (*http.Response, error) {
-	return c.Request("HEAD", p, ro)
-}
-
-// Post issues an HTTP POST request.
-func (c *Client) Post(p string, ro *twitch.RequestOptions) (*http.Response, error) {
-	return c.Request("POST", p, ro)
-}
-
-// PostForm issues an HTTP POST request with the given interface form-encoded.
-func (c *Client) PostForm(p string, i interface{}, ro *twitch.RequestOptions) (*http.Response, error) {
-	return c.RequestForm("POST", p, i, ro)
-}
-
-// Put issues an HTTP PUT request.
-func (c *Client) Put(p string, ro *twitch.RequestOptions) (*http.Response, error) {
-	return c.Request("PUT", p, ro)
-}
-
-// PutForm issues an HTTP PUT request with the given interface form-encoded.
-func (c *Client) PutForm(p string, i interface{}, ro *twitch.RequestOptions) (*http.Response, error) {
-	return c.RequestForm("PUT", p, i, ro)
-}
-
-// Delete issues an HTTP DELETE request.
-func (c *Client) Delete(p string, ro *twitch.RequestOptions) (*http.Response, error) {
-	return c.Request("DELETE", p, ro)
+// service configures the shared client for Helix: a Bearer token, comma
+// separated parameters sent as repeated parameters, and known errors.
+// See:
+//  - https://dev.twitch.tv/docs/api#requests
+var service = &twitch.Service{
+	Name:       "helix",
+	AuthScheme: "Bearer",
+	SplitParam: twitch.SplitCommas,
+	WrapError:  newError,
 }
 
-// Request makes an HTTP request against the HTTPClient using the given verb,
-// Path, and request options.
-func (c *Client) Request(verb, p string, ro *twitch.RequestOptions) (*http.Response, error) {
-	req, err := c.RawRequest(verb, p, ro)
-	if err != nil {
-		return nil, err
-	}
-
-	resp, err := checkResp(c.httpClient.Do(req))
-	if err != nil {
-		return resp, err
-	}
-
-	return resp, nil
-}
-
-// RequestForm makes an HTTP request with the given interface being encoded as
-// form data.
-func (c *Client) RequestForm(verb, p string, i interface{}, ro *twitch.RequestOptions) (*http.Response, error) {
-	if ro == nil {
-		ro = new(twitch.RequestOptions)
-	}
-
-	if ro.Headers == nil {
-		ro.Headers = make(map[string]string)
-	}
-
-	ro.Headers["Content-Type"] = "application/x-www-form-urlencoded"
-
-	buf := new(bytes.Buffer)
-	if err := form.NewEncoder(buf).KeepZeros(true).DelimitWith('|').Encode(i); err != nil {
-		return nil, err
-	}
-	body := buf.String()
-
-	ro.Body = strings.NewReader(body)
-	ro.BodyLength = int64(len(body))
-
-	return c.Request(verb, p, ro)
-}
-
-// checkResp wraps an HTTP request from the default client and verifies that the
-// request was successful. A non-200 request returns an error formatted to
-// included any validation problems or otherwise.
-func checkResp(resp *http.Response, err error) (*http.Response, error) {
-	// If the err is already there, there was an error higher up the chain, so
-	// just return that.
-	if err != nil {
-		return resp, err
-	}
-
-	switch resp.StatusCode {
-	case 200, 201, 202, 204, 205, 206:
-		return resp, nil
-	default:
-		return resp, twitch.NewHTTPError(resp)
-	}
+// WithContext returns a copy of the client making its requests with ctx, Ex:
+// to cancel them, or to trace them as part of the caller's span.
+func (c *Client) WithContext(ctx context.Context) *Client {
+	c2 := *c
+	c2.Client = c.Client.WithContext(ctx)
+	return &c2
 }
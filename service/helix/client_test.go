@@ -1,13 +1,15 @@
 package helix
 
 import (
+	"errors"
 	"fmt"
 	"log"
+	"net/http"
 	"os"
 	"testing"
 
 	"github.com/catsby/go-twitch/twitch"
-	"github.com/dnaeon/go-vcr/recorder"
+	"github.com/catsby/go-twitch/twitchtest"
 	cleanhttp "github.com/hashicorp/go-cleanhttp"
 )
 
@@ -173,14 +175,7 @@ type currentEnv struct {
 }
 
 func recordHelix(t *testing.T, fixture string, f func(*Client)) {
-	modeDisabledEnv := os.Getenv("RECORD_DISABLE")
-	mode := recorder.ModeReplaying
-	if modeDisabledEnv == "true" {
-		mode = recorder.ModeDisabled
-		log.Printf("[DEBUG]: Response recording disabled")
-	}
-
-	r, err := recorder.NewAsMode("fixtures/"+fixture, mode, nil)
+	r, err := twitchtest.NewRecorder("fixtures/"+fixture, nil)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -202,3 +197,58 @@ func recordHelix(t *testing.T, fixture string, f func(*Client)) {
 
 	f(client)
 }
+
+func TestHelixClient_middlewares(t *testing.T) {
+	s := twitchtest.NewServer(&twitchtest.State{
+		Games: []*twitchtest.Game{{Id: "504461", Name: "Celeste"}},
+	})
+	defer s.Close()
+
+	var calls []*twitch.Call
+	config := s.HelixConfig()
+	config.Middlewares = []twitch.Middleware{
+		func(next http.RoundTripper) http.RoundTripper {
+			return twitch.RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+				calls = append(calls, twitch.CallFromRequest(req))
+				return next.RoundTrip(req)
+			})
+		},
+		twitch.SetHeaders(http.Header{"X-Test": {"1"}}),
+	}
+
+	c, err := NewClient(config)
+	if err != nil {
+		t.Fatalf("Error creating client: %s", err)
+	}
+
+	input := &GetGamesInput{Names: []string{"Celeste"}}
+	if _, err := c.GetGames(input); err != nil {
+		t.Fatalf("Error getting games: %s", err)
+	}
+	if _, err := c.Get("/games", &twitch.RequestOptions{Params: map[string]string{"id": "504461"}}); err != nil {
+		t.Fatalf("Error getting games: %s", err)
+	}
+
+	if len(calls) != 2 {
+		t.Fatalf("Expected 2 calls, got: %d", len(calls))
+	}
+	if calls[0].Name() != "helix.GetGames" || calls[0].Input != input {
+		t.Errorf("Expected the GetGames call and input, got: %#v", calls[0])
+	}
+	if calls[1].Name() != "helix" || calls[1].Input != nil {
+		t.Errorf("Expected no endpoint for a direct request, got: %#v", calls[1])
+	}
+
+	for _, r := range s.Requests() {
+		if r.Header.Get("X-Test") != "1" {
+			t.Errorf("Expected the header to be set on %s", r.Path)
+		}
+	}
+
+	// Known errors are still matched through the shared sender.
+	s.Inject(&twitchtest.Fault{Status: 400, Message: "The broadcaster may not raid themselves", Times: 1})
+	_, err = c.StartRaid(&StartRaidInput{FromBroadcasterId: "1", ToBroadcasterId: "1"})
+	if !errors.Is(err, ErrRaidSelf) {
+		t.Fatalf("Expected ErrRaidSelf, got: %v", err)
+	}
+}
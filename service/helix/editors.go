@@ -0,0 +1,57 @@
+package helix
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/catsby/go-twitch/twitch"
+)
+
+// Editor is a user who can edit a broadcaster's channel.
+type Editor struct {
+	UserId   string `mapstructure:"user_id"`
+	UserName string `mapstructure:"user_name"`
+
+	// CreatedAt is when the user became an editor.
+	CreatedAt time.Time `mapstructure:"created_at"`
+}
+
+// GetChannelEditorsInput is the input to the GetChannelEditors function.
+type GetChannelEditorsInput struct {
+	BroadcasterId string
+}
+
+// GetChannelEditorsOutput is the output of the GetChannelEditors function.
+type GetChannelEditorsOutput struct {
+	Editors []*Editor `mapstructure:"data"`
+}
+
+// GetChannelEditors returns the editors of a broadcaster's channel.
+// Scope: channel:read:editors
+// See:
+//  - https://dev.twitch.tv/docs/api/reference#get-channel-editors
+func (k *Client) GetChannelEditors(i *GetChannelEditorsInput) (*GetChannelEditorsOutput, error) {
+	if i == nil || i.BroadcasterId == "" {
+		return nil, fmt.Errorf("[ERR] No BroadcasterId for GetChannelEditors")
+	}
+
+	ro := &twitch.RequestOptions{
+		Params: map[string]string{
+			"broadcaster_id": i.BroadcasterId,
+		},
+		Endpoint: "GetChannelEditors",
+		Input:    i,
+	}
+
+	resp, err := k.Get("/channels/editors", ro)
+	if err != nil {
+		return nil, err
+	}
+
+	var o GetChannelEditorsOutput
+	if err := twitch.DecodeJSON(&o, resp.Body); err != nil {
+		return nil, err
+	}
+
+	return &o, nil
+}
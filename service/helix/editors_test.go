@@ -0,0 +1,25 @@
+package helix
+
+import "testing"
+
+func TestEditors_GetChannelEditors(t *testing.T) {
+	t.Parallel()
+
+	var err error
+	var output *GetChannelEditorsOutput
+	recordHelix(t, "editors/get", func(c *Client) {
+		output, err = c.GetChannelEditors(&GetChannelEditorsInput{
+			BroadcasterId: "141981764",
+		})
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(output.Editors) != 2 {
+		t.Fatalf("Expected (2) editors, got (%d)", len(output.Editors))
+	}
+	if e := output.Editors[0]; e.UserName != "mauerbac" || e.CreatedAt.Year() != 2019 {
+		t.Fatalf("Bad editor: %#v", e)
+	}
+}
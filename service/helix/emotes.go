@@ -0,0 +1,314 @@
+package helix
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/catsby/go-twitch/twitch"
+)
+
+// MaxEmoteSetBatch is the number of emote sets Twitch accepts in a single
+// GetEmoteSets request.
+const MaxEmoteSetBatch = 25
+
+// DefaultEmoteTemplate is the emote URL template Twitch returns, used when an
+// Emote has none, Ex: for an emote ID read from a chat message.
+const DefaultEmoteTemplate = "https://static-cdn.jtvnw.net/emoticons/v2/{{id}}/{{format}}/{{theme_mode}}/{{scale}}"
+
+// EmoteFormat is the image format of an emote.
+type EmoteFormat string
+
+const (
+	EmoteFormatStatic   EmoteFormat = "static"
+	EmoteFormatAnimated EmoteFormat = "animated"
+)
+
+// EmoteScale is the size of an emote image.
+type EmoteScale string
+
+const (
+	EmoteScale1x EmoteScale = "1.0"
+	EmoteScale2x EmoteScale = "2.0"
+	EmoteScale3x EmoteScale = "3.0"
+)
+
+// EmoteTheme is the background an emote image is made for.
+type EmoteTheme string
+
+const (
+	EmoteThemeLight EmoteTheme = "light"
+	EmoteThemeDark  EmoteTheme = "dark"
+)
+
+// EmoteImages are the static URLs of an emote, in the light theme.
+type EmoteImages struct {
+	Url1x string `mapstructure:"url_1x"`
+	Url2x string `mapstructure:"url_2x"`
+	Url4x string `mapstructure:"url_4x"`
+}
+
+// Emote is a chat emote.
+type Emote struct {
+	Id     string       `mapstructure:"id"`
+	Name   string       `mapstructure:"name"`
+	Images *EmoteImages `mapstructure:"images"`
+
+	// Tier is the subscriber tier that unlocks the emote, Ex: 1000, and only
+	// set for subscriber emotes.
+	Tier string `mapstructure:"tier"`
+
+	// EmoteType is how the emote is unlocked, Ex: subscriptions, bitstier,
+	// follower.
+	EmoteType  string `mapstructure:"emote_type"`
+	EmoteSetId string `mapstructure:"emote_set_id"`
+
+	// OwnerId is the broadcaster the emote belongs to. It is only set by
+	// GetEmoteSets and GetUserEmotes.
+	OwnerId string `mapstructure:"owner_id"`
+
+	// The formats, scales and themes the emote is available in.
+	Format    []EmoteFormat `mapstructure:"format"`
+	Scale     []EmoteScale  `mapstructure:"scale"`
+	ThemeMode []EmoteTheme  `mapstructure:"theme_mode"`
+
+	// Template is the URL template returned with the emote, used by URL.
+	Template string `mapstructure:"-"`
+}
+
+// URL expands the emote's URL template. Animated falls back to static for
+// emotes that are not animated.
+func (e *Emote) URL(format EmoteFormat, theme EmoteTheme, scale EmoteScale) string {
+	if format == EmoteFormatAnimated && !e.hasFormat(EmoteFormatAnimated) {
+		format = EmoteFormatStatic
+	}
+	return EmoteURL(e.Template, e.Id, format, theme, scale)
+}
+
+func (e *Emote) hasFormat(f EmoteFormat) bool {
+	for _, ef := range e.Format {
+		if ef == f {
+			return true
+		}
+	}
+	return false
+}
+
+// EmoteURL expands an emote URL template for the given emote ID. An empty
+// template uses DefaultEmoteTemplate.
+func EmoteURL(template, id string, format EmoteFormat, theme EmoteTheme, scale EmoteScale) string {
+	if template == "" {
+		template = DefaultEmoteTemplate
+	}
+	r := strings.NewReplacer(
+		"{{id}}", id,
+		"{{format}}", string(format),
+		"{{theme_mode}}", string(theme),
+		"{{scale}}", string(scale),
+	)
+	return r.Replace(template)
+}
+
+// EmotesOutput is the output of the functions returning emotes.
+type EmotesOutput struct {
+	Emotes   []*Emote `mapstructure:"data"`
+	Template string   `mapstructure:"template"`
+
+	// Pagination is only returned by GetUserEmotes.
+	Pagination *Pagination `mapstructure:"pagination"`
+}
+
+// GetChannelEmotesInput is the input to the GetChannelEmotes function.
+type GetChannelEmotesInput struct {
+	BroadcasterId string
+}
+
+// GetChannelEmotes returns the subscriber, Bits tier and follower emotes of a
+// channel.
+// See:
+//  - https://dev.twitch.tv/docs/api/reference#get-channel-emotes
+func (k *Client) GetChannelEmotes(i *GetChannelEmotesInput) (*EmotesOutput, error) {
+	if i == nil || i.BroadcasterId == "" {
+		return nil, fmt.Errorf("[ERR] No BroadcasterId for GetChannelEmotes")
+	}
+
+	ro := &twitch.RequestOptions{
+		Params: map[string]string{
+			"broadcaster_id": i.BroadcasterId,
+		},
+		Endpoint: "GetChannelEmotes",
+		Input:    i,
+	}
+
+	return k.getEmotes("/chat/emotes", ro)
+}
+
+// GetGlobalEmotes returns the emotes available in every channel.
+// See:
+//  - https://dev.twitch.tv/docs/api/reference#get-global-emotes
+func (k *Client) GetGlobalEmotes() (*EmotesOutput, error) {
+	return k.getEmotes("/chat/emotes/global", nil)
+}
+
+// GetEmoteSetsInput is the input to the GetEmoteSets function.
+type GetEmoteSetsInput struct {
+	// EmoteSetIds are sent in batches of MaxEmoteSetBatch.
+	EmoteSetIds []string
+}
+
+// GetEmoteSets returns the emotes in the given emote sets, Ex: from the
+// emote-sets tag of a USERSTATE message. Any number of sets may be given; they
+// are requested in as many batches as needed.
+// See:
+//  - https://dev.twitch.tv/docs/api/reference#get-emote-sets
+func (k *Client) GetEmoteSets(i *GetEmoteSetsInput) (*EmotesOutput, error) {
+	if i == nil || len(i.EmoteSetIds) == 0 {
+		return nil, fmt.Errorf("[ERR] No EmoteSetIds for GetEmoteSets")
+	}
+
+	var out EmotesOutput
+	for start := 0; start < len(i.EmoteSetIds); start += MaxEmoteSetBatch {
+		end := start + MaxEmoteSetBatch
+		if end > len(i.EmoteSetIds) {
+			end = len(i.EmoteSetIds)
+		}
+
+		ro := &twitch.RequestOptions{
+			Params: map[string]string{
+				"emote_set_id": strings.Join(i.EmoteSetIds[start:end], ","),
+			},
+			Endpoint: "GetEmoteSets",
+			Input:    i,
+		}
+		o, err := k.getEmotes("/chat/emotes/set", ro)
+		if err != nil {
+			return nil, err
+		}
+		out.Emotes = append(out.Emotes, o.Emotes...)
+		out.Template = o.Template
+	}
+
+	return &out, nil
+}
+
+// GetUserEmotesInput is the input to the GetUserEmotes function.
+type GetUserEmotesInput struct {
+	UserId string
+
+	// BroadcasterId also returns the follower emotes of the channel, if the
+	// user follows it.
+	BroadcasterId string
+
+	After string
+}
+
+// GetUserEmotes returns a page of the emotes a user can use.
+// Scope: user:read:emotes
+// See:
+//  - https://dev.twitch.tv/docs/api/reference#get-user-emotes
+func (k *Client) GetUserEmotes(i *GetUserEmotesInput) (*EmotesOutput, error) {
+	if i == nil || i.UserId == "" {
+		return nil, fmt.Errorf("[ERR] No UserId for GetUserEmotes")
+	}
+
+	ro := &twitch.RequestOptions{
+		Params: map[string]string{
+			"user_id": i.UserId,
+		},
+		Endpoint: "GetUserEmotes",
+		Input:    i,
+	}
+	if i.BroadcasterId != "" {
+		ro.Params["broadcaster_id"] = i.BroadcasterId
+	}
+	setPageParams(ro, 0, i.After, "")
+
+	return k.getEmotes("/chat/emotes/user", ro)
+}
+
+func (k *Client) getEmotes(p string, ro *twitch.RequestOptions) (*EmotesOutput, error) {
+	resp, err := k.Get(p, ro)
+	if err != nil {
+		return nil, err
+	}
+
+	var o EmotesOutput
+	if err := twitch.DecodeJSON(&o, resp.Body); err != nil {
+		return nil, err
+	}
+	for _, e := range o.Emotes {
+		e.Template = o.Template
+	}
+
+	return &o, nil
+}
+
+// EmoteCache caches the emotes of emote sets, keyed by set ID. It is safe for
+// concurrent use.
+type EmoteCache struct {
+	client *Client
+
+	mu   sync.Mutex
+	sets map[string][]*Emote
+}
+
+// NewEmoteCache returns an empty EmoteCache using the given client.
+func NewEmoteCache(c *Client) *EmoteCache {
+	return &EmoteCache{
+		client: c,
+		sets:   make(map[string][]*Emote),
+	}
+}
+
+// Get returns the emotes of the given sets, requesting only the sets that are
+// not cached yet.
+func (c *EmoteCache) Get(setIds ...string) ([]*Emote, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var missing []string
+	for _, id := range setIds {
+		if _, ok := c.sets[id]; !ok {
+			missing = append(missing, id)
+		}
+	}
+	if len(missing) > 0 {
+		if err := c.fetch(missing); err != nil {
+			return nil, err
+		}
+	}
+
+	var out []*Emote
+	for _, id := range setIds {
+		out = append(out, c.sets[id]...)
+	}
+	return out, nil
+}
+
+// Refresh requests the given sets again, Ex: after a broadcaster adds
+// emotes.
+func (c *EmoteCache) Refresh(setIds ...string) error {
+	if len(setIds) == 0 {
+		return nil
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.fetch(setIds)
+}
+
+func (c *EmoteCache) fetch(setIds []string) error {
+	o, err := c.client.GetEmoteSets(&GetEmoteSetsInput{EmoteSetIds: setIds})
+	if err != nil {
+		return err
+	}
+
+	// Sets with no emotes are cached too, so they are not requested again.
+	for _, id := range setIds {
+		c.sets[id] = nil
+	}
+	for _, e := range o.Emotes {
+		c.sets[e.EmoteSetId] = append(c.sets[e.EmoteSetId], e)
+	}
+	return nil
+}
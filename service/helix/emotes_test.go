@@ -0,0 +1,145 @@
+package helix
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestEmotes_GetChannelEmotes(t *testing.T) {
+	t.Parallel()
+
+	var err error
+	var output *EmotesOutput
+	recordHelix(t, "emotes/channel", func(c *Client) {
+		output, err = c.GetChannelEmotes(&GetChannelEmotesInput{
+			BroadcasterId: "141981764",
+		})
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(output.Emotes) != 1 {
+		t.Fatalf("Expected (1) emote, got (%d)", len(output.Emotes))
+	}
+
+	e := output.Emotes[0]
+	if e.Tier != "1000" || e.EmoteType != "subscriptions" {
+		t.Fatalf("Bad emote: %#v", e)
+	}
+
+	expected := "https://static-cdn.jtvnw.net/emoticons/v2/304456832/animated/dark/3.0"
+	if u := e.URL(EmoteFormatAnimated, EmoteThemeDark, EmoteScale3x); u != expected {
+		t.Fatalf("Expected URL (%s), got (%s)", expected, u)
+	}
+}
+
+func TestEmotes_GetGlobalEmotes(t *testing.T) {
+	t.Parallel()
+
+	var err error
+	var output *EmotesOutput
+	recordHelix(t, "emotes/global", func(c *Client) {
+		output, err = c.GetGlobalEmotes()
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// TwitchUnity is not animated, so the static image is used.
+	expected := "https://static-cdn.jtvnw.net/emoticons/v2/196892/static/light/1.0"
+	if u := output.Emotes[0].URL(EmoteFormatAnimated, EmoteThemeLight, EmoteScale1x); u != expected {
+		t.Fatalf("Expected URL (%s), got (%s)", expected, u)
+	}
+}
+
+func TestEmotes_GetEmoteSets(t *testing.T) {
+	t.Parallel()
+
+	var ids []string
+	for n := 0; n < MaxEmoteSetBatch+1; n++ {
+		ids = append(ids, fmt.Sprint(1000+n))
+	}
+
+	var err error
+	var output *EmotesOutput
+	recordHelix(t, "emotes/sets", func(c *Client) {
+		output, err = c.GetEmoteSets(&GetEmoteSetsInput{EmoteSetIds: ids})
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(output.Emotes) != 3 {
+		t.Fatalf("Expected (3) emotes across both batches, got (%d)", len(output.Emotes))
+	}
+	if e := output.Emotes[2]; e.EmoteSetId != "1025" || e.OwnerId != "141981764" || e.Template == "" {
+		t.Fatalf("Bad emote from the second batch: %#v", e)
+	}
+}
+
+func TestEmotes_GetUserEmotes(t *testing.T) {
+	t.Parallel()
+
+	var err error
+	var output *EmotesOutput
+	recordHelix(t, "emotes/user", func(c *Client) {
+		output, err = c.GetUserEmotes(&GetUserEmotesInput{
+			UserId:        "123456",
+			BroadcasterId: "141981764",
+		})
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(output.Emotes) != 1 || output.Emotes[0].Name != "<3" {
+		t.Fatalf("Bad emotes: %#v", output.Emotes)
+	}
+	if output.Pagination == nil || output.Pagination.Cursor == "" {
+		t.Fatalf("Expected a pagination cursor")
+	}
+}
+
+func TestEmoteURL(t *testing.T) {
+	expected := "https://static-cdn.jtvnw.net/emoticons/v2/25/static/dark/2.0"
+	if u := EmoteURL("", "25", EmoteFormatStatic, EmoteThemeDark, EmoteScale2x); u != expected {
+		t.Fatalf("Expected URL (%s), got (%s)", expected, u)
+	}
+}
+
+func TestEmoteCache(t *testing.T) {
+	t.Parallel()
+
+	recordHelix(t, "emotes/cache", func(c *Client) {
+		cache := NewEmoteCache(c)
+
+		// Set 1001 has no emotes, and must not be requested again.
+		emotes, err := cache.Get("1000", "1001")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(emotes) != 1 {
+			t.Fatalf("Expected (1) emote, got (%d)", len(emotes))
+		}
+
+		emotes, err = cache.Get("1001", "1002", "1000")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(emotes) != 2 || emotes[0].Name != "twitchdevLUL" {
+			t.Fatalf("Expected emotes in set order, got %#v", emotes)
+		}
+
+		if err := cache.Refresh("1000"); err != nil {
+			t.Fatal(err)
+		}
+		emotes, err = cache.Get("1000")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(emotes) != 2 {
+			t.Fatalf("Expected (2) emotes after a refresh, got (%d)", len(emotes))
+		}
+	})
+}
@@ -0,0 +1,138 @@
+package helix
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/catsby/go-twitch/twitch"
+)
+
+// ErrUserAlreadyBanned is returned when banning a user that is already
+// banned.
+var ErrUserAlreadyBanned = errors.New("User is already banned")
+
+// ErrUserNotBanned is returned when unbanning a user that is not banned.
+var ErrUserNotBanned = errors.New("User is not banned")
+
+// ErrUserMayNotBeBanned is returned when banning a user that cannot be banned,
+// such as the broadcaster or another moderator.
+var ErrUserMayNotBeBanned = errors.New("User may not be banned")
+
+// ErrBanConflict is returned when another moderator is changing the user's ban
+// state at the same time.
+var ErrBanConflict = errors.New("User's ban state is being updated by someone else")
+
+// ErrUserAlreadyModerator is returned when adding a moderator that already is
+// one.
+var ErrUserAlreadyModerator = errors.New("User is already a moderator")
+
+// ErrUserNotModerator is returned when removing a moderator that is not one.
+var ErrUserNotModerator = errors.New("User is not a moderator")
+
+// ErrUserIsVIP is returned when adding a moderator that is a VIP. The user
+// must be removed as a VIP first.
+var ErrUserIsVIP = errors.New("User is a VIP")
+
+// ErrUserAlreadyVIP is returned when adding a VIP that already is one.
+var ErrUserAlreadyVIP = errors.New("User is already a VIP")
+
+// ErrUserNotVIP is returned when removing a VIP that is not one.
+var ErrUserNotVIP = errors.New("User is not a VIP")
+
+// ErrUserIsModerator is returned when adding a VIP that is a moderator. The
+// user must be removed as a moderator first.
+var ErrUserIsModerator = errors.New("User is a moderator")
+
+// ErrNoVIPSlots is returned when adding a VIP while the broadcaster has no VIP
+// slots left.
+var ErrNoVIPSlots = errors.New("Broadcaster has no VIP slots available")
+
+// ErrVIPNotAllowed is returned when adding a VIP before the broadcaster has
+// completed the Build a Community achievement.
+var ErrVIPNotAllowed = errors.New("Broadcaster may not assign VIPs yet")
+
+// ErrRaidSelf is returned when a broadcaster tries to raid themselves.
+var ErrRaidSelf = errors.New("Broadcaster may not raid themselves")
+
+// ErrRaidNotAllowed is returned when the target channel's settings do not
+// allow the broadcaster to raid it.
+var ErrRaidNotAllowed = errors.New("Target channel does not allow the raid")
+
+// ErrRaidTargetNotFound is returned when the target channel does not exist.
+var ErrRaidTargetNotFound = errors.New("Target channel not found")
+
+// ErrRaidInProgress is returned when starting a raid while another is pending.
+var ErrRaidInProgress = errors.New("Broadcaster is already raiding")
+
+// ErrNoPendingRaid is returned when canceling a raid that is not pending.
+var ErrNoPendingRaid = errors.New("Broadcaster has no pending raid")
+
+// ErrNotSubscribed is returned by CheckUserSubscription when the user is not
+// subscribed to the broadcaster.
+var ErrNotSubscribed = errors.New("User is not subscribed")
+
+// knownError maps an error message returned by Helix to an error value.
+type knownError struct {
+	StatusCode int
+
+	// Message is matched case-insensitively against the start of the
+	// message Twitch returns, which usually continues with more detail.
+	Message string
+
+	Err error
+}
+
+var knownErrors = []knownError{
+	{400, "the user specified in the user_id field is already banned", ErrUserAlreadyBanned},
+	{400, "the user specified in the user_id field is not banned", ErrUserNotBanned},
+	{400, "the user specified in the user_id field may not be banned", ErrUserMayNotBeBanned},
+	{409, "you may not update the user's ban state while someone else is updating the state", ErrBanConflict},
+	{400, "the user in the user_id query parameter is already a moderator", ErrUserAlreadyModerator},
+	{400, "the user in the user_id query parameter is not a moderator", ErrUserNotModerator},
+	{422, "the user in the user_id query parameter is a vip", ErrUserIsVIP},
+	{422, "the user in the user_id query parameter is already a vip", ErrUserAlreadyVIP},
+	{422, "the user in the user_id query parameter is not a vip", ErrUserNotVIP},
+	{422, "the user in the user_id query parameter is a moderator", ErrUserIsModerator},
+	{409, "the broadcaster doesn't have available vip slots", ErrNoVIPSlots},
+	{425, "the broadcaster must complete the build a community requirement", ErrVIPNotAllowed},
+	{400, "the broadcaster may not raid themselves", ErrRaidSelf},
+	{400, "the targeted channel's settings prevent you from raiding them", ErrRaidNotAllowed},
+	{404, "the targeted channel was not found", ErrRaidTargetNotFound},
+	{409, "the broadcaster is already in the process of raiding another channel", ErrRaidInProgress},
+	{404, "the broadcaster doesn't have a pending raid to cancel", ErrNoPendingRaid},
+}
+
+// Ensure Error is, in fact, an error.
+var _ error = (*Error)(nil)
+
+// Error is returned when Helix responds with an error this package recognizes.
+// Use errors.Is to check for one of the Err* values, or errors.As to get the
+// underlying *twitch.HTTPError.
+type Error struct {
+	*twitch.HTTPError
+
+	// Err is one of the Err* values of this package.
+	Err error
+}
+
+// Error implements the error interface.
+func (e *Error) Error() string {
+	return e.Err.Error() + ": " + e.HTTPError.Error()
+}
+
+// Unwrap returns both the known error and the HTTP error.
+func (e *Error) Unwrap() []error {
+	return []error{e.Err, e.HTTPError}
+}
+
+// newError returns an *Error if the HTTP error matches a known Helix error,
+// or the HTTP error itself otherwise.
+func newError(e *twitch.HTTPError) error {
+	msg := strings.ToLower(e.Message)
+	for _, k := range knownErrors {
+		if e.StatusCode == k.StatusCode && strings.HasPrefix(msg, k.Message) {
+			return &Error{HTTPError: e, Err: k.Err}
+		}
+	}
+	return e
+}
@@ -35,7 +35,7 @@ func (k *Client) GetGames(i *GetGamesInput) (*GetGamesOutput, error) {
 	}
 	path := "/games"
 
-	ro := new(twitch.RequestOptions)
+	ro := &twitch.RequestOptions{Endpoint: "GetGames", Input: i}
 	if len(i.Names) > 0 {
 		ro.Params = map[string]string{
 			"name": strings.Join(i.Names, ","),
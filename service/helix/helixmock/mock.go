@@ -0,0 +1,759 @@
+// Code generated by internal/mockgen from api.go. DO NOT EDIT.
+
+// Package helixmock provides a mock of helix.API that records its calls.
+package helixmock
+
+import (
+	"sync"
+
+	"github.com/catsby/go-twitch/service/helix"
+)
+
+var _ helix.API = (*Client)(nil)
+
+// Call is a call made to a Client.
+type Call struct {
+	Method string
+	Args   []interface{}
+}
+
+// Client is a mock of helix.API. Each method calls the matching Func field
+// if it is set, and returns zero values otherwise. Every call is recorded.
+type Client struct {
+	StartCommercialFunc             func(i *helix.StartCommercialInput) (*helix.StartCommercialOutput, error)
+	GetAdScheduleFunc               func(i *helix.GetAdScheduleInput) (*helix.GetAdScheduleOutput, error)
+	SnoozeNextAdFunc                func(i *helix.SnoozeNextAdInput) (*helix.SnoozeNextAdOutput, error)
+	CheckAutoModStatusFunc          func(i *helix.CheckAutoModStatusInput) (*helix.CheckAutoModStatusOutput, error)
+	ManageHeldAutoModMessageFunc    func(i *helix.ManageHeldAutoModMessageInput) error
+	GetAutoModSettingsFunc          func(i *helix.GetAutoModSettingsInput) (*helix.GetAutoModSettingsOutput, error)
+	UpdateAutoModSettingsFunc       func(i *helix.UpdateAutoModSettingsInput) (*helix.UpdateAutoModSettingsOutput, error)
+	GetBitsLeaderboardFunc          func(i *helix.GetBitsLeaderboardInput) (*helix.GetBitsLeaderboardOutput, error)
+	GetCheermotesFunc               func(i *helix.GetCheermotesInput) (*helix.GetCheermotesOutput, error)
+	CreateCustomRewardFunc          func(i *helix.CreateCustomRewardInput) (*helix.CustomRewardOutput, error)
+	UpdateCustomRewardFunc          func(i *helix.UpdateCustomRewardInput) (*helix.CustomRewardOutput, error)
+	DeleteCustomRewardFunc          func(i *helix.DeleteCustomRewardInput) error
+	GetCustomRewardFunc             func(i *helix.GetCustomRewardInput) (*helix.CustomRewardOutput, error)
+	GetCustomRewardRedemptionFunc   func(i *helix.GetCustomRewardRedemptionInput) (*helix.GetCustomRewardRedemptionOutput, error)
+	UpdateRedemptionStatusFunc      func(i *helix.UpdateRedemptionStatusInput) (*helix.UpdateRedemptionStatusOutput, error)
+	GetChannelEditorsFunc           func(i *helix.GetChannelEditorsInput) (*helix.GetChannelEditorsOutput, error)
+	GetVIPsFunc                     func(i *helix.GetVIPsInput) (*helix.GetVIPsOutput, error)
+	AddChannelVIPFunc               func(i *helix.AddChannelVIPInput) error
+	RemoveChannelVIPFunc            func(i *helix.RemoveChannelVIPInput) error
+	SyncVIPsFunc                    func(i *helix.SyncVIPsInput) (*helix.VIPsDiff, error)
+	GetChatSettingsFunc             func(i *helix.GetChatSettingsInput) (*helix.GetChatSettingsOutput, error)
+	UpdateChatSettingsFunc          func(i *helix.UpdateChatSettingsInput) (*helix.UpdateChatSettingsOutput, error)
+	SendChatAnnouncementFunc        func(i *helix.SendChatAnnouncementInput) error
+	SendShoutoutFunc                func(i *helix.SendShoutoutInput) error
+	SendChatMessageFunc             func(i *helix.SendChatMessageInput) (*helix.SendChatMessageOutput, error)
+	GetUserChatColorFunc            func(i *helix.GetUserChatColorInput) (*helix.GetUserChatColorOutput, error)
+	UpdateUserChatColorFunc         func(i *helix.UpdateUserChatColorInput) error
+	GetChattersFunc                 func(i *helix.GetChattersInput) (*helix.GetChattersOutput, error)
+	GetChannelChatBadgesFunc        func(i *helix.GetChannelChatBadgesInput) (*helix.ChatBadgesOutput, error)
+	GetGlobalChatBadgesFunc         func() (*helix.ChatBadgesOutput, error)
+	GetChannelEmotesFunc            func(i *helix.GetChannelEmotesInput) (*helix.EmotesOutput, error)
+	GetGlobalEmotesFunc             func() (*helix.EmotesOutput, error)
+	GetEmoteSetsFunc                func(i *helix.GetEmoteSetsInput) (*helix.EmotesOutput, error)
+	GetUserEmotesFunc               func(i *helix.GetUserEmotesInput) (*helix.EmotesOutput, error)
+	GetGamesFunc                    func(i *helix.GetGamesInput) (*helix.GetGamesOutput, error)
+	GetHypeTrainEventsFunc          func(i *helix.GetHypeTrainEventsInput) (*helix.GetHypeTrainEventsOutput, error)
+	BanUserFunc                     func(i *helix.BanUserInput) (*helix.BanUserOutput, error)
+	UnbanUserFunc                   func(i *helix.UnbanUserInput) error
+	GetBannedUsersFunc              func(i *helix.GetBannedUsersInput) (*helix.GetBannedUsersOutput, error)
+	GetModeratorsFunc               func(i *helix.GetModeratorsInput) (*helix.GetModeratorsOutput, error)
+	AddChannelModeratorFunc         func(i *helix.AddChannelModeratorInput) error
+	RemoveChannelModeratorFunc      func(i *helix.RemoveChannelModeratorInput) error
+	GetBlockedTermsFunc             func(i *helix.GetBlockedTermsInput) (*helix.GetBlockedTermsOutput, error)
+	AddBlockedTermFunc              func(i *helix.AddBlockedTermInput) (*helix.AddBlockedTermOutput, error)
+	RemoveBlockedTermFunc           func(i *helix.RemoveBlockedTermInput) error
+	SyncBlockedTermsFunc            func(i *helix.SyncBlockedTermsInput) (*helix.BlockedTermsDiff, error)
+	CreatePollFunc                  func(i *helix.CreatePollInput) (*helix.PollsOutput, error)
+	EndPollFunc                     func(i *helix.EndPollInput) (*helix.PollsOutput, error)
+	GetPollsFunc                    func(i *helix.GetPollsInput) (*helix.PollsOutput, error)
+	WaitPollFunc                    func(i *helix.WaitPollInput) (*helix.Poll, error)
+	CreatePredictionFunc            func(i *helix.CreatePredictionInput) (*helix.PredictionsOutput, error)
+	EndPredictionFunc               func(i *helix.EndPredictionInput) (*helix.PredictionsOutput, error)
+	GetPredictionsFunc              func(i *helix.GetPredictionsInput) (*helix.PredictionsOutput, error)
+	WaitPredictionFunc              func(i *helix.WaitPredictionInput) (*helix.Prediction, error)
+	StartRaidFunc                   func(i *helix.StartRaidInput) (*helix.StartRaidOutput, error)
+	CancelRaidFunc                  func(i *helix.CancelRaidInput) error
+	GetChannelStreamScheduleFunc    func(i *helix.GetChannelStreamScheduleInput) (*helix.GetChannelStreamScheduleOutput, error)
+	CreateScheduleSegmentFunc       func(i *helix.CreateScheduleSegmentInput) (*helix.CreateScheduleSegmentOutput, error)
+	UpdateScheduleSegmentFunc       func(i *helix.UpdateScheduleSegmentInput) (*helix.UpdateScheduleSegmentOutput, error)
+	DeleteScheduleSegmentFunc       func(i *helix.DeleteScheduleSegmentInput) error
+	UpdateScheduleSettingsFunc      func(i *helix.UpdateScheduleSettingsInput) error
+	GetStreamsFunc                  func(i *helix.GetStreamsInput) (*helix.GetStreamsOutput, error)
+	CreateStreamMarkerFunc          func(i *helix.CreateStreamMarkerInput) (*helix.CreateStreamMarkerOutput, error)
+	GetStreamMarkersFunc            func(i *helix.GetStreamMarkersInput) (*helix.GetStreamMarkersOutput, error)
+	GetBroadcasterSubscriptionsFunc func(i *helix.GetBroadcasterSubscriptionsInput) (*helix.GetBroadcasterSubscriptionsOutput, error)
+	CheckUserSubscriptionFunc       func(i *helix.CheckUserSubscriptionInput) (*helix.CheckUserSubscriptionOutput, error)
+	GetTeamsFunc                    func(i *helix.GetTeamsInput) (*helix.GetTeamsOutput, error)
+	GetChannelTeamsFunc             func(i *helix.GetChannelTeamsInput) (*helix.GetChannelTeamsOutput, error)
+	GetTeamMembersFunc              func(i *helix.GetTeamMembersInput) (*helix.GetTeamMembersOutput, error)
+	GetUsersFunc                    func(i *helix.GetUsersInput) (*helix.GetUsersOutput, error)
+
+	mu    sync.Mutex
+	calls []Call
+}
+
+// Calls returns every call made, oldest first.
+func (m *Client) Calls() []Call {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	out := make([]Call, len(m.calls))
+	copy(out, m.calls)
+	return out
+}
+
+// CallsTo returns the calls made to the named method, oldest first.
+func (m *Client) CallsTo(method string) []Call {
+	var out []Call
+	for _, c := range m.Calls() {
+		if c.Method == method {
+			out = append(out, c)
+		}
+	}
+	return out
+}
+
+// Reset forgets the calls made so far.
+func (m *Client) Reset() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.calls = nil
+}
+
+func (m *Client) record(method string, args ...interface{}) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.calls = append(m.calls, Call{Method: method, Args: args})
+}
+
+// StartCommercial implements helix.AdsAPI.
+func (m *Client) StartCommercial(i *helix.StartCommercialInput) (*helix.StartCommercialOutput, error) {
+	m.record("StartCommercial", i)
+	if m.StartCommercialFunc != nil {
+		return m.StartCommercialFunc(i)
+	}
+	return nil, nil
+}
+
+// GetAdSchedule implements helix.AdsAPI.
+func (m *Client) GetAdSchedule(i *helix.GetAdScheduleInput) (*helix.GetAdScheduleOutput, error) {
+	m.record("GetAdSchedule", i)
+	if m.GetAdScheduleFunc != nil {
+		return m.GetAdScheduleFunc(i)
+	}
+	return nil, nil
+}
+
+// SnoozeNextAd implements helix.AdsAPI.
+func (m *Client) SnoozeNextAd(i *helix.SnoozeNextAdInput) (*helix.SnoozeNextAdOutput, error) {
+	m.record("SnoozeNextAd", i)
+	if m.SnoozeNextAdFunc != nil {
+		return m.SnoozeNextAdFunc(i)
+	}
+	return nil, nil
+}
+
+// CheckAutoModStatus implements helix.AutoModAPI.
+func (m *Client) CheckAutoModStatus(i *helix.CheckAutoModStatusInput) (*helix.CheckAutoModStatusOutput, error) {
+	m.record("CheckAutoModStatus", i)
+	if m.CheckAutoModStatusFunc != nil {
+		return m.CheckAutoModStatusFunc(i)
+	}
+	return nil, nil
+}
+
+// ManageHeldAutoModMessage implements helix.AutoModAPI.
+func (m *Client) ManageHeldAutoModMessage(i *helix.ManageHeldAutoModMessageInput) error {
+	m.record("ManageHeldAutoModMessage", i)
+	if m.ManageHeldAutoModMessageFunc != nil {
+		return m.ManageHeldAutoModMessageFunc(i)
+	}
+	return nil
+}
+
+// GetAutoModSettings implements helix.AutoModAPI.
+func (m *Client) GetAutoModSettings(i *helix.GetAutoModSettingsInput) (*helix.GetAutoModSettingsOutput, error) {
+	m.record("GetAutoModSettings", i)
+	if m.GetAutoModSettingsFunc != nil {
+		return m.GetAutoModSettingsFunc(i)
+	}
+	return nil, nil
+}
+
+// UpdateAutoModSettings implements helix.AutoModAPI.
+func (m *Client) UpdateAutoModSettings(i *helix.UpdateAutoModSettingsInput) (*helix.UpdateAutoModSettingsOutput, error) {
+	m.record("UpdateAutoModSettings", i)
+	if m.UpdateAutoModSettingsFunc != nil {
+		return m.UpdateAutoModSettingsFunc(i)
+	}
+	return nil, nil
+}
+
+// GetBitsLeaderboard implements helix.BitsAPI.
+func (m *Client) GetBitsLeaderboard(i *helix.GetBitsLeaderboardInput) (*helix.GetBitsLeaderboardOutput, error) {
+	m.record("GetBitsLeaderboard", i)
+	if m.GetBitsLeaderboardFunc != nil {
+		return m.GetBitsLeaderboardFunc(i)
+	}
+	return nil, nil
+}
+
+// GetCheermotes implements helix.BitsAPI.
+func (m *Client) GetCheermotes(i *helix.GetCheermotesInput) (*helix.GetCheermotesOutput, error) {
+	m.record("GetCheermotes", i)
+	if m.GetCheermotesFunc != nil {
+		return m.GetCheermotesFunc(i)
+	}
+	return nil, nil
+}
+
+// CreateCustomReward implements helix.ChannelPointsAPI.
+func (m *Client) CreateCustomReward(i *helix.CreateCustomRewardInput) (*helix.CustomRewardOutput, error) {
+	m.record("CreateCustomReward", i)
+	if m.CreateCustomRewardFunc != nil {
+		return m.CreateCustomRewardFunc(i)
+	}
+	return nil, nil
+}
+
+// UpdateCustomReward implements helix.ChannelPointsAPI.
+func (m *Client) UpdateCustomReward(i *helix.UpdateCustomRewardInput) (*helix.CustomRewardOutput, error) {
+	m.record("UpdateCustomReward", i)
+	if m.UpdateCustomRewardFunc != nil {
+		return m.UpdateCustomRewardFunc(i)
+	}
+	return nil, nil
+}
+
+// DeleteCustomReward implements helix.ChannelPointsAPI.
+func (m *Client) DeleteCustomReward(i *helix.DeleteCustomRewardInput) error {
+	m.record("DeleteCustomReward", i)
+	if m.DeleteCustomRewardFunc != nil {
+		return m.DeleteCustomRewardFunc(i)
+	}
+	return nil
+}
+
+// GetCustomReward implements helix.ChannelPointsAPI.
+func (m *Client) GetCustomReward(i *helix.GetCustomRewardInput) (*helix.CustomRewardOutput, error) {
+	m.record("GetCustomReward", i)
+	if m.GetCustomRewardFunc != nil {
+		return m.GetCustomRewardFunc(i)
+	}
+	return nil, nil
+}
+
+// GetCustomRewardRedemption implements helix.ChannelPointsAPI.
+func (m *Client) GetCustomRewardRedemption(i *helix.GetCustomRewardRedemptionInput) (*helix.GetCustomRewardRedemptionOutput, error) {
+	m.record("GetCustomRewardRedemption", i)
+	if m.GetCustomRewardRedemptionFunc != nil {
+		return m.GetCustomRewardRedemptionFunc(i)
+	}
+	return nil, nil
+}
+
+// UpdateRedemptionStatus implements helix.ChannelPointsAPI.
+func (m *Client) UpdateRedemptionStatus(i *helix.UpdateRedemptionStatusInput) (*helix.UpdateRedemptionStatusOutput, error) {
+	m.record("UpdateRedemptionStatus", i)
+	if m.UpdateRedemptionStatusFunc != nil {
+		return m.UpdateRedemptionStatusFunc(i)
+	}
+	return nil, nil
+}
+
+// GetChannelEditors implements helix.ChannelsAPI.
+func (m *Client) GetChannelEditors(i *helix.GetChannelEditorsInput) (*helix.GetChannelEditorsOutput, error) {
+	m.record("GetChannelEditors", i)
+	if m.GetChannelEditorsFunc != nil {
+		return m.GetChannelEditorsFunc(i)
+	}
+	return nil, nil
+}
+
+// GetVIPs implements helix.ChannelsAPI.
+func (m *Client) GetVIPs(i *helix.GetVIPsInput) (*helix.GetVIPsOutput, error) {
+	m.record("GetVIPs", i)
+	if m.GetVIPsFunc != nil {
+		return m.GetVIPsFunc(i)
+	}
+	return nil, nil
+}
+
+// AddChannelVIP implements helix.ChannelsAPI.
+func (m *Client) AddChannelVIP(i *helix.AddChannelVIPInput) error {
+	m.record("AddChannelVIP", i)
+	if m.AddChannelVIPFunc != nil {
+		return m.AddChannelVIPFunc(i)
+	}
+	return nil
+}
+
+// RemoveChannelVIP implements helix.ChannelsAPI.
+func (m *Client) RemoveChannelVIP(i *helix.RemoveChannelVIPInput) error {
+	m.record("RemoveChannelVIP", i)
+	if m.RemoveChannelVIPFunc != nil {
+		return m.RemoveChannelVIPFunc(i)
+	}
+	return nil
+}
+
+// SyncVIPs implements helix.ChannelsAPI.
+func (m *Client) SyncVIPs(i *helix.SyncVIPsInput) (*helix.VIPsDiff, error) {
+	m.record("SyncVIPs", i)
+	if m.SyncVIPsFunc != nil {
+		return m.SyncVIPsFunc(i)
+	}
+	return nil, nil
+}
+
+// GetChatSettings implements helix.ChatAPI.
+func (m *Client) GetChatSettings(i *helix.GetChatSettingsInput) (*helix.GetChatSettingsOutput, error) {
+	m.record("GetChatSettings", i)
+	if m.GetChatSettingsFunc != nil {
+		return m.GetChatSettingsFunc(i)
+	}
+	return nil, nil
+}
+
+// UpdateChatSettings implements helix.ChatAPI.
+func (m *Client) UpdateChatSettings(i *helix.UpdateChatSettingsInput) (*helix.UpdateChatSettingsOutput, error) {
+	m.record("UpdateChatSettings", i)
+	if m.UpdateChatSettingsFunc != nil {
+		return m.UpdateChatSettingsFunc(i)
+	}
+	return nil, nil
+}
+
+// SendChatAnnouncement implements helix.ChatAPI.
+func (m *Client) SendChatAnnouncement(i *helix.SendChatAnnouncementInput) error {
+	m.record("SendChatAnnouncement", i)
+	if m.SendChatAnnouncementFunc != nil {
+		return m.SendChatAnnouncementFunc(i)
+	}
+	return nil
+}
+
+// SendShoutout implements helix.ChatAPI.
+func (m *Client) SendShoutout(i *helix.SendShoutoutInput) error {
+	m.record("SendShoutout", i)
+	if m.SendShoutoutFunc != nil {
+		return m.SendShoutoutFunc(i)
+	}
+	return nil
+}
+
+// SendChatMessage implements helix.ChatAPI.
+func (m *Client) SendChatMessage(i *helix.SendChatMessageInput) (*helix.SendChatMessageOutput, error) {
+	m.record("SendChatMessage", i)
+	if m.SendChatMessageFunc != nil {
+		return m.SendChatMessageFunc(i)
+	}
+	return nil, nil
+}
+
+// GetUserChatColor implements helix.ChatAPI.
+func (m *Client) GetUserChatColor(i *helix.GetUserChatColorInput) (*helix.GetUserChatColorOutput, error) {
+	m.record("GetUserChatColor", i)
+	if m.GetUserChatColorFunc != nil {
+		return m.GetUserChatColorFunc(i)
+	}
+	return nil, nil
+}
+
+// UpdateUserChatColor implements helix.ChatAPI.
+func (m *Client) UpdateUserChatColor(i *helix.UpdateUserChatColorInput) error {
+	m.record("UpdateUserChatColor", i)
+	if m.UpdateUserChatColorFunc != nil {
+		return m.UpdateUserChatColorFunc(i)
+	}
+	return nil
+}
+
+// GetChatters implements helix.ChatAPI.
+func (m *Client) GetChatters(i *helix.GetChattersInput) (*helix.GetChattersOutput, error) {
+	m.record("GetChatters", i)
+	if m.GetChattersFunc != nil {
+		return m.GetChattersFunc(i)
+	}
+	return nil, nil
+}
+
+// GetChannelChatBadges implements helix.ChatAPI.
+func (m *Client) GetChannelChatBadges(i *helix.GetChannelChatBadgesInput) (*helix.ChatBadgesOutput, error) {
+	m.record("GetChannelChatBadges", i)
+	if m.GetChannelChatBadgesFunc != nil {
+		return m.GetChannelChatBadgesFunc(i)
+	}
+	return nil, nil
+}
+
+// GetGlobalChatBadges implements helix.ChatAPI.
+func (m *Client) GetGlobalChatBadges() (*helix.ChatBadgesOutput, error) {
+	m.record("GetGlobalChatBadges")
+	if m.GetGlobalChatBadgesFunc != nil {
+		return m.GetGlobalChatBadgesFunc()
+	}
+	return nil, nil
+}
+
+// GetChannelEmotes implements helix.ChatAPI.
+func (m *Client) GetChannelEmotes(i *helix.GetChannelEmotesInput) (*helix.EmotesOutput, error) {
+	m.record("GetChannelEmotes", i)
+	if m.GetChannelEmotesFunc != nil {
+		return m.GetChannelEmotesFunc(i)
+	}
+	return nil, nil
+}
+
+// GetGlobalEmotes implements helix.ChatAPI.
+func (m *Client) GetGlobalEmotes() (*helix.EmotesOutput, error) {
+	m.record("GetGlobalEmotes")
+	if m.GetGlobalEmotesFunc != nil {
+		return m.GetGlobalEmotesFunc()
+	}
+	return nil, nil
+}
+
+// GetEmoteSets implements helix.ChatAPI.
+func (m *Client) GetEmoteSets(i *helix.GetEmoteSetsInput) (*helix.EmotesOutput, error) {
+	m.record("GetEmoteSets", i)
+	if m.GetEmoteSetsFunc != nil {
+		return m.GetEmoteSetsFunc(i)
+	}
+	return nil, nil
+}
+
+// GetUserEmotes implements helix.ChatAPI.
+func (m *Client) GetUserEmotes(i *helix.GetUserEmotesInput) (*helix.EmotesOutput, error) {
+	m.record("GetUserEmotes", i)
+	if m.GetUserEmotesFunc != nil {
+		return m.GetUserEmotesFunc(i)
+	}
+	return nil, nil
+}
+
+// GetGames implements helix.GamesAPI.
+func (m *Client) GetGames(i *helix.GetGamesInput) (*helix.GetGamesOutput, error) {
+	m.record("GetGames", i)
+	if m.GetGamesFunc != nil {
+		return m.GetGamesFunc(i)
+	}
+	return nil, nil
+}
+
+// GetHypeTrainEvents implements helix.HypeTrainAPI.
+func (m *Client) GetHypeTrainEvents(i *helix.GetHypeTrainEventsInput) (*helix.GetHypeTrainEventsOutput, error) {
+	m.record("GetHypeTrainEvents", i)
+	if m.GetHypeTrainEventsFunc != nil {
+		return m.GetHypeTrainEventsFunc(i)
+	}
+	return nil, nil
+}
+
+// BanUser implements helix.ModerationAPI.
+func (m *Client) BanUser(i *helix.BanUserInput) (*helix.BanUserOutput, error) {
+	m.record("BanUser", i)
+	if m.BanUserFunc != nil {
+		return m.BanUserFunc(i)
+	}
+	return nil, nil
+}
+
+// UnbanUser implements helix.ModerationAPI.
+func (m *Client) UnbanUser(i *helix.UnbanUserInput) error {
+	m.record("UnbanUser", i)
+	if m.UnbanUserFunc != nil {
+		return m.UnbanUserFunc(i)
+	}
+	return nil
+}
+
+// GetBannedUsers implements helix.ModerationAPI.
+func (m *Client) GetBannedUsers(i *helix.GetBannedUsersInput) (*helix.GetBannedUsersOutput, error) {
+	m.record("GetBannedUsers", i)
+	if m.GetBannedUsersFunc != nil {
+		return m.GetBannedUsersFunc(i)
+	}
+	return nil, nil
+}
+
+// GetModerators implements helix.ModerationAPI.
+func (m *Client) GetModerators(i *helix.GetModeratorsInput) (*helix.GetModeratorsOutput, error) {
+	m.record("GetModerators", i)
+	if m.GetModeratorsFunc != nil {
+		return m.GetModeratorsFunc(i)
+	}
+	return nil, nil
+}
+
+// AddChannelModerator implements helix.ModerationAPI.
+func (m *Client) AddChannelModerator(i *helix.AddChannelModeratorInput) error {
+	m.record("AddChannelModerator", i)
+	if m.AddChannelModeratorFunc != nil {
+		return m.AddChannelModeratorFunc(i)
+	}
+	return nil
+}
+
+// RemoveChannelModerator implements helix.ModerationAPI.
+func (m *Client) RemoveChannelModerator(i *helix.RemoveChannelModeratorInput) error {
+	m.record("RemoveChannelModerator", i)
+	if m.RemoveChannelModeratorFunc != nil {
+		return m.RemoveChannelModeratorFunc(i)
+	}
+	return nil
+}
+
+// GetBlockedTerms implements helix.ModerationAPI.
+func (m *Client) GetBlockedTerms(i *helix.GetBlockedTermsInput) (*helix.GetBlockedTermsOutput, error) {
+	m.record("GetBlockedTerms", i)
+	if m.GetBlockedTermsFunc != nil {
+		return m.GetBlockedTermsFunc(i)
+	}
+	return nil, nil
+}
+
+// AddBlockedTerm implements helix.ModerationAPI.
+func (m *Client) AddBlockedTerm(i *helix.AddBlockedTermInput) (*helix.AddBlockedTermOutput, error) {
+	m.record("AddBlockedTerm", i)
+	if m.AddBlockedTermFunc != nil {
+		return m.AddBlockedTermFunc(i)
+	}
+	return nil, nil
+}
+
+// RemoveBlockedTerm implements helix.ModerationAPI.
+func (m *Client) RemoveBlockedTerm(i *helix.RemoveBlockedTermInput) error {
+	m.record("RemoveBlockedTerm", i)
+	if m.RemoveBlockedTermFunc != nil {
+		return m.RemoveBlockedTermFunc(i)
+	}
+	return nil
+}
+
+// SyncBlockedTerms implements helix.ModerationAPI.
+func (m *Client) SyncBlockedTerms(i *helix.SyncBlockedTermsInput) (*helix.BlockedTermsDiff, error) {
+	m.record("SyncBlockedTerms", i)
+	if m.SyncBlockedTermsFunc != nil {
+		return m.SyncBlockedTermsFunc(i)
+	}
+	return nil, nil
+}
+
+// CreatePoll implements helix.PollsAPI.
+func (m *Client) CreatePoll(i *helix.CreatePollInput) (*helix.PollsOutput, error) {
+	m.record("CreatePoll", i)
+	if m.CreatePollFunc != nil {
+		return m.CreatePollFunc(i)
+	}
+	return nil, nil
+}
+
+// EndPoll implements helix.PollsAPI.
+func (m *Client) EndPoll(i *helix.EndPollInput) (*helix.PollsOutput, error) {
+	m.record("EndPoll", i)
+	if m.EndPollFunc != nil {
+		return m.EndPollFunc(i)
+	}
+	return nil, nil
+}
+
+// GetPolls implements helix.PollsAPI.
+func (m *Client) GetPolls(i *helix.GetPollsInput) (*helix.PollsOutput, error) {
+	m.record("GetPolls", i)
+	if m.GetPollsFunc != nil {
+		return m.GetPollsFunc(i)
+	}
+	return nil, nil
+}
+
+// WaitPoll implements helix.PollsAPI.
+func (m *Client) WaitPoll(i *helix.WaitPollInput) (*helix.Poll, error) {
+	m.record("WaitPoll", i)
+	if m.WaitPollFunc != nil {
+		return m.WaitPollFunc(i)
+	}
+	return nil, nil
+}
+
+// CreatePrediction implements helix.PredictionsAPI.
+func (m *Client) CreatePrediction(i *helix.CreatePredictionInput) (*helix.PredictionsOutput, error) {
+	m.record("CreatePrediction", i)
+	if m.CreatePredictionFunc != nil {
+		return m.CreatePredictionFunc(i)
+	}
+	return nil, nil
+}
+
+// EndPrediction implements helix.PredictionsAPI.
+func (m *Client) EndPrediction(i *helix.EndPredictionInput) (*helix.PredictionsOutput, error) {
+	m.record("EndPrediction", i)
+	if m.EndPredictionFunc != nil {
+		return m.EndPredictionFunc(i)
+	}
+	return nil, nil
+}
+
+// GetPredictions implements helix.PredictionsAPI.
+func (m *Client) GetPredictions(i *helix.GetPredictionsInput) (*helix.PredictionsOutput, error) {
+	m.record("GetPredictions", i)
+	if m.GetPredictionsFunc != nil {
+		return m.GetPredictionsFunc(i)
+	}
+	return nil, nil
+}
+
+// WaitPrediction implements helix.PredictionsAPI.
+func (m *Client) WaitPrediction(i *helix.WaitPredictionInput) (*helix.Prediction, error) {
+	m.record("WaitPrediction", i)
+	if m.WaitPredictionFunc != nil {
+		return m.WaitPredictionFunc(i)
+	}
+	return nil, nil
+}
+
+// StartRaid implements helix.RaidsAPI.
+func (m *Client) StartRaid(i *helix.StartRaidInput) (*helix.StartRaidOutput, error) {
+	m.record("StartRaid", i)
+	if m.StartRaidFunc != nil {
+		return m.StartRaidFunc(i)
+	}
+	return nil, nil
+}
+
+// CancelRaid implements helix.RaidsAPI.
+func (m *Client) CancelRaid(i *helix.CancelRaidInput) error {
+	m.record("CancelRaid", i)
+	if m.CancelRaidFunc != nil {
+		return m.CancelRaidFunc(i)
+	}
+	return nil
+}
+
+// GetChannelStreamSchedule implements helix.ScheduleAPI.
+func (m *Client) GetChannelStreamSchedule(i *helix.GetChannelStreamScheduleInput) (*helix.GetChannelStreamScheduleOutput, error) {
+	m.record("GetChannelStreamSchedule", i)
+	if m.GetChannelStreamScheduleFunc != nil {
+		return m.GetChannelStreamScheduleFunc(i)
+	}
+	return nil, nil
+}
+
+// CreateScheduleSegment implements helix.ScheduleAPI.
+func (m *Client) CreateScheduleSegment(i *helix.CreateScheduleSegmentInput) (*helix.CreateScheduleSegmentOutput, error) {
+	m.record("CreateScheduleSegment", i)
+	if m.CreateScheduleSegmentFunc != nil {
+		return m.CreateScheduleSegmentFunc(i)
+	}
+	return nil, nil
+}
+
+// UpdateScheduleSegment implements helix.ScheduleAPI.
+func (m *Client) UpdateScheduleSegment(i *helix.UpdateScheduleSegmentInput) (*helix.UpdateScheduleSegmentOutput, error) {
+	m.record("UpdateScheduleSegment", i)
+	if m.UpdateScheduleSegmentFunc != nil {
+		return m.UpdateScheduleSegmentFunc(i)
+	}
+	return nil, nil
+}
+
+// DeleteScheduleSegment implements helix.ScheduleAPI.
+func (m *Client) DeleteScheduleSegment(i *helix.DeleteScheduleSegmentInput) error {
+	m.record("DeleteScheduleSegment", i)
+	if m.DeleteScheduleSegmentFunc != nil {
+		return m.DeleteScheduleSegmentFunc(i)
+	}
+	return nil
+}
+
+// UpdateScheduleSettings implements helix.ScheduleAPI.
+func (m *Client) UpdateScheduleSettings(i *helix.UpdateScheduleSettingsInput) error {
+	m.record("UpdateScheduleSettings", i)
+	if m.UpdateScheduleSettingsFunc != nil {
+		return m.UpdateScheduleSettingsFunc(i)
+	}
+	return nil
+}
+
+// GetStreams implements helix.StreamsAPI.
+func (m *Client) GetStreams(i *helix.GetStreamsInput) (*helix.GetStreamsOutput, error) {
+	m.record("GetStreams", i)
+	if m.GetStreamsFunc != nil {
+		return m.GetStreamsFunc(i)
+	}
+	return nil, nil
+}
+
+// CreateStreamMarker implements helix.StreamsAPI.
+func (m *Client) CreateStreamMarker(i *helix.CreateStreamMarkerInput) (*helix.CreateStreamMarkerOutput, error) {
+	m.record("CreateStreamMarker", i)
+	if m.CreateStreamMarkerFunc != nil {
+		return m.CreateStreamMarkerFunc(i)
+	}
+	return nil, nil
+}
+
+// GetStreamMarkers implements helix.StreamsAPI.
+func (m *Client) GetStreamMarkers(i *helix.GetStreamMarkersInput) (*helix.GetStreamMarkersOutput, error) {
+	m.record("GetStreamMarkers", i)
+	if m.GetStreamMarkersFunc != nil {
+		return m.GetStreamMarkersFunc(i)
+	}
+	return nil, nil
+}
+
+// GetBroadcasterSubscriptions implements helix.SubscriptionsAPI.
+func (m *Client) GetBroadcasterSubscriptions(i *helix.GetBroadcasterSubscriptionsInput) (*helix.GetBroadcasterSubscriptionsOutput, error) {
+	m.record("GetBroadcasterSubscriptions", i)
+	if m.GetBroadcasterSubscriptionsFunc != nil {
+		return m.GetBroadcasterSubscriptionsFunc(i)
+	}
+	return nil, nil
+}
+
+// CheckUserSubscription implements helix.SubscriptionsAPI.
+func (m *Client) CheckUserSubscription(i *helix.CheckUserSubscriptionInput) (*helix.CheckUserSubscriptionOutput, error) {
+	m.record("CheckUserSubscription", i)
+	if m.CheckUserSubscriptionFunc != nil {
+		return m.CheckUserSubscriptionFunc(i)
+	}
+	return nil, nil
+}
+
+// GetTeams implements helix.TeamsAPI.
+func (m *Client) GetTeams(i *helix.GetTeamsInput) (*helix.GetTeamsOutput, error) {
+	m.record("GetTeams", i)
+	if m.GetTeamsFunc != nil {
+		return m.GetTeamsFunc(i)
+	}
+	return nil, nil
+}
+
+// GetChannelTeams implements helix.TeamsAPI.
+func (m *Client) GetChannelTeams(i *helix.GetChannelTeamsInput) (*helix.GetChannelTeamsOutput, error) {
+	m.record("GetChannelTeams", i)
+	if m.GetChannelTeamsFunc != nil {
+		return m.GetChannelTeamsFunc(i)
+	}
+	return nil, nil
+}
+
+// GetTeamMembers implements helix.TeamsAPI.
+func (m *Client) GetTeamMembers(i *helix.GetTeamMembersInput) (*helix.GetTeamMembersOutput, error) {
+	m.record("GetTeamMembers", i)
+	if m.GetTeamMembersFunc != nil {
+		return m.GetTeamMembersFunc(i)
+	}
+	return nil, nil
+}
+
+// GetUsers implements helix.UsersAPI.
+func (m *Client) GetUsers(i *helix.GetUsersInput) (*helix.GetUsersOutput, error) {
+	m.record("GetUsers", i)
+	if m.GetUsersFunc != nil {
+		return m.GetUsersFunc(i)
+	}
+	return nil, nil
+}
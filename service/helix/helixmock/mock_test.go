@@ -0,0 +1,89 @@
+package helixmock
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/catsby/go-twitch/service/helix"
+)
+
+// getGame uses the API rather than the concrete client, as code under test
+// would.
+func getGame(api helix.API, name string) (*helix.Game, error) {
+	o, err := api.GetGames(&helix.GetGamesInput{Names: []string{name}})
+	if err != nil {
+		return nil, err
+	}
+	if o == nil || len(o.Games) == 0 {
+		return nil, nil
+	}
+	return o.Games[0], nil
+}
+
+func TestClient_zeroValues(t *testing.T) {
+	m := new(Client)
+
+	game, err := getGame(m, "Celeste")
+	if err != nil {
+		t.Fatalf("Expected no error, got: %s", err)
+	}
+	if game != nil {
+		t.Fatalf("Expected no game, got: %#v", game)
+	}
+
+	if err := m.CancelRaid(&helix.CancelRaidInput{BroadcasterId: "1"}); err != nil {
+		t.Fatalf("Expected no error, got: %s", err)
+	}
+}
+
+func TestClient_funcs(t *testing.T) {
+	m := &Client{
+		GetGamesFunc: func(i *helix.GetGamesInput) (*helix.GetGamesOutput, error) {
+			return &helix.GetGamesOutput{
+				Games: []*helix.Game{{Id: "504461", Name: i.Names[0]}},
+			}, nil
+		},
+	}
+
+	game, err := getGame(m, "Celeste")
+	if err != nil {
+		t.Fatalf("Expected no error, got: %s", err)
+	}
+	if game == nil || game.Id != "504461" || game.Name != "Celeste" {
+		t.Fatalf("Expected Celeste, got: %#v", game)
+	}
+
+	boom := errors.New("boom")
+	m.GetGamesFunc = func(i *helix.GetGamesInput) (*helix.GetGamesOutput, error) {
+		return nil, boom
+	}
+	if _, err := getGame(m, "Celeste"); err != boom {
+		t.Fatalf("Expected the stubbed error, got: %v", err)
+	}
+}
+
+func TestClient_calls(t *testing.T) {
+	m := new(Client)
+
+	getGame(m, "Celeste")
+	m.GetStreams(&helix.GetStreamsInput{UserLogins: []string{"catsby"}})
+	getGame(m, "Hades")
+
+	if calls := m.Calls(); len(calls) != 3 {
+		t.Fatalf("Expected 3 calls, got: %d", len(calls))
+	}
+
+	calls := m.CallsTo("GetGames")
+	if len(calls) != 2 {
+		t.Fatalf("Expected 2 calls to GetGames, got: %d", len(calls))
+	}
+	i, ok := calls[1].Args[0].(*helix.GetGamesInput)
+	if !ok || i.Names[0] != "Hades" {
+		t.Fatalf("Expected the second call to be for Hades, got: %#v", calls[1].Args)
+	}
+
+	m.Reset()
+	if calls := m.Calls(); len(calls) != 0 {
+		t.Fatalf("Expected no calls after Reset, got: %d", len(calls))
+	}
+}
@@ -0,0 +1,105 @@
+package helix
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/catsby/go-twitch/twitch"
+)
+
+// HypeTrainContributionType is the kind of a hype train contribution.
+type HypeTrainContributionType string
+
+const (
+	HypeTrainContributionBits  HypeTrainContributionType = "BITS"
+	HypeTrainContributionSubs  HypeTrainContributionType = "SUBS"
+	HypeTrainContributionOther HypeTrainContributionType = "OTHER"
+)
+
+// HypeTrainContribution is a user's contribution to a hype train.
+type HypeTrainContribution struct {
+	// Total is Bits for HypeTrainContributionBits, and points for
+	// HypeTrainContributionSubs: 500, 1000 and 2500 for tier 1, 2 and 3.
+	Total int                       `mapstructure:"total"`
+	Type  HypeTrainContributionType `mapstructure:"type"`
+
+	// User is the ID of the contributing user.
+	User string `mapstructure:"user"`
+}
+
+// HypeTrain is the state of a hype train.
+type HypeTrain struct {
+	Id            string `mapstructure:"id"`
+	BroadcasterId string `mapstructure:"broadcaster_id"`
+	Level         int    `mapstructure:"level"`
+
+	// Goal is the points needed to reach the next level, and Total the
+	// points contributed so far.
+	Goal  int `mapstructure:"goal"`
+	Total int `mapstructure:"total"`
+
+	LastContribution *HypeTrainContribution   `mapstructure:"last_contribution"`
+	TopContributions []*HypeTrainContribution `mapstructure:"top_contributions"`
+
+	StartedAt       time.Time `mapstructure:"started_at"`
+	ExpiresAt       time.Time `mapstructure:"expires_at"`
+	CooldownEndTime time.Time `mapstructure:"cooldown_end_time"`
+}
+
+// HypeTrainEvent is a change to a hype train.
+type HypeTrainEvent struct {
+	Id string `mapstructure:"id"`
+
+	// EventType is hypetrain.progression.
+	EventType      string     `mapstructure:"event_type"`
+	EventTimestamp time.Time  `mapstructure:"event_timestamp"`
+	Version        string     `mapstructure:"version"`
+	EventData      *HypeTrain `mapstructure:"event_data"`
+}
+
+// GetHypeTrainEventsInput is the input to the GetHypeTrainEvents function.
+type GetHypeTrainEventsInput struct {
+	BroadcasterId string
+
+	// Maximum number of objects to return. Default: 1. Maximum: 100.
+	First int
+	After string
+}
+
+// GetHypeTrainEventsOutput is the output of the GetHypeTrainEvents function.
+type GetHypeTrainEventsOutput struct {
+	Events     []*HypeTrainEvent `mapstructure:"data"`
+	Pagination *Pagination       `mapstructure:"pagination"`
+}
+
+// GetHypeTrainEvents returns a page of the hype train events of a channel,
+// most recent first.
+// Scope: channel:read:hype_train
+// See:
+//  - https://dev.twitch.tv/docs/api/reference#get-hype-train-events
+func (k *Client) GetHypeTrainEvents(i *GetHypeTrainEventsInput) (*GetHypeTrainEventsOutput, error) {
+	if i == nil || i.BroadcasterId == "" {
+		return nil, fmt.Errorf("[ERR] No BroadcasterId for GetHypeTrainEvents")
+	}
+
+	ro := &twitch.RequestOptions{
+		Params: map[string]string{
+			"broadcaster_id": i.BroadcasterId,
+		},
+		Endpoint: "GetHypeTrainEvents",
+		Input:    i,
+	}
+	setPageParams(ro, i.First, i.After, "")
+
+	resp, err := k.Get("/hypetrain/events", ro)
+	if err != nil {
+		return nil, err
+	}
+
+	var o GetHypeTrainEventsOutput
+	if err := twitch.DecodeJSON(&o, resp.Body); err != nil {
+		return nil, err
+	}
+
+	return &o, nil
+}
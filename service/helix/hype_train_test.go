@@ -0,0 +1,35 @@
+package helix
+
+import "testing"
+
+func TestHypeTrain_GetHypeTrainEvents(t *testing.T) {
+	t.Parallel()
+
+	var err error
+	var output *GetHypeTrainEventsOutput
+	recordHelix(t, "hype_train/events", func(c *Client) {
+		output, err = c.GetHypeTrainEvents(&GetHypeTrainEventsInput{
+			BroadcasterId: "270954519",
+			First:         1,
+		})
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	e := output.Events[0]
+	if e.EventType != "hypetrain.progression" || e.EventTimestamp.IsZero() {
+		t.Fatalf("Bad event: %#v", e)
+	}
+
+	train := e.EventData
+	if train.Level != 2 || train.Goal != 1800 || train.CooldownEndTime.IsZero() {
+		t.Fatalf("Bad hype train: %#v", train)
+	}
+	if c := train.LastContribution; c.Type != HypeTrainContributionBits || c.Total != 200 {
+		t.Fatalf("Bad last contribution: %#v", c)
+	}
+	if output.Pagination.Cursor == "" {
+		t.Fatalf("Expected a cursor")
+	}
+}
@@ -0,0 +1,223 @@
+package helix
+
+import (
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// DefaultLoaderWait is how long a loader collects lookups before requesting
+// them together.
+const DefaultLoaderWait = 10 * time.Millisecond
+
+// MaxLoaderBatch is the most IDs a loader requests at once, the most Twitch
+// accepts.
+const MaxLoaderBatch = 100
+
+// LoaderConfig configures a GameLoader or UserLoader.
+type LoaderConfig struct {
+	// Wait is how long lookups are collected after the first one before
+	// they are requested. Default: DefaultLoaderWait.
+	Wait time.Duration
+
+	// MaxBatch is the most IDs requested at once; a batch is requested as
+	// soon as it is full. Default and maximum: MaxLoaderBatch.
+	MaxBatch int
+}
+
+// NotFoundError is returned by a loader for an ID Twitch returned nothing for.
+type NotFoundError struct {
+	// Kind is what was looked up, Ex: "game".
+	Kind string
+	Id   string
+}
+
+// Error implements the error interface.
+func (e *NotFoundError) Error() string {
+	return fmt.Sprintf("%s %s not found", e.Kind, e.Id)
+}
+
+// GameLoader looks up games by ID for many goroutines at once. Lookups made
+// within its Wait of each other are requested with a single call to GetGames,
+// and lookups of an ID already being requested wait for that request.
+type GameLoader struct {
+	l *loader
+}
+
+// NewGameLoader returns a GameLoader requesting games with c, Ex: a *Client
+// or a helixmock.Client. A nil config uses the defaults.
+func NewGameLoader(c GamesAPI, config *LoaderConfig) *GameLoader {
+	return &GameLoader{l: newLoader(config, func(ids []string) (map[string]interface{}, error) {
+		input := &GetGamesInput{}
+		for _, id := range ids {
+			n, err := strconv.Atoi(id)
+			if err != nil {
+				return nil, err
+			}
+			input.Ids = append(input.Ids, n)
+		}
+
+		o, err := c.GetGames(input)
+		if err != nil {
+			return nil, err
+		}
+		games := make(map[string]interface{}, len(o.Games))
+		for _, g := range o.Games {
+			games[g.Id] = g
+		}
+		return games, nil
+	})}
+}
+
+// Load returns the game with the given ID, or a *NotFoundError if there is
+// none. It blocks until the batch it is part of has been requested.
+func (g *GameLoader) Load(id int) (*Game, error) {
+	v, err := g.l.load("game", strconv.Itoa(id))
+	if err != nil {
+		return nil, err
+	}
+	return v.(*Game), nil
+}
+
+// UserLoader looks up users by ID for many goroutines at once. Lookups made
+// within its Wait of each other are requested with a single call to GetUsers,
+// and lookups of an ID already being requested wait for that request.
+type UserLoader struct {
+	l *loader
+}
+
+// NewUserLoader returns a UserLoader requesting users with c, Ex: a *Client
+// or a helixmock.Client. A nil config uses the defaults.
+func NewUserLoader(c UsersAPI, config *LoaderConfig) *UserLoader {
+	return &UserLoader{l: newLoader(config, func(ids []string) (map[string]interface{}, error) {
+		o, err := c.GetUsers(&GetUsersInput{Ids: ids})
+		if err != nil {
+			return nil, err
+		}
+		users := make(map[string]interface{}, len(o.Users))
+		for _, u := range o.Users {
+			users[u.Id] = u
+		}
+		return users, nil
+	})}
+}
+
+// Load returns the user with the given ID, or a *NotFoundError if there is
+// none. It blocks until the batch it is part of has been requested.
+func (u *UserLoader) Load(id string) (*User, error) {
+	v, err := u.l.load("user", id)
+	if err != nil {
+		return nil, err
+	}
+	return v.(*User), nil
+}
+
+// loader collects the IDs looked up into batches, and fetches each batch
+// with one request.
+type loader struct {
+	fetch    func(ids []string) (map[string]interface{}, error)
+	wait     time.Duration
+	maxBatch int
+
+	mu      sync.Mutex
+	pending *batch
+
+	// inflight holds the batch of every ID that is pending or being
+	// fetched, so lookups of the same ID share it.
+	inflight map[string]*batch
+}
+
+// batch is a set of IDs fetched together. Its values and err are set before
+// done is closed.
+type batch struct {
+	ids  []string
+	once sync.Once
+	done chan struct{}
+
+	values map[string]interface{}
+	err    error
+}
+
+func newLoader(config *LoaderConfig, fetch func([]string) (map[string]interface{}, error)) *loader {
+	if config == nil {
+		config = &LoaderConfig{}
+	}
+	l := &loader{
+		fetch:    fetch,
+		wait:     config.Wait,
+		maxBatch: config.MaxBatch,
+		inflight: make(map[string]*batch),
+	}
+	if l.wait <= 0 {
+		l.wait = DefaultLoaderWait
+	}
+	if l.maxBatch <= 0 || l.maxBatch > MaxLoaderBatch {
+		l.maxBatch = MaxLoaderBatch
+	}
+	return l
+}
+
+// load adds id to the pending batch, unless it is already in one, and
+// returns its value once the batch is fetched.
+func (l *loader) load(kind, id string) (interface{}, error) {
+	l.mu.Lock()
+	b, ok := l.inflight[id]
+	if !ok {
+		b = l.pending
+		if b == nil {
+			b = &batch{done: make(chan struct{})}
+			l.pending = b
+			time.AfterFunc(l.wait, func() { l.dispatch(b) })
+		}
+		b.ids = append(b.ids, id)
+		l.inflight[id] = b
+		if len(b.ids) >= l.maxBatch {
+			go l.dispatch(b)
+			l.pending = nil
+		}
+	}
+	l.mu.Unlock()
+
+	<-b.done
+	if b.err != nil {
+		return nil, b.err
+	}
+	v, ok := b.values[id]
+	if !ok {
+		return nil, &NotFoundError{Kind: kind, Id: id}
+	}
+	return v, nil
+}
+
+// dispatch fetches a batch, once, and wakes the lookups waiting on it. A
+// panic in fetch happens on the loader's goroutine, where no caller could
+// recover it, so it is returned to every lookup as an error instead.
+func (l *loader) dispatch(b *batch) {
+	b.once.Do(func() {
+		l.mu.Lock()
+		if l.pending == b {
+			l.pending = nil
+		}
+		ids := b.ids
+		l.mu.Unlock()
+
+		defer func() {
+			l.mu.Lock()
+			for _, id := range ids {
+				if l.inflight[id] == b {
+					delete(l.inflight, id)
+				}
+			}
+			l.mu.Unlock()
+			close(b.done)
+		}()
+		defer func() {
+			if r := recover(); r != nil {
+				b.values, b.err = nil, fmt.Errorf("[ERR] Loading %d IDs panicked: %v", len(ids), r)
+			}
+		}()
+
+		b.values, b.err = l.fetch(ids)
+	})
+}
@@ -0,0 +1,192 @@
+package helix
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/catsby/go-twitch/twitchtest"
+)
+
+func TestGameLoader(t *testing.T) {
+	state := &twitchtest.State{}
+	for id := 1; id <= 120; id++ {
+		state.Games = append(state.Games, &twitchtest.Game{Id: strconv.Itoa(id), Name: "Game " + strconv.Itoa(id)})
+	}
+	s := twitchtest.NewServer(state)
+	defer s.Close()
+
+	c, err := NewClient(s.HelixConfig())
+	if err != nil {
+		t.Fatalf("Error creating client: %s", err)
+	}
+	loader := NewGameLoader(c, &LoaderConfig{Wait: 50 * time.Millisecond})
+
+	// 125 IDs, 5 of them missing, each looked up twice.
+	var wg sync.WaitGroup
+	errs := make(chan error, 250)
+	for n := 0; n < 250; n++ {
+		id := n/2 + 1
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			g, err := loader.Load(id)
+			if id > 120 {
+				var nf *NotFoundError
+				if !errors.As(err, &nf) || nf.Id != strconv.Itoa(id) {
+					errs <- fmt.Errorf("Expected a NotFoundError for game %d, got: %v", id, err)
+				}
+				return
+			}
+			if err != nil {
+				errs <- err
+				return
+			}
+			if g.Id != strconv.Itoa(id) {
+				errs <- fmt.Errorf("Expected game %d, got: %s", id, g.Id)
+			}
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		t.Error(err)
+	}
+
+	// A full batch of 100 and the 25 IDs left.
+	s.AssertRequested(t, "GET", "/helix/games", 2)
+	ids := 0
+	for _, r := range s.Requested("GET", "/helix/games") {
+		ids += len(r.Query["id"])
+	}
+	if ids != 125 {
+		t.Fatalf("Expected 125 IDs requested once each, got: %d", ids)
+	}
+}
+
+func TestUserLoader(t *testing.T) {
+	s := twitchtest.NewServer(&twitchtest.State{
+		Users: []*twitchtest.User{{Id: "1", Login: "catsby"}, {Id: "2", Login: "twitchdev"}},
+	})
+	defer s.Close()
+
+	c, err := NewClient(s.HelixConfig())
+	if err != nil {
+		t.Fatalf("Error creating client: %s", err)
+	}
+	loader := NewUserLoader(c, nil)
+
+	var wg sync.WaitGroup
+	users := make([]*User, 4)
+	errs := make([]error, 4)
+	for n, id := range []string{"1", "2", "1", "3"} {
+		wg.Add(1)
+		go func(n int, id string) {
+			defer wg.Done()
+			users[n], errs[n] = loader.Load(id)
+		}(n, id)
+	}
+	wg.Wait()
+
+	for n, login := range []string{"catsby", "twitchdev", "catsby"} {
+		if errs[n] != nil || users[n].Login != login {
+			t.Errorf("Expected user %s, got: %v, %v", login, users[n], errs[n])
+		}
+	}
+	var nf *NotFoundError
+	if !errors.As(errs[3], &nf) || nf.Kind != "user" {
+		t.Errorf("Expected a NotFoundError for user 3, got: %v", errs[3])
+	}
+
+	r := s.AssertRequested(t, "GET", "/helix/users", 1)
+	if len(r.Query["id"]) != 3 {
+		t.Fatalf("Expected the 3 IDs in one request, got: %v", r.Query["id"])
+	}
+
+	// Lookups after the batch was fetched are requested again.
+	if _, err := loader.Load("1"); err != nil {
+		t.Fatalf("Error loading user: %s", err)
+	}
+	s.AssertRequested(t, "GET", "/helix/users", 2)
+}
+
+func TestLoader_error(t *testing.T) {
+	s := twitchtest.NewServer(&twitchtest.State{})
+	defer s.Close()
+
+	c, err := NewClient(s.HelixConfig())
+	if err != nil {
+		t.Fatalf("Error creating client: %s", err)
+	}
+	loader := NewGameLoader(c, nil)
+
+	s.FailNext(500)
+	if _, err := loader.Load(1); err == nil {
+		t.Fatal("Expected the error of the request")
+	} else if _, ok := err.(*NotFoundError); ok {
+		t.Fatalf("Expected the error of the request, got: %s", err)
+	}
+}
+
+// usersFunc and gamesFunc implement UsersAPI and GamesAPI with a function,
+// as a helixmock.Client would.
+type usersFunc func(*GetUsersInput) (*GetUsersOutput, error)
+
+func (f usersFunc) GetUsers(i *GetUsersInput) (*GetUsersOutput, error) { return f(i) }
+
+type gamesFunc func(*GetGamesInput) (*GetGamesOutput, error)
+
+func (f gamesFunc) GetGames(i *GetGamesInput) (*GetGamesOutput, error) { return f(i) }
+
+func TestLoader_fake(t *testing.T) {
+	var calls int
+	loader := NewUserLoader(usersFunc(func(i *GetUsersInput) (*GetUsersOutput, error) {
+		calls++
+		if len(i.Ids) != 2 {
+			t.Errorf("Expected 2 IDs, got: %v", i.Ids)
+		}
+		return &GetUsersOutput{Users: []*User{{Id: "1", Login: "catsby"}}}, nil
+	}), nil)
+
+	var wg sync.WaitGroup
+	errs := make([]error, 2)
+	for n, id := range []string{"1", "2"} {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, errs[n] = loader.Load(id)
+		}()
+	}
+	wg.Wait()
+
+	if errs[0] != nil || calls != 1 {
+		t.Fatalf("Expected user 1 from one call, got: %v after %d calls", errs[0], calls)
+	}
+	if _, ok := errs[1].(*NotFoundError); !ok {
+		t.Fatalf("Expected a NotFoundError for user 2, got: %v", errs[1])
+	}
+}
+
+func TestLoader_panic(t *testing.T) {
+	loader := NewGameLoader(gamesFunc(func(*GetGamesInput) (*GetGamesOutput, error) {
+		panic("boom")
+	}), nil)
+
+	done := make(chan error)
+	go func() {
+		_, err := loader.Load(1)
+		done <- err
+	}()
+
+	select {
+	case err := <-done:
+		if err == nil {
+			t.Fatal("Expected an error from the panicking request")
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Timed out waiting for the lookup to return")
+	}
+}
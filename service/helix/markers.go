@@ -0,0 +1,144 @@
+package helix
+
+import (
+	"fmt"
+	"time"
+	"unicode/utf8"
+
+	"github.com/catsby/go-twitch/twitch"
+)
+
+// MaxStreamMarkerDescriptionLength is the longest description Twitch accepts
+// for a stream marker.
+const MaxStreamMarkerDescriptionLength = 140
+
+// StreamMarker marks a moment of a broadcast, Ex: a highlight.
+type StreamMarker struct {
+	Id          string    `mapstructure:"id"`
+	CreatedAt   time.Time `mapstructure:"created_at"`
+	Description string    `mapstructure:"description"`
+
+	// Position is the time from the start of the broadcast.
+	Position time.Duration `mapstructure:"position_seconds"`
+
+	// Url links to the marker in the VOD. It is only set by
+	// GetStreamMarkers.
+	Url string `mapstructure:"url"`
+}
+
+// CreateStreamMarkerInput is the input to the CreateStreamMarker function.
+type CreateStreamMarkerInput struct {
+	// UserId is the broadcaster, who must be live.
+	UserId string
+
+	// Description is at most MaxStreamMarkerDescriptionLength characters.
+	Description string
+}
+
+// CreateStreamMarkerOutput is the output of the CreateStreamMarker function.
+type CreateStreamMarkerOutput struct {
+	Markers []*StreamMarker `mapstructure:"data"`
+}
+
+// CreateStreamMarker marks the current moment of a live broadcast.
+// Scope: channel:manage:broadcast
+// See:
+//  - https://dev.twitch.tv/docs/api/reference#create-stream-marker
+func (k *Client) CreateStreamMarker(i *CreateStreamMarkerInput) (*CreateStreamMarkerOutput, error) {
+	if i == nil || i.UserId == "" {
+		return nil, fmt.Errorf("[ERR] No UserId for CreateStreamMarker")
+	}
+	if utf8.RuneCountInString(i.Description) > MaxStreamMarkerDescriptionLength {
+		return nil, fmt.Errorf("[ERR] Description for CreateStreamMarker is longer than %d characters", MaxStreamMarkerDescriptionLength)
+	}
+
+	body := map[string]string{
+		"user_id": i.UserId,
+	}
+	if i.Description != "" {
+		body["description"] = i.Description
+	}
+
+	resp, err := k.PostJSON("/streams/markers", body, &twitch.RequestOptions{Endpoint: "CreateStreamMarker", Input: i})
+	if err != nil {
+		return nil, err
+	}
+
+	var o CreateStreamMarkerOutput
+	if err := twitch.DecodeJSON(&o, resp.Body); err != nil {
+		return nil, err
+	}
+
+	return &o, nil
+}
+
+// VideoMarkers are the markers of a VOD.
+type VideoMarkers struct {
+	VideoId string          `mapstructure:"video_id"`
+	Markers []*StreamMarker `mapstructure:"markers"`
+}
+
+// UserMarkers are the markers of a broadcaster, grouped per VOD.
+type UserMarkers struct {
+	UserId    string          `mapstructure:"user_id"`
+	UserName  string          `mapstructure:"user_name"`
+	UserLogin string          `mapstructure:"user_login"`
+	Videos    []*VideoMarkers `mapstructure:"videos"`
+}
+
+// GetStreamMarkersInput is the input to the GetStreamMarkers function. Either
+// UserId or VideoId must be set.
+type GetStreamMarkersInput struct {
+	// UserId returns the markers of the broadcaster's most recent VOD.
+	UserId string
+
+	VideoId string
+
+	// Maximum number of markers to return. Default: 20. Maximum: 100.
+	First int
+
+	// Cursors for forward and backward pagination.
+	After  string
+	Before string
+}
+
+// GetStreamMarkersOutput is the output of the GetStreamMarkers function.
+type GetStreamMarkersOutput struct {
+	Users      []*UserMarkers `mapstructure:"data"`
+	Pagination *Pagination    `mapstructure:"pagination"`
+}
+
+// GetStreamMarkers returns a page of the markers of a broadcaster's most
+// recent VOD, or of a given VOD.
+// Scope: user:read:broadcast
+// See:
+//  - https://dev.twitch.tv/docs/api/reference#get-stream-markers
+func (k *Client) GetStreamMarkers(i *GetStreamMarkersInput) (*GetStreamMarkersOutput, error) {
+	if i == nil || (i.UserId == "") == (i.VideoId == "") {
+		return nil, fmt.Errorf("[ERR] Exactly one of UserId or VideoId is required for GetStreamMarkers")
+	}
+
+	ro := &twitch.RequestOptions{
+		Params:   map[string]string{},
+		Endpoint: "GetStreamMarkers",
+		Input:    i,
+	}
+	if i.UserId != "" {
+		ro.Params["user_id"] = i.UserId
+	} else {
+		ro.Params["video_id"] = i.VideoId
+	}
+	setPageParams(ro, i.First, i.After, i.Before)
+
+	resp, err := k.Get("/streams/markers", ro)
+	if err != nil {
+		return nil, err
+	}
+
+	var o GetStreamMarkersOutput
+	if err := twitch.DecodeJSON(&o, resp.Body); err != nil {
+		return nil, err
+	}
+
+	return &o, nil
+}
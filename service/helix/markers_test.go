@@ -0,0 +1,61 @@
+package helix
+
+import (
+	"testing"
+	"time"
+)
+
+func TestMarkers_CreateStreamMarker(t *testing.T) {
+	t.Parallel()
+
+	var err error
+	var output *CreateStreamMarkerOutput
+	recordHelix(t, "markers/create", func(c *Client) {
+		output, err = c.CreateStreamMarker(&CreateStreamMarkerInput{
+			UserId:      "123",
+			Description: "hello, this is a marker!",
+		})
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if m := output.Markers[0]; m.Position != 4*time.Minute+4*time.Second || m.Description != "hello, this is a marker!" {
+		t.Fatalf("Bad marker: %#v", m)
+	}
+}
+
+func TestMarkers_GetStreamMarkers(t *testing.T) {
+	t.Parallel()
+
+	var err error
+	var output *GetStreamMarkersOutput
+	recordHelix(t, "markers/get", func(c *Client) {
+		output, err = c.GetStreamMarkers(&GetStreamMarkersInput{
+			UserId: "123",
+			First:  5,
+		})
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	videos := output.Users[0].Videos
+	if len(videos) != 2 || len(videos[0].Markers) != 2 || videos[1].VideoId != "457" {
+		t.Fatalf("Expected markers grouped by (2) videos, got %#v", videos)
+	}
+	if m := videos[0].Markers[1]; m.Position != time.Hour+2*time.Minute+5*time.Second || m.Url == "" {
+		t.Fatalf("Bad marker: %#v", m)
+	}
+	if output.Pagination == nil || output.Pagination.Cursor == "" {
+		t.Fatalf("Expected a pagination cursor")
+	}
+
+	_, err = (&Client{}).GetStreamMarkers(&GetStreamMarkersInput{
+		UserId:  "123",
+		VideoId: "456",
+	})
+	if err == nil {
+		t.Fatalf("Expected an error with both UserId and VideoId")
+	}
+}
@@ -0,0 +1,329 @@
+package helix
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/catsby/go-twitch/twitch"
+)
+
+// Limits of BanUserInput.
+const (
+	MinTimeoutDuration = time.Second
+	MaxTimeoutDuration = 14 * 24 * time.Hour
+	MaxBanReasonLength = 500
+)
+
+// Ban is the result of banning or timing out a user.
+type Ban struct {
+	BroadcasterId string    `mapstructure:"broadcaster_id"`
+	ModeratorId   string    `mapstructure:"moderator_id"`
+	UserId        string    `mapstructure:"user_id"`
+	CreatedAt     time.Time `mapstructure:"created_at"`
+
+	// EndTime is when a timeout ends, and the zero time for a ban.
+	EndTime time.Time `mapstructure:"end_time"`
+}
+
+// BanUserInput is the input to the BanUser function.
+type BanUserInput struct {
+	// BroadcasterId is the channel the user is banned from, and ModeratorId
+	// the broadcaster or moderator the access token belongs to.
+	BroadcasterId string
+	ModeratorId   string
+
+	UserId string
+
+	// Duration times the user out rather than banning them. It is sent in
+	// whole seconds, between MinTimeoutDuration and MaxTimeoutDuration.
+	Duration time.Duration
+
+	// Reason is shown to the user and other moderators. It is at most
+	// MaxBanReasonLength characters.
+	Reason string
+}
+
+// validate checks the input against the limits Twitch enforces, so invalid
+// bans fail before a request is made.
+func (i *BanUserInput) validate() error {
+	if i.BroadcasterId == "" || i.ModeratorId == "" || i.UserId == "" {
+		return fmt.Errorf("[ERR] No BroadcasterId, ModeratorId or UserId for BanUser")
+	}
+	if i.Duration != 0 && (i.Duration < MinTimeoutDuration || i.Duration > MaxTimeoutDuration) {
+		return fmt.Errorf("[ERR] Duration %s for BanUser must be between %s and %s", i.Duration, MinTimeoutDuration, MaxTimeoutDuration)
+	}
+	if utf8.RuneCountInString(i.Reason) > MaxBanReasonLength {
+		return fmt.Errorf("[ERR] Reason for BanUser is longer than %d characters", MaxBanReasonLength)
+	}
+	return nil
+}
+
+// BanUserOutput is the output of the BanUser function.
+type BanUserOutput struct {
+	Bans []*Ban `mapstructure:"data"`
+}
+
+// BanUser bans a user from a channel, or times them out if Duration is set.
+// Scope: moderator:manage:banned_users
+// See:
+//  - https://dev.twitch.tv/docs/api/reference#ban-user
+func (k *Client) BanUser(i *BanUserInput) (*BanUserOutput, error) {
+	if i == nil {
+		return nil, fmt.Errorf("[ERR] No BroadcasterId, ModeratorId or UserId for BanUser")
+	}
+	if err := i.validate(); err != nil {
+		return nil, err
+	}
+
+	ro := &twitch.RequestOptions{
+		Params: map[string]string{
+			"broadcaster_id": i.BroadcasterId,
+			"moderator_id":   i.ModeratorId,
+		},
+		Endpoint: "BanUser",
+		Input:    i,
+	}
+
+	data := map[string]interface{}{
+		"user_id": i.UserId,
+	}
+	if i.Duration > 0 {
+		data["duration"] = int(i.Duration / time.Second)
+	}
+	if i.Reason != "" {
+		data["reason"] = i.Reason
+	}
+
+	resp, err := k.PostJSON("/moderation/bans", map[string]interface{}{"data": data}, ro)
+	if err != nil {
+		return nil, err
+	}
+
+	var o BanUserOutput
+	if err := twitch.DecodeJSON(&o, resp.Body); err != nil {
+		return nil, err
+	}
+
+	return &o, nil
+}
+
+// UnbanUserInput is the input to the UnbanUser function.
+type UnbanUserInput struct {
+	BroadcasterId string
+	ModeratorId   string
+	UserId        string
+}
+
+// UnbanUser removes a ban or timeout.
+// Scope: moderator:manage:banned_users
+// See:
+//  - https://dev.twitch.tv/docs/api/reference#unban-user
+func (k *Client) UnbanUser(i *UnbanUserInput) error {
+	if i == nil || i.BroadcasterId == "" || i.ModeratorId == "" || i.UserId == "" {
+		return fmt.Errorf("[ERR] No BroadcasterId, ModeratorId or UserId for UnbanUser")
+	}
+
+	ro := &twitch.RequestOptions{
+		Params: map[string]string{
+			"broadcaster_id": i.BroadcasterId,
+			"moderator_id":   i.ModeratorId,
+			"user_id":        i.UserId,
+		},
+		Endpoint: "UnbanUser",
+		Input:    i,
+	}
+
+	resp, err := k.Delete("/moderation/bans", ro)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+
+	return nil
+}
+
+// BannedUser is a user that is banned or timed out in a channel.
+type BannedUser struct {
+	UserId    string `mapstructure:"user_id"`
+	UserLogin string `mapstructure:"user_login"`
+	UserName  string `mapstructure:"user_name"`
+
+	// ExpiresAt is when a timeout ends, and the zero time for a ban.
+	ExpiresAt time.Time `mapstructure:"expires_at"`
+	CreatedAt time.Time `mapstructure:"created_at"`
+	Reason    string    `mapstructure:"reason"`
+
+	ModeratorId    string `mapstructure:"moderator_id"`
+	ModeratorLogin string `mapstructure:"moderator_login"`
+	ModeratorName  string `mapstructure:"moderator_name"`
+}
+
+// GetBannedUsersInput is the input to the GetBannedUsers function.
+type GetBannedUsersInput struct {
+	BroadcasterId string
+
+	// UserIds filters the list to the given users. Maximum: 100.
+	UserIds []string
+
+	// Maximum number of objects to return. Default: 20. Maximum: 100.
+	First int
+
+	// Cursors for forward and backward pagination.
+	After  string
+	Before string
+}
+
+// GetBannedUsersOutput is the output of the GetBannedUsers function.
+type GetBannedUsersOutput struct {
+	BannedUsers []*BannedUser `mapstructure:"data"`
+	Pagination  *Pagination   `mapstructure:"pagination"`
+}
+
+// GetBannedUsers returns the users banned or timed out in a channel.
+// Scope: moderation:read
+// See:
+//  - https://dev.twitch.tv/docs/api/reference#get-banned-users
+func (k *Client) GetBannedUsers(i *GetBannedUsersInput) (*GetBannedUsersOutput, error) {
+	if i == nil || i.BroadcasterId == "" {
+		return nil, fmt.Errorf("[ERR] No BroadcasterId for GetBannedUsers")
+	}
+
+	ro := &twitch.RequestOptions{
+		Params: map[string]string{
+			"broadcaster_id": i.BroadcasterId,
+		},
+		Endpoint: "GetBannedUsers",
+		Input:    i,
+	}
+	if len(i.UserIds) > 0 {
+		ro.Params["user_id"] = strings.Join(i.UserIds, ",")
+	}
+	setPageParams(ro, i.First, i.After, i.Before)
+
+	resp, err := k.Get("/moderation/banned", ro)
+	if err != nil {
+		return nil, err
+	}
+
+	var o GetBannedUsersOutput
+	if err := twitch.DecodeJSON(&o, resp.Body); err != nil {
+		return nil, err
+	}
+
+	return &o, nil
+}
+
+// Moderator is a moderator of a channel.
+type Moderator struct {
+	UserId    string `mapstructure:"user_id"`
+	UserLogin string `mapstructure:"user_login"`
+	UserName  string `mapstructure:"user_name"`
+}
+
+// GetModeratorsInput is the input to the GetModerators function.
+type GetModeratorsInput struct {
+	BroadcasterId string
+
+	// UserIds filters the list to the given users. Maximum: 100.
+	UserIds []string
+
+	// Maximum number of objects to return. Default: 20. Maximum: 100.
+	First int
+	After string
+}
+
+// GetModeratorsOutput is the output of the GetModerators function.
+type GetModeratorsOutput struct {
+	Moderators []*Moderator `mapstructure:"data"`
+	Pagination *Pagination  `mapstructure:"pagination"`
+}
+
+// GetModerators returns the moderators of a channel.
+// Scope: moderation:read
+// See:
+//  - https://dev.twitch.tv/docs/api/reference#get-moderators
+func (k *Client) GetModerators(i *GetModeratorsInput) (*GetModeratorsOutput, error) {
+	if i == nil || i.BroadcasterId == "" {
+		return nil, fmt.Errorf("[ERR] No BroadcasterId for GetModerators")
+	}
+
+	ro := &twitch.RequestOptions{
+		Params: map[string]string{
+			"broadcaster_id": i.BroadcasterId,
+		},
+		Endpoint: "GetModerators",
+		Input:    i,
+	}
+	if len(i.UserIds) > 0 {
+		ro.Params["user_id"] = strings.Join(i.UserIds, ",")
+	}
+	setPageParams(ro, i.First, i.After, "")
+
+	resp, err := k.Get("/moderation/moderators", ro)
+	if err != nil {
+		return nil, err
+	}
+
+	var o GetModeratorsOutput
+	if err := twitch.DecodeJSON(&o, resp.Body); err != nil {
+		return nil, err
+	}
+
+	return &o, nil
+}
+
+// AddChannelModeratorInput is the input to the AddChannelModerator function.
+type AddChannelModeratorInput struct {
+	BroadcasterId string
+	UserId        string
+}
+
+// AddChannelModerator makes a user a moderator of a channel.
+// Scope: channel:manage:moderators
+// See:
+//  - https://dev.twitch.tv/docs/api/reference#add-channel-moderator
+func (k *Client) AddChannelModerator(i *AddChannelModeratorInput) error {
+	if i == nil || i.BroadcasterId == "" || i.UserId == "" {
+		return fmt.Errorf("[ERR] No BroadcasterId or UserId for AddChannelModerator")
+	}
+	return k.channelModerator("POST", i.BroadcasterId, i.UserId, "AddChannelModerator", i)
+}
+
+// RemoveChannelModeratorInput is the input to the RemoveChannelModerator
+// function.
+type RemoveChannelModeratorInput struct {
+	BroadcasterId string
+	UserId        string
+}
+
+// RemoveChannelModerator removes a user as a moderator of a channel.
+// Scope: channel:manage:moderators
+// See:
+//  - https://dev.twitch.tv/docs/api/reference#remove-channel-moderator
+func (k *Client) RemoveChannelModerator(i *RemoveChannelModeratorInput) error {
+	if i == nil || i.BroadcasterId == "" || i.UserId == "" {
+		return fmt.Errorf("[ERR] No BroadcasterId or UserId for RemoveChannelModerator")
+	}
+	return k.channelModerator("DELETE", i.BroadcasterId, i.UserId, "RemoveChannelModerator", i)
+}
+
+func (k *Client) channelModerator(verb, broadcasterId, userId, endpoint string, input interface{}) error {
+	ro := &twitch.RequestOptions{
+		Params: map[string]string{
+			"broadcaster_id": broadcasterId,
+			"user_id":        userId,
+		},
+		Endpoint: endpoint,
+		Input:    input,
+	}
+
+	resp, err := k.Request(verb, "/moderation/moderators", ro)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+
+	return nil
+}
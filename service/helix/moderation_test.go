@@ -0,0 +1,196 @@
+package helix
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/catsby/go-twitch/twitch"
+)
+
+func TestModeration_BanUser(t *testing.T) {
+	t.Parallel()
+
+	var err error
+	var output *BanUserOutput
+
+	recordHelix(t, "moderation/ban_timeout", func(c *Client) {
+		output, err = c.BanUser(&BanUserInput{
+			BroadcasterId: "198704263",
+			ModeratorId:   "198704263",
+			UserId:        "9876",
+			Duration:      5 * time.Minute,
+			Reason:        "no spam",
+		})
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(output.Bans) != 1 {
+		t.Fatalf("Expected (1) ban, got (%d)", len(output.Bans))
+	}
+
+	b := output.Bans[0]
+	if b.UserId != "9876" {
+		t.Fatalf("Expected user (9876), got (%s)", b.UserId)
+	}
+	if d := b.EndTime.Sub(b.CreatedAt); d != 5*time.Minute {
+		t.Fatalf("Expected a 5m timeout, got (%s)", d)
+	}
+}
+
+func TestModeration_BanUser_validate(t *testing.T) {
+	t.Parallel()
+
+	valid := func() *BanUserInput {
+		return &BanUserInput{
+			BroadcasterId: "198704263",
+			ModeratorId:   "198704263",
+			UserId:        "9876",
+		}
+	}
+
+	for _, d := range []time.Duration{0, MinTimeoutDuration, MaxTimeoutDuration} {
+		i := valid()
+		i.Duration = d
+		if err := i.validate(); err != nil {
+			t.Fatalf("Expected a duration of %s to be valid, got (%s)", d, err)
+		}
+	}
+
+	cases := map[string]func(*BanUserInput){
+		"no user":        func(i *BanUserInput) { i.UserId = "" },
+		"sub-second":     func(i *BanUserInput) { i.Duration = 500 * time.Millisecond },
+		"negative":       func(i *BanUserInput) { i.Duration = -time.Minute },
+		"over two weeks": func(i *BanUserInput) { i.Duration = MaxTimeoutDuration + time.Second },
+		"long reason":    func(i *BanUserInput) { i.Reason = strings.Repeat("a", MaxBanReasonLength+1) },
+	}
+	for name, f := range cases {
+		i := valid()
+		f(i)
+		if err := i.validate(); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
+
+func TestModeration_BanUser_alreadyBanned(t *testing.T) {
+	t.Parallel()
+
+	var err error
+	recordHelix(t, "moderation/ban_already_banned", func(c *Client) {
+		_, err = c.BanUser(&BanUserInput{
+			BroadcasterId: "198704263",
+			ModeratorId:   "198704263",
+			UserId:        "9876",
+		})
+	})
+
+	if !errors.Is(err, ErrUserAlreadyBanned) {
+		t.Fatalf("Expected ErrUserAlreadyBanned, got (%v)", err)
+	}
+
+	var httpErr *twitch.HTTPError
+	if !errors.As(err, &httpErr) || httpErr.StatusCode != 400 {
+		t.Fatalf("Expected a 400 HTTPError, got (%v)", err)
+	}
+}
+
+func TestModeration_UnbanUser(t *testing.T) {
+	t.Parallel()
+
+	var err error
+	recordHelix(t, "moderation/unban", func(c *Client) {
+		err = c.UnbanUser(&UnbanUserInput{
+			BroadcasterId: "198704263",
+			ModeratorId:   "198704263",
+			UserId:        "9876",
+		})
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestModeration_GetBannedUsers(t *testing.T) {
+	t.Parallel()
+
+	var err error
+	var output *GetBannedUsersOutput
+
+	recordHelix(t, "moderation/get_banned", func(c *Client) {
+		output, err = c.GetBannedUsers(&GetBannedUsersInput{
+			BroadcasterId: "198704263",
+			First:         2,
+		})
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(output.BannedUsers) != 2 {
+		t.Fatalf("Expected (2) banned users, got (%d)", len(output.BannedUsers))
+	}
+
+	if output.BannedUsers[0].ExpiresAt.IsZero() {
+		t.Fatalf("Expected a timeout to have an expiry")
+	}
+	if !output.BannedUsers[1].ExpiresAt.IsZero() {
+		t.Fatalf("Expected a ban to have no expiry, got (%s)", output.BannedUsers[1].ExpiresAt)
+	}
+
+	if output.Pagination == nil || output.Pagination.Cursor == "" {
+		t.Fatalf("Expected a pagination cursor")
+	}
+}
+
+func TestModeration_GetModerators(t *testing.T) {
+	t.Parallel()
+
+	var err error
+	var output *GetModeratorsOutput
+
+	recordHelix(t, "moderation/get_moderators", func(c *Client) {
+		output, err = c.GetModerators(&GetModeratorsInput{
+			BroadcasterId: "198704263",
+			UserIds:       []string{"424596340", "423374343"},
+		})
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(output.Moderators) != 2 {
+		t.Fatalf("Expected (2) moderators, got (%d)", len(output.Moderators))
+	}
+	if output.Pagination.Cursor != "" {
+		t.Fatalf("Expected no cursor on the last page, got (%s)", output.Pagination.Cursor)
+	}
+}
+
+func TestModeration_ChannelModerator(t *testing.T) {
+	t.Parallel()
+
+	var err error
+	recordHelix(t, "moderation/add_moderator_vip", func(c *Client) {
+		err = c.AddChannelModerator(&AddChannelModeratorInput{
+			BroadcasterId: "198704263",
+			UserId:        "9876",
+		})
+	})
+	if !errors.Is(err, ErrUserIsVIP) {
+		t.Fatalf("Expected ErrUserIsVIP, got (%v)", err)
+	}
+
+	recordHelix(t, "moderation/remove_moderator", func(c *Client) {
+		err = c.RemoveChannelModerator(&RemoveChannelModeratorInput{
+			BroadcasterId: "198704263",
+			UserId:        "9876",
+		})
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,32 @@
+package helix
+
+import (
+	"strconv"
+
+	"github.com/catsby/go-twitch/twitch"
+)
+
+// Pagination is returned by endpoints that page their results. Pass Cursor as
+// the After field of the next request to get the next page. Cursor is empty on
+// the last page.
+// See https://dev.twitch.tv/docs/api/guide#pagination
+type Pagination struct {
+	Cursor string `mapstructure:"cursor"`
+}
+
+// setPageParams adds the pagination parameters that are set to the request
+// options.
+func setPageParams(ro *twitch.RequestOptions, first int, after, before string) {
+	if ro.Params == nil {
+		ro.Params = map[string]string{}
+	}
+	if first > 0 {
+		ro.Params["first"] = strconv.Itoa(first)
+	}
+	if after != "" {
+		ro.Params["after"] = after
+	}
+	if before != "" {
+		ro.Params["before"] = before
+	}
+}
@@ -0,0 +1,290 @@
+package helix
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/catsby/go-twitch/twitch"
+)
+
+// Limits Twitch enforces on polls, checked before a poll is created.
+const (
+	MaxPollTitleLength  = 60
+	MaxPollChoiceLength = 25
+	MinPollChoices      = 2
+	MaxPollChoices      = 5
+	MinPollDuration     = 15 * time.Second
+	MaxPollDuration     = 1800 * time.Second
+)
+
+// PollStatus is the state of a poll.
+type PollStatus string
+
+const (
+	PollStatusActive    PollStatus = "ACTIVE"
+	PollStatusCompleted PollStatus = "COMPLETED"
+
+	// PollStatusTerminated is a poll ended early, with its results shown,
+	// and PollStatusArchived one ended early with its results hidden.
+	PollStatusTerminated PollStatus = "TERMINATED"
+	PollStatusArchived   PollStatus = "ARCHIVED"
+
+	PollStatusModerated PollStatus = "MODERATED"
+	PollStatusInvalid   PollStatus = "INVALID"
+)
+
+// PollChoice is a choice of a poll and its votes.
+type PollChoice struct {
+	Id    string `mapstructure:"id"`
+	Title string `mapstructure:"title"`
+
+	// Votes is the total, including ChannelPointsVotes.
+	Votes              int `mapstructure:"votes"`
+	ChannelPointsVotes int `mapstructure:"channel_points_votes"`
+	BitsVotes          int `mapstructure:"bits_votes"`
+}
+
+// Poll is a poll in a channel.
+type Poll struct {
+	Id               string        `mapstructure:"id"`
+	BroadcasterId    string        `mapstructure:"broadcaster_id"`
+	BroadcasterName  string        `mapstructure:"broadcaster_name"`
+	BroadcasterLogin string        `mapstructure:"broadcaster_login"`
+	Title            string        `mapstructure:"title"`
+	Choices          []*PollChoice `mapstructure:"choices"`
+
+	ChannelPointsVotingEnabled bool `mapstructure:"channel_points_voting_enabled"`
+	ChannelPointsPerVote       int  `mapstructure:"channel_points_per_vote"`
+
+	Status PollStatus `mapstructure:"status"`
+
+	// Duration is in seconds.
+	Duration  int       `mapstructure:"duration"`
+	StartedAt time.Time `mapstructure:"started_at"`
+
+	// EndedAt is the zero time while the poll is active.
+	EndedAt time.Time `mapstructure:"ended_at"`
+}
+
+// Done reports whether the poll has ended.
+func (p *Poll) Done() bool {
+	return p.Status != "" && p.Status != PollStatusActive
+}
+
+// PollsOutput is the output of the functions returning polls.
+type PollsOutput struct {
+	Polls []*Poll `mapstructure:"data"`
+
+	// Pagination is only returned by GetPolls.
+	Pagination *Pagination `mapstructure:"pagination"`
+}
+
+// CreatePollInput is the input to the CreatePoll function.
+type CreatePollInput struct {
+	BroadcasterId string
+
+	// Title is at most MaxPollTitleLength characters.
+	Title string
+
+	// Choices are the titles of MinPollChoices to MaxPollChoices choices,
+	// each at most MaxPollChoiceLength characters.
+	Choices []string
+
+	// Duration is sent in whole seconds, between MinPollDuration and
+	// MaxPollDuration.
+	Duration time.Duration
+
+	// ChannelPointsPerVote lets viewers spend Channel Points on extra votes
+	// when set.
+	ChannelPointsPerVote int
+}
+
+// validate checks the input against the limits Twitch enforces.
+func (i *CreatePollInput) validate() error {
+	if i.BroadcasterId == "" || i.Title == "" {
+		return fmt.Errorf("[ERR] No BroadcasterId or Title for CreatePoll")
+	}
+	if utf8.RuneCountInString(i.Title) > MaxPollTitleLength {
+		return fmt.Errorf("[ERR] Title for CreatePoll is longer than %d characters", MaxPollTitleLength)
+	}
+	if len(i.Choices) < MinPollChoices || len(i.Choices) > MaxPollChoices {
+		return fmt.Errorf("[ERR] CreatePoll needs %d-%d Choices, got %d", MinPollChoices, MaxPollChoices, len(i.Choices))
+	}
+	for _, c := range i.Choices {
+		if c == "" || utf8.RuneCountInString(c) > MaxPollChoiceLength {
+			return fmt.Errorf("[ERR] Choice %q for CreatePoll must be 1-%d characters", c, MaxPollChoiceLength)
+		}
+	}
+	if i.Duration < MinPollDuration || i.Duration > MaxPollDuration {
+		return fmt.Errorf("[ERR] Duration %s for CreatePoll must be between %s and %s", i.Duration, MinPollDuration, MaxPollDuration)
+	}
+	if i.ChannelPointsPerVote < 0 {
+		return fmt.Errorf("[ERR] Invalid ChannelPointsPerVote %d for CreatePoll", i.ChannelPointsPerVote)
+	}
+	return nil
+}
+
+// CreatePoll starts a poll in a channel.
+// Scope: channel:manage:polls
+// See:
+//  - https://dev.twitch.tv/docs/api/reference#create-poll
+func (k *Client) CreatePoll(i *CreatePollInput) (*PollsOutput, error) {
+	if i == nil {
+		return nil, fmt.Errorf("[ERR] No BroadcasterId or Title for CreatePoll")
+	}
+	if err := i.validate(); err != nil {
+		return nil, err
+	}
+
+	var choices []map[string]string
+	for _, c := range i.Choices {
+		choices = append(choices, map[string]string{"title": c})
+	}
+
+	body := map[string]interface{}{
+		"broadcaster_id": i.BroadcasterId,
+		"title":          i.Title,
+		"choices":        choices,
+		"duration":       int(i.Duration / time.Second),
+	}
+	if i.ChannelPointsPerVote > 0 {
+		body["channel_points_voting_enabled"] = true
+		body["channel_points_per_vote"] = i.ChannelPointsPerVote
+	}
+
+	return k.pollsRequest("POST", body, &twitch.RequestOptions{Endpoint: "CreatePoll", Input: i})
+}
+
+// EndPollInput is the input to the EndPoll function.
+type EndPollInput struct {
+	BroadcasterId string
+	Id            string
+
+	// Status is PollStatusTerminated to show the results, or
+	// PollStatusArchived to hide them.
+	Status PollStatus
+}
+
+// EndPoll ends an active poll.
+// Scope: channel:manage:polls
+// See:
+//  - https://dev.twitch.tv/docs/api/reference#end-poll
+func (k *Client) EndPoll(i *EndPollInput) (*PollsOutput, error) {
+	if i == nil || i.BroadcasterId == "" || i.Id == "" {
+		return nil, fmt.Errorf("[ERR] No BroadcasterId or Id for EndPoll")
+	}
+	if i.Status != PollStatusTerminated && i.Status != PollStatusArchived {
+		return nil, fmt.Errorf("[ERR] Invalid Status %q for EndPoll, must be TERMINATED or ARCHIVED", i.Status)
+	}
+
+	body := map[string]string{
+		"broadcaster_id": i.BroadcasterId,
+		"id":             i.Id,
+		"status":         string(i.Status),
+	}
+
+	return k.pollsRequest("PATCH", body, &twitch.RequestOptions{Endpoint: "EndPoll", Input: i})
+}
+
+// GetPollsInput is the input to the GetPolls function.
+type GetPollsInput struct {
+	BroadcasterId string
+
+	// Ids filters the list to the given polls. Maximum: 20.
+	Ids []string
+
+	// Maximum number of objects to return. Default: 20. Maximum: 20.
+	First int
+	After string
+}
+
+// GetPolls returns a page of the polls of a channel, most recent first. Polls
+// are available for 90 days.
+// Scope: channel:read:polls
+// See:
+//  - https://dev.twitch.tv/docs/api/reference#get-polls
+func (k *Client) GetPolls(i *GetPollsInput) (*PollsOutput, error) {
+	if i == nil || i.BroadcasterId == "" {
+		return nil, fmt.Errorf("[ERR] No BroadcasterId for GetPolls")
+	}
+
+	ro := &twitch.RequestOptions{
+		Params: map[string]string{
+			"broadcaster_id": i.BroadcasterId,
+		},
+		Endpoint: "GetPolls",
+		Input:    i,
+	}
+	if len(i.Ids) > 0 {
+		ro.Params["id"] = strings.Join(i.Ids, ",")
+	}
+	setPageParams(ro, i.First, i.After, "")
+
+	return k.pollsRequest("GET", nil, ro)
+}
+
+func (k *Client) pollsRequest(verb string, body interface{}, ro *twitch.RequestOptions) (*PollsOutput, error) {
+	var resp *http.Response
+	var err error
+	if body != nil {
+		resp, err = k.RequestJSON(verb, "/polls", body, ro)
+	} else {
+		resp, err = k.Request(verb, "/polls", ro)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var o PollsOutput
+	if err := twitch.DecodeJSON(&o, resp.Body); err != nil {
+		return nil, err
+	}
+
+	return &o, nil
+}
+
+// WaitPollInput is the input to the WaitPoll function.
+type WaitPollInput struct {
+	BroadcasterId string
+	Id            string
+
+	// Interval between requests. Default: DefaultWaitInterval.
+	Interval time.Duration
+
+	// Timeout is how long to wait before returning ErrWaitTimeout. A zero
+	// Timeout waits until the poll ends.
+	Timeout time.Duration
+}
+
+// WaitPoll requests a poll every Interval until it has ended, and returns the
+// ended poll with its final results.
+// Scope: channel:read:polls
+func (k *Client) WaitPoll(i *WaitPollInput) (*Poll, error) {
+	if i == nil || i.BroadcasterId == "" || i.Id == "" {
+		return nil, fmt.Errorf("[ERR] No BroadcasterId or Id for WaitPoll")
+	}
+
+	var poll *Poll
+	err := waitUntil(i.Interval, i.Timeout, func() (bool, error) {
+		o, err := k.GetPolls(&GetPollsInput{
+			BroadcasterId: i.BroadcasterId,
+			Ids:           []string{i.Id},
+		})
+		if err != nil {
+			return false, err
+		}
+		if len(o.Polls) == 0 {
+			return false, fmt.Errorf("[ERR] Poll %s not found", i.Id)
+		}
+		poll = o.Polls[0]
+		return poll.Done(), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return poll, nil
+}
@@ -0,0 +1,151 @@
+package helix
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestPolls_CreatePoll(t *testing.T) {
+	t.Parallel()
+
+	var err error
+	var output *PollsOutput
+	recordHelix(t, "polls/create", func(c *Client) {
+		output, err = c.CreatePoll(&CreatePollInput{
+			BroadcasterId:        "141981764",
+			Title:                "Heads or Tails?",
+			Choices:              []string{"Heads", "Tails"},
+			Duration:             30 * time.Minute,
+			ChannelPointsPerVote: 100,
+		})
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	p := output.Polls[0]
+	if p.Status != PollStatusActive || p.Done() {
+		t.Fatalf("Expected an active poll, got (%s)", p.Status)
+	}
+	if len(p.Choices) != 2 || p.Choices[1].Title != "Tails" {
+		t.Fatalf("Bad choices: %#v", p.Choices)
+	}
+	if p.Duration != 1800 || !p.EndedAt.IsZero() {
+		t.Fatalf("Bad poll: %#v", p)
+	}
+}
+
+func TestCreatePollInput_validate(t *testing.T) {
+	valid := func() *CreatePollInput {
+		return &CreatePollInput{
+			BroadcasterId: "141981764",
+			Title:         "Heads or Tails?",
+			Choices:       []string{"Heads", "Tails"},
+			Duration:      MinPollDuration,
+		}
+	}
+
+	if err := valid().validate(); err != nil {
+		t.Fatalf("Expected a valid poll, got (%s)", err)
+	}
+
+	cases := map[string]func(*CreatePollInput){
+		"long title":     func(i *CreatePollInput) { i.Title = strings.Repeat("a", MaxPollTitleLength+1) },
+		"one choice":     func(i *CreatePollInput) { i.Choices = i.Choices[:1] },
+		"six choices":    func(i *CreatePollInput) { i.Choices = []string{"a", "b", "c", "d", "e", "f"} },
+		"empty choice":   func(i *CreatePollInput) { i.Choices[0] = "" },
+		"long choice":    func(i *CreatePollInput) { i.Choices[0] = strings.Repeat("a", MaxPollChoiceLength+1) },
+		"short duration": func(i *CreatePollInput) { i.Duration = 14 * time.Second },
+		"long duration":  func(i *CreatePollInput) { i.Duration = MaxPollDuration + time.Second },
+	}
+	for name, f := range cases {
+		i := valid()
+		f(i)
+		if err := i.validate(); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+
+	// Limits are in characters, not bytes.
+	i := valid()
+	i.Title = strings.Repeat("é", MaxPollTitleLength)
+	if err := i.validate(); err != nil {
+		t.Fatalf("Expected a %d character title to be valid, got (%s)", MaxPollTitleLength, err)
+	}
+}
+
+func TestPolls_EndPoll(t *testing.T) {
+	t.Parallel()
+
+	var err error
+	var output *PollsOutput
+	recordHelix(t, "polls/end", func(c *Client) {
+		output, err = c.EndPoll(&EndPollInput{
+			BroadcasterId: "141981764",
+			Id:            "ed961efd-8a3f-4cf5-a9d0-e616c590cd2a",
+			Status:        PollStatusTerminated,
+		})
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	p := output.Polls[0]
+	if !p.Done() || p.EndedAt.IsZero() || p.Choices[1].Votes != 5 {
+		t.Fatalf("Bad ended poll: %#v", p)
+	}
+
+	_, err = (&Client{}).EndPoll(&EndPollInput{
+		BroadcasterId: "141981764",
+		Id:            "ed961efd-8a3f-4cf5-a9d0-e616c590cd2a",
+		Status:        PollStatusCompleted,
+	})
+	if err == nil {
+		t.Fatalf("Expected an error for a COMPLETED status")
+	}
+}
+
+func TestPolls_GetPolls(t *testing.T) {
+	t.Parallel()
+
+	var err error
+	var output *PollsOutput
+	recordHelix(t, "polls/get", func(c *Client) {
+		output, err = c.GetPolls(&GetPollsInput{
+			BroadcasterId: "141981764",
+			First:         1,
+		})
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(output.Polls) != 1 {
+		t.Fatalf("Expected (1) poll, got (%d)", len(output.Polls))
+	}
+	if output.Pagination == nil || output.Pagination.Cursor == "" {
+		t.Fatalf("Expected a pagination cursor")
+	}
+}
+
+func TestPolls_WaitPoll(t *testing.T) {
+	t.Parallel()
+
+	var err error
+	var poll *Poll
+	recordHelix(t, "polls/wait", func(c *Client) {
+		poll, err = c.WaitPoll(&WaitPollInput{
+			BroadcasterId: "141981764",
+			Id:            "ed961efd-8a3f-4cf5-a9d0-e616c590cd2a",
+			Interval:      time.Millisecond,
+		})
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if poll.Status != PollStatusCompleted || poll.Choices[1].Votes != 6 {
+		t.Fatalf("Expected the final results, got %#v", poll)
+	}
+}
@@ -0,0 +1,326 @@
+package helix
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/catsby/go-twitch/twitch"
+)
+
+// Limits Twitch enforces on predictions, checked before a prediction is
+// created.
+const (
+	MaxPredictionTitleLength   = 45
+	MaxPredictionOutcomeLength = 25
+	MinPredictionOutcomes      = 2
+	MaxPredictionOutcomes      = 10
+	MinPredictionWindow        = 30 * time.Second
+	MaxPredictionWindow        = 1800 * time.Second
+)
+
+// PredictionStatus is the state of a prediction.
+type PredictionStatus string
+
+const (
+	// PredictionStatusActive accepts predictions until the window closes.
+	PredictionStatusActive PredictionStatus = "ACTIVE"
+
+	// PredictionStatusLocked no longer accepts predictions, and waits to
+	// be resolved or canceled.
+	PredictionStatusLocked PredictionStatus = "LOCKED"
+
+	// PredictionStatusResolved paid out the winning outcome, and
+	// PredictionStatusCanceled refunded every prediction.
+	PredictionStatusResolved PredictionStatus = "RESOLVED"
+	PredictionStatusCanceled PredictionStatus = "CANCELED"
+)
+
+// Predictor is a user who spent the most Channel Points on an outcome.
+type Predictor struct {
+	UserId            string `mapstructure:"user_id"`
+	UserName          string `mapstructure:"user_name"`
+	UserLogin         string `mapstructure:"user_login"`
+	ChannelPointsUsed int    `mapstructure:"channel_points_used"`
+
+	// ChannelPointsWon is zero until the prediction is resolved.
+	ChannelPointsWon int `mapstructure:"channel_points_won"`
+}
+
+// PredictionOutcome is an outcome of a prediction and the Channel Points
+// spent on it.
+type PredictionOutcome struct {
+	Id            string       `mapstructure:"id"`
+	Title         string       `mapstructure:"title"`
+	Users         int          `mapstructure:"users"`
+	ChannelPoints int          `mapstructure:"channel_points"`
+	TopPredictors []*Predictor `mapstructure:"top_predictors"`
+
+	// Color is BLUE for the first outcome of a two outcome prediction and
+	// PINK for the second; every outcome is BLUE otherwise.
+	Color string `mapstructure:"color"`
+}
+
+// Prediction is a prediction in a channel.
+type Prediction struct {
+	Id               string               `mapstructure:"id"`
+	BroadcasterId    string               `mapstructure:"broadcaster_id"`
+	BroadcasterName  string               `mapstructure:"broadcaster_name"`
+	BroadcasterLogin string               `mapstructure:"broadcaster_login"`
+	Title            string               `mapstructure:"title"`
+	Outcomes         []*PredictionOutcome `mapstructure:"outcomes"`
+
+	// WinningOutcomeId is empty until the prediction is resolved.
+	WinningOutcomeId string `mapstructure:"winning_outcome_id"`
+
+	// PredictionWindow is in seconds.
+	PredictionWindow int              `mapstructure:"prediction_window"`
+	Status           PredictionStatus `mapstructure:"status"`
+	CreatedAt        time.Time        `mapstructure:"created_at"`
+
+	// EndedAt and LockedAt are the zero time until the prediction ends or is
+	// locked.
+	EndedAt  time.Time `mapstructure:"ended_at"`
+	LockedAt time.Time `mapstructure:"locked_at"`
+}
+
+// Done reports whether the prediction was resolved or canceled.
+func (p *Prediction) Done() bool {
+	return p.Status == PredictionStatusResolved || p.Status == PredictionStatusCanceled
+}
+
+// WinningOutcome returns the winning outcome, or nil until the prediction is
+// resolved.
+func (p *Prediction) WinningOutcome() *PredictionOutcome {
+	if p.WinningOutcomeId == "" {
+		return nil
+	}
+	for _, o := range p.Outcomes {
+		if o.Id == p.WinningOutcomeId {
+			return o
+		}
+	}
+	return nil
+}
+
+// PredictionsOutput is the output of the functions returning predictions.
+type PredictionsOutput struct {
+	Predictions []*Prediction `mapstructure:"data"`
+
+	// Pagination is only returned by GetPredictions.
+	Pagination *Pagination `mapstructure:"pagination"`
+}
+
+// CreatePredictionInput is the input to the CreatePrediction function.
+type CreatePredictionInput struct {
+	BroadcasterId string
+
+	// Title is at most MaxPredictionTitleLength characters.
+	Title string
+
+	// Outcomes are the titles of MinPredictionOutcomes to
+	// MaxPredictionOutcomes outcomes, each at most
+	// MaxPredictionOutcomeLength characters.
+	Outcomes []string
+
+	// PredictionWindow is how long predictions are accepted. It is sent in
+	// whole seconds, between MinPredictionWindow and MaxPredictionWindow.
+	PredictionWindow time.Duration
+}
+
+// validate checks the input against the limits Twitch enforces.
+func (i *CreatePredictionInput) validate() error {
+	if i.BroadcasterId == "" || i.Title == "" {
+		return fmt.Errorf("[ERR] No BroadcasterId or Title for CreatePrediction")
+	}
+	if utf8.RuneCountInString(i.Title) > MaxPredictionTitleLength {
+		return fmt.Errorf("[ERR] Title for CreatePrediction is longer than %d characters", MaxPredictionTitleLength)
+	}
+	if len(i.Outcomes) < MinPredictionOutcomes || len(i.Outcomes) > MaxPredictionOutcomes {
+		return fmt.Errorf("[ERR] CreatePrediction needs %d-%d Outcomes, got %d", MinPredictionOutcomes, MaxPredictionOutcomes, len(i.Outcomes))
+	}
+	for _, o := range i.Outcomes {
+		if o == "" || utf8.RuneCountInString(o) > MaxPredictionOutcomeLength {
+			return fmt.Errorf("[ERR] Outcome %q for CreatePrediction must be 1-%d characters", o, MaxPredictionOutcomeLength)
+		}
+	}
+	if i.PredictionWindow < MinPredictionWindow || i.PredictionWindow > MaxPredictionWindow {
+		return fmt.Errorf("[ERR] PredictionWindow %s for CreatePrediction must be between %s and %s", i.PredictionWindow, MinPredictionWindow, MaxPredictionWindow)
+	}
+	return nil
+}
+
+// CreatePrediction starts a prediction in a channel.
+// Scope: channel:manage:predictions
+// See:
+//  - https://dev.twitch.tv/docs/api/reference#create-prediction
+func (k *Client) CreatePrediction(i *CreatePredictionInput) (*PredictionsOutput, error) {
+	if i == nil {
+		return nil, fmt.Errorf("[ERR] No BroadcasterId or Title for CreatePrediction")
+	}
+	if err := i.validate(); err != nil {
+		return nil, err
+	}
+
+	var outcomes []map[string]string
+	for _, o := range i.Outcomes {
+		outcomes = append(outcomes, map[string]string{"title": o})
+	}
+
+	body := map[string]interface{}{
+		"broadcaster_id":    i.BroadcasterId,
+		"title":             i.Title,
+		"outcomes":          outcomes,
+		"prediction_window": int(i.PredictionWindow / time.Second),
+	}
+
+	return k.predictionsRequest("POST", body, &twitch.RequestOptions{Endpoint: "CreatePrediction", Input: i})
+}
+
+// EndPredictionInput is the input to the EndPrediction function.
+type EndPredictionInput struct {
+	BroadcasterId string
+	Id            string
+
+	// Status is PredictionStatusResolved, PredictionStatusCanceled or
+	// PredictionStatusLocked.
+	Status PredictionStatus
+
+	// WinningOutcomeId is required to resolve a prediction.
+	WinningOutcomeId string
+}
+
+// EndPrediction locks, resolves or cancels a prediction. A locked prediction
+// must still be resolved or canceled.
+// Scope: channel:manage:predictions
+// See:
+//  - https://dev.twitch.tv/docs/api/reference#end-prediction
+func (k *Client) EndPrediction(i *EndPredictionInput) (*PredictionsOutput, error) {
+	if i == nil || i.BroadcasterId == "" || i.Id == "" {
+		return nil, fmt.Errorf("[ERR] No BroadcasterId or Id for EndPrediction")
+	}
+
+	switch i.Status {
+	case PredictionStatusResolved:
+		if i.WinningOutcomeId == "" {
+			return nil, fmt.Errorf("[ERR] No WinningOutcomeId to resolve the prediction for EndPrediction")
+		}
+	case PredictionStatusCanceled, PredictionStatusLocked:
+	default:
+		return nil, fmt.Errorf("[ERR] Invalid Status %q for EndPrediction, must be RESOLVED, CANCELED or LOCKED", i.Status)
+	}
+
+	body := map[string]string{
+		"broadcaster_id": i.BroadcasterId,
+		"id":             i.Id,
+		"status":         string(i.Status),
+	}
+	if i.Status == PredictionStatusResolved {
+		body["winning_outcome_id"] = i.WinningOutcomeId
+	}
+
+	return k.predictionsRequest("PATCH", body, &twitch.RequestOptions{Endpoint: "EndPrediction", Input: i})
+}
+
+// GetPredictionsInput is the input to the GetPredictions function.
+type GetPredictionsInput struct {
+	BroadcasterId string
+
+	// Ids filters the list to the given predictions. Maximum: 25.
+	Ids []string
+
+	// Maximum number of objects to return. Default: 20. Maximum: 25.
+	First int
+	After string
+}
+
+// GetPredictions returns a page of the predictions of a channel, most recent
+// first. Predictions are available for 90 days.
+// Scope: channel:read:predictions
+// See:
+//  - https://dev.twitch.tv/docs/api/reference#get-predictions
+func (k *Client) GetPredictions(i *GetPredictionsInput) (*PredictionsOutput, error) {
+	if i == nil || i.BroadcasterId == "" {
+		return nil, fmt.Errorf("[ERR] No BroadcasterId for GetPredictions")
+	}
+
+	ro := &twitch.RequestOptions{
+		Params: map[string]string{
+			"broadcaster_id": i.BroadcasterId,
+		},
+		Endpoint: "GetPredictions",
+		Input:    i,
+	}
+	if len(i.Ids) > 0 {
+		ro.Params["id"] = strings.Join(i.Ids, ",")
+	}
+	setPageParams(ro, i.First, i.After, "")
+
+	return k.predictionsRequest("GET", nil, ro)
+}
+
+func (k *Client) predictionsRequest(verb string, body interface{}, ro *twitch.RequestOptions) (*PredictionsOutput, error) {
+	var resp *http.Response
+	var err error
+	if body != nil {
+		resp, err = k.RequestJSON(verb, "/predictions", body, ro)
+	} else {
+		resp, err = k.Request(verb, "/predictions", ro)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var o PredictionsOutput
+	if err := twitch.DecodeJSON(&o, resp.Body); err != nil {
+		return nil, err
+	}
+
+	return &o, nil
+}
+
+// WaitPredictionInput is the input to the WaitPrediction function.
+type WaitPredictionInput struct {
+	BroadcasterId string
+	Id            string
+
+	// Interval between requests. Default: DefaultWaitInterval.
+	Interval time.Duration
+
+	// Timeout is how long to wait before returning ErrWaitTimeout. A zero
+	// Timeout waits until the prediction is resolved or canceled.
+	Timeout time.Duration
+}
+
+// WaitPrediction requests a prediction every Interval until it is resolved or
+// canceled, and returns the ended prediction. A locked prediction is still
+// waited on.
+// Scope: channel:read:predictions
+func (k *Client) WaitPrediction(i *WaitPredictionInput) (*Prediction, error) {
+	if i == nil || i.BroadcasterId == "" || i.Id == "" {
+		return nil, fmt.Errorf("[ERR] No BroadcasterId or Id for WaitPrediction")
+	}
+
+	var prediction *Prediction
+	err := waitUntil(i.Interval, i.Timeout, func() (bool, error) {
+		o, err := k.GetPredictions(&GetPredictionsInput{
+			BroadcasterId: i.BroadcasterId,
+			Ids:           []string{i.Id},
+		})
+		if err != nil {
+			return false, err
+		}
+		if len(o.Predictions) == 0 {
+			return false, fmt.Errorf("[ERR] Prediction %s not found", i.Id)
+		}
+		prediction = o.Predictions[0]
+		return prediction.Done(), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return prediction, nil
+}
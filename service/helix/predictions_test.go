@@ -0,0 +1,173 @@
+package helix
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestPredictions_CreatePrediction(t *testing.T) {
+	t.Parallel()
+
+	var err error
+	var output *PredictionsOutput
+	recordHelix(t, "predictions/create", func(c *Client) {
+		output, err = c.CreatePrediction(&CreatePredictionInput{
+			BroadcasterId:    "141981764",
+			Title:            "World Series 2021",
+			Outcomes:         []string{"Yes", "No"},
+			PredictionWindow: 10 * time.Minute,
+		})
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	p := output.Predictions[0]
+	if p.Status != PredictionStatusActive || p.Done() || p.WinningOutcome() != nil {
+		t.Fatalf("Expected an active prediction, got %#v", p)
+	}
+	if len(p.Outcomes) != 2 || p.Outcomes[1].Color != "PINK" || p.Outcomes[1].TopPredictors != nil {
+		t.Fatalf("Bad outcomes: %#v", p.Outcomes)
+	}
+	if p.PredictionWindow != 600 || !p.LockedAt.IsZero() {
+		t.Fatalf("Bad prediction: %#v", p)
+	}
+}
+
+func TestCreatePredictionInput_validate(t *testing.T) {
+	valid := func() *CreatePredictionInput {
+		return &CreatePredictionInput{
+			BroadcasterId:    "141981764",
+			Title:            "World Series 2021",
+			Outcomes:         []string{"Yes", "No"},
+			PredictionWindow: MaxPredictionWindow,
+		}
+	}
+
+	if err := valid().validate(); err != nil {
+		t.Fatalf("Expected a valid prediction, got (%s)", err)
+	}
+
+	cases := map[string]func(*CreatePredictionInput){
+		"long title":    func(i *CreatePredictionInput) { i.Title = strings.Repeat("a", MaxPredictionTitleLength+1) },
+		"one outcome":   func(i *CreatePredictionInput) { i.Outcomes = i.Outcomes[:1] },
+		"long outcome":  func(i *CreatePredictionInput) { i.Outcomes[1] = strings.Repeat("a", MaxPredictionOutcomeLength+1) },
+		"short window":  func(i *CreatePredictionInput) { i.PredictionWindow = 15 * time.Second },
+		"long window":   func(i *CreatePredictionInput) { i.PredictionWindow = MaxPredictionWindow + time.Second },
+		"many outcomes": func(i *CreatePredictionInput) { i.Outcomes = make([]string, MaxPredictionOutcomes+1) },
+	}
+	for name, f := range cases {
+		i := valid()
+		f(i)
+		if err := i.validate(); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
+
+func TestPredictions_EndPrediction(t *testing.T) {
+	t.Parallel()
+
+	var err error
+	var locked, resolved *PredictionsOutput
+	recordHelix(t, "predictions/end", func(c *Client) {
+		locked, err = c.EndPrediction(&EndPredictionInput{
+			BroadcasterId: "141981764",
+			Id:            "d6676d5c-c86e-44d2-bfc4-100fb48f0656",
+			Status:        PredictionStatusLocked,
+		})
+		if err != nil {
+			return
+		}
+		resolved, err = c.EndPrediction(&EndPredictionInput{
+			BroadcasterId:    "141981764",
+			Id:               "d6676d5c-c86e-44d2-bfc4-100fb48f0656",
+			Status:           PredictionStatusResolved,
+			WinningOutcomeId: "021e9234-5893-49b4-982e-cfe9a0aaddd9",
+		})
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if p := locked.Predictions[0]; p.Done() || p.LockedAt.IsZero() {
+		t.Fatalf("Expected a locked prediction, got %#v", p)
+	}
+
+	p := resolved.Predictions[0]
+	if !p.Done() {
+		t.Fatalf("Expected a resolved prediction, got (%s)", p.Status)
+	}
+	w := p.WinningOutcome()
+	if w == nil || w.Title != "Yes" || w.TopPredictors[0].ChannelPointsWon != 900 {
+		t.Fatalf("Bad winning outcome: %#v", w)
+	}
+
+	_, err = (&Client{}).EndPrediction(&EndPredictionInput{
+		BroadcasterId: "141981764",
+		Id:            "d6676d5c-c86e-44d2-bfc4-100fb48f0656",
+		Status:        PredictionStatusResolved,
+	})
+	if err == nil {
+		t.Fatalf("Expected an error resolving without a WinningOutcomeId")
+	}
+}
+
+func TestPredictions_GetPredictions(t *testing.T) {
+	t.Parallel()
+
+	var err error
+	var output *PredictionsOutput
+	recordHelix(t, "predictions/get", func(c *Client) {
+		output, err = c.GetPredictions(&GetPredictionsInput{
+			BroadcasterId: "141981764",
+			Ids:           []string{"d6676d5c-c86e-44d2-bfc4-100fb48f0656"},
+		})
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(output.Predictions) != 1 || output.Predictions[0].Title != "World Series 2021" {
+		t.Fatalf("Bad predictions: %#v", output.Predictions)
+	}
+}
+
+func TestPredictions_WaitPrediction(t *testing.T) {
+	t.Parallel()
+
+	var err error
+	var prediction *Prediction
+	recordHelix(t, "predictions/wait", func(c *Client) {
+		prediction, err = c.WaitPrediction(&WaitPredictionInput{
+			BroadcasterId: "141981764",
+			Id:            "d6676d5c-c86e-44d2-bfc4-100fb48f0656",
+			Interval:      time.Millisecond,
+		})
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if prediction.Status != PredictionStatusCanceled {
+		t.Fatalf("Expected a canceled prediction, got (%s)", prediction.Status)
+	}
+}
+
+func TestPredictions_WaitPrediction_timeout(t *testing.T) {
+	t.Parallel()
+
+	var err error
+	recordHelix(t, "predictions/wait_timeout", func(c *Client) {
+		_, err = c.WaitPrediction(&WaitPredictionInput{
+			BroadcasterId: "141981764",
+			Id:            "d6676d5c-c86e-44d2-bfc4-100fb48f0656",
+			Interval:      10 * time.Millisecond,
+			Timeout:       25 * time.Millisecond,
+		})
+	})
+	if err != ErrWaitTimeout {
+		t.Fatalf("Expected ErrWaitTimeout, got (%v)", err)
+	}
+}
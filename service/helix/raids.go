@@ -0,0 +1,93 @@
+package helix
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/catsby/go-twitch/twitch"
+)
+
+// Raid is a pending raid.
+type Raid struct {
+	CreatedAt time.Time `mapstructure:"created_at"`
+
+	// IsMature is whether the target channel is for mature audiences.
+	IsMature bool `mapstructure:"is_mature"`
+}
+
+// StartRaidInput is the input to the StartRaid function.
+type StartRaidInput struct {
+	FromBroadcasterId string
+	ToBroadcasterId   string
+}
+
+// StartRaidOutput is the output of the StartRaid function.
+type StartRaidOutput struct {
+	Raids []*Raid `mapstructure:"data"`
+}
+
+// StartRaid starts a raid of another channel. The raid happens when the
+// broadcaster clicks Raid Now, or after 90 seconds. Errors matching
+// ErrRaidSelf, ErrRaidNotAllowed, ErrRaidTargetNotFound or ErrRaidInProgress
+// are returned when Twitch refuses the raid.
+// Scope: channel:manage:raids
+// See:
+//  - https://dev.twitch.tv/docs/api/reference#start-a-raid
+func (k *Client) StartRaid(i *StartRaidInput) (*StartRaidOutput, error) {
+	if i == nil || i.FromBroadcasterId == "" || i.ToBroadcasterId == "" {
+		return nil, fmt.Errorf("[ERR] No FromBroadcasterId or ToBroadcasterId for StartRaid")
+	}
+
+	ro := &twitch.RequestOptions{
+		Params: map[string]string{
+			"from_broadcaster_id": i.FromBroadcasterId,
+			"to_broadcaster_id":   i.ToBroadcasterId,
+		},
+		Endpoint: "StartRaid",
+		Input:    i,
+	}
+
+	resp, err := k.Post("/raids", ro)
+	if err != nil {
+		return nil, err
+	}
+
+	var o StartRaidOutput
+	if err := twitch.DecodeJSON(&o, resp.Body); err != nil {
+		return nil, err
+	}
+
+	return &o, nil
+}
+
+// CancelRaidInput is the input to the CancelRaid function.
+type CancelRaidInput struct {
+	BroadcasterId string
+}
+
+// CancelRaid cancels a pending raid. An error matching ErrNoPendingRaid is
+// returned if there is none.
+// Scope: channel:manage:raids
+// See:
+//  - https://dev.twitch.tv/docs/api/reference#cancel-a-raid
+func (k *Client) CancelRaid(i *CancelRaidInput) error {
+	if i == nil || i.BroadcasterId == "" {
+		return fmt.Errorf("[ERR] No BroadcasterId for CancelRaid")
+	}
+
+	ro := &twitch.RequestOptions{
+		Params: map[string]string{
+			"broadcaster_id": i.BroadcasterId,
+		},
+		Endpoint: "CancelRaid",
+		Input:    i,
+	}
+
+	resp, err := k.Delete("/raids", ro)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+
+	return nil
+}
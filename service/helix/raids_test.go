@@ -0,0 +1,59 @@
+package helix
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestRaids_StartRaid(t *testing.T) {
+	t.Parallel()
+
+	var err error
+	var output *StartRaidOutput
+	recordHelix(t, "raids/start", func(c *Client) {
+		output, err = c.StartRaid(&StartRaidInput{
+			FromBroadcasterId: "12345678",
+			ToBroadcasterId:   "87654321",
+		})
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(output.Raids) != 1 || output.Raids[0].CreatedAt.IsZero() || output.Raids[0].IsMature {
+		t.Fatalf("Bad raid: %#v", output.Raids)
+	}
+
+	recordHelix(t, "raids/start_not_allowed", func(c *Client) {
+		_, err = c.StartRaid(&StartRaidInput{
+			FromBroadcasterId: "12345678",
+			ToBroadcasterId:   "87654321",
+		})
+	})
+	if !errors.Is(err, ErrRaidNotAllowed) {
+		t.Fatalf("Expected ErrRaidNotAllowed, got (%v)", err)
+	}
+}
+
+func TestRaids_CancelRaid(t *testing.T) {
+	t.Parallel()
+
+	var err error
+	recordHelix(t, "raids/cancel", func(c *Client) {
+		err = c.CancelRaid(&CancelRaidInput{
+			BroadcasterId: "12345678",
+		})
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	recordHelix(t, "raids/cancel_none", func(c *Client) {
+		err = c.CancelRaid(&CancelRaidInput{
+			BroadcasterId: "12345678",
+		})
+	})
+	if !errors.Is(err, ErrNoPendingRaid) {
+		t.Fatalf("Expected ErrNoPendingRaid, got (%v)", err)
+	}
+}
@@ -0,0 +1,386 @@
+package helix
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/catsby/go-twitch/twitch"
+)
+
+// Limits Twitch enforces on schedule segments.
+const (
+	MinScheduleSegmentDuration = 30 * time.Minute
+	MaxScheduleSegmentDuration = 23 * time.Hour
+	MaxScheduleTitleLength     = 140
+)
+
+// ScheduleCategory is the game or category of a schedule segment.
+type ScheduleCategory struct {
+	Id   string `mapstructure:"id"`
+	Name string `mapstructure:"name"`
+}
+
+// ScheduleSegment is a scheduled broadcast. Recurring segments are returned
+// once per week they occur in, each with its own Id.
+type ScheduleSegment struct {
+	Id          string    `mapstructure:"id"`
+	StartTime   time.Time `mapstructure:"start_time"`
+	EndTime     time.Time `mapstructure:"end_time"`
+	Title       string    `mapstructure:"title"`
+	IsRecurring bool      `mapstructure:"is_recurring"`
+
+	// CanceledUntil is the zero time unless the broadcast was canceled.
+	CanceledUntil time.Time `mapstructure:"canceled_until"`
+
+	// Category is nil if the broadcaster did not set one.
+	Category *ScheduleCategory `mapstructure:"category"`
+}
+
+// Canceled reports whether the broadcast was canceled.
+func (s *ScheduleSegment) Canceled() bool {
+	return !s.CanceledUntil.IsZero()
+}
+
+// ScheduleVacation is when a broadcaster's schedule is paused.
+type ScheduleVacation struct {
+	StartTime time.Time `mapstructure:"start_time"`
+	EndTime   time.Time `mapstructure:"end_time"`
+}
+
+// Schedule is a broadcaster's stream schedule.
+type Schedule struct {
+	Segments         []*ScheduleSegment `mapstructure:"segments"`
+	BroadcasterId    string             `mapstructure:"broadcaster_id"`
+	BroadcasterName  string             `mapstructure:"broadcaster_name"`
+	BroadcasterLogin string             `mapstructure:"broadcaster_login"`
+
+	// Vacation is nil unless the broadcaster is on vacation.
+	Vacation *ScheduleVacation `mapstructure:"vacation"`
+}
+
+// GetChannelStreamScheduleInput is the input to the GetChannelStreamSchedule
+// function.
+type GetChannelStreamScheduleInput struct {
+	BroadcasterId string
+
+	// Ids filters the schedule to the given segments. Maximum: 100.
+	Ids []string
+
+	// StartTime returns the segments starting on or after it. Default: now.
+	StartTime time.Time
+
+	// Maximum number of segments to return. Default: 20. Maximum: 25.
+	First int
+	After string
+}
+
+// GetChannelStreamScheduleOutput is the output of the GetChannelStreamSchedule
+// function.
+type GetChannelStreamScheduleOutput struct {
+	Schedule   *Schedule   `mapstructure:"data"`
+	Pagination *Pagination `mapstructure:"pagination"`
+}
+
+// GetChannelStreamSchedule returns a page of a broadcaster's stream schedule.
+// See:
+//  - https://dev.twitch.tv/docs/api/reference#get-channel-stream-schedule
+func (k *Client) GetChannelStreamSchedule(i *GetChannelStreamScheduleInput) (*GetChannelStreamScheduleOutput, error) {
+	if i == nil || i.BroadcasterId == "" {
+		return nil, fmt.Errorf("[ERR] No BroadcasterId for GetChannelStreamSchedule")
+	}
+
+	ro := &twitch.RequestOptions{
+		Params: map[string]string{
+			"broadcaster_id": i.BroadcasterId,
+		},
+		Endpoint: "GetChannelStreamSchedule",
+		Input:    i,
+	}
+	if len(i.Ids) > 0 {
+		ro.Params["id"] = strings.Join(i.Ids, ",")
+	}
+	if !i.StartTime.IsZero() {
+		ro.Params["start_time"] = i.StartTime.UTC().Format(time.RFC3339)
+	}
+	setPageParams(ro, i.First, i.After, "")
+
+	resp, err := k.Get("/schedule", ro)
+	if err != nil {
+		return nil, err
+	}
+
+	var o GetChannelStreamScheduleOutput
+	if err := twitch.DecodeJSON(&o, resp.Body); err != nil {
+		return nil, err
+	}
+
+	return &o, nil
+}
+
+// validScheduleSegment checks the fields shared by creating and updating a
+// segment. Zero values are not checked.
+func validScheduleSegment(fn string, d time.Duration, title string) error {
+	if d != 0 && (d < MinScheduleSegmentDuration || d > MaxScheduleSegmentDuration) {
+		return fmt.Errorf("[ERR] Duration %s for %s must be between %s and %s", d, fn, MinScheduleSegmentDuration, MaxScheduleSegmentDuration)
+	}
+	if utf8.RuneCountInString(title) > MaxScheduleTitleLength {
+		return fmt.Errorf("[ERR] Title for %s is longer than %d characters", fn, MaxScheduleTitleLength)
+	}
+	return nil
+}
+
+// CreateScheduleSegmentInput is the input to the CreateScheduleSegment
+// function.
+type CreateScheduleSegmentInput struct {
+	BroadcasterId string
+
+	StartTime time.Time
+
+	// Timezone is an IANA time zone, Ex: America/New_York, used to keep
+	// recurring segments at the same local time across daylight saving
+	// changes.
+	Timezone string
+
+	// Duration is sent in whole minutes, between MinScheduleSegmentDuration
+	// and MaxScheduleSegmentDuration. Default: 240 minutes.
+	Duration time.Duration
+
+	// IsRecurring repeats the segment every week.
+	IsRecurring bool
+
+	CategoryId string
+	Title      string
+}
+
+// CreateScheduleSegmentOutput is the output of the CreateScheduleSegment
+// function. The Schedule only contains the new segment.
+type CreateScheduleSegmentOutput struct {
+	Schedule *Schedule `mapstructure:"data"`
+}
+
+// CreateScheduleSegment adds a broadcast to a broadcaster's stream schedule.
+// Scope: channel:manage:schedule
+// See:
+//  - https://dev.twitch.tv/docs/api/reference#create-channel-stream-schedule-segment
+func (k *Client) CreateScheduleSegment(i *CreateScheduleSegmentInput) (*CreateScheduleSegmentOutput, error) {
+	if i == nil || i.BroadcasterId == "" || i.StartTime.IsZero() || i.Timezone == "" {
+		return nil, fmt.Errorf("[ERR] No BroadcasterId, StartTime or Timezone for CreateScheduleSegment")
+	}
+	if err := validScheduleSegment("CreateScheduleSegment", i.Duration, i.Title); err != nil {
+		return nil, err
+	}
+
+	body := map[string]interface{}{
+		"start_time":   i.StartTime.UTC().Format(time.RFC3339),
+		"timezone":     i.Timezone,
+		"is_recurring": i.IsRecurring,
+	}
+	if i.Duration != 0 {
+		body["duration"] = strconv.Itoa(int(i.Duration / time.Minute))
+	}
+	if i.CategoryId != "" {
+		body["category_id"] = i.CategoryId
+	}
+	if i.Title != "" {
+		body["title"] = i.Title
+	}
+
+	ro := &twitch.RequestOptions{
+		Params: map[string]string{
+			"broadcaster_id": i.BroadcasterId,
+		},
+		Endpoint: "CreateScheduleSegment",
+		Input:    i,
+	}
+
+	resp, err := k.PostJSON("/schedule/segment", body, ro)
+	if err != nil {
+		return nil, err
+	}
+
+	var o CreateScheduleSegmentOutput
+	if err := twitch.DecodeJSON(&o, resp.Body); err != nil {
+		return nil, err
+	}
+
+	return &o, nil
+}
+
+// UpdateScheduleSegmentInput is the input to the UpdateScheduleSegment
+// function. Only the fields that are set are changed.
+type UpdateScheduleSegmentInput struct {
+	BroadcasterId string
+	Id            string
+
+	// StartTime and Duration are left unchanged when zero. Timezone is
+	// required to change the StartTime of a recurring segment.
+	StartTime time.Time
+	Timezone  string
+	Duration  time.Duration
+
+	CategoryId *string
+	Title      *string
+
+	// IsCanceled cancels, or restores, a single occurrence of the segment.
+	IsCanceled *bool
+}
+
+// UpdateScheduleSegmentOutput is the output of the UpdateScheduleSegment
+// function. The Schedule only contains the updated segment.
+type UpdateScheduleSegmentOutput struct {
+	Schedule *Schedule `mapstructure:"data"`
+}
+
+// UpdateScheduleSegment changes a broadcast in a broadcaster's stream
+// schedule.
+// Scope: channel:manage:schedule
+// See:
+//  - https://dev.twitch.tv/docs/api/reference#update-channel-stream-schedule-segment
+func (k *Client) UpdateScheduleSegment(i *UpdateScheduleSegmentInput) (*UpdateScheduleSegmentOutput, error) {
+	if i == nil || i.BroadcasterId == "" || i.Id == "" {
+		return nil, fmt.Errorf("[ERR] No BroadcasterId or Id for UpdateScheduleSegment")
+	}
+
+	var title string
+	if i.Title != nil {
+		title = *i.Title
+	}
+	if err := validScheduleSegment("UpdateScheduleSegment", i.Duration, title); err != nil {
+		return nil, err
+	}
+
+	body := map[string]interface{}{}
+	if !i.StartTime.IsZero() {
+		body["start_time"] = i.StartTime.UTC().Format(time.RFC3339)
+	}
+	if i.Timezone != "" {
+		body["timezone"] = i.Timezone
+	}
+	if i.Duration != 0 {
+		body["duration"] = strconv.Itoa(int(i.Duration / time.Minute))
+	}
+	if i.CategoryId != nil {
+		body["category_id"] = *i.CategoryId
+	}
+	if i.Title != nil {
+		body["title"] = *i.Title
+	}
+	if i.IsCanceled != nil {
+		body["is_canceled"] = *i.IsCanceled
+	}
+	if len(body) == 0 {
+		return nil, fmt.Errorf("[ERR] No fields to change for UpdateScheduleSegment")
+	}
+
+	ro := &twitch.RequestOptions{
+		Params: map[string]string{
+			"broadcaster_id": i.BroadcasterId,
+			"id":             i.Id,
+		},
+		Endpoint: "UpdateScheduleSegment",
+		Input:    i,
+	}
+
+	resp, err := k.PatchJSON("/schedule/segment", body, ro)
+	if err != nil {
+		return nil, err
+	}
+
+	var o UpdateScheduleSegmentOutput
+	if err := twitch.DecodeJSON(&o, resp.Body); err != nil {
+		return nil, err
+	}
+
+	return &o, nil
+}
+
+// DeleteScheduleSegmentInput is the input to the DeleteScheduleSegment
+// function.
+type DeleteScheduleSegmentInput struct {
+	BroadcasterId string
+	Id            string
+}
+
+// DeleteScheduleSegment removes a broadcast from a broadcaster's stream
+// schedule. Deleting a recurring segment removes every occurrence.
+// Scope: channel:manage:schedule
+// See:
+//  - https://dev.twitch.tv/docs/api/reference#delete-channel-stream-schedule-segment
+func (k *Client) DeleteScheduleSegment(i *DeleteScheduleSegmentInput) error {
+	if i == nil || i.BroadcasterId == "" || i.Id == "" {
+		return fmt.Errorf("[ERR] No BroadcasterId or Id for DeleteScheduleSegment")
+	}
+
+	ro := &twitch.RequestOptions{
+		Params: map[string]string{
+			"broadcaster_id": i.BroadcasterId,
+			"id":             i.Id,
+		},
+		Endpoint: "DeleteScheduleSegment",
+		Input:    i,
+	}
+
+	resp, err := k.Delete("/schedule/segment", ro)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+
+	return nil
+}
+
+// UpdateScheduleSettingsInput is the input to the UpdateScheduleSettings
+// function.
+type UpdateScheduleSettingsInput struct {
+	BroadcasterId string
+
+	// IsVacationEnabled pauses the schedule from VacationStartTime to
+	// VacationEndTime, which are required along with Timezone when it is
+	// true. Setting it to false ends a vacation.
+	IsVacationEnabled bool
+	VacationStartTime time.Time
+	VacationEndTime   time.Time
+	Timezone          string
+}
+
+// UpdateScheduleSettings starts or ends a broadcaster's vacation.
+// Scope: channel:manage:schedule
+// See:
+//  - https://dev.twitch.tv/docs/api/reference#update-channel-stream-schedule
+func (k *Client) UpdateScheduleSettings(i *UpdateScheduleSettingsInput) error {
+	if i == nil || i.BroadcasterId == "" {
+		return fmt.Errorf("[ERR] No BroadcasterId for UpdateScheduleSettings")
+	}
+
+	ro := &twitch.RequestOptions{
+		Params: map[string]string{
+			"broadcaster_id":      i.BroadcasterId,
+			"is_vacation_enabled": strconv.FormatBool(i.IsVacationEnabled),
+		},
+		Endpoint: "UpdateScheduleSettings",
+		Input:    i,
+	}
+
+	if i.IsVacationEnabled {
+		if i.VacationStartTime.IsZero() || i.VacationEndTime.IsZero() || i.Timezone == "" {
+			return fmt.Errorf("[ERR] No VacationStartTime, VacationEndTime or Timezone for UpdateScheduleSettings")
+		}
+		if !i.VacationEndTime.After(i.VacationStartTime) {
+			return fmt.Errorf("[ERR] VacationEndTime for UpdateScheduleSettings must be after VacationStartTime")
+		}
+		ro.Params["vacation_start_time"] = i.VacationStartTime.UTC().Format(time.RFC3339)
+		ro.Params["vacation_end_time"] = i.VacationEndTime.UTC().Format(time.RFC3339)
+		ro.Params["timezone"] = i.Timezone
+	}
+
+	resp, err := k.Patch("/schedule/settings", ro)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+
+	return nil
+}
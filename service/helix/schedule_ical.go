@@ -0,0 +1,327 @@
+package helix
+
+import (
+	"bufio"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// icalNow is the clock used for DTSTAMP, replaced in tests.
+var icalNow = time.Now
+
+const (
+	icalDateTime    = "20060102T150405"
+	icalDateTimeUTC = "20060102T150405Z"
+)
+
+// WriteICalendar writes the schedule as an RFC 5545 iCalendar document, so it
+// can be published to calendar apps.
+//
+// Times are written in loc, which should come from time.LoadLocation so its
+// name is a valid time zone ID, and a VTIMEZONE describing it is included. A
+// nil loc writes every time in UTC, and is an error if the schedule has
+// recurring segments: a weekly rule in UTC would be an hour off after every
+// daylight saving change. For those, loc should be the broadcaster's time
+// zone.
+//
+// Twitch returns a recurring segment once per week; those occurrences are
+// written as a single weekly event, with canceled occurrences excluded. Other
+// canceled segments are written with a CANCELLED status.
+func (s *Schedule) WriteICalendar(w io.Writer, loc *time.Location) error {
+	if loc == nil {
+		for _, seg := range s.Segments {
+			if seg.IsRecurring {
+				return fmt.Errorf("[ERR] No time zone for the recurring segments of WriteICalendar")
+			}
+		}
+		loc = time.UTC
+	}
+
+	segments := make([]*ScheduleSegment, len(s.Segments))
+	copy(segments, s.Segments)
+	sort.SliceStable(segments, func(i, j int) bool {
+		return segments[i].StartTime.Before(segments[j].StartTime)
+	})
+
+	c := &icalWriter{w: bufio.NewWriter(w), loc: loc}
+	c.line("BEGIN:VCALENDAR")
+	c.line("VERSION:2.0")
+	c.line("PRODID:-//catsby//go-twitch//EN")
+	c.line("CALSCALE:GREGORIAN")
+	c.line("METHOD:PUBLISH")
+	if s.BroadcasterName != "" {
+		c.line("X-WR-CALNAME:" + icalText(s.BroadcasterName+" stream schedule"))
+	}
+
+	if loc != time.UTC {
+		year := icalNow().In(loc).Year()
+		if len(segments) > 0 {
+			year = segments[0].StartTime.In(loc).Year()
+		}
+		c.timezone(year)
+	}
+
+	// Group the weekly occurrences of recurring segments into series, keyed
+	// by the series Twitch encodes in their Id or, failing that, by their
+	// local weekday and time, length, title and category.
+	type series struct {
+		first    *ScheduleSegment
+		canceled []time.Time
+	}
+	var order []*series
+	recurring := make(map[string]*series)
+	for _, seg := range segments {
+		if !seg.IsRecurring {
+			order = append(order, &series{first: seg})
+			continue
+		}
+
+		key := scheduleSeriesId(seg.Id)
+		if key == "" {
+			start := seg.StartTime.In(loc)
+			key = fmt.Sprintf("%d|%s|%s|%s", start.Weekday(), start.Format("15:04"), seg.EndTime.Sub(seg.StartTime), seg.Title)
+			if seg.Category != nil {
+				key += "|" + seg.Category.Id
+			}
+		}
+
+		r, ok := recurring[key]
+		if !ok {
+			r = &series{first: seg}
+			recurring[key] = r
+			order = append(order, r)
+		}
+		if seg.Canceled() {
+			r.canceled = append(r.canceled, seg.StartTime)
+		}
+	}
+
+	stamp := icalNow().UTC().Format(icalDateTimeUTC)
+	for _, r := range order {
+		seg := r.first
+
+		c.line("BEGIN:VEVENT")
+		c.line("UID:" + icalText(seg.Id) + "@twitch.tv")
+		c.line("DTSTAMP:" + stamp)
+		c.time("DTSTART", seg.StartTime)
+		c.time("DTEND", seg.EndTime)
+
+		summary := seg.Title
+		if summary == "" && seg.Category != nil {
+			summary = seg.Category.Name
+		}
+		if summary == "" {
+			summary = "Stream"
+		}
+		c.line("SUMMARY:" + icalText(summary))
+		if seg.Category != nil && seg.Category.Name != "" {
+			c.line("CATEGORIES:" + icalText(seg.Category.Name))
+		}
+		if s.BroadcasterLogin != "" {
+			c.line("URL:https://www.twitch.tv/" + s.BroadcasterLogin)
+		}
+
+		if seg.IsRecurring {
+			c.line("RRULE:FREQ=WEEKLY")
+			for _, t := range r.canceled {
+				c.time("EXDATE", t)
+			}
+		} else if seg.Canceled() {
+			c.line("STATUS:CANCELLED")
+		}
+		c.line("END:VEVENT")
+	}
+
+	if v := s.Vacation; v != nil {
+		c.line("BEGIN:VEVENT")
+		c.line("UID:vacation-" + icalText(s.BroadcasterId) + "@twitch.tv")
+		c.line("DTSTAMP:" + stamp)
+		c.time("DTSTART", v.StartTime)
+		c.time("DTEND", v.EndTime)
+		c.line("SUMMARY:Vacation")
+		c.line("TRANSP:TRANSPARENT")
+		c.line("END:VEVENT")
+	}
+
+	c.line("END:VCALENDAR")
+
+	if c.err != nil {
+		return c.err
+	}
+	return c.w.Flush()
+}
+
+// scheduleSeriesId returns the id of the series a segment belongs to, which
+// Twitch encodes with the week of the occurrence in the segment's Id, Ex:
+// {"segmentID":"e4acc724-...","isoYear":2021,"isoWeek":26} in base64. It
+// returns an empty string for Ids in another format.
+func scheduleSeriesId(id string) string {
+	b, err := base64.StdEncoding.DecodeString(id)
+	if err != nil {
+		return ""
+	}
+	var v struct {
+		SegmentId string `json:"segmentID"`
+	}
+	if err := json.Unmarshal(b, &v); err != nil {
+		return ""
+	}
+	return v.SegmentId
+}
+
+// icalWriter writes the content lines of an iCalendar document, keeping the
+// first error.
+type icalWriter struct {
+	w   *bufio.Writer
+	loc *time.Location
+	err error
+}
+
+// line writes a content line, folded to 75 octets without splitting a UTF-8
+// sequence.
+func (c *icalWriter) line(l string) {
+	if c.err != nil {
+		return
+	}
+
+	limit := 75
+	for len(l) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(l[cut]) {
+			cut--
+		}
+		_, c.err = c.w.WriteString(l[:cut] + "\r\n ")
+		l = l[cut:]
+
+		// The leading space of a continuation line counts.
+		limit = 74
+	}
+	if c.err == nil {
+		_, c.err = c.w.WriteString(l + "\r\n")
+	}
+}
+
+// time writes a date-time property in the writer's location.
+func (c *icalWriter) time(name string, t time.Time) {
+	if c.loc == time.UTC {
+		c.line(name + ":" + t.UTC().Format(icalDateTimeUTC))
+		return
+	}
+	c.line(name + ";TZID=" + c.loc.String() + ":" + t.In(c.loc).Format(icalDateTime))
+}
+
+// timezone writes a VTIMEZONE for the writer's location, with the daylight
+// saving transitions of the given year as yearly rules.
+func (c *icalWriter) timezone(year int) {
+	c.line("BEGIN:VTIMEZONE")
+	c.line("TZID:" + c.loc.String())
+
+	transitions := zoneTransitions(c.loc, year)
+	if len(transitions) == 0 {
+		name, offset := time.Date(year, 1, 1, 0, 0, 0, 0, c.loc).Zone()
+		c.line("BEGIN:STANDARD")
+		c.line("DTSTART:19700101T000000")
+		c.line("TZOFFSETFROM:" + icalOffset(offset))
+		c.line("TZOFFSETTO:" + icalOffset(offset))
+		c.line("TZNAME:" + icalText(name))
+		c.line("END:STANDARD")
+	}
+
+	for _, t := range transitions {
+		_, from := t.Add(-time.Minute).Zone()
+		name, to := t.Zone()
+
+		kind := "STANDARD"
+		if to > from {
+			kind = "DAYLIGHT"
+		}
+
+		// The start of an observance is in the local time before it.
+		local := t.In(time.FixedZone("", from))
+
+		c.line("BEGIN:" + kind)
+		c.line("DTSTART:" + local.Format(icalDateTime))
+		c.line("TZOFFSETFROM:" + icalOffset(from))
+		c.line("TZOFFSETTO:" + icalOffset(to))
+		c.line("TZNAME:" + icalText(name))
+		c.line("RRULE:FREQ=YEARLY;BYMONTH=" + fmt.Sprint(int(local.Month())) + ";BYDAY=" + icalWeekday(local))
+		c.line("END:" + kind)
+	}
+
+	c.line("END:VTIMEZONE")
+}
+
+// zoneTransitions returns the times in the year when the UTC offset of loc
+// changes.
+func zoneTransitions(loc *time.Location, year int) []time.Time {
+	start := time.Date(year, 1, 1, 0, 0, 0, 0, loc)
+	end := start.AddDate(1, 0, 0)
+
+	var out []time.Time
+	_, prev := start.Zone()
+	for t := start; t.Before(end); t = t.Add(24 * time.Hour) {
+		next := t.Add(24 * time.Hour)
+		_, offset := next.Zone()
+		if offset == prev {
+			continue
+		}
+
+		lo, hi := t, next
+		for hi.Sub(lo) > time.Minute {
+			mid := lo.Add(hi.Sub(lo) / 2)
+			if _, o := mid.Zone(); o == prev {
+				lo = mid
+			} else {
+				hi = mid
+			}
+		}
+		out = append(out, hi.Truncate(time.Minute).In(loc))
+		prev = offset
+	}
+	return out
+}
+
+var icalDays = [...]string{"SU", "MO", "TU", "WE", "TH", "FR", "SA"}
+
+// icalWeekday returns the BYDAY value matching t's weekday in its month, Ex:
+// 2SU for the second Sunday, or -1SU for the last Sunday.
+func icalWeekday(t time.Time) string {
+	day := icalDays[t.Weekday()]
+	if t.AddDate(0, 0, 7).Month() != t.Month() {
+		return "-1" + day
+	}
+	return fmt.Sprint((t.Day()-1)/7+1) + day
+}
+
+// icalOffset formats a UTC offset in seconds, Ex: -0500.
+func icalOffset(seconds int) string {
+	sign := "+"
+	if seconds < 0 {
+		sign = "-"
+		seconds = -seconds
+	}
+	s := fmt.Sprintf("%s%02d%02d", sign, seconds/3600, seconds/60%60)
+	if seconds%60 != 0 {
+		s += fmt.Sprintf("%02d", seconds%60)
+	}
+	return s
+}
+
+var icalEscaper = strings.NewReplacer(
+	`\`, `\\`,
+	";", `\;`,
+	",", `\,`,
+	"\r\n", `\n`,
+	"\n", `\n`,
+)
+
+// icalText escapes a TEXT value.
+func icalText(s string) string {
+	return icalEscaper.Replace(s)
+}
@@ -0,0 +1,217 @@
+package helix
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+)
+
+func testSchedule() *Schedule {
+	category := &ScheduleCategory{Id: "509670", Name: "Science & Technology"}
+	at := func(day, hour int) time.Time {
+		return time.Date(2021, 7, day, hour, 0, 0, 0, time.UTC)
+	}
+
+	return &Schedule{
+		BroadcasterId:    "141981764",
+		BroadcasterName:  "TwitchDev",
+		BroadcasterLogin: "twitchdev",
+		Segments: []*ScheduleSegment{
+			{Id: "b", StartTime: at(8, 18), EndTime: at(8, 19), Title: "Monthly Update; live", Category: category, IsRecurring: true, CanceledUntil: at(8, 19)},
+			{Id: "a", StartTime: at(1, 18), EndTime: at(1, 19), Title: "Monthly Update; live", Category: category, IsRecurring: true},
+			{Id: "c", StartTime: at(10, 20), EndTime: at(10, 22)},
+			{Id: "d", StartTime: at(11, 20), EndTime: at(11, 22), Title: "Canceled", CanceledUntil: at(11, 22)},
+		},
+		Vacation: &ScheduleVacation{StartTime: at(20, 0), EndTime: at(27, 0)},
+	}
+}
+
+func TestSchedule_WriteICalendar(t *testing.T) {
+	defer func(now func() time.Time) { icalNow = now }(icalNow)
+	icalNow = func() time.Time { return time.Date(2021, 6, 30, 12, 0, 0, 0, time.UTC) }
+
+	var buf bytes.Buffer
+	if err := testSchedule().WriteICalendar(&buf, time.UTC); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := strings.Join([]string{
+		"BEGIN:VCALENDAR",
+		"VERSION:2.0",
+		"PRODID:-//catsby//go-twitch//EN",
+		"CALSCALE:GREGORIAN",
+		"METHOD:PUBLISH",
+		"X-WR-CALNAME:TwitchDev stream schedule",
+		"BEGIN:VEVENT",
+		"UID:a@twitch.tv",
+		"DTSTAMP:20210630T120000Z",
+		"DTSTART:20210701T180000Z",
+		"DTEND:20210701T190000Z",
+		`SUMMARY:Monthly Update\; live`,
+		`CATEGORIES:Science & Technology`,
+		"URL:https://www.twitch.tv/twitchdev",
+		"RRULE:FREQ=WEEKLY",
+		"EXDATE:20210708T180000Z",
+		"END:VEVENT",
+		"BEGIN:VEVENT",
+		"UID:c@twitch.tv",
+		"DTSTAMP:20210630T120000Z",
+		"DTSTART:20210710T200000Z",
+		"DTEND:20210710T220000Z",
+		"SUMMARY:Stream",
+		"URL:https://www.twitch.tv/twitchdev",
+		"END:VEVENT",
+		"BEGIN:VEVENT",
+		"UID:d@twitch.tv",
+		"DTSTAMP:20210630T120000Z",
+		"DTSTART:20210711T200000Z",
+		"DTEND:20210711T220000Z",
+		"SUMMARY:Canceled",
+		"URL:https://www.twitch.tv/twitchdev",
+		"STATUS:CANCELLED",
+		"END:VEVENT",
+		"BEGIN:VEVENT",
+		"UID:vacation-141981764@twitch.tv",
+		"DTSTAMP:20210630T120000Z",
+		"DTSTART:20210720T000000Z",
+		"DTEND:20210727T000000Z",
+		"SUMMARY:Vacation",
+		"TRANSP:TRANSPARENT",
+		"END:VEVENT",
+		"END:VCALENDAR",
+		"",
+	}, "\r\n")
+
+	if buf.String() != expected {
+		t.Fatalf("Expected:\n%s\ngot:\n%s", expected, buf.String())
+	}
+}
+
+func TestSchedule_WriteICalendar_timezone(t *testing.T) {
+	loc, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skipf("No time zone database: %s", err)
+	}
+
+	var buf bytes.Buffer
+	if err := testSchedule().WriteICalendar(&buf, loc); err != nil {
+		t.Fatal(err)
+	}
+	out := buf.String()
+
+	for _, l := range []string{
+		"BEGIN:VTIMEZONE\r\nTZID:America/New_York\r\n",
+		"BEGIN:STANDARD\r\nDTSTART:20211107T020000\r\nTZOFFSETFROM:-0400\r\nTZOFFSETTO:-0500\r\nTZNAME:EST\r\nRRULE:FREQ=YEARLY;BYMONTH=11;BYDAY=1SU\r\n",
+		"BEGIN:DAYLIGHT\r\nDTSTART:20210314T020000\r\nTZOFFSETFROM:-0500\r\nTZOFFSETTO:-0400\r\nTZNAME:EDT\r\nRRULE:FREQ=YEARLY;BYMONTH=3;BYDAY=2SU\r\n",
+		"DTSTART;TZID=America/New_York:20210701T140000\r\n",
+		"EXDATE;TZID=America/New_York:20210708T140000\r\n",
+	} {
+		if !strings.Contains(out, l) {
+			t.Errorf("Expected the calendar to contain:\n%s\ngot:\n%s", l, out)
+		}
+	}
+}
+
+func TestSchedule_WriteICalendar_noTimezone(t *testing.T) {
+	var buf bytes.Buffer
+	if err := testSchedule().WriteICalendar(&buf, nil); err == nil {
+		t.Fatal("Expected an error for recurring segments without a time zone")
+	}
+
+	s := testSchedule()
+	s.Segments = s.Segments[2:]
+	if err := s.WriteICalendar(&buf, nil); err != nil {
+		t.Fatalf("Expected no error without recurring segments, got: %s", err)
+	}
+}
+
+func TestSchedule_WriteICalendar_dst(t *testing.T) {
+	loc, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skipf("No time zone database: %s", err)
+	}
+
+	// A weekly 18:00 stream in New York, before and after daylight saving
+	// ends on November 7.
+	id := func(week int) string {
+		return base64.StdEncoding.EncodeToString([]byte(fmt.Sprintf(
+			`{"segmentID":"e4acc724-371f-402c-81ca-23ada79759d4","isoYear":2021,"isoWeek":%d}`, week)))
+	}
+	var segments []*ScheduleSegment
+	for n, day := range []int{25, 32, 39} {
+		start := time.Date(2021, 10, day, 18, 0, 0, 0, loc)
+		segments = append(segments, &ScheduleSegment{
+			Id:          id(43 + n),
+			StartTime:   start.UTC(),
+			EndTime:     start.Add(2 * time.Hour).UTC(),
+			Title:       "Weekly",
+			IsRecurring: true,
+		})
+	}
+
+	for _, l := range []*time.Location{loc, time.UTC} {
+		var buf bytes.Buffer
+		if err := (&Schedule{Segments: segments}).WriteICalendar(&buf, l); err != nil {
+			t.Fatal(err)
+		}
+		out := buf.String()
+
+		if n := strings.Count(out, "RRULE:FREQ=WEEKLY"); n != 1 {
+			t.Errorf("Expected a single weekly rule in %s, got (%d):\n%s", l, n, out)
+		}
+	}
+
+	// Without the series in the Ids, the occurrences are still grouped by
+	// their time in the broadcaster's time zone.
+	for n, seg := range segments {
+		seg.Id = fmt.Sprint(n)
+	}
+	var buf bytes.Buffer
+	if err := (&Schedule{Segments: segments}).WriteICalendar(&buf, loc); err != nil {
+		t.Fatal(err)
+	}
+	out := buf.String()
+	if n := strings.Count(out, "RRULE:FREQ=WEEKLY"); n != 1 {
+		t.Errorf("Expected a single weekly rule, got (%d):\n%s", n, out)
+	}
+	if !strings.Contains(out, "DTSTART;TZID=America/New_York:20211025T180000\r\n") {
+		t.Errorf("Expected the series to start at 18:00 local time, got:\n%s", out)
+	}
+}
+
+func TestICalendar_folding(t *testing.T) {
+	s := &Schedule{
+		Segments: []*ScheduleSegment{{
+			Id:        "a",
+			StartTime: time.Date(2021, 7, 1, 18, 0, 0, 0, time.UTC),
+			EndTime:   time.Date(2021, 7, 1, 19, 0, 0, 0, time.UTC),
+			Title:     strings.Repeat("é", 100),
+		}},
+	}
+
+	var buf bytes.Buffer
+	if err := s.WriteICalendar(&buf, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	var summary string
+	for _, l := range strings.Split(buf.String(), "\r\n") {
+		if len(l) > 75 {
+			t.Fatalf("Line longer than 75 octets: %q", l)
+		}
+		if strings.HasPrefix(l, "SUMMARY:") {
+			summary = l
+		} else if summary != "" && strings.HasPrefix(l, " ") {
+			summary += l[1:]
+		} else if summary != "" {
+			break
+		}
+	}
+
+	if summary != "SUMMARY:"+strings.Repeat("é", 100) {
+		t.Fatalf("Bad unfolded summary: %q", summary)
+	}
+}
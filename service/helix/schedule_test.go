@@ -0,0 +1,162 @@
+package helix
+
+import (
+	"testing"
+	"time"
+
+	"github.com/catsby/go-twitch/twitch"
+)
+
+const testSegmentId = "eyJzZWdtZW50SUQiOiJlNGFjYzcyNC0zNzFmLTQwMmMtODFjYS0yM2FkYTc5NzU5ZDQiLCJpc29ZZWFyIjoyMDIxLCJpc29XZWVrIjoyNn0="
+
+func TestSchedule_GetChannelStreamSchedule(t *testing.T) {
+	t.Parallel()
+
+	var err error
+	var output *GetChannelStreamScheduleOutput
+	recordHelix(t, "schedule/get", func(c *Client) {
+		output, err = c.GetChannelStreamSchedule(&GetChannelStreamScheduleInput{
+			BroadcasterId: "141981764",
+			StartTime:     time.Date(2021, 6, 28, 0, 0, 0, 0, time.UTC),
+			First:         3,
+		})
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s := output.Schedule
+	if len(s.Segments) != 3 {
+		t.Fatalf("Expected (3) segments, got (%d)", len(s.Segments))
+	}
+
+	if seg := s.Segments[0]; !seg.IsRecurring || seg.Canceled() || seg.Category.Name != "Science & Technology" {
+		t.Fatalf("Bad first segment: %#v", seg)
+	}
+	if !s.Segments[1].Canceled() {
+		t.Fatalf("Expected the second segment to be canceled")
+	}
+	if seg := s.Segments[2]; seg.IsRecurring || seg.Category != nil {
+		t.Fatalf("Bad one-off segment: %#v", seg)
+	}
+
+	if s.Vacation == nil || s.Vacation.EndTime.Sub(s.Vacation.StartTime) != 7*24*time.Hour {
+		t.Fatalf("Expected a week of vacation, got %#v", s.Vacation)
+	}
+	if output.Pagination == nil || output.Pagination.Cursor == "" {
+		t.Fatalf("Expected a pagination cursor")
+	}
+}
+
+func TestSchedule_CreateScheduleSegment(t *testing.T) {
+	t.Parallel()
+
+	var err error
+	var output *CreateScheduleSegmentOutput
+	recordHelix(t, "schedule/create", func(c *Client) {
+		output, err = c.CreateScheduleSegment(&CreateScheduleSegmentInput{
+			BroadcasterId: "141981764",
+			StartTime:     time.Date(2021, 7, 1, 18, 0, 0, 0, time.UTC),
+			Timezone:      "America/New_York",
+			Duration:      time.Hour,
+			IsRecurring:   true,
+			CategoryId:    "509670",
+			Title:         "TwitchDev Monthly Update // July 1, 2021",
+		})
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	seg := output.Schedule.Segments[0]
+	if seg.Id != testSegmentId || seg.EndTime.Sub(seg.StartTime) != time.Hour {
+		t.Fatalf("Bad segment: %#v", seg)
+	}
+
+	_, err = (&Client{}).CreateScheduleSegment(&CreateScheduleSegmentInput{
+		BroadcasterId: "141981764",
+		StartTime:     time.Date(2021, 7, 1, 18, 0, 0, 0, time.UTC),
+		Timezone:      "America/New_York",
+		Duration:      24 * time.Hour,
+	})
+	if err == nil {
+		t.Fatalf("Expected an error for a 24h segment")
+	}
+}
+
+func TestSchedule_UpdateScheduleSegment(t *testing.T) {
+	t.Parallel()
+
+	var err error
+	var output *UpdateScheduleSegmentOutput
+	recordHelix(t, "schedule/update", func(c *Client) {
+		output, err = c.UpdateScheduleSegment(&UpdateScheduleSegmentInput{
+			BroadcasterId: "141981764",
+			Id:            testSegmentId,
+			Duration:      2 * time.Hour,
+			IsCanceled:    twitch.Bool(true),
+		})
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if seg := output.Schedule.Segments[0]; !seg.Canceled() || seg.EndTime.Sub(seg.StartTime) != 2*time.Hour {
+		t.Fatalf("Bad updated segment: %#v", seg)
+	}
+
+	_, err = (&Client{}).UpdateScheduleSegment(&UpdateScheduleSegmentInput{
+		BroadcasterId: "141981764",
+		Id:            testSegmentId,
+	})
+	if err == nil {
+		t.Fatalf("Expected an error when no fields are set")
+	}
+}
+
+func TestSchedule_DeleteScheduleSegment(t *testing.T) {
+	t.Parallel()
+
+	var err error
+	recordHelix(t, "schedule/delete", func(c *Client) {
+		err = c.DeleteScheduleSegment(&DeleteScheduleSegmentInput{
+			BroadcasterId: "141981764",
+			Id:            testSegmentId,
+		})
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestSchedule_UpdateScheduleSettings(t *testing.T) {
+	t.Parallel()
+
+	var err error
+	recordHelix(t, "schedule/settings", func(c *Client) {
+		err = c.UpdateScheduleSettings(&UpdateScheduleSettingsInput{
+			BroadcasterId:     "141981764",
+			IsVacationEnabled: true,
+			VacationStartTime: time.Date(2021, 5, 16, 0, 0, 0, 0, time.UTC),
+			VacationEndTime:   time.Date(2021, 5, 30, 23, 59, 59, 0, time.UTC),
+			Timezone:          "America/New_York",
+		})
+		if err != nil {
+			return
+		}
+		err = c.UpdateScheduleSettings(&UpdateScheduleSettingsInput{
+			BroadcasterId: "141981764",
+		})
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = (&Client{}).UpdateScheduleSettings(&UpdateScheduleSettingsInput{
+		BroadcasterId:     "141981764",
+		IsVacationEnabled: true,
+	})
+	if err == nil {
+		t.Fatalf("Expected an error for a vacation with no times")
+	}
+}
@@ -0,0 +1,106 @@
+package helix
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/catsby/go-twitch/twitch"
+)
+
+// MaxStreamsBatch is the number of user IDs and logins GetStreams accepts in
+// one request.
+const MaxStreamsBatch = 100
+
+// Stream is a live stream.
+type Stream struct {
+	Id        string `mapstructure:"id"`
+	UserId    string `mapstructure:"user_id"`
+	UserLogin string `mapstructure:"user_login"`
+	UserName  string `mapstructure:"user_name"`
+	GameId    string `mapstructure:"game_id"`
+	GameName  string `mapstructure:"game_name"`
+
+	// Type is "live", or empty if there was an error.
+	Type        string    `mapstructure:"type"`
+	Title       string    `mapstructure:"title"`
+	Tags        []string  `mapstructure:"tags"`
+	ViewerCount int       `mapstructure:"viewer_count"`
+	StartedAt   time.Time `mapstructure:"started_at"`
+	Language    string    `mapstructure:"language"`
+	IsMature    bool      `mapstructure:"is_mature"`
+
+	// ThumbnailURL has {width} and {height} placeholders.
+	ThumbnailURL string `mapstructure:"thumbnail_url"`
+}
+
+// GetStreamsInput is the input to the GetStreams function.
+type GetStreamsInput struct {
+	// UserIds and UserLogins filter the list to the given users, together at
+	// most MaxStreamsBatch.
+	UserIds    []string
+	UserLogins []string
+	GameIds    []string
+	Languages  []string
+
+	// Type is "all" or "live". Default: "all".
+	Type string
+
+	// Maximum number of objects to return. Default: 20. Maximum: 100.
+	First  int
+	After  string
+	Before string
+}
+
+// GetStreamsOutput is the output of the GetStreams function.
+type GetStreamsOutput struct {
+	Streams    []*Stream   `mapstructure:"data"`
+	Pagination *Pagination `mapstructure:"pagination"`
+}
+
+// GetStreams returns a page of live streams, sorted by number of viewers.
+// Users that are not live are left out.
+// See:
+//  - https://dev.twitch.tv/docs/api/reference#get-streams
+func (k *Client) GetStreams(i *GetStreamsInput) (*GetStreamsOutput, error) {
+	if i == nil {
+		i = new(GetStreamsInput)
+	}
+	if n := len(i.UserIds) + len(i.UserLogins); n > MaxStreamsBatch {
+		return nil, fmt.Errorf("[ERR] Too many UserIds and UserLogins for GetStreams, got %d, max %d", n, MaxStreamsBatch)
+	}
+
+	ro := &twitch.RequestOptions{
+		Params:   map[string]string{},
+		Endpoint: "GetStreams",
+		Input:    i,
+	}
+	if len(i.UserIds) > 0 {
+		ro.Params["user_id"] = strings.Join(i.UserIds, ",")
+	}
+	if len(i.UserLogins) > 0 {
+		ro.Params["user_login"] = strings.Join(i.UserLogins, ",")
+	}
+	if len(i.GameIds) > 0 {
+		ro.Params["game_id"] = strings.Join(i.GameIds, ",")
+	}
+	if len(i.Languages) > 0 {
+		ro.Params["language"] = strings.Join(i.Languages, ",")
+	}
+	if i.Type != "" {
+		ro.Params["type"] = i.Type
+	}
+	setPageParams(ro, i.First, i.After, i.Before)
+
+	resp, err := k.Get("/streams", ro)
+	if err != nil {
+		return nil, err
+	}
+
+	var o GetStreamsOutput
+	if err := twitch.DecodeJSON(&o, resp.Body); err != nil {
+		return nil, err
+	}
+
+	return &o, nil
+}
@@ -0,0 +1,38 @@
+package helix
+
+import (
+	"strconv"
+	"testing"
+)
+
+func TestStreams_GetStreams(t *testing.T) {
+	t.Parallel()
+
+	var err error
+	var output *GetStreamsOutput
+	recordHelix(t, "streams/get", func(c *Client) {
+		output, err = c.GetStreams(&GetStreamsInput{
+			UserLogins: []string{"afro", "offline"},
+			First:      2,
+		})
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(output.Streams) != 1 {
+		t.Fatalf("Expected (1) stream, got (%d)", len(output.Streams))
+	}
+	if s := output.Streams[0]; s.UserLogin != "afro" || s.ViewerCount != 1490 || s.StartedAt.IsZero() {
+		t.Fatalf("Bad stream: %#v", s)
+	}
+
+	var ids []string
+	for n := 0; n <= MaxStreamsBatch; n++ {
+		ids = append(ids, strconv.Itoa(n))
+	}
+	_, err = (&Client{}).GetStreams(&GetStreamsInput{UserIds: ids})
+	if err == nil {
+		t.Fatalf("Expected an error for %d UserIds", len(ids))
+	}
+}
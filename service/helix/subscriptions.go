@@ -0,0 +1,170 @@
+package helix
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/catsby/go-twitch/twitch"
+)
+
+// SubscriptionTier is the tier of a subscription.
+type SubscriptionTier string
+
+const (
+	// SubscriptionTier1 includes Prime Gaming subscriptions.
+	SubscriptionTier1 SubscriptionTier = "1000"
+	SubscriptionTier2 SubscriptionTier = "2000"
+	SubscriptionTier3 SubscriptionTier = "3000"
+)
+
+// Points returns the subscriber points the tier is worth, or 0 for an unknown
+// tier.
+func (t SubscriptionTier) Points() int {
+	switch t {
+	case SubscriptionTier1:
+		return 1
+	case SubscriptionTier2:
+		return 2
+	case SubscriptionTier3:
+		return 6
+	}
+	return 0
+}
+
+// Subscription is a user's subscription to a broadcaster.
+type Subscription struct {
+	BroadcasterId    string `mapstructure:"broadcaster_id"`
+	BroadcasterLogin string `mapstructure:"broadcaster_login"`
+	BroadcasterName  string `mapstructure:"broadcaster_name"`
+
+	// The gifter fields are empty unless IsGift is true.
+	IsGift      bool   `mapstructure:"is_gift"`
+	GifterId    string `mapstructure:"gifter_id"`
+	GifterLogin string `mapstructure:"gifter_login"`
+	GifterName  string `mapstructure:"gifter_name"`
+
+	Tier SubscriptionTier `mapstructure:"tier"`
+
+	// PlanName and the user fields are only set by
+	// GetBroadcasterSubscriptions.
+	PlanName  string `mapstructure:"plan_name"`
+	UserId    string `mapstructure:"user_id"`
+	UserLogin string `mapstructure:"user_login"`
+	UserName  string `mapstructure:"user_name"`
+}
+
+// GetBroadcasterSubscriptionsInput is the input to the
+// GetBroadcasterSubscriptions function.
+type GetBroadcasterSubscriptionsInput struct {
+	BroadcasterId string
+
+	// UserIds filters the list to the given users. Maximum: 100.
+	UserIds []string
+
+	// Maximum number of objects to return. Default: 20. Maximum: 100.
+	First int
+
+	// Cursors for forward and backward pagination.
+	After  string
+	Before string
+}
+
+// GetBroadcasterSubscriptionsOutput is the output of the
+// GetBroadcasterSubscriptions function.
+type GetBroadcasterSubscriptionsOutput struct {
+	Subscriptions []*Subscription `mapstructure:"data"`
+	Pagination    *Pagination     `mapstructure:"pagination"`
+
+	// Total is the number of subscribers, and Points the subscriber points
+	// they are worth.
+	Total  int `mapstructure:"total"`
+	Points int `mapstructure:"points"`
+}
+
+// GetBroadcasterSubscriptions returns a page of the users subscribed to a
+// broadcaster.
+// Scope: channel:read:subscriptions
+// See:
+//  - https://dev.twitch.tv/docs/api/reference#get-broadcaster-subscriptions
+func (k *Client) GetBroadcasterSubscriptions(i *GetBroadcasterSubscriptionsInput) (*GetBroadcasterSubscriptionsOutput, error) {
+	if i == nil || i.BroadcasterId == "" {
+		return nil, fmt.Errorf("[ERR] No BroadcasterId for GetBroadcasterSubscriptions")
+	}
+
+	ro := &twitch.RequestOptions{
+		Params: map[string]string{
+			"broadcaster_id": i.BroadcasterId,
+		},
+		Endpoint: "GetBroadcasterSubscriptions",
+		Input:    i,
+	}
+	if len(i.UserIds) > 0 {
+		ro.Params["user_id"] = strings.Join(i.UserIds, ",")
+	}
+	setPageParams(ro, i.First, i.After, i.Before)
+
+	resp, err := k.Get("/subscriptions", ro)
+	if err != nil {
+		return nil, err
+	}
+
+	var o GetBroadcasterSubscriptionsOutput
+	if err := twitch.DecodeJSON(&o, resp.Body); err != nil {
+		return nil, err
+	}
+
+	return &o, nil
+}
+
+// CheckUserSubscriptionInput is the input to the CheckUserSubscription
+// function.
+type CheckUserSubscriptionInput struct {
+	BroadcasterId string
+
+	// UserId must match the user the access token belongs to.
+	UserId string
+}
+
+// CheckUserSubscriptionOutput is the output of the CheckUserSubscription
+// function.
+type CheckUserSubscriptionOutput struct {
+	Subscriptions []*Subscription `mapstructure:"data"`
+}
+
+// CheckUserSubscription returns a user's subscription to a broadcaster. An
+// error matching ErrNotSubscribed is returned if the user is not subscribed.
+// Scope: user:read:subscriptions
+// See:
+//  - https://dev.twitch.tv/docs/api/reference#check-user-subscription
+func (k *Client) CheckUserSubscription(i *CheckUserSubscriptionInput) (*CheckUserSubscriptionOutput, error) {
+	if i == nil || i.BroadcasterId == "" || i.UserId == "" {
+		return nil, fmt.Errorf("[ERR] No BroadcasterId or UserId for CheckUserSubscription")
+	}
+
+	ro := &twitch.RequestOptions{
+		Params: map[string]string{
+			"broadcaster_id": i.BroadcasterId,
+			"user_id":        i.UserId,
+		},
+		Endpoint: "CheckUserSubscription",
+		Input:    i,
+	}
+
+	resp, err := k.Get("/subscriptions/user", ro)
+	if err != nil {
+		// Twitch names both users in the message, so it is matched on the
+		// status alone.
+		if e, ok := err.(*twitch.HTTPError); ok && e.StatusCode == http.StatusNotFound {
+			return nil, &Error{HTTPError: e, Err: ErrNotSubscribed}
+		}
+		return nil, err
+	}
+
+	var o CheckUserSubscriptionOutput
+	if err := twitch.DecodeJSON(&o, resp.Body); err != nil {
+		return nil, err
+	}
+
+	return &o, nil
+}
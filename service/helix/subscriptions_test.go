@@ -0,0 +1,75 @@
+package helix
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/catsby/go-twitch/twitch"
+)
+
+func TestSubscriptions_GetBroadcasterSubscriptions(t *testing.T) {
+	t.Parallel()
+
+	var err error
+	var output *GetBroadcasterSubscriptionsOutput
+	recordHelix(t, "subscriptions/get", func(c *Client) {
+		output, err = c.GetBroadcasterSubscriptions(&GetBroadcasterSubscriptionsInput{
+			BroadcasterId: "141981764",
+			First:         2,
+		})
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if output.Total != 13 || output.Points != 13 {
+		t.Fatalf("Expected (13) subscribers and points, got (%d) and (%d)", output.Total, output.Points)
+	}
+
+	gift := output.Subscriptions[0]
+	if !gift.IsGift || gift.GifterId != "12826" || gift.Tier != SubscriptionTier1 {
+		t.Fatalf("Bad gift subscription: %#v", gift)
+	}
+
+	sub := output.Subscriptions[1]
+	if sub.IsGift || sub.GifterLogin != "" || sub.Tier != SubscriptionTier3 || sub.Tier.Points() != 6 {
+		t.Fatalf("Bad tier 3 subscription: %#v", sub)
+	}
+
+	if output.Pagination == nil || output.Pagination.Cursor == "" {
+		t.Fatalf("Expected a pagination cursor")
+	}
+}
+
+func TestSubscriptions_CheckUserSubscription(t *testing.T) {
+	t.Parallel()
+
+	input := &CheckUserSubscriptionInput{
+		BroadcasterId: "149747285",
+		UserId:        "141981764",
+	}
+
+	var err error
+	var output *CheckUserSubscriptionOutput
+	recordHelix(t, "subscriptions/check", func(c *Client) {
+		output, err = c.CheckUserSubscription(input)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s := output.Subscriptions[0]; s.Tier != SubscriptionTier1 || s.BroadcasterLogin != "twitchpresents" {
+		t.Fatalf("Bad subscription: %#v", s)
+	}
+
+	recordHelix(t, "subscriptions/check_not_subscribed", func(c *Client) {
+		_, err = c.CheckUserSubscription(input)
+	})
+	if !errors.Is(err, ErrNotSubscribed) {
+		t.Fatalf("Expected ErrNotSubscribed, got (%v)", err)
+	}
+
+	var httpErr *twitch.HTTPError
+	if !errors.As(err, &httpErr) || httpErr.StatusCode != 404 {
+		t.Fatalf("Expected a 404 HTTPError, got (%v)", err)
+	}
+}
@@ -0,0 +1,204 @@
+package helix
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/catsby/go-twitch/twitch"
+)
+
+// TeamUser is a member of a team.
+type TeamUser struct {
+	UserId    string `mapstructure:"user_id"`
+	UserName  string `mapstructure:"user_name"`
+	UserLogin string `mapstructure:"user_login"`
+}
+
+// Team is a Twitch team.
+type Team struct {
+	Id              string `mapstructure:"id"`
+	TeamName        string `mapstructure:"team_name"`
+	TeamDisplayName string `mapstructure:"team_display_name"`
+	Info            string `mapstructure:"info"`
+
+	ThumbnailURL       string `mapstructure:"thumbnail_url"`
+	BackgroundImageURL string `mapstructure:"background_image_url"`
+	Banner             string `mapstructure:"banner"`
+
+	CreatedAt time.Time `mapstructure:"created_at"`
+	UpdatedAt time.Time `mapstructure:"updated_at"`
+
+	// Users is only returned by GetTeams.
+	Users []*TeamUser `mapstructure:"users"`
+}
+
+// GetTeamsInput is the input to the GetTeams function.
+type GetTeamsInput struct {
+	// Name or Id of the team, Ex: "livecoders".
+	Name string
+	Id   string
+}
+
+// GetTeamsOutput is the output of the GetTeams function.
+type GetTeamsOutput struct {
+	Teams []*Team `mapstructure:"data"`
+}
+
+// GetTeams returns a team and its members, by name or ID.
+// See:
+//  - https://dev.twitch.tv/docs/api/reference#get-teams
+func (k *Client) GetTeams(i *GetTeamsInput) (*GetTeamsOutput, error) {
+	if i == nil || (i.Name == "") == (i.Id == "") {
+		return nil, fmt.Errorf("[ERR] GetTeams needs exactly one of Name or Id")
+	}
+
+	ro := &twitch.RequestOptions{
+		Params:   map[string]string{},
+		Endpoint: "GetTeams",
+		Input:    i,
+	}
+	if i.Name != "" {
+		ro.Params["name"] = i.Name
+	} else {
+		ro.Params["id"] = i.Id
+	}
+
+	resp, err := k.Get("/teams", ro)
+	if err != nil {
+		return nil, err
+	}
+
+	var o GetTeamsOutput
+	if err := twitch.DecodeJSON(&o, resp.Body); err != nil {
+		return nil, err
+	}
+
+	return &o, nil
+}
+
+// ChannelTeam is a team a broadcaster is a member of.
+type ChannelTeam struct {
+	Team `mapstructure:",squash"`
+
+	BroadcasterId    string `mapstructure:"broadcaster_id"`
+	BroadcasterLogin string `mapstructure:"broadcaster_login"`
+	BroadcasterName  string `mapstructure:"broadcaster_name"`
+}
+
+// GetChannelTeamsInput is the input to the GetChannelTeams function.
+type GetChannelTeamsInput struct {
+	BroadcasterId string
+}
+
+// GetChannelTeamsOutput is the output of the GetChannelTeams function.
+type GetChannelTeamsOutput struct {
+	Teams []*ChannelTeam `mapstructure:"data"`
+}
+
+// GetChannelTeams returns the teams a broadcaster is a member of.
+// See:
+//  - https://dev.twitch.tv/docs/api/reference#get-channel-teams
+func (k *Client) GetChannelTeams(i *GetChannelTeamsInput) (*GetChannelTeamsOutput, error) {
+	if i == nil || i.BroadcasterId == "" {
+		return nil, fmt.Errorf("[ERR] No BroadcasterId for GetChannelTeams")
+	}
+
+	ro := &twitch.RequestOptions{
+		Params: map[string]string{
+			"broadcaster_id": i.BroadcasterId,
+		},
+		Endpoint: "GetChannelTeams",
+		Input:    i,
+	}
+
+	resp, err := k.Get("/teams/channel", ro)
+	if err != nil {
+		return nil, err
+	}
+
+	var o GetChannelTeamsOutput
+	if err := twitch.DecodeJSON(&o, resp.Body); err != nil {
+		return nil, err
+	}
+
+	return &o, nil
+}
+
+// TeamMember is a member of a team and their stream.
+type TeamMember struct {
+	*TeamUser
+
+	// Stream is nil if the member is not live.
+	Stream *Stream
+}
+
+// Live reports whether the member is streaming.
+func (m *TeamMember) Live() bool {
+	return m.Stream != nil
+}
+
+// GetTeamMembersInput is the input to the GetTeamMembers function.
+type GetTeamMembersInput struct {
+	// Name or Id of the team, Ex: "livecoders".
+	Name string
+	Id   string
+}
+
+// GetTeamMembersOutput is the output of the GetTeamMembers function.
+type GetTeamMembersOutput struct {
+	Team *Team
+
+	// Members are in the order of Team.Users.
+	Members []*TeamMember
+}
+
+// GetTeamMembers returns a team with the live status of every member. The
+// streams are looked up in batches of MaxStreamsBatch members, so a team
+// costs one request plus one per batch.
+func (k *Client) GetTeamMembers(i *GetTeamMembersInput) (*GetTeamMembersOutput, error) {
+	if i == nil || (i.Name == "") == (i.Id == "") {
+		return nil, fmt.Errorf("[ERR] GetTeamMembers needs exactly one of Name or Id")
+	}
+
+	teams, err := k.GetTeams(&GetTeamsInput{Name: i.Name, Id: i.Id})
+	if err != nil {
+		return nil, err
+	}
+	if len(teams.Teams) == 0 {
+		return nil, fmt.Errorf("[ERR] Team %s%s not found", i.Name, i.Id)
+	}
+	team := teams.Teams[0]
+
+	live := make(map[string]*Stream)
+	for start := 0; start < len(team.Users); start += MaxStreamsBatch {
+		end := start + MaxStreamsBatch
+		if end > len(team.Users) {
+			end = len(team.Users)
+		}
+
+		var ids []string
+		for _, u := range team.Users[start:end] {
+			ids = append(ids, u.UserId)
+		}
+		o, err := k.GetStreams(&GetStreamsInput{
+			UserIds: ids,
+			First:   MaxStreamsBatch,
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, s := range o.Streams {
+			live[s.UserId] = s
+		}
+	}
+
+	out := &GetTeamMembersOutput{Team: team}
+	for _, u := range team.Users {
+		out.Members = append(out.Members, &TeamMember{
+			TeamUser: u,
+			Stream:   live[u.UserId],
+		})
+	}
+
+	return out, nil
+}
@@ -0,0 +1,81 @@
+package helix
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTeams_GetTeams(t *testing.T) {
+	t.Parallel()
+
+	var err error
+	var output *GetTeamsOutput
+	recordHelix(t, "teams/get", func(c *Client) {
+		output, err = c.GetTeams(&GetTeamsInput{
+			Name: "livecoders",
+		})
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	team := output.Teams[0]
+	if team.TeamDisplayName != "Live Coders" || len(team.Users) != 3 {
+		t.Fatalf("Bad team: %#v", team)
+	}
+	if !team.CreatedAt.Equal(time.Date(2019, 2, 5, 5, 7, 16, 683284000, time.UTC)) {
+		t.Fatalf("Bad created at: %s", team.CreatedAt)
+	}
+
+	_, err = (&Client{}).GetTeams(&GetTeamsInput{Name: "livecoders", Id: "6358"})
+	if err == nil {
+		t.Fatalf("Expected an error for both Name and Id")
+	}
+}
+
+func TestTeams_GetChannelTeams(t *testing.T) {
+	t.Parallel()
+
+	var err error
+	var output *GetChannelTeamsOutput
+	recordHelix(t, "teams/channel", func(c *Client) {
+		output, err = c.GetChannelTeams(&GetChannelTeamsInput{
+			BroadcasterId: "96909659",
+		})
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	team := output.Teams[0]
+	if team.TeamName != "livecoders" || team.BroadcasterLogin != "csharpfritz" || team.UpdatedAt.IsZero() {
+		t.Fatalf("Bad channel team: %#v", team)
+	}
+}
+
+func TestTeams_GetTeamMembers(t *testing.T) {
+	t.Parallel()
+
+	var err error
+	var output *GetTeamMembersOutput
+	recordHelix(t, "teams/members", func(c *Client) {
+		output, err = c.GetTeamMembers(&GetTeamMembersInput{
+			Id: "6358",
+		})
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(output.Members) != 3 {
+		t.Fatalf("Expected (3) members, got (%d)", len(output.Members))
+	}
+	for _, m := range output.Members {
+		if m.Live() != (m.UserLogin == "afro") {
+			t.Fatalf("Bad live status for %s: %v", m.UserLogin, m.Live())
+		}
+	}
+	if output.Members[1].Stream.ViewerCount != 1490 {
+		t.Fatalf("Bad stream: %#v", output.Members[1].Stream)
+	}
+}
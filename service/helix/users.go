@@ -0,0 +1,84 @@
+package helix
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/catsby/go-twitch/twitch"
+)
+
+// MaxUsers is the number of IDs and logins GetUsers accepts in one request.
+const MaxUsers = 100
+
+// User is a Twitch user.
+type User struct {
+	Id          string `mapstructure:"id"`
+	Login       string `mapstructure:"login"`
+	DisplayName string `mapstructure:"display_name"`
+
+	// Type is "staff", "admin", "global_mod" or empty, and BroadcasterType
+	// "partner", "affiliate" or empty.
+	Type            string `mapstructure:"type"`
+	BroadcasterType string `mapstructure:"broadcaster_type"`
+
+	Description     string `mapstructure:"description"`
+	ProfileImageURL string `mapstructure:"profile_image_url"`
+	OfflineImageURL string `mapstructure:"offline_image_url"`
+
+	// Email is only returned with the user:read:email scope, for the user
+	// of the token.
+	Email string `mapstructure:"email"`
+
+	CreatedAt time.Time `mapstructure:"created_at"`
+}
+
+// GetUsersInput is the input to the GetUsers function.
+type GetUsersInput struct {
+	// Ids and Logins of the users, at most MaxUsers together. With neither,
+	// the user of the token is returned.
+	Ids    []string
+	Logins []string
+}
+
+// GetUsersOutput is the output of the GetUsers function.
+type GetUsersOutput struct {
+	Users []*User `mapstructure:"data"`
+}
+
+// GetUsers returns users by ID or login. Users that don't exist are left out
+// of the output.
+// See:
+//  - https://dev.twitch.tv/docs/api/reference#get-users
+func (k *Client) GetUsers(i *GetUsersInput) (*GetUsersOutput, error) {
+	if i == nil {
+		i = &GetUsersInput{}
+	}
+	if len(i.Ids)+len(i.Logins) > MaxUsers {
+		return nil, fmt.Errorf("[ERR] GetUsers accepts at most %d Ids and Logins", MaxUsers)
+	}
+
+	ro := &twitch.RequestOptions{
+		Params:   map[string]string{},
+		Endpoint: "GetUsers",
+		Input:    i,
+	}
+	if len(i.Ids) > 0 {
+		ro.Params["id"] = strings.Join(i.Ids, ",")
+	}
+	if len(i.Logins) > 0 {
+		ro.Params["login"] = strings.Join(i.Logins, ",")
+	}
+
+	resp, err := k.Get("/users", ro)
+	if err != nil {
+		return nil, err
+	}
+
+	var o GetUsersOutput
+	if err := twitch.DecodeJSON(&o, resp.Body); err != nil {
+		return nil, err
+	}
+
+	return &o, nil
+}
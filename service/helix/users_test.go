@@ -0,0 +1,47 @@
+package helix
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestUsers_GetUsers(t *testing.T) {
+	t.Parallel()
+
+	var err error
+	var output *GetUsersOutput
+	recordHelix(t, "users/get", func(c *Client) {
+		output, err = c.GetUsers(&GetUsersInput{
+			Ids:    []string{"141981764"},
+			Logins: []string{"twitchdev", "nobody_here"},
+		})
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(output.Users) != 1 {
+		t.Fatalf("Expected (1) user, got (%d)", len(output.Users))
+	}
+	u := output.Users[0]
+	if u.Id != "141981764" || u.Login != "twitchdev" || u.BroadcasterType != "partner" || u.CreatedAt.IsZero() {
+		t.Fatalf("Bad user: %#v", u)
+	}
+}
+
+func TestUsers_GetUsers_tooMany(t *testing.T) {
+	t.Parallel()
+
+	var ids []string
+	for n := 0; n <= MaxUsers; n++ {
+		ids = append(ids, fmt.Sprint(n))
+	}
+
+	var err error
+	recordHelix(t, "users/get", func(c *Client) {
+		_, err = c.GetUsers(&GetUsersInput{Ids: ids})
+	})
+	if err == nil {
+		t.Fatal("Expected an error for too many users")
+	}
+}
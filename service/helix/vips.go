@@ -0,0 +1,231 @@
+package helix
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/catsby/go-twitch/twitch"
+)
+
+// VIP is a VIP of a channel.
+type VIP struct {
+	UserId    string `mapstructure:"user_id"`
+	UserName  string `mapstructure:"user_name"`
+	UserLogin string `mapstructure:"user_login"`
+}
+
+// GetVIPsInput is the input to the GetVIPs function.
+type GetVIPsInput struct {
+	BroadcasterId string
+
+	// UserIds filters the list to the given users. Maximum: 100.
+	UserIds []string
+
+	// Maximum number of objects to return. Default: 20. Maximum: 100.
+	First int
+	After string
+}
+
+// GetVIPsOutput is the output of the GetVIPs function.
+type GetVIPsOutput struct {
+	VIPs       []*VIP      `mapstructure:"data"`
+	Pagination *Pagination `mapstructure:"pagination"`
+}
+
+// GetVIPs returns a page of the VIPs of a channel.
+// Scope: channel:read:vips
+// See:
+//  - https://dev.twitch.tv/docs/api/reference#get-vips
+func (k *Client) GetVIPs(i *GetVIPsInput) (*GetVIPsOutput, error) {
+	if i == nil || i.BroadcasterId == "" {
+		return nil, fmt.Errorf("[ERR] No BroadcasterId for GetVIPs")
+	}
+
+	ro := &twitch.RequestOptions{
+		Params: map[string]string{
+			"broadcaster_id": i.BroadcasterId,
+		},
+		Endpoint: "GetVIPs",
+		Input:    i,
+	}
+	if len(i.UserIds) > 0 {
+		ro.Params["user_id"] = strings.Join(i.UserIds, ",")
+	}
+	setPageParams(ro, i.First, i.After, "")
+
+	resp, err := k.Get("/channels/vips", ro)
+	if err != nil {
+		return nil, err
+	}
+
+	var o GetVIPsOutput
+	if err := twitch.DecodeJSON(&o, resp.Body); err != nil {
+		return nil, err
+	}
+
+	return &o, nil
+}
+
+// AddChannelVIPInput is the input to the AddChannelVIP function.
+type AddChannelVIPInput struct {
+	BroadcasterId string
+	UserId        string
+}
+
+// AddChannelVIP makes a user a VIP of a channel. Errors matching
+// ErrUserAlreadyVIP, ErrUserIsModerator, ErrNoVIPSlots or ErrVIPNotAllowed are
+// returned when Twitch refuses.
+// Scope: channel:manage:vips
+// See:
+//  - https://dev.twitch.tv/docs/api/reference#add-channel-vip
+func (k *Client) AddChannelVIP(i *AddChannelVIPInput) error {
+	if i == nil || i.BroadcasterId == "" || i.UserId == "" {
+		return fmt.Errorf("[ERR] No BroadcasterId or UserId for AddChannelVIP")
+	}
+	return k.channelVIP("POST", i.BroadcasterId, i.UserId, "AddChannelVIP", i)
+}
+
+// RemoveChannelVIPInput is the input to the RemoveChannelVIP function.
+type RemoveChannelVIPInput struct {
+	BroadcasterId string
+	UserId        string
+}
+
+// RemoveChannelVIP removes a user as a VIP of a channel. An error matching
+// ErrUserNotVIP is returned if the user is not one.
+// Scope: channel:manage:vips
+// See:
+//  - https://dev.twitch.tv/docs/api/reference#remove-channel-vip
+func (k *Client) RemoveChannelVIP(i *RemoveChannelVIPInput) error {
+	if i == nil || i.BroadcasterId == "" || i.UserId == "" {
+		return fmt.Errorf("[ERR] No BroadcasterId or UserId for RemoveChannelVIP")
+	}
+	return k.channelVIP("DELETE", i.BroadcasterId, i.UserId, "RemoveChannelVIP", i)
+}
+
+func (k *Client) channelVIP(verb, broadcasterId, userId, endpoint string, input interface{}) error {
+	ro := &twitch.RequestOptions{
+		Params: map[string]string{
+			"broadcaster_id": broadcasterId,
+			"user_id":        userId,
+		},
+		Endpoint: endpoint,
+		Input:    input,
+	}
+
+	resp, err := k.Request(verb, "/channels/vips", ro)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+
+	return nil
+}
+
+// VIPsDiff is the change needed to make a channel's VIPs match a desired list.
+type VIPsDiff struct {
+	// Add are the IDs of the desired users that are not VIPs yet.
+	Add []string
+
+	// Remove are the VIPs that are not in the desired list.
+	Remove []*VIP
+}
+
+// Empty reports whether the diff has no changes.
+func (d *VIPsDiff) Empty() bool {
+	return len(d.Add) == 0 && len(d.Remove) == 0
+}
+
+// DiffVIPs compares the current VIPs to a desired list of user IDs.
+func DiffVIPs(current []*VIP, desired []string) *VIPsDiff {
+	want := make(map[string]bool)
+	for _, id := range desired {
+		if id = strings.TrimSpace(id); id != "" {
+			want[id] = true
+		}
+	}
+
+	d := new(VIPsDiff)
+	have := make(map[string]bool)
+	for _, v := range current {
+		if !want[v.UserId] && !have[v.UserId] {
+			d.Remove = append(d.Remove, v)
+		}
+		have[v.UserId] = true
+	}
+
+	for id := range want {
+		if !have[id] {
+			d.Add = append(d.Add, id)
+		}
+	}
+	sort.Strings(d.Add)
+
+	return d
+}
+
+// SyncVIPsInput is the input to the SyncVIPs function.
+type SyncVIPsInput struct {
+	BroadcasterId string
+
+	// UserIds is the desired list of VIPs.
+	UserIds []string
+
+	// DryRun returns the diff without applying it.
+	DryRun bool
+}
+
+// SyncVIPs makes a channel's VIPs match UserIds, adding and removing VIPs as
+// needed, and returns the changes made. VIPs are removed before any are
+// added, so their slots can be reused.
+// Scope: channel:manage:vips
+func (k *Client) SyncVIPs(i *SyncVIPsInput) (*VIPsDiff, error) {
+	if i == nil || i.BroadcasterId == "" {
+		return nil, fmt.Errorf("[ERR] No BroadcasterId for SyncVIPs")
+	}
+
+	var current []*VIP
+	get := &GetVIPsInput{
+		BroadcasterId: i.BroadcasterId,
+		First:         100,
+	}
+	for {
+		o, err := k.GetVIPs(get)
+		if err != nil {
+			return nil, err
+		}
+		current = append(current, o.VIPs...)
+		if o.Pagination == nil || o.Pagination.Cursor == "" {
+			break
+		}
+		get.After = o.Pagination.Cursor
+	}
+
+	d := DiffVIPs(current, i.UserIds)
+	if i.DryRun {
+		return d, nil
+	}
+
+	for _, v := range d.Remove {
+		err := k.RemoveChannelVIP(&RemoveChannelVIPInput{
+			BroadcasterId: i.BroadcasterId,
+			UserId:        v.UserId,
+		})
+		if err != nil {
+			return d, err
+		}
+	}
+
+	for _, id := range d.Add {
+		err := k.AddChannelVIP(&AddChannelVIPInput{
+			BroadcasterId: i.BroadcasterId,
+			UserId:        id,
+		})
+		if err != nil {
+			return d, err
+		}
+	}
+
+	return d, nil
+}
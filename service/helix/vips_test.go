@@ -0,0 +1,118 @@
+package helix
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestVIPs_GetVIPs(t *testing.T) {
+	t.Parallel()
+
+	var err error
+	var output *GetVIPsOutput
+	recordHelix(t, "vips/get", func(c *Client) {
+		output, err = c.GetVIPs(&GetVIPsInput{
+			BroadcasterId: "123",
+			First:         2,
+		})
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(output.VIPs) != 2 || output.VIPs[1].UserLogin != "usertwo" {
+		t.Fatalf("Bad VIPs: %#v", output.VIPs)
+	}
+	if output.Pagination.Cursor == "" {
+		t.Fatalf("Expected a cursor")
+	}
+}
+
+func TestVIPs_ChannelVIP(t *testing.T) {
+	t.Parallel()
+
+	var err error
+	recordHelix(t, "vips/add", func(c *Client) {
+		err = c.AddChannelVIP(&AddChannelVIPInput{
+			BroadcasterId: "123",
+			UserId:        "456",
+		})
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	recordHelix(t, "vips/add_no_slots", func(c *Client) {
+		err = c.AddChannelVIP(&AddChannelVIPInput{
+			BroadcasterId: "123",
+			UserId:        "456",
+		})
+	})
+	if !errors.Is(err, ErrNoVIPSlots) {
+		t.Fatalf("Expected ErrNoVIPSlots, got (%v)", err)
+	}
+
+	recordHelix(t, "vips/add_moderator", func(c *Client) {
+		err = c.AddChannelVIP(&AddChannelVIPInput{
+			BroadcasterId: "123",
+			UserId:        "456",
+		})
+	})
+	if !errors.Is(err, ErrUserIsModerator) {
+		t.Fatalf("Expected ErrUserIsModerator, got (%v)", err)
+	}
+
+	recordHelix(t, "vips/remove_not_vip", func(c *Client) {
+		err = c.RemoveChannelVIP(&RemoveChannelVIPInput{
+			BroadcasterId: "123",
+			UserId:        "456",
+		})
+	})
+	if !errors.Is(err, ErrUserNotVIP) {
+		t.Fatalf("Expected ErrUserNotVIP, got (%v)", err)
+	}
+}
+
+func TestVIPs_DiffVIPs(t *testing.T) {
+	t.Parallel()
+
+	current := []*VIP{
+		{UserId: "1"},
+		{UserId: "2"},
+		{UserId: "2"},
+		{UserId: "3"},
+	}
+	d := DiffVIPs(current, []string{" 3", "4", "1", "4"})
+
+	if !reflect.DeepEqual(d.Add, []string{"4"}) {
+		t.Fatalf("Bad Add: %v", d.Add)
+	}
+	if len(d.Remove) != 1 || d.Remove[0].UserId != "2" {
+		t.Fatalf("Bad Remove: %#v", d.Remove)
+	}
+
+	if !DiffVIPs(current, []string{"1", "2", "3"}).Empty() {
+		t.Fatalf("Expected an empty diff")
+	}
+}
+
+func TestVIPs_SyncVIPs(t *testing.T) {
+	t.Parallel()
+
+	var err error
+	var d *VIPsDiff
+	recordHelix(t, "vips/sync", func(c *Client) {
+		d, err = c.SyncVIPs(&SyncVIPsInput{
+			BroadcasterId: "123",
+			UserIds:       []string{"11111", "33333", "44444"},
+		})
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(d.Add, []string{"44444"}) || len(d.Remove) != 1 || d.Remove[0].UserId != "22222" {
+		t.Fatalf("Bad diff: %#v", d)
+	}
+}
@@ -0,0 +1,42 @@
+package helix
+
+import (
+	"errors"
+	"time"
+)
+
+// DefaultWaitInterval is how often the Wait functions request the state of
+// what they are waiting for.
+const DefaultWaitInterval = 5 * time.Second
+
+// ErrWaitTimeout is returned by the Wait functions when the timeout passes
+// before a terminal state is reached.
+var ErrWaitTimeout = errors.New("Timed out waiting for a terminal state")
+
+// waitUntil calls done every interval until it returns true or an error, or
+// until the timeout passes. A zero timeout waits forever.
+func waitUntil(interval, timeout time.Duration, done func() (bool, error)) error {
+	if interval <= 0 {
+		interval = DefaultWaitInterval
+	}
+
+	var deadline time.Time
+	if timeout > 0 {
+		deadline = time.Now().Add(timeout)
+	}
+
+	for {
+		ok, err := done()
+		if err != nil {
+			return err
+		}
+		if ok {
+			return nil
+		}
+
+		if !deadline.IsZero() && time.Now().Add(interval).After(deadline) {
+			return ErrWaitTimeout
+		}
+		time.Sleep(interval)
+	}
+}
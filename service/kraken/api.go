@@ -0,0 +1,57 @@
+package kraken
+
+import "net/http"
+
+//go:generate go run ../../internal/mockgen -source api.go -package krakenmock -out krakenmock/mock.go
+
+// The interfaces below group the methods of Client by the resource they act
+// on, so code using the client can depend on the smallest interface it needs
+// and be tested against a fake, such as the mocks in the krakenmock package.
+// API combines them all.
+
+// ChannelsAPI reads channels, their followers and videos.
+type ChannelsAPI interface {
+	GetChannel(i *GetChannelInput) (*GetChannelOutput, error)
+	GetChannelFollowers(i *GetChannelFollowersInput) (*GetChannelFollowersOutput, error)
+	GetChannelVideos(i *GetChannelVideosInput) (*GetChannelVideosOutput, error)
+}
+
+// ClipsAPI reads clips.
+type ClipsAPI interface {
+	GetClip(i *GetClipInput) (*GetClipOutput, error)
+	GetTopClips(i *GetTopClipsInput) (*GetTopClipsOutput, error)
+	GetFollowedClips(i *GetFollowedClipsInput) (*GetFollowedClipsOutput, error)
+}
+
+// IngestsAPI reads the ingest servers.
+type IngestsAPI interface {
+	GetIngestServerList(i *GetIngestServerListInput) (*GetIngestServerListOutput, error)
+}
+
+// StreamsAPI reads live streams.
+type StreamsAPI interface {
+	GetFollowedStreams(i *GetFollowedStreamsInput) (*GetFollowedStreamsOutput, error)
+	GetStream(i *GetStreamInput) (*GetStreamOutput, *http.Response, error)
+	GetLiveStreams(i *GetLiveStreamsInput) (*GetLiveStreamsOutput, error)
+	GetStreamSummary(i *GetStreamSummaryInput) (*GetStreamSummaryOutput, error)
+	GetFeaturedStreams(i *GetFeaturedStreamsInput) (*GetFeaturedStreamsOutput, error)
+}
+
+// UsersAPI reads users and the channels they follow.
+type UsersAPI interface {
+	GetUser(i *GetUserInput) (*GetUserOutput, error)
+	GetUserFollows(i *GetUserFollowsInput) (*GetUserFollowsOutput, error)
+}
+
+// API is every resource of the Kraken API.
+type API interface {
+	ChannelsAPI
+	ClipsAPI
+	IngestsAPI
+	StreamsAPI
+	UsersAPI
+}
+
+// Client must implement every interface; a method added to an interface and
+// not the client, or changed on only one of them, fails to compile here.
+var _ API = (*Client)(nil)
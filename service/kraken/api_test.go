@@ -0,0 +1,107 @@
+package kraken
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"reflect"
+	"strconv"
+	"testing"
+)
+
+// clientPlumbing are the exported methods of Client that make requests or
+// configure it, rather than call an endpoint, and are left out of API.
+var clientPlumbing = map[string]bool{
+	"Context":     true,
+	"Delete":      true,
+	"Get":         true,
+	"Head":        true,
+	"Logger":      true,
+	"Patch":       true,
+	"PatchJSON":   true,
+	"Post":        true,
+	"PostForm":    true,
+	"PostJSON":    true,
+	"Put":         true,
+	"PutForm":     true,
+	"PutJSON":     true,
+	"RawRequest":  true,
+	"Request":     true,
+	"RequestForm": true,
+	"RequestJSON": true,
+	"WithContext": true,
+}
+
+func TestAPI_coversClient(t *testing.T) {
+	api := reflect.TypeOf((*API)(nil)).Elem()
+	client := reflect.TypeOf((*Client)(nil))
+
+	for n := 0; n < client.NumMethod(); n++ {
+		name := client.Method(n).Name
+		if clientPlumbing[name] {
+			continue
+		}
+		if _, ok := api.MethodByName(name); !ok {
+			t.Errorf("Client.%s is not in API", name)
+		}
+	}
+}
+
+// TestClient_endpoints checks that the methods of Client name themselves as
+// the Endpoint of the RequestOptions they build, or pass their name to the
+// helper building them.
+func TestClient_endpoints(t *testing.T) {
+	api := reflect.TypeOf((*API)(nil)).Elem()
+
+	pkgs, err := parser.ParseDir(token.NewFileSet(), ".", nil, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, file := range pkgs["kraken"].Files {
+		for _, decl := range file.Decls {
+			fn, ok := decl.(*ast.FuncDecl)
+			if !ok || fn.Recv == nil || !fn.Name.IsExported() {
+				continue
+			}
+			name := fn.Name.Name
+
+			ast.Inspect(fn.Body, func(n ast.Node) bool {
+				switch n := n.(type) {
+				case *ast.CompositeLit:
+					sel, ok := n.Type.(*ast.SelectorExpr)
+					if !ok || sel.Sel.Name != "RequestOptions" {
+						return true
+					}
+					endpoint := ""
+					for _, elt := range n.Elts {
+						kv := elt.(*ast.KeyValueExpr)
+						if key, ok := kv.Key.(*ast.Ident); ok && key.Name == "Endpoint" {
+							if lit, ok := kv.Value.(*ast.BasicLit); ok {
+								endpoint, _ = strconv.Unquote(lit.Value)
+							}
+						}
+					}
+					if endpoint != name {
+						t.Errorf("Expected the RequestOptions of %s to have Endpoint %q, got: %q", name, name, endpoint)
+					}
+				case *ast.CallExpr:
+					sel, ok := n.Fun.(*ast.SelectorExpr)
+					if !ok || sel.Sel.IsExported() {
+						return true
+					}
+					for _, arg := range n.Args {
+						lit, ok := arg.(*ast.BasicLit)
+						if !ok || lit.Kind != token.STRING {
+							continue
+						}
+						v, _ := strconv.Unquote(lit.Value)
+						if _, ok := api.MethodByName(v); ok && v != name {
+							t.Errorf("Expected %s to pass its own name to %s, got: %q", name, sel.Sel.Name, v)
+						}
+					}
+				}
+				return true
+			})
+		}
+	}
+}
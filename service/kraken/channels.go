@@ -63,7 +63,7 @@ func (k *Client) GetChannel(i *GetChannelInput) (*GetChannelOutput, error) {
 		path = fmt.Sprintf("%s%d", path, i.Id)
 	}
 
-	resp, err := k.Get(path, nil)
+	resp, err := k.Get(path, &twitch.RequestOptions{Endpoint: "GetChannel", Input: i})
 	if err != nil {
 		return nil, err
 	}
@@ -90,7 +90,7 @@ type GetChannelFollowersInput struct {
 // GetChannelFollowers returns the full list of users following a channel
 func (k *Client) GetChannelFollowers(i *GetChannelFollowersInput) (*GetChannelFollowersOutput, error) {
 	path := fmt.Sprintf("/channels/%d/follows", i.Id)
-	resp, err := k.Get(path, nil)
+	resp, err := k.Get(path, &twitch.RequestOptions{Endpoint: "GetChannelFollowers", Input: i})
 	if err != nil {
 		return nil, err
 	}
@@ -143,7 +143,7 @@ type GetChannelVideosInput struct {
 // GetChannelVideos returns the full list of users following a channel
 func (k *Client) GetChannelVideos(i *GetChannelVideosInput) (*GetChannelVideosOutput, error) {
 	path := fmt.Sprintf("/channels/%d/videos", i.Id)
-	ro := new(twitch.RequestOptions)
+	ro := &twitch.RequestOptions{Endpoint: "GetChannelVideos", Input: i}
 	if i.Limit != 0 {
 		ro.Params = map[string]string{
 			"limit": strconv.Itoa(i.Limit),
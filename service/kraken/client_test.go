@@ -1,24 +1,15 @@
 package kraken
 
 import (
-	"log"
-	"os"
 	"testing"
 
 	"github.com/catsby/go-twitch/twitch"
-	"github.com/dnaeon/go-vcr/recorder"
+	"github.com/catsby/go-twitch/twitchtest"
 	cleanhttp "github.com/hashicorp/go-cleanhttp"
 )
 
 func recordKraken(t *testing.T, fixture string, f func(*Client)) {
-	modeDisabledEnv := os.Getenv("RECORD_DISABLE")
-	mode := recorder.ModeReplaying
-	if modeDisabledEnv == "true" {
-		mode = recorder.ModeDisabled
-		log.Printf("[DEBUG]: Response recording disabled")
-	}
-
-	r, err := recorder.NewAsMode("fixtures/"+fixture, mode, nil)
+	r, err := twitchtest.NewRecorder("fixtures/"+fixture, nil)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -2,7 +2,6 @@ package kraken
 
 import (
 	"fmt"
-	"log"
 	"strconv"
 	"time"
 
@@ -86,7 +85,7 @@ func (k *Client) GetClip(i *GetClipInput) (*GetClipOutput, error) {
 	}
 	path := fmt.Sprintf("/%s/%s", "clips", i.Slug)
 
-	resp, err := k.Get(path, nil)
+	resp, err := k.Get(path, &twitch.RequestOptions{Endpoint: "GetClip", Input: i})
 	if err != nil {
 		return nil, err
 	}
@@ -152,7 +151,9 @@ func (k *Client) GetTopClips(i *GetTopClipsInput) (*GetTopClipsOutput, error) {
 	}
 
 	ro := twitch.RequestOptions{
-		Params: params,
+		Params:   params,
+		Endpoint: "GetTopClips",
+		Input:    i,
 	}
 
 	resp, err := k.Get(path, &ro)
@@ -214,8 +215,8 @@ type GetFollowedClipsInput struct {
 // See:
 //  - https://dev.twitch.tv/docs/v5/reference/clips#get-top-clips
 func (k *Client) GetFollowedClips(i *GetFollowedClipsInput) (*GetFollowedClipsOutput, error) {
-	log.Printf("[WARN] GetFollowedClips probably doesn't acually work")
-	resp, err := k.Get("/clips/followed", nil)
+	k.Logger().Warn("GetFollowedClips probably doesn't actually work")
+	resp, err := k.Get("/clips/followed", &twitch.RequestOptions{Endpoint: "GetFollowedClips", Input: i})
 
 	if err != nil {
 		return nil, err
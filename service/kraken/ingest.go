@@ -24,8 +24,8 @@ type GetIngestServerListOutput struct {
 
 // GetIngestServerList returns a list of servers for ingesting streams.
 // See https://dev.twitch.tv/docs/v5/reference/ingests/#get-ingest-server-list
-func (k *Client) GetIngestServerList(_ *GetIngestServerListInput) (*GetIngestServerListOutput, error) {
-	resp, err := k.Get("ingests", nil)
+func (k *Client) GetIngestServerList(i *GetIngestServerListInput) (*GetIngestServerListOutput, error) {
+	resp, err := k.Get("ingests", &twitch.RequestOptions{Endpoint: "GetIngestServerList", Input: i})
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,200 @@
+// Code generated by internal/mockgen from api.go. DO NOT EDIT.
+
+// Package krakenmock provides a mock of kraken.API that records its calls.
+package krakenmock
+
+import (
+	"net/http"
+	"sync"
+
+	"github.com/catsby/go-twitch/service/kraken"
+)
+
+var _ kraken.API = (*Client)(nil)
+
+// Call is a call made to a Client.
+type Call struct {
+	Method string
+	Args   []interface{}
+}
+
+// Client is a mock of kraken.API. Each method calls the matching Func field
+// if it is set, and returns zero values otherwise. Every call is recorded.
+type Client struct {
+	GetChannelFunc          func(i *kraken.GetChannelInput) (*kraken.GetChannelOutput, error)
+	GetChannelFollowersFunc func(i *kraken.GetChannelFollowersInput) (*kraken.GetChannelFollowersOutput, error)
+	GetChannelVideosFunc    func(i *kraken.GetChannelVideosInput) (*kraken.GetChannelVideosOutput, error)
+	GetClipFunc             func(i *kraken.GetClipInput) (*kraken.GetClipOutput, error)
+	GetTopClipsFunc         func(i *kraken.GetTopClipsInput) (*kraken.GetTopClipsOutput, error)
+	GetFollowedClipsFunc    func(i *kraken.GetFollowedClipsInput) (*kraken.GetFollowedClipsOutput, error)
+	GetIngestServerListFunc func(i *kraken.GetIngestServerListInput) (*kraken.GetIngestServerListOutput, error)
+	GetFollowedStreamsFunc  func(i *kraken.GetFollowedStreamsInput) (*kraken.GetFollowedStreamsOutput, error)
+	GetStreamFunc           func(i *kraken.GetStreamInput) (*kraken.GetStreamOutput, *http.Response, error)
+	GetLiveStreamsFunc      func(i *kraken.GetLiveStreamsInput) (*kraken.GetLiveStreamsOutput, error)
+	GetStreamSummaryFunc    func(i *kraken.GetStreamSummaryInput) (*kraken.GetStreamSummaryOutput, error)
+	GetFeaturedStreamsFunc  func(i *kraken.GetFeaturedStreamsInput) (*kraken.GetFeaturedStreamsOutput, error)
+	GetUserFunc             func(i *kraken.GetUserInput) (*kraken.GetUserOutput, error)
+	GetUserFollowsFunc      func(i *kraken.GetUserFollowsInput) (*kraken.GetUserFollowsOutput, error)
+
+	mu    sync.Mutex
+	calls []Call
+}
+
+// Calls returns every call made, oldest first.
+func (m *Client) Calls() []Call {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	out := make([]Call, len(m.calls))
+	copy(out, m.calls)
+	return out
+}
+
+// CallsTo returns the calls made to the named method, oldest first.
+func (m *Client) CallsTo(method string) []Call {
+	var out []Call
+	for _, c := range m.Calls() {
+		if c.Method == method {
+			out = append(out, c)
+		}
+	}
+	return out
+}
+
+// Reset forgets the calls made so far.
+func (m *Client) Reset() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.calls = nil
+}
+
+func (m *Client) record(method string, args ...interface{}) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.calls = append(m.calls, Call{Method: method, Args: args})
+}
+
+// GetChannel implements kraken.ChannelsAPI.
+func (m *Client) GetChannel(i *kraken.GetChannelInput) (*kraken.GetChannelOutput, error) {
+	m.record("GetChannel", i)
+	if m.GetChannelFunc != nil {
+		return m.GetChannelFunc(i)
+	}
+	return nil, nil
+}
+
+// GetChannelFollowers implements kraken.ChannelsAPI.
+func (m *Client) GetChannelFollowers(i *kraken.GetChannelFollowersInput) (*kraken.GetChannelFollowersOutput, error) {
+	m.record("GetChannelFollowers", i)
+	if m.GetChannelFollowersFunc != nil {
+		return m.GetChannelFollowersFunc(i)
+	}
+	return nil, nil
+}
+
+// GetChannelVideos implements kraken.ChannelsAPI.
+func (m *Client) GetChannelVideos(i *kraken.GetChannelVideosInput) (*kraken.GetChannelVideosOutput, error) {
+	m.record("GetChannelVideos", i)
+	if m.GetChannelVideosFunc != nil {
+		return m.GetChannelVideosFunc(i)
+	}
+	return nil, nil
+}
+
+// GetClip implements kraken.ClipsAPI.
+func (m *Client) GetClip(i *kraken.GetClipInput) (*kraken.GetClipOutput, error) {
+	m.record("GetClip", i)
+	if m.GetClipFunc != nil {
+		return m.GetClipFunc(i)
+	}
+	return nil, nil
+}
+
+// GetTopClips implements kraken.ClipsAPI.
+func (m *Client) GetTopClips(i *kraken.GetTopClipsInput) (*kraken.GetTopClipsOutput, error) {
+	m.record("GetTopClips", i)
+	if m.GetTopClipsFunc != nil {
+		return m.GetTopClipsFunc(i)
+	}
+	return nil, nil
+}
+
+// GetFollowedClips implements kraken.ClipsAPI.
+func (m *Client) GetFollowedClips(i *kraken.GetFollowedClipsInput) (*kraken.GetFollowedClipsOutput, error) {
+	m.record("GetFollowedClips", i)
+	if m.GetFollowedClipsFunc != nil {
+		return m.GetFollowedClipsFunc(i)
+	}
+	return nil, nil
+}
+
+// GetIngestServerList implements kraken.IngestsAPI.
+func (m *Client) GetIngestServerList(i *kraken.GetIngestServerListInput) (*kraken.GetIngestServerListOutput, error) {
+	m.record("GetIngestServerList", i)
+	if m.GetIngestServerListFunc != nil {
+		return m.GetIngestServerListFunc(i)
+	}
+	return nil, nil
+}
+
+// GetFollowedStreams implements kraken.StreamsAPI.
+func (m *Client) GetFollowedStreams(i *kraken.GetFollowedStreamsInput) (*kraken.GetFollowedStreamsOutput, error) {
+	m.record("GetFollowedStreams", i)
+	if m.GetFollowedStreamsFunc != nil {
+		return m.GetFollowedStreamsFunc(i)
+	}
+	return nil, nil
+}
+
+// GetStream implements kraken.StreamsAPI.
+func (m *Client) GetStream(i *kraken.GetStreamInput) (*kraken.GetStreamOutput, *http.Response, error) {
+	m.record("GetStream", i)
+	if m.GetStreamFunc != nil {
+		return m.GetStreamFunc(i)
+	}
+	return nil, nil, nil
+}
+
+// GetLiveStreams implements kraken.StreamsAPI.
+func (m *Client) GetLiveStreams(i *kraken.GetLiveStreamsInput) (*kraken.GetLiveStreamsOutput, error) {
+	m.record("GetLiveStreams", i)
+	if m.GetLiveStreamsFunc != nil {
+		return m.GetLiveStreamsFunc(i)
+	}
+	return nil, nil
+}
+
+// GetStreamSummary implements kraken.StreamsAPI.
+func (m *Client) GetStreamSummary(i *kraken.GetStreamSummaryInput) (*kraken.GetStreamSummaryOutput, error) {
+	m.record("GetStreamSummary", i)
+	if m.GetStreamSummaryFunc != nil {
+		return m.GetStreamSummaryFunc(i)
+	}
+	return nil, nil
+}
+
+// GetFeaturedStreams implements kraken.StreamsAPI.
+func (m *Client) GetFeaturedStreams(i *kraken.GetFeaturedStreamsInput) (*kraken.GetFeaturedStreamsOutput, error) {
+	m.record("GetFeaturedStreams", i)
+	if m.GetFeaturedStreamsFunc != nil {
+		return m.GetFeaturedStreamsFunc(i)
+	}
+	return nil, nil
+}
+
+// GetUser implements kraken.UsersAPI.
+func (m *Client) GetUser(i *kraken.GetUserInput) (*kraken.GetUserOutput, error) {
+	m.record("GetUser", i)
+	if m.GetUserFunc != nil {
+		return m.GetUserFunc(i)
+	}
+	return nil, nil
+}
+
+// GetUserFollows implements kraken.UsersAPI.
+func (m *Client) GetUserFollows(i *kraken.GetUserFollowsInput) (*kraken.GetUserFollowsOutput, error) {
+	m.record("GetUserFollows", i)
+	if m.GetUserFollowsFunc != nil {
+		return m.GetUserFollowsFunc(i)
+	}
+	return nil, nil
+}
@@ -0,0 +1,36 @@
+package krakenmock
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/catsby/go-twitch/service/kraken"
+)
+
+func TestClient_GetStream(t *testing.T) {
+	m := new(Client)
+
+	o, resp, err := m.GetStream(&kraken.GetStreamInput{ChannelId: 1})
+	if o != nil || resp != nil || err != nil {
+		t.Fatalf("Expected zero values, got: %v, %v, %v", o, resp, err)
+	}
+
+	m.GetStreamFunc = func(i *kraken.GetStreamInput) (*kraken.GetStreamOutput, *http.Response, error) {
+		return &kraken.GetStreamOutput{}, &http.Response{StatusCode: 200}, nil
+	}
+	o, resp, err = m.GetStream(&kraken.GetStreamInput{ChannelId: 2})
+	if err != nil {
+		t.Fatalf("Expected no error, got: %s", err)
+	}
+	if o == nil || resp.StatusCode != 200 {
+		t.Fatalf("Expected the stubbed output, got: %v, %v", o, resp)
+	}
+
+	calls := m.CallsTo("GetStream")
+	if len(calls) != 2 {
+		t.Fatalf("Expected 2 calls to GetStream, got: %d", len(calls))
+	}
+	if i := calls[1].Args[0].(*kraken.GetStreamInput); i.ChannelId != 2 {
+		t.Fatalf("Expected the second call for channel 2, got: %d", i.ChannelId)
+	}
+}
@@ -59,7 +59,7 @@ type GetFollowedStreamsOutput struct {
 // based on a specified OAuth token.
 func (k *Client) GetFollowedStreams(i *GetFollowedStreamsInput) (*GetFollowedStreamsOutput, error) {
 	path := fmt.Sprintf("/streams/followed")
-	resp, err := k.Get(path, nil)
+	resp, err := k.Get(path, &twitch.RequestOptions{Endpoint: "GetFollowedStreams", Input: i})
 	if err != nil {
 		return nil, err
 	}
@@ -91,7 +91,7 @@ func (k *Client) GetStream(i *GetStreamInput) (*GetStreamOutput, *http.Response,
 		return nil, nil, errors.New("Invalid GetStreamInput: ChannelId is required and cannot be zero")
 	}
 	path := fmt.Sprintf("/streams/%d", i.ChannelId)
-	resp, err := k.Get(path, nil)
+	resp, err := k.Get(path, &twitch.RequestOptions{Endpoint: "GetStream", Input: i})
 	if err != nil {
 		return nil, resp, err
 	}
@@ -139,7 +139,7 @@ type GetLiveStreamsOutput struct {
 // GetStream returns the full list of all versions of the given service.
 func (k *Client) GetLiveStreams(i *GetLiveStreamsInput) (*GetLiveStreamsOutput, error) {
 	path := "/streams"
-	ro := new(twitch.RequestOptions)
+	ro := &twitch.RequestOptions{Endpoint: "GetLiveStreams", Input: i}
 	// for _,s:=range i.
 	if i.Game != "" {
 		ro.Params = map[string]string{
@@ -174,7 +174,7 @@ type GetStreamSummaryOutput struct {
 // GetStream returns the full list of all versions of the given service.
 func (k *Client) GetStreamSummary(i *GetStreamSummaryInput) (*GetStreamSummaryOutput, error) {
 	path := "/streams/summary"
-	ro := new(twitch.RequestOptions)
+	ro := &twitch.RequestOptions{Endpoint: "GetStreamSummary", Input: i}
 	// for _,s:=range i.
 	if i.Game != "" {
 		ro.Params = map[string]string{
@@ -226,7 +226,7 @@ type GetFeaturedStreamsOutput struct {
 // GetFeaturedStreams returns the full list of all versions of the given service.
 func (k *Client) GetFeaturedStreams(i *GetFeaturedStreamsInput) (*GetFeaturedStreamsOutput, error) {
 	path := fmt.Sprintf("/streams/featured")
-	resp, err := k.Get(path, nil)
+	resp, err := k.Get(path, &twitch.RequestOptions{Endpoint: "GetFeaturedStreams", Input: i})
 	if err != nil {
 		return nil, err
 	}
@@ -42,7 +42,7 @@ func (k *Client) GetUser(i *GetUserInput) (*GetUserOutput, error) {
 		path = fmt.Sprintf("%s%d", path, i.Id)
 	}
 
-	resp, err := k.Get(path, nil)
+	resp, err := k.Get(path, &twitch.RequestOptions{Endpoint: "GetUser", Input: i})
 	if err != nil {
 		return nil, err
 	}
@@ -80,7 +80,7 @@ func (k *Client) GetUserFollows(i *GetUserFollowsInput) (*GetUserFollowsOutput,
 
 	path := fmt.Sprintf("/users/%d/follows/channels", i.Id)
 
-	resp, err := k.Get(path, nil)
+	resp, err := k.Get(path, &twitch.RequestOptions{Endpoint: "GetUserFollows", Input: i})
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,292 @@
+// Package pubsub exposes a client for Twitch PubSub, which pushes events such
+// as channel points redemptions, bits and moderator actions over a WebSocket.
+//
+// See:
+//  - https://dev.twitch.tv/docs/pubsub
+package pubsub
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	twitch "github.com/catsby/go-twitch/twitch"
+	"github.com/gorilla/websocket"
+)
+
+// MaxTopicsPerConn is the number of topics Twitch allows a single connection
+// to listen to. Listening to more topics opens additional connections.
+const MaxTopicsPerConn = 50
+
+// DefaultPingInterval is how often a PING is sent. Twitch closes connections
+// that have not sent a PING in the last five minutes.
+const DefaultPingInterval = 4 * time.Minute
+
+// DefaultPongTimeout is how long to wait for a PONG before reconnecting.
+const DefaultPongTimeout = 10 * time.Second
+
+// DefaultResponseTimeout is how long to wait for the RESPONSE to a LISTEN or
+// UNLISTEN.
+const DefaultResponseTimeout = 10 * time.Second
+
+// Handler is called for every MESSAGE received. Handlers are called from the
+// connection's read loop, and should not block.
+type Handler func(*Message)
+
+// Config holds the configuration options for a Client.
+type Config struct {
+	// Endpoint is the PubSub WebSocket address. Default:
+	// twitch.PubSubEndpoint.
+	Endpoint string
+
+	// AccessToken is the OAuth token sent with each LISTEN. It needs the
+	// scopes required by the topics listened to.
+	AccessToken string
+
+	// Handler receives every message on every topic.
+	Handler Handler
+
+	// ErrorHandler receives the errors of listening to the topics again
+	// after a reconnect, which have no caller to return to. Topics rejected
+	// with a *ListenError, Ex: ERR_BADAUTH for an expired AccessToken, are no
+	// longer listened to. Like Handler, it is called from the connection's
+	// goroutines and should not block.
+	ErrorHandler func(error)
+
+	// Dialer is used to open connections. If one is not provided,
+	// websocket.DefaultDialer will be used.
+	Dialer *websocket.Dialer
+
+	PingInterval    time.Duration
+	PongTimeout     time.Duration
+	ResponseTimeout time.Duration
+}
+
+// ListenError is returned when Twitch rejects a LISTEN or UNLISTEN.
+type ListenError struct {
+	Topics []string
+
+	// Err is the error returned by Twitch, Ex: ERR_BADAUTH, ERR_BADTOPIC.
+	Err string
+}
+
+// Error implements the error interface.
+func (e *ListenError) Error() string {
+	return fmt.Sprintf("PubSub error for %s: %s", strings.Join(e.Topics, ", "), e.Err)
+}
+
+// Client listens to PubSub topics, sharding them across as many connections
+// as needed and keeping each one alive.
+type Client struct {
+	config Config
+
+	mu         sync.Mutex
+	conns      []*conn
+	closed     bool
+	reconnects int
+}
+
+// ClientStats is a snapshot of a Client's connections.
+type ClientStats struct {
+	// Connections is the number of connections listening to topics, and
+	// Connected how many of them have an open WebSocket. The others are
+	// reconnecting.
+	Connections int
+	Connected   int
+
+	Topics int
+
+	// Reconnects is the number of times a connection has been replaced since
+	// the client was created.
+	Reconnects int
+}
+
+// NewClient creates a new PubSub client. No connection is opened until the
+// first call to Listen.
+func NewClient(config *Config) (*Client, error) {
+	if config.AccessToken == "" {
+		return nil, fmt.Errorf("Access Token not specified")
+	}
+
+	c := &Client{config: *config}
+	if c.config.Endpoint == "" {
+		c.config.Endpoint = twitch.PubSubEndpoint
+	}
+	if c.config.Dialer == nil {
+		c.config.Dialer = websocket.DefaultDialer
+	}
+	if c.config.PingInterval == 0 {
+		c.config.PingInterval = DefaultPingInterval
+	}
+	if c.config.PongTimeout == 0 {
+		c.config.PongTimeout = DefaultPongTimeout
+	}
+	if c.config.ResponseTimeout == 0 {
+		c.config.ResponseTimeout = DefaultResponseTimeout
+	}
+
+	return c, nil
+}
+
+// Listen subscribes to the given topics, opening new connections when the
+// existing ones are full. Topics that are already subscribed to are ignored.
+func (c *Client) Listen(topics ...string) error {
+	c.mu.Lock()
+	if c.closed {
+		c.mu.Unlock()
+		return fmt.Errorf("PubSub client is closed")
+	}
+
+	groups := make(map[*conn][]string)
+	for _, t := range topics {
+		if c.connFor(t) != nil {
+			continue
+		}
+
+		var target *conn
+		for _, cn := range c.conns {
+			if cn.count() < MaxTopicsPerConn {
+				target = cn
+				break
+			}
+		}
+		if target == nil {
+			target = newConn(c)
+			c.conns = append(c.conns, target)
+		}
+		target.add(t)
+		groups[target] = append(groups[target], t)
+	}
+	c.mu.Unlock()
+
+	var firstErr error
+	for cn, ts := range groups {
+		if err := cn.listen(ts); err != nil {
+			cn.remove(ts)
+			c.closeIfEmpty(cn)
+			if firstErr == nil {
+				firstErr = err
+			}
+		}
+	}
+
+	return firstErr
+}
+
+// Unlisten unsubscribes from the given topics. Connections left with no
+// topics are closed.
+func (c *Client) Unlisten(topics ...string) error {
+	c.mu.Lock()
+	groups := make(map[*conn][]string)
+	for _, t := range topics {
+		if cn := c.connFor(t); cn != nil {
+			groups[cn] = append(groups[cn], t)
+		}
+	}
+	c.mu.Unlock()
+
+	var firstErr error
+	for cn, ts := range groups {
+		err := cn.unlisten(ts)
+		cn.remove(ts)
+		if err != nil && firstErr == nil {
+			firstErr = err
+		}
+
+		c.closeIfEmpty(cn)
+	}
+
+	return firstErr
+}
+
+// closeIfEmpty closes a connection left with no topics and drops it from the
+// client. The check is made under c.mu, which Listen holds while adding
+// topics, so a connection being closed is never picked for new topics.
+func (c *Client) closeIfEmpty(cn *conn) {
+	c.mu.Lock()
+	if cn.count() != 0 {
+		c.mu.Unlock()
+		return
+	}
+	for i, o := range c.conns {
+		if o == cn {
+			c.conns = append(c.conns[:i], c.conns[i+1:]...)
+			break
+		}
+	}
+	c.mu.Unlock()
+	cn.close()
+}
+
+// Topics returns the topics currently subscribed to.
+func (c *Client) Topics() []string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var topics []string
+	for _, cn := range c.conns {
+		topics = append(topics, cn.list()...)
+	}
+	return topics
+}
+
+// Stats returns a snapshot of the client's connections.
+func (c *Client) Stats() ClientStats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	stats := ClientStats{
+		Connections: len(c.conns),
+		Reconnects:  c.reconnects,
+	}
+	for _, cn := range c.conns {
+		cn.mu.Lock()
+		if cn.ws != nil {
+			stats.Connected++
+		}
+		stats.Topics += len(cn.topics)
+		cn.mu.Unlock()
+	}
+	return stats
+}
+
+// Close closes every connection.
+func (c *Client) Close() error {
+	c.mu.Lock()
+	conns := c.conns
+	c.conns = nil
+	c.closed = true
+	c.mu.Unlock()
+
+	for _, cn := range conns {
+		cn.close()
+	}
+	return nil
+}
+
+// connFor returns the connection listening to a topic. Must be called with
+// c.mu held.
+func (c *Client) connFor(topic string) *conn {
+	for _, cn := range c.conns {
+		if cn.has(topic) {
+			return cn
+		}
+	}
+	return nil
+}
+
+// error passes an error with no caller to return to to the ErrorHandler.
+func (c *Client) error(err error) {
+	if h := c.config.ErrorHandler; h != nil {
+		h(err)
+	}
+}
+
+func (c *Client) dial() (*websocket.Conn, error) {
+	ws, _, err := c.config.Dialer.Dial(c.config.Endpoint, http.Header{
+		"User-Agent": []string{twitch.UserAgent},
+	})
+	return ws, err
+}
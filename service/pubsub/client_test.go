@@ -0,0 +1,441 @@
+package pubsub
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// fakeConn is a server side PubSub connection.
+type fakeConn struct {
+	mu sync.Mutex
+	ws *websocket.Conn
+}
+
+func (c *fakeConn) write(f *frame) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.ws.WriteJSON(f)
+}
+
+// fakeServer is a local PubSub server that answers LISTEN, UNLISTEN and PING,
+// and records what it received.
+type fakeServer struct {
+	*httptest.Server
+
+	mu      sync.Mutex
+	conns   []*fakeConn
+	listens [][]string
+	noPong  bool
+	reject  map[string]string
+
+	// silent topics get no RESPONSE.
+	silent map[string]bool
+}
+
+func newFakeServer(t *testing.T) *fakeServer {
+	s := &fakeServer{reject: make(map[string]string), silent: make(map[string]bool)}
+	upgrader := websocket.Upgrader{}
+
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ws, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			t.Errorf("Failed to upgrade: %s", err)
+			return
+		}
+		c := &fakeConn{ws: ws}
+		s.mu.Lock()
+		s.conns = append(s.conns, c)
+		s.mu.Unlock()
+
+		for {
+			var f frame
+			if err := ws.ReadJSON(&f); err != nil {
+				return
+			}
+
+			switch f.Type {
+			case "PING":
+				s.mu.Lock()
+				noPong := s.noPong
+				s.mu.Unlock()
+				if !noPong {
+					c.write(&frame{Type: "PONG"})
+				}
+			case "LISTEN", "UNLISTEN":
+				var d listenData
+				json.Unmarshal(f.Data, &d)
+				resp := &frame{Type: "RESPONSE", Nonce: f.Nonce}
+				s.mu.Lock()
+				if f.Type == "LISTEN" {
+					s.listens = append(s.listens, d.Topics)
+				}
+				silent := false
+				for _, topic := range d.Topics {
+					if e, ok := s.reject[topic]; ok {
+						resp.Error = e
+					}
+					silent = silent || s.silent[topic]
+				}
+				s.mu.Unlock()
+				if !silent {
+					c.write(resp)
+				}
+			}
+		}
+	}))
+
+	return s
+}
+
+func (s *fakeServer) endpoint() string {
+	return "ws" + strings.TrimPrefix(s.URL, "http")
+}
+
+func (s *fakeServer) conn(i int) *fakeConn {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.conns[i]
+}
+
+func (s *fakeServer) connCount() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.conns)
+}
+
+func (s *fakeServer) listenCount() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.listens)
+}
+
+func (s *fakeServer) push(i int, topic, message string) {
+	data, _ := json.Marshal(&messageData{Topic: topic, Message: message})
+	s.conn(i).write(&frame{Type: "MESSAGE", Data: data})
+}
+
+func waitFor(t *testing.T, what string, f func() bool) {
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		if f() {
+			return
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	t.Fatalf("Timed out waiting for %s", what)
+}
+
+func TestClient_Listen_basic(t *testing.T) {
+	t.Parallel()
+
+	s := newFakeServer(t)
+	defer s.Close()
+
+	messages := make(chan *Message, 1)
+	c, err := NewClient(&Config{
+		Endpoint:    s.endpoint(),
+		AccessToken: "xxxxxxxxxxxxx",
+		Handler: func(m *Message) {
+			messages <- m
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+
+	topic := BitsTopic("44322889")
+	if err := c.Listen(topic); err != nil {
+		t.Fatal(err)
+	}
+
+	s.push(0, topic, bitsMessage)
+	select {
+	case m := <-messages:
+		if m.Topic != topic {
+			t.Fatalf("Expected topic (%s), got (%s)", topic, m.Topic)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Timed out waiting for message")
+	}
+
+	s.mu.Lock()
+	s.reject[ChannelPointsTopic("1")] = "ERR_BADAUTH"
+	s.mu.Unlock()
+	err = c.Listen(ChannelPointsTopic("1"))
+	if e, ok := err.(*ListenError); !ok || e.Err != "ERR_BADAUTH" {
+		t.Fatalf("Expected ERR_BADAUTH ListenError, got (%v)", err)
+	}
+	if len(c.Topics()) != 1 {
+		t.Fatalf("Expected rejected topic to be removed, got %v", c.Topics())
+	}
+}
+
+func TestClient_Listen_rejectedConn(t *testing.T) {
+	t.Parallel()
+
+	s := newFakeServer(t)
+	defer s.Close()
+
+	c, err := NewClient(&Config{
+		Endpoint:    s.endpoint(),
+		AccessToken: "xxxxxxxxxxxxx",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+
+	topic := ChannelPointsTopic("1")
+	s.mu.Lock()
+	s.reject[topic] = "ERR_BADAUTH"
+	s.mu.Unlock()
+	if err := c.Listen(topic); err == nil {
+		t.Fatal("Expected an error for a rejected topic")
+	}
+
+	// The connection opened for the rejected topic is closed and dropped.
+	if stats := c.Stats(); stats.Connections != 0 || stats.Topics != 0 {
+		t.Fatalf("Expected no connections, got %+v", stats)
+	}
+	s.mu.Lock()
+	delete(s.reject, topic)
+	s.mu.Unlock()
+	if err := c.Listen(topic); err != nil {
+		t.Fatal(err)
+	}
+	if s.connCount() != 2 || c.Stats().Connections != 1 {
+		t.Fatalf("Expected a new connection, got (%d) on the server and %+v", s.connCount(), c.Stats())
+	}
+}
+
+func TestClient_Listen_sharding(t *testing.T) {
+	t.Parallel()
+
+	s := newFakeServer(t)
+	defer s.Close()
+
+	c, err := NewClient(&Config{
+		Endpoint:    s.endpoint(),
+		AccessToken: "xxxxxxxxxxxxx",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+
+	var topics []string
+	for i := 0; i < MaxTopicsPerConn+10; i++ {
+		topics = append(topics, BitsTopic(fmt.Sprintf("%d", i)))
+	}
+	if err := c.Listen(topics...); err != nil {
+		t.Fatal(err)
+	}
+
+	if s.connCount() != 2 {
+		t.Fatalf("Expected (2) connections, got (%d)", s.connCount())
+	}
+	if len(c.Topics()) != len(topics) {
+		t.Fatalf("Expected (%d) topics, got (%d)", len(topics), len(c.Topics()))
+	}
+
+	// Unlistening from every topic on the second connection closes it.
+	if err := c.Unlisten(topics[MaxTopicsPerConn:]...); err != nil {
+		t.Fatal(err)
+	}
+	c.mu.Lock()
+	conns := len(c.conns)
+	c.mu.Unlock()
+	if conns != 1 {
+		t.Fatalf("Expected (1) connection after unlisten, got (%d)", conns)
+	}
+}
+
+func TestClient_Reconnect(t *testing.T) {
+	t.Parallel()
+
+	s := newFakeServer(t)
+	defer s.Close()
+
+	c, err := NewClient(&Config{
+		Endpoint:     s.endpoint(),
+		AccessToken:  "xxxxxxxxxxxxx",
+		PingInterval: 50 * time.Millisecond,
+		PongTimeout:  50 * time.Millisecond,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+
+	topic := WhispersTopic("1337")
+	if err := c.Listen(topic); err != nil {
+		t.Fatal(err)
+	}
+
+	// RECONNECT
+	s.conn(0).write(&frame{Type: "RECONNECT"})
+	waitFor(t, "resubscription after RECONNECT", func() bool {
+		return s.connCount() == 2 && s.listenCount() == 2
+	})
+
+	// Missing PONG
+	s.mu.Lock()
+	s.noPong = true
+	s.mu.Unlock()
+	waitFor(t, "reconnect after PONG timeout", func() bool {
+		return s.connCount() >= 3 && s.listenCount() >= 3
+	})
+
+	s.mu.Lock()
+	last := s.listens[len(s.listens)-1]
+	s.mu.Unlock()
+	if len(last) != 1 || last[0] != topic {
+		t.Fatalf("Expected resubscription to (%s), got %v", topic, last)
+	}
+}
+
+func TestClient_Reconnect_pending(t *testing.T) {
+	t.Parallel()
+
+	s := newFakeServer(t)
+	defer s.Close()
+
+	c, err := NewClient(&Config{
+		Endpoint:        s.endpoint(),
+		AccessToken:     "xxxxxxxxxxxxx",
+		ResponseTimeout: 5 * time.Second,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+
+	if err := c.Listen(WhispersTopic("1337")); err != nil {
+		t.Fatal(err)
+	}
+
+	// A LISTEN still waiting for its RESPONSE when the connection is
+	// replaced fails rather than reporting success.
+	topic := BitsTopic("1337")
+	s.mu.Lock()
+	s.silent[topic] = true
+	s.mu.Unlock()
+	errs := make(chan error, 1)
+	go func() {
+		errs <- c.Listen(topic)
+	}()
+	waitFor(t, "the unanswered LISTEN", func() bool {
+		return s.listenCount() == 2
+	})
+	s.mu.Lock()
+	delete(s.silent, topic)
+	s.mu.Unlock()
+	s.conn(0).write(&frame{Type: "RECONNECT"})
+
+	select {
+	case err := <-errs:
+		if err != errReconnected {
+			t.Fatalf("Expected the pending LISTEN to fail with errReconnected, got (%v)", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Timed out waiting for the pending LISTEN")
+	}
+	for _, tp := range c.Topics() {
+		if tp == topic {
+			t.Fatalf("Expected the failed topic to be removed, got %v", c.Topics())
+		}
+	}
+}
+
+func TestClient_Reconnect_error(t *testing.T) {
+	t.Parallel()
+
+	s := newFakeServer(t)
+	defer s.Close()
+
+	errs := make(chan error, 1)
+	c, err := NewClient(&Config{
+		Endpoint:    s.endpoint(),
+		AccessToken: "xxxxxxxxxxxxx",
+		ErrorHandler: func(err error) {
+			errs <- err
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+
+	topic := WhispersTopic("1337")
+	if err := c.Listen(topic); err != nil {
+		t.Fatal(err)
+	}
+
+	// The token expired while connected, so the resubscription is rejected.
+	s.mu.Lock()
+	s.reject[topic] = "ERR_BADAUTH"
+	s.mu.Unlock()
+	s.conn(0).write(&frame{Type: "RECONNECT"})
+
+	select {
+	case err := <-errs:
+		if e, ok := err.(*ListenError); !ok || e.Err != "ERR_BADAUTH" || len(e.Topics) != 1 || e.Topics[0] != topic {
+			t.Fatalf("Expected ERR_BADAUTH ListenError for (%s), got (%v)", topic, err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Timed out waiting for the resubscription error")
+	}
+	if topics := c.Topics(); len(topics) != 0 {
+		t.Fatalf("Expected the rejected topic to be removed, got %v", topics)
+	}
+}
+
+func TestJitter(t *testing.T) {
+	for i := 0; i < 100; i++ {
+		if d := jitter(time.Second); d < 500*time.Millisecond || d > time.Second {
+			t.Fatalf("Expected a jittered backoff between 500ms and 1s, got %s", d)
+		}
+	}
+}
+
+func TestClient_Stats(t *testing.T) {
+	t.Parallel()
+
+	s := newFakeServer(t)
+	defer s.Close()
+
+	c, err := NewClient(&Config{
+		Endpoint:    s.endpoint(),
+		AccessToken: "xxxxxxxxxxxxx",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+
+	if stats := c.Stats(); stats != (ClientStats{}) {
+		t.Fatalf("Expected empty stats before listening, got %+v", stats)
+	}
+
+	if err := c.Listen(WhispersTopic("1337"), WhispersTopic("1338")); err != nil {
+		t.Fatal(err)
+	}
+	s.conn(0).write(&frame{Type: "RECONNECT"})
+	waitFor(t, "resubscription after RECONNECT", func() bool {
+		return s.listenCount() == 2
+	})
+
+	expected := ClientStats{Connections: 1, Connected: 1, Topics: 2, Reconnects: 1}
+	if stats := c.Stats(); stats != expected {
+		t.Fatalf("Expected %+v, got %+v", expected, stats)
+	}
+}
@@ -0,0 +1,374 @@
+package pubsub
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	mathrand "math/rand"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// maxReconnectBackoff caps the delay between reconnection attempts.
+const maxReconnectBackoff = 2 * time.Minute
+
+var errConnClosed = errors.New("PubSub connection is closed")
+
+// errReconnected is returned by requests still waiting for their RESPONSE when
+// the connection is replaced, as Twitch won't answer them on the new one.
+var errReconnected = errors.New("PubSub connection was replaced before the response")
+
+// frame is the envelope of every PubSub message, in both directions.
+type frame struct {
+	Type  string          `json:"type"`
+	Nonce string          `json:"nonce,omitempty"`
+	Error string          `json:"error,omitempty"`
+	Data  json.RawMessage `json:"data,omitempty"`
+}
+
+type listenData struct {
+	Topics    []string `json:"topics"`
+	AuthToken string   `json:"auth_token,omitempty"`
+}
+
+type messageData struct {
+	Topic   string `json:"topic"`
+	Message string `json:"message"`
+}
+
+// conn is a single PubSub connection and the topics it listens to. The
+// WebSocket is dialed lazily and replaced whenever it fails, asks to
+// reconnect, or misses a PONG.
+type conn struct {
+	client *Client
+
+	// dialMu serializes dialing so only one WebSocket is opened at a time.
+	dialMu sync.Mutex
+
+	mu      sync.Mutex
+	ws      *websocket.Conn
+	topics  map[string]bool
+	pending map[string]*pendingRequest
+	closed  bool
+	done    chan struct{}
+
+	writeMu sync.Mutex
+}
+
+func newConn(c *Client) *conn {
+	return &conn{
+		client:  c,
+		topics:  make(map[string]bool),
+		pending: make(map[string]*pendingRequest),
+		done:    make(chan struct{}),
+	}
+}
+
+// pendingRequest is a LISTEN or UNLISTEN waiting for its RESPONSE. The error
+// of the request, or nil, is sent on done.
+type pendingRequest struct {
+	topics []string
+	done   chan error
+}
+
+func (cn *conn) add(topic string) {
+	cn.mu.Lock()
+	defer cn.mu.Unlock()
+	cn.topics[topic] = true
+}
+
+func (cn *conn) remove(topics []string) {
+	cn.mu.Lock()
+	defer cn.mu.Unlock()
+	for _, t := range topics {
+		delete(cn.topics, t)
+	}
+}
+
+func (cn *conn) has(topic string) bool {
+	cn.mu.Lock()
+	defer cn.mu.Unlock()
+	return cn.topics[topic]
+}
+
+func (cn *conn) count() int {
+	cn.mu.Lock()
+	defer cn.mu.Unlock()
+	return len(cn.topics)
+}
+
+func (cn *conn) list() []string {
+	cn.mu.Lock()
+	defer cn.mu.Unlock()
+	topics := make([]string, 0, len(cn.topics))
+	for t := range cn.topics {
+		topics = append(topics, t)
+	}
+	return topics
+}
+
+func (cn *conn) listen(topics []string) error {
+	return cn.request("LISTEN", topics)
+}
+
+func (cn *conn) unlisten(topics []string) error {
+	cn.mu.Lock()
+	connected := cn.ws != nil
+	cn.mu.Unlock()
+	if !connected {
+		return nil
+	}
+	return cn.request("UNLISTEN", topics)
+}
+
+// request sends a LISTEN or UNLISTEN and waits for its RESPONSE.
+func (cn *conn) request(typ string, topics []string) error {
+	ws, err := cn.ensure()
+	if err != nil {
+		return err
+	}
+
+	nonce := newNonce()
+	p := &pendingRequest{topics: topics, done: make(chan error, 1)}
+	cn.mu.Lock()
+	cn.pending[nonce] = p
+	cn.mu.Unlock()
+
+	defer func() {
+		cn.mu.Lock()
+		delete(cn.pending, nonce)
+		cn.mu.Unlock()
+	}()
+
+	if err := cn.send(ws, typ, nonce, topics); err != nil {
+		return err
+	}
+
+	select {
+	case err := <-p.done:
+		return err
+	case <-time.After(cn.client.config.ResponseTimeout):
+		return fmt.Errorf("Timed out waiting for PubSub %s response", typ)
+	}
+}
+
+func (cn *conn) send(ws *websocket.Conn, typ, nonce string, topics []string) error {
+	data, err := json.Marshal(&listenData{
+		Topics:    topics,
+		AuthToken: cn.client.config.AccessToken,
+	})
+	if err != nil {
+		return err
+	}
+	return cn.write(ws, &frame{Type: typ, Nonce: nonce, Data: data})
+}
+
+func (cn *conn) write(ws *websocket.Conn, f *frame) error {
+	cn.writeMu.Lock()
+	defer cn.writeMu.Unlock()
+	return ws.WriteJSON(f)
+}
+
+// ensure returns the current WebSocket, dialing a new one if needed.
+func (cn *conn) ensure() (*websocket.Conn, error) {
+	cn.dialMu.Lock()
+	defer cn.dialMu.Unlock()
+
+	cn.mu.Lock()
+	ws, closed := cn.ws, cn.closed
+	cn.mu.Unlock()
+	if closed {
+		return nil, errConnClosed
+	}
+	if ws != nil {
+		return ws, nil
+	}
+
+	ws, err := cn.client.dial()
+	if err != nil {
+		return nil, err
+	}
+
+	cn.mu.Lock()
+	if cn.closed {
+		cn.mu.Unlock()
+		ws.Close()
+		return nil, errConnClosed
+	}
+	cn.ws = ws
+	cn.mu.Unlock()
+
+	go cn.run(ws)
+
+	return ws, nil
+}
+
+// reconnect replaces a failed WebSocket and listens to every topic again.
+// Topics Twitch rejects on the new connection are removed, and the error is
+// passed to the ErrorHandler.
+func (cn *conn) reconnect(old *websocket.Conn) {
+	cn.mu.Lock()
+	if cn.closed || cn.ws != old {
+		cn.mu.Unlock()
+		return
+	}
+	cn.ws = nil
+	for nonce, p := range cn.pending {
+		p.done <- errReconnected
+		delete(cn.pending, nonce)
+	}
+	cn.mu.Unlock()
+	old.Close()
+
+	backoff := time.Second
+	for {
+		_, err := cn.ensure()
+		if err == errConnClosed {
+			return
+		}
+		if err == nil {
+			cn.client.mu.Lock()
+			cn.client.reconnects++
+			cn.client.mu.Unlock()
+
+			if topics := cn.list(); len(topics) > 0 {
+				err := cn.listen(topics)
+				if e, ok := err.(*ListenError); ok {
+					cn.remove(e.Topics)
+				}
+				if err != nil && err != errConnClosed {
+					cn.client.error(err)
+				}
+			}
+			return
+		}
+
+		select {
+		case <-cn.done:
+			return
+		case <-time.After(jitter(backoff)):
+		}
+		if backoff *= 2; backoff > maxReconnectBackoff {
+			backoff = maxReconnectBackoff
+		}
+	}
+}
+
+// run reads from a WebSocket until it fails, then reconnects.
+func (cn *conn) run(ws *websocket.Conn) {
+	stop := make(chan struct{})
+	pong := make(chan struct{}, 1)
+	go cn.ping(ws, pong, stop)
+	defer close(stop)
+
+	for {
+		var f frame
+		if err := ws.ReadJSON(&f); err != nil {
+			go cn.reconnect(ws)
+			return
+		}
+
+		switch f.Type {
+		case "PONG":
+			select {
+			case pong <- struct{}{}:
+			default:
+			}
+		case "RECONNECT":
+			go cn.reconnect(ws)
+			return
+		case "RESPONSE":
+			cn.mu.Lock()
+			if p, ok := cn.pending[f.Nonce]; ok {
+				if f.Error != "" {
+					p.done <- &ListenError{Topics: p.topics, Err: f.Error}
+				} else {
+					p.done <- nil
+				}
+				delete(cn.pending, f.Nonce)
+			}
+			cn.mu.Unlock()
+		case "MESSAGE":
+			var d messageData
+			if err := json.Unmarshal(f.Data, &d); err != nil {
+				continue
+			}
+			if h := cn.client.config.Handler; h != nil {
+				h(&Message{Topic: d.Topic, Data: d.Message})
+			}
+		}
+	}
+}
+
+// ping sends a PING every PingInterval and reconnects if no PONG arrives
+// within PongTimeout.
+func (cn *conn) ping(ws *websocket.Conn, pong <-chan struct{}, stop <-chan struct{}) {
+	config := cn.client.config
+	ticker := time.NewTicker(config.PingInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+		}
+
+		select {
+		case <-pong:
+		default:
+		}
+
+		if err := cn.write(ws, &frame{Type: "PING"}); err != nil {
+			go cn.reconnect(ws)
+			return
+		}
+
+		select {
+		case <-stop:
+			return
+		case <-pong:
+		case <-time.After(config.PongTimeout):
+			go cn.reconnect(ws)
+			return
+		}
+	}
+}
+
+func (cn *conn) close() {
+	cn.mu.Lock()
+	if cn.closed {
+		cn.mu.Unlock()
+		return
+	}
+	cn.closed = true
+	ws := cn.ws
+	cn.ws = nil
+	for nonce, p := range cn.pending {
+		p.done <- errConnClosed
+		delete(cn.pending, nonce)
+	}
+	close(cn.done)
+	cn.mu.Unlock()
+
+	if ws != nil {
+		ws.Close()
+	}
+}
+
+// jitter returns a random duration between half of d and d, so connections
+// dropped together don't all redial at once.
+func jitter(d time.Duration) time.Duration {
+	half := int64(d / 2)
+	return time.Duration(half + mathrand.Int63n(half+1))
+}
+
+func newNonce() string {
+	b := make([]byte, 16)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,197 @@
+package pubsub
+
+import (
+	"errors"
+	"io/ioutil"
+	"strings"
+	"time"
+
+	"github.com/catsby/go-twitch/twitch"
+)
+
+// ErrUnknownTopic is an error that is returned when decoding a message from a
+// topic this package has no type for.
+var ErrUnknownTopic = errors.New("Unknown PubSub topic")
+
+// Topic prefixes, each followed by one or more IDs separated by periods.
+const (
+	ChannelPointsTopicPrefix   = "channel-points-channel-v1"
+	BitsTopicPrefix            = "channel-bits-events-v2"
+	WhispersTopicPrefix        = "whispers"
+	ModeratorActionTopicPrefix = "chat_moderator_actions"
+)
+
+// ChannelPointsTopic returns the topic for channel points redemptions in a
+// channel. Scope: channel:read:redemptions
+func ChannelPointsTopic(channelId string) string {
+	return ChannelPointsTopicPrefix + "." + channelId
+}
+
+// BitsTopic returns the topic for bits cheered in a channel.
+// Scope: bits:read
+func BitsTopic(channelId string) string {
+	return BitsTopicPrefix + "." + channelId
+}
+
+// WhispersTopic returns the topic for whispers sent to a user.
+// Scope: whispers:read
+func WhispersTopic(userId string) string {
+	return WhispersTopicPrefix + "." + userId
+}
+
+// ModeratorActionTopic returns the topic for moderator actions taken in a
+// channel, as seen by the given moderator. Scope: channel:moderate
+func ModeratorActionTopic(userId, channelId string) string {
+	return ModeratorActionTopicPrefix + "." + userId + "." + channelId
+}
+
+// Message is a single message received on a topic.
+type Message struct {
+	Topic string
+
+	// Data is the raw JSON payload of the message.
+	Data string
+}
+
+// Decode decodes the message into the type for its topic: one of
+// *ChannelPointsEvent, *BitsEvent, *WhisperEvent or *ModeratorActionEvent.
+func (m *Message) Decode() (interface{}, error) {
+	var out interface{}
+	switch strings.SplitN(m.Topic, ".", 2)[0] {
+	case ChannelPointsTopicPrefix:
+		out = new(ChannelPointsEvent)
+	case BitsTopicPrefix:
+		out = new(BitsEvent)
+	case WhispersTopicPrefix:
+		out = new(WhisperEvent)
+	case ModeratorActionTopicPrefix:
+		out = new(ModeratorActionEvent)
+	default:
+		return nil, ErrUnknownTopic
+	}
+
+	if err := twitch.DecodeJSON(out, ioutil.NopCloser(strings.NewReader(m.Data))); err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
+
+// User is the user attached to PubSub events.
+type User struct {
+	Id          string `mapstructure:"id"`
+	Login       string `mapstructure:"login"`
+	DisplayName string `mapstructure:"display_name"`
+}
+
+// ChannelPointsEvent is sent on ChannelPointsTopic.
+type ChannelPointsEvent struct {
+	// Type is the kind of event, Ex: reward-redeemed
+	Type string             `mapstructure:"type"`
+	Data *ChannelPointsData `mapstructure:"data"`
+}
+
+type ChannelPointsData struct {
+	Timestamp  time.Time   `mapstructure:"timestamp"`
+	Redemption *Redemption `mapstructure:"redemption"`
+}
+
+// Redemption is a single redemption of a custom reward.
+type Redemption struct {
+	Id         string    `mapstructure:"id"`
+	User       *User     `mapstructure:"user"`
+	ChannelId  string    `mapstructure:"channel_id"`
+	RedeemedAt time.Time `mapstructure:"redeemed_at"`
+	Reward     *Reward   `mapstructure:"reward"`
+	UserInput  string    `mapstructure:"user_input"`
+
+	// Status is FULFILLED or UNFULFILLED.
+	Status string `mapstructure:"status"`
+}
+
+// Reward is a custom channel points reward.
+type Reward struct {
+	Id                  string `mapstructure:"id"`
+	ChannelId           string `mapstructure:"channel_id"`
+	Title               string `mapstructure:"title"`
+	Prompt              string `mapstructure:"prompt"`
+	Cost                int    `mapstructure:"cost"`
+	IsUserInputRequired bool   `mapstructure:"is_user_input_required"`
+	BackgroundColor     string `mapstructure:"background_color"`
+}
+
+// BitsEvent is sent on BitsTopic.
+type BitsEvent struct {
+	Data        *BitsEventData `mapstructure:"data"`
+	Version     string         `mapstructure:"version"`
+	MessageType string         `mapstructure:"message_type"`
+	MessageId   string         `mapstructure:"message_id"`
+	IsAnonymous bool           `mapstructure:"is_anonymous"`
+}
+
+type BitsEventData struct {
+	UserId        string    `mapstructure:"user_id"`
+	UserName      string    `mapstructure:"user_name"`
+	ChannelId     string    `mapstructure:"channel_id"`
+	ChannelName   string    `mapstructure:"channel_name"`
+	Time          time.Time `mapstructure:"time"`
+	ChatMessage   string    `mapstructure:"chat_message"`
+	BitsUsed      int       `mapstructure:"bits_used"`
+	TotalBitsUsed int       `mapstructure:"total_bits_used"`
+	Context       string    `mapstructure:"context"`
+}
+
+// WhisperEvent is sent on WhispersTopic.
+type WhisperEvent struct {
+	// Type is the kind of event, Ex: whisper_received, whisper_sent
+	Type string   `mapstructure:"type"`
+	Data *Whisper `mapstructure:"data_object"`
+}
+
+type Whisper struct {
+	Id        int               `mapstructure:"id"`
+	MessageId string            `mapstructure:"message_id"`
+	ThreadId  string            `mapstructure:"thread_id"`
+	Body      string            `mapstructure:"body"`
+	SentTs    int64             `mapstructure:"sent_ts"`
+	FromId    int               `mapstructure:"from_id"`
+	Tags      *WhisperTags      `mapstructure:"tags"`
+	Recipient *WhisperRecipient `mapstructure:"recipient"`
+}
+
+// SentAt returns SentTs as a time.Time.
+func (w *Whisper) SentAt() time.Time {
+	return time.Unix(w.SentTs, 0)
+}
+
+type WhisperTags struct {
+	Login       string `mapstructure:"login"`
+	DisplayName string `mapstructure:"display_name"`
+	Color       string `mapstructure:"color"`
+}
+
+type WhisperRecipient struct {
+	Id          int    `mapstructure:"id"`
+	Username    string `mapstructure:"username"`
+	DisplayName string `mapstructure:"display_name"`
+	Color       string `mapstructure:"color"`
+}
+
+// ModeratorActionEvent is sent on ModeratorActionTopic.
+type ModeratorActionEvent struct {
+	Data *ModeratorAction `mapstructure:"data"`
+}
+
+type ModeratorAction struct {
+	Type string `mapstructure:"type"`
+
+	// ModerationAction is the command used, Ex: ban, timeout, delete, slow
+	ModerationAction string   `mapstructure:"moderation_action"`
+	Args             []string `mapstructure:"args"`
+	CreatedBy        string   `mapstructure:"created_by"`
+	CreatedByUserId  string   `mapstructure:"created_by_user_id"`
+	MsgId            string   `mapstructure:"msg_id"`
+	TargetUserId     string   `mapstructure:"target_user_id"`
+	TargetUserLogin  string   `mapstructure:"target_user_login"`
+	FromAutomod      bool     `mapstructure:"from_automod"`
+}
@@ -0,0 +1,130 @@
+package pubsub
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+const bitsMessage = `{"data":{"user_name":"dallasnchains","channel_name":"dallas","user_id":"129454141","channel_id":"44322889","time":"2017-02-09T13:23:58.168Z","chat_message":"cheer10000 New badge hype!","bits_used":10000,"total_bits_used":25000,"context":"cheer"},"version":"1.0","message_type":"bits_event","message_id":"8145728a4-35f0-4cf7-9dc0-f2ef24de1eb6","is_anonymous":true}`
+
+func TestMessage_Decode(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		Label    string
+		Message  *Message
+		Expected interface{}
+	}{
+		{
+			Label:   "Bits",
+			Message: &Message{Topic: BitsTopic("44322889"), Data: bitsMessage},
+			Expected: &BitsEvent{
+				Data: &BitsEventData{
+					UserId:        "129454141",
+					UserName:      "dallasnchains",
+					ChannelId:     "44322889",
+					ChannelName:   "dallas",
+					Time:          time.Date(2017, 2, 9, 13, 23, 58, 168000000, time.UTC),
+					ChatMessage:   "cheer10000 New badge hype!",
+					BitsUsed:      10000,
+					TotalBitsUsed: 25000,
+					Context:       "cheer",
+				},
+				Version:     "1.0",
+				MessageType: "bits_event",
+				MessageId:   "8145728a4-35f0-4cf7-9dc0-f2ef24de1eb6",
+				IsAnonymous: true,
+			},
+		},
+		{
+			Label: "ChannelPoints",
+			Message: &Message{
+				Topic: ChannelPointsTopic("30515034"),
+				Data:  `{"type":"reward-redeemed","data":{"timestamp":"2019-11-12T01:29:34.98329743Z","redemption":{"id":"9203c6f0-51b6-4d1d-a9ae-8eafdb0d6d47","user":{"id":"30515034","login":"davethecust","display_name":"davethecust"},"channel_id":"30515034","redeemed_at":"2019-12-11T18:52:53.128421623Z","reward":{"id":"6ef17bb2-e5ae-432e-8b3f-5ac4dd774668","channel_id":"30515034","title":"hit a gleesh walk on stream","prompt":"cleanside's finest","cost":10,"is_user_input_required":true,"background_color":"#00C7AC"},"user_input":"yeooo","status":"FULFILLED"}}}`,
+			},
+			Expected: &ChannelPointsEvent{
+				Type: "reward-redeemed",
+				Data: &ChannelPointsData{
+					Timestamp: time.Date(2019, 11, 12, 1, 29, 34, 983297430, time.UTC),
+					Redemption: &Redemption{
+						Id:         "9203c6f0-51b6-4d1d-a9ae-8eafdb0d6d47",
+						User:       &User{Id: "30515034", Login: "davethecust", DisplayName: "davethecust"},
+						ChannelId:  "30515034",
+						RedeemedAt: time.Date(2019, 12, 11, 18, 52, 53, 128421623, time.UTC),
+						Reward: &Reward{
+							Id:                  "6ef17bb2-e5ae-432e-8b3f-5ac4dd774668",
+							ChannelId:           "30515034",
+							Title:               "hit a gleesh walk on stream",
+							Prompt:              "cleanside's finest",
+							Cost:                10,
+							IsUserInputRequired: true,
+							BackgroundColor:     "#00C7AC",
+						},
+						UserInput: "yeooo",
+						Status:    "FULFILLED",
+					},
+				},
+			},
+		},
+		{
+			Label: "Whisper",
+			Message: &Message{
+				Topic: WhispersTopic("12826"),
+				Data:  `{"type":"whisper_received","data":"{}","data_object":{"id":41,"message_id":"ae6f3fc7-8e5e-4d4e-9bd3-1b8a0d2f4c5b","thread_id":"129454141_44322889","body":"hello","sent_ts":1479160009,"from_id":39141793,"tags":{"login":"dallas","display_name":"dallas","color":"#8A2BE2"},"recipient":{"id":129454141,"username":"dallasnchains","display_name":"dallasnchains","color":""}}}`,
+			},
+			Expected: &WhisperEvent{
+				Type: "whisper_received",
+				Data: &Whisper{
+					Id:        41,
+					MessageId: "ae6f3fc7-8e5e-4d4e-9bd3-1b8a0d2f4c5b",
+					ThreadId:  "129454141_44322889",
+					Body:      "hello",
+					SentTs:    1479160009,
+					FromId:    39141793,
+					Tags:      &WhisperTags{Login: "dallas", DisplayName: "dallas", Color: "#8A2BE2"},
+					Recipient: &WhisperRecipient{Id: 129454141, Username: "dallasnchains", DisplayName: "dallasnchains"},
+				},
+			},
+		},
+		{
+			Label: "ModeratorAction",
+			Message: &Message{
+				Topic: ModeratorActionTopic("1337", "44322889"),
+				Data:  `{"data":{"type":"chat_login_moderation","moderation_action":"timeout","args":["spammer","600","links"],"created_by":"dallas","created_by_user_id":"44322889","msg_id":"","target_user_id":"12345","target_user_login":"","from_automod":false}}`,
+			},
+			Expected: &ModeratorActionEvent{
+				Data: &ModeratorAction{
+					Type:             "chat_login_moderation",
+					ModerationAction: "timeout",
+					Args:             []string{"spammer", "600", "links"},
+					CreatedBy:        "dallas",
+					CreatedByUserId:  "44322889",
+					TargetUserId:     "12345",
+				},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Label, func(t *testing.T) {
+			out, err := tc.Message.Decode()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !reflect.DeepEqual(out, tc.Expected) {
+				t.Fatalf("Error in matching event, got: \n%#v\n\nexpected:\n%#v\n\n", out, tc.Expected)
+			}
+		})
+	}
+}
+
+func TestMessage_Decode_unknownTopic(t *testing.T) {
+	t.Parallel()
+
+	m := &Message{Topic: "video-playback.dallas", Data: `{}`}
+	if _, err := m.Decode(); err != ErrUnknownTopic {
+		t.Fatalf("Expected ErrUnknownTopic, got (%v)", err)
+	}
+}
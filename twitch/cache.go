@@ -0,0 +1,324 @@
+package twitch
+
+import (
+	"bytes"
+	"container/list"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Defaults of CacheOptions.
+const (
+	DefaultCacheSize        = 1000
+	DefaultCacheTTL         = time.Minute
+	DefaultCacheNotFoundTTL = 30 * time.Second
+	DefaultCacheRevalidate  = time.Hour
+)
+
+// Cache stores the responses of the CacheResponses middleware, Ex: in memory
+// with an LRUCache, or in an external store shared by several processes.
+// Implementations must be safe for concurrent use.
+type Cache interface {
+	// Get returns the value stored under key, if it has not expired.
+	Get(key string) ([]byte, bool)
+
+	// Set stores value under key for ttl.
+	Set(key string, value []byte, ttl time.Duration)
+
+	// Delete removes the value stored under key, if any.
+	Delete(key string)
+}
+
+// CacheOptions configures the CacheResponses middleware.
+type CacheOptions struct {
+	// Cache stores the responses. Default: an LRUCache of DefaultCacheSize
+	// responses.
+	Cache Cache
+
+	// TTL is how long a response is served from the cache before it is
+	// requested again. Default: DefaultCacheTTL.
+	TTL time.Duration
+
+	// TTLs override TTL for the calls they name, Ex: "helix.GetGames". A
+	// negative TTL disables caching of the call.
+	TTLs map[string]time.Duration
+
+	// NotFoundTTL is how long a 404 response is served from the cache. A
+	// negative NotFoundTTL disables caching of 404s. Default:
+	// DefaultCacheNotFoundTTL.
+	NotFoundTTL time.Duration
+
+	// Revalidate is how long a response with an ETag is kept after it
+	// expires, to be requested again with If-None-Match and served from the
+	// cache if Twitch answers 304 Not Modified. Default:
+	// DefaultCacheRevalidate.
+	Revalidate time.Duration
+
+	// Shared names the calls whose responses don't depend on the access
+	// token, Ex: "helix.GetGames", and are shared by clients using
+	// different tokens. The responses of other calls are only served to
+	// requests made with the same token.
+	Shared []string
+}
+
+// cacheEntry is a response as stored in a Cache.
+type cacheEntry struct {
+	Expires    time.Time   `json:"expires"`
+	StatusCode int         `json:"status"`
+	Header     http.Header `json:"header"`
+	Body       []byte      `json:"body"`
+}
+
+// CacheResponses returns a middleware serving GET requests from a cache. 2xx
+// and 404 responses are cached for the TTL of their call, and responses with
+// an ETag are revalidated with a conditional request once they expire. Put it
+// first in the Config's Middlewares, so cached responses don't wait on the
+// rate limit.
+func CacheResponses(o *CacheOptions) Middleware {
+	var opts CacheOptions
+	if o != nil {
+		opts = *o
+	}
+	if opts.Cache == nil {
+		opts.Cache = NewLRUCache(DefaultCacheSize)
+	}
+	if opts.TTL == 0 {
+		opts.TTL = DefaultCacheTTL
+	}
+	if opts.NotFoundTTL == 0 {
+		opts.NotFoundTTL = DefaultCacheNotFoundTTL
+	}
+	if opts.Revalidate <= 0 {
+		opts.Revalidate = DefaultCacheRevalidate
+	}
+	shared := make(map[string]bool)
+	for _, name := range opts.Shared {
+		shared[name] = true
+	}
+
+	return func(next http.RoundTripper) http.RoundTripper {
+		return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			name := CallFromRequest(req).Name()
+			ttl := opts.TTL
+			if t, ok := opts.TTLs[name]; ok {
+				ttl = t
+			}
+			if req.Method != "GET" || ttl < 0 {
+				return next.RoundTrip(req)
+			}
+
+			key := cacheKey(req, shared[name])
+			entry := getCacheEntry(opts.Cache, key)
+			now := time.Now()
+			if entry != nil && now.Before(entry.Expires) {
+				return entry.response(req), nil
+			}
+
+			if etag := entry.etag(); etag != "" {
+				req = req.Clone(req.Context())
+				req.Header.Set("If-None-Match", etag)
+			}
+			resp, err := next.RoundTrip(req)
+			if err != nil {
+				return resp, err
+			}
+
+			if resp.StatusCode == http.StatusNotModified && entry != nil {
+				resp.Body.Close()
+				// The 304 carries the current headers, Ex: the rate limit.
+				for k, v := range resp.Header {
+					if k != "Content-Length" {
+						entry.Header[k] = v
+					}
+				}
+				entry.Expires = now.Add(ttl)
+				setCacheEntry(opts.Cache, key, entry, ttl+opts.Revalidate)
+				return entry.response(req), nil
+			}
+
+			switch {
+			case resp.StatusCode == http.StatusNotFound:
+				ttl = opts.NotFoundTTL
+			case resp.StatusCode < 200 || resp.StatusCode > 299:
+				return resp, nil
+			}
+			if ttl <= 0 || strings.Contains(resp.Header.Get("Cache-Control"), "no-store") {
+				return resp, nil
+			}
+
+			body, err := ioutil.ReadAll(resp.Body)
+			resp.Body.Close()
+			if err != nil {
+				return nil, err
+			}
+			resp.Body = ioutil.NopCloser(bytes.NewReader(body))
+
+			entry = &cacheEntry{
+				Expires:    now.Add(ttl),
+				StatusCode: resp.StatusCode,
+				Header:     resp.Header.Clone(),
+				Body:       body,
+			}
+			if entry.etag() != "" {
+				ttl += opts.Revalidate
+			}
+			setCacheEntry(opts.Cache, key, entry, ttl)
+			return resp, nil
+		})
+	}
+}
+
+// cacheKey returns the key of a request's response. Unless the response is
+// shared, the key includes the access token, which is hashed with the rest of
+// the request so it is never stored.
+func cacheKey(req *http.Request, shared bool) string {
+	h := sha256.New()
+	h.Write([]byte(req.URL.String()))
+	for _, header := range []string{"Accept", ClientIdHeader} {
+		h.Write([]byte{0})
+		h.Write([]byte(req.Header.Get(header)))
+	}
+	if !shared {
+		h.Write([]byte{0})
+		h.Write([]byte(req.Header.Get(AccessTokenHeader)))
+	}
+	return "twitch:" + hex.EncodeToString(h.Sum(nil))
+}
+
+// getCacheEntry returns the entry stored under key, or nil.
+func getCacheEntry(c Cache, key string) *cacheEntry {
+	data, ok := c.Get(key)
+	if !ok {
+		return nil
+	}
+	var entry cacheEntry
+	if err := json.Unmarshal(data, &entry); err != nil {
+		c.Delete(key)
+		return nil
+	}
+	if entry.Header == nil {
+		entry.Header = make(http.Header)
+	}
+	return &entry
+}
+
+func setCacheEntry(c Cache, key string, entry *cacheEntry, ttl time.Duration) {
+	if data, err := json.Marshal(entry); err == nil {
+		c.Set(key, data, ttl)
+	}
+}
+
+// etag returns the ETag of the entry's response, if it is not nil.
+func (e *cacheEntry) etag() string {
+	if e == nil {
+		return ""
+	}
+	return e.Header.Get("Etag")
+}
+
+// response returns the cached response to req.
+func (e *cacheEntry) response(req *http.Request) *http.Response {
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", e.StatusCode, http.StatusText(e.StatusCode)),
+		StatusCode:    e.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        e.Header.Clone(),
+		Body:          ioutil.NopCloser(bytes.NewReader(e.Body)),
+		ContentLength: int64(len(e.Body)),
+		Request:       req,
+	}
+}
+
+// LRUCache is an in-memory Cache holding a fixed number of values, evicting
+// the least recently used when full.
+type LRUCache struct {
+	size int
+
+	mu      sync.Mutex
+	order   *list.List
+	entries map[string]*list.Element
+}
+
+type lruEntry struct {
+	key     string
+	value   []byte
+	expires time.Time
+}
+
+// NewLRUCache returns an LRUCache holding up to size values.
+func NewLRUCache(size int) *LRUCache {
+	if size <= 0 {
+		size = DefaultCacheSize
+	}
+	return &LRUCache{
+		size:    size,
+		order:   list.New(),
+		entries: make(map[string]*list.Element),
+	}
+}
+
+// Get implements Cache.
+func (c *LRUCache) Get(key string) ([]byte, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	el, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+	e := el.Value.(*lruEntry)
+	if time.Now().After(e.expires) {
+		c.order.Remove(el)
+		delete(c.entries, key)
+		return nil, false
+	}
+	c.order.MoveToFront(el)
+	return e.value, true
+}
+
+// Set implements Cache.
+func (c *LRUCache) Set(key string, value []byte, ttl time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	e := &lruEntry{key: key, value: value, expires: time.Now().Add(ttl)}
+	if el, ok := c.entries[key]; ok {
+		el.Value = e
+		c.order.MoveToFront(el)
+		return
+	}
+	c.entries[key] = c.order.PushFront(e)
+	for c.order.Len() > c.size {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*lruEntry).key)
+	}
+}
+
+// Delete implements Cache.
+func (c *LRUCache) Delete(key string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if el, ok := c.entries[key]; ok {
+		c.order.Remove(el)
+		delete(c.entries, key)
+	}
+}
+
+// Len returns the number of values held, including expired ones not yet
+// evicted.
+func (c *LRUCache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.order.Len()
+}
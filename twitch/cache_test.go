@@ -0,0 +1,194 @@
+package twitch
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+// origin is a RoundTripper counting the requests it answers, with an ETag if
+// etag is set, and 304 Not Modified to requests for it.
+type origin struct {
+	status   int
+	etag     string
+	requests []*http.Request
+}
+
+func (o *origin) RoundTrip(req *http.Request) (*http.Response, error) {
+	o.requests = append(o.requests, req)
+	resp := &http.Response{
+		StatusCode: o.status,
+		Header:     make(http.Header),
+		Body:       ioutil.NopCloser(strings.NewReader(`{"n":` + strconv.Itoa(len(o.requests)) + `}`)),
+		Request:    req,
+	}
+	if o.etag != "" {
+		resp.Header.Set("Etag", o.etag)
+		if req.Header.Get("If-None-Match") == o.etag {
+			resp.StatusCode = http.StatusNotModified
+			resp.Body = ioutil.NopCloser(strings.NewReader(""))
+		}
+	}
+	resp.Header.Set("Ratelimit-Remaining", strconv.Itoa(len(o.requests)))
+	return resp, nil
+}
+
+// get sends a GET for call with token through rt, and returns the body.
+func get(t *testing.T, rt http.RoundTripper, call *Call, token string) (*http.Response, string) {
+	req := httptest.NewRequest("GET", "https://api.twitch.tv/helix/games?id=1", nil)
+	req = req.WithContext(WithCall(context.Background(), call))
+	req.Header.Set(AccessTokenHeader, "Bearer "+token)
+	resp, err := rt.RoundTrip(req)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %s", err)
+	}
+	body, _ := ioutil.ReadAll(resp.Body)
+	return resp, string(body)
+}
+
+func TestCacheResponses(t *testing.T) {
+	o := &origin{status: 200}
+	rt := Chain(o, CacheResponses(nil))
+	call := &Call{Service: "helix", Endpoint: "GetGames"}
+
+	if _, body := get(t, rt, call, "a"); body != `{"n":1}` {
+		t.Fatalf("Unexpected body: %s", body)
+	}
+	resp, body := get(t, rt, call, "a")
+	if body != `{"n":1}` || resp.StatusCode != 200 {
+		t.Fatalf("Expected the cached response, got: %d %s", resp.StatusCode, body)
+	}
+	if len(o.requests) != 1 {
+		t.Fatalf("Expected 1 request, got: %d", len(o.requests))
+	}
+
+	// Other tokens don't share the response.
+	if _, body := get(t, rt, call, "b"); body != `{"n":2}` {
+		t.Fatalf("Expected a response for the other token, got: %s", body)
+	}
+
+	// Other methods aren't cached.
+	req := httptest.NewRequest("POST", "https://api.twitch.tv/helix/games?id=1", nil)
+	rt.RoundTrip(req)
+	rt.RoundTrip(req)
+	if len(o.requests) != 4 {
+		t.Fatalf("Expected POSTs not to be cached, got %d requests", len(o.requests))
+	}
+}
+
+func TestCacheResponses_options(t *testing.T) {
+	o := &origin{status: 200}
+	rt := Chain(o, CacheResponses(&CacheOptions{
+		TTLs: map[string]time.Duration{
+			"helix.GetStreams": -1,
+		},
+		Shared: []string{"helix.GetGames"},
+	}))
+
+	games := &Call{Service: "helix", Endpoint: "GetGames"}
+	get(t, rt, games, "a")
+	if _, body := get(t, rt, games, "b"); body != `{"n":1}` {
+		t.Fatalf("Expected a shared response, got: %s", body)
+	}
+
+	streams := &Call{Service: "helix", Endpoint: "GetStreams"}
+	get(t, rt, streams, "a")
+	if _, body := get(t, rt, streams, "a"); body != `{"n":3}` {
+		t.Fatalf("Expected caching to be disabled, got: %s", body)
+	}
+}
+
+func TestCacheResponses_notFound(t *testing.T) {
+	o := &origin{status: 404}
+	rt := Chain(o, CacheResponses(nil))
+	call := &Call{Service: "helix", Endpoint: "GetGames"}
+
+	get(t, rt, call, "a")
+	resp, _ := get(t, rt, call, "a")
+	if resp.StatusCode != 404 || len(o.requests) != 1 {
+		t.Fatalf("Expected a cached 404, got: %d after %d requests", resp.StatusCode, len(o.requests))
+	}
+
+	if _, err := CheckResponse(resp, nil); err == nil {
+		t.Fatalf("Expected a cached 404 to be an error")
+	}
+
+	o = &origin{status: 404}
+	rt = Chain(o, CacheResponses(&CacheOptions{NotFoundTTL: -1}))
+	get(t, rt, call, "a")
+	get(t, rt, call, "a")
+	if len(o.requests) != 2 {
+		t.Fatalf("Expected 404s not to be cached, got %d requests", len(o.requests))
+	}
+
+	o = &origin{status: 500}
+	rt = Chain(o, CacheResponses(nil))
+	get(t, rt, call, "a")
+	get(t, rt, call, "a")
+	if len(o.requests) != 2 {
+		t.Fatalf("Expected 500s not to be cached, got %d requests", len(o.requests))
+	}
+}
+
+func TestCacheResponses_revalidate(t *testing.T) {
+	o := &origin{status: 200, etag: `"v1"`}
+	rt := Chain(o, CacheResponses(&CacheOptions{TTL: time.Nanosecond}))
+	call := &Call{Service: "helix", Endpoint: "GetGames"}
+
+	get(t, rt, call, "a")
+	time.Sleep(time.Millisecond)
+	resp, body := get(t, rt, call, "a")
+
+	if len(o.requests) != 2 {
+		t.Fatalf("Expected the expired response to be requested again, got %d requests", len(o.requests))
+	}
+	if inm := o.requests[1].Header.Get("If-None-Match"); inm != `"v1"` {
+		t.Fatalf("Expected a conditional request, got If-None-Match: %q", inm)
+	}
+	if resp.StatusCode != 200 || body != `{"n":1}` {
+		t.Fatalf("Expected the cached response after a 304, got: %d %s", resp.StatusCode, body)
+	}
+	if rem := resp.Header.Get("Ratelimit-Remaining"); rem != "2" {
+		t.Fatalf("Expected the headers of the 304, got Ratelimit-Remaining: %s", rem)
+	}
+
+	// A new version replaces the cached one.
+	o.etag = `"v2"`
+	time.Sleep(time.Millisecond)
+	if _, body := get(t, rt, call, "a"); body != `{"n":3}` {
+		t.Fatalf("Expected the new version, got: %s", body)
+	}
+}
+
+func TestLRUCache(t *testing.T) {
+	c := NewLRUCache(2)
+	c.Set("a", []byte("1"), time.Hour)
+	c.Set("b", []byte("2"), time.Hour)
+	c.Get("a")
+	c.Set("c", []byte("3"), time.Hour)
+
+	if _, ok := c.Get("b"); ok {
+		t.Fatalf("Expected the least recently used value to be evicted")
+	}
+	if v, ok := c.Get("a"); !ok || string(v) != "1" {
+		t.Fatalf("Expected a to be kept, got: %q, %t", v, ok)
+	}
+	if c.Len() != 2 {
+		t.Fatalf("Expected 2 values, got: %d", c.Len())
+	}
+
+	c.Set("d", []byte("4"), -time.Second)
+	if _, ok := c.Get("d"); ok {
+		t.Fatalf("Expected an expired value not to be returned")
+	}
+
+	c.Delete("a")
+	if _, ok := c.Get("a"); ok {
+		t.Fatalf("Expected a deleted value not to be returned")
+	}
+}
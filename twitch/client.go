@@ -0,0 +1,290 @@
+package twitch
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/ajg/form"
+	cleanhttp "github.com/hashicorp/go-cleanhttp"
+)
+
+// AccessTokenHeader is the name of the header that contains the Twitch API key.
+const AccessTokenHeader = "Authorization"
+
+// ClientIdHeader is the name of the header that contains the client id.
+const ClientIdHeader = "Client-ID"
+
+// Service describes how the requests of a service client differ from the
+// others. The helix and kraken packages each define one.
+type Service struct {
+	// Name is the last element of the service client's package path, Ex:
+	// "helix". It names the service in the Call given to middlewares.
+	Name string
+
+	// AuthScheme prefixes the access token in the Authorization header, Ex:
+	// "Bearer".
+	AuthScheme string
+
+	// Headers are set on every request, Ex: the Accept header selecting v5
+	// of the Kraken API.
+	Headers map[string]string
+
+	// SplitParam, if set, returns the values a request parameter is sent as,
+	// Ex: SplitCommas to send a comma separated list as repeated parameters.
+	SplitParam func(value string) []string
+
+	// WrapError, if set, is given the *HTTPError of a failed response and
+	// returns the error to return instead.
+	WrapError func(*HTTPError) error
+}
+
+// SplitCommas splits a comma separated list of parameter values.
+func SplitCommas(value string) []string {
+	return strings.Split(value, ",")
+}
+
+// Client is the transport shared by the service clients, which embed it. It
+// builds requests against the Config's Endpoint, authenticated as its
+// Service requires, and sends them through the Config's middlewares.
+type Client struct {
+	// Config is the configuration the client was created with.
+	Config *Config
+
+	service *Service
+	sender  *Sender
+
+	// ctx is the context requests are made with, set by WithContext.
+	ctx context.Context
+
+	// url is the parsed URL from Endpoint
+	url *url.URL
+}
+
+// NewClient creates a client for the service with the given config. Twitch
+// requires an access token for requests, so we error if it is empty. A
+// default HTTPClient is set on config if it has none.
+func NewClient(config *Config, service *Service) (*Client, error) {
+	if config.AccessToken == "" {
+		return nil, fmt.Errorf("Access Token not specified")
+	}
+
+	if config.HTTPClient == nil {
+		config.HTTPClient = cleanhttp.DefaultClient()
+	}
+
+	u, err := url.Parse(config.Endpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	c := &Client{
+		Config:  config,
+		service: service,
+		sender:  NewSender(service.Name, config),
+		url:     u,
+	}
+	c.sender.WrapError = service.WrapError
+
+	return c, nil
+}
+
+// WithContext returns a copy of the client making its requests with ctx, Ex:
+// to cancel them, or to trace them as part of the caller's span.
+func (c *Client) WithContext(ctx context.Context) *Client {
+	c2 := *c
+	c2.ctx = ctx
+	return &c2
+}
+
+// Context returns the context requests are made with: the one given to
+// WithContext, or context.Background.
+func (c *Client) Context() context.Context {
+	if c.ctx == nil {
+		return context.Background()
+	}
+	return c.ctx
+}
+
+// Get issues an HTTP GET request.
+func (c *Client) Get(p string, ro *RequestOptions) (*http.Response, error) {
+	return c.Request("GET", p, ro)
+}
+
+// Head issues an HTTP HEAD request.
+func (c *Client) Head(p string, ro *RequestOptions) (*http.Response, error) {
+	return c.Request("HEAD", p, ro)
+}
+
+// Post issues an HTTP POST request.
+func (c *Client) Post(p string, ro *RequestOptions) (*http.Response, error) {
+	return c.Request("POST", p, ro)
+}
+
+// PostForm issues an HTTP POST request with the given interface form-encoded.
+func (c *Client) PostForm(p string, i interface{}, ro *RequestOptions) (*http.Response, error) {
+	return c.RequestForm("POST", p, i, ro)
+}
+
+// PostJSON issues an HTTP POST request with the given interface JSON-encoded.
+func (c *Client) PostJSON(p string, i interface{}, ro *RequestOptions) (*http.Response, error) {
+	return c.RequestJSON("POST", p, i, ro)
+}
+
+// Put issues an HTTP PUT request.
+func (c *Client) Put(p string, ro *RequestOptions) (*http.Response, error) {
+	return c.Request("PUT", p, ro)
+}
+
+// PutForm issues an HTTP PUT request with the given interface form-encoded.
+func (c *Client) PutForm(p string, i interface{}, ro *RequestOptions) (*http.Response, error) {
+	return c.RequestForm("PUT", p, i, ro)
+}
+
+// PutJSON issues an HTTP PUT request with the given interface JSON-encoded.
+func (c *Client) PutJSON(p string, i interface{}, ro *RequestOptions) (*http.Response, error) {
+	return c.RequestJSON("PUT", p, i, ro)
+}
+
+// Patch issues an HTTP PATCH request.
+func (c *Client) Patch(p string, ro *RequestOptions) (*http.Response, error) {
+	return c.Request("PATCH", p, ro)
+}
+
+// PatchJSON issues an HTTP PATCH request with the given interface
+// JSON-encoded.
+func (c *Client) PatchJSON(p string, i interface{}, ro *RequestOptions) (*http.Response, error) {
+	return c.RequestJSON("PATCH", p, i, ro)
+}
+
+// Delete issues an HTTP DELETE request.
+func (c *Client) Delete(p string, ro *RequestOptions) (*http.Response, error) {
+	return c.Request("DELETE", p, ro)
+}
+
+// Request makes an HTTP request against the HTTPClient using the given verb,
+// Path, and request options.
+func (c *Client) Request(verb, p string, ro *RequestOptions) (*http.Response, error) {
+	req, err := c.RawRequest(verb, p, ro)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.sender.Do(req, ro)
+}
+
+// RequestForm makes an HTTP request with the given interface being encoded as
+// form data.
+func (c *Client) RequestForm(verb, p string, i interface{}, ro *RequestOptions) (*http.Response, error) {
+	if ro == nil {
+		ro = new(RequestOptions)
+	}
+
+	if ro.Headers == nil {
+		ro.Headers = make(map[string]string)
+	}
+
+	ro.Headers["Content-Type"] = "application/x-www-form-urlencoded"
+
+	buf := new(bytes.Buffer)
+	if err := form.NewEncoder(buf).KeepZeros(true).DelimitWith('|').Encode(i); err != nil {
+		return nil, err
+	}
+	body := buf.String()
+
+	ro.Body = strings.NewReader(body)
+	ro.BodyLength = int64(len(body))
+
+	return c.Request(verb, p, ro)
+}
+
+// RequestJSON makes an HTTP request with the given interface being encoded as
+// JSON. Most Helix endpoints that create or update resources expect a JSON
+// body.
+func (c *Client) RequestJSON(verb, p string, i interface{}, ro *RequestOptions) (*http.Response, error) {
+	if ro == nil {
+		ro = new(RequestOptions)
+	}
+
+	if ro.Headers == nil {
+		ro.Headers = make(map[string]string)
+	}
+
+	ro.Headers["Content-Type"] = "application/json"
+
+	body, err := json.Marshal(i)
+	if err != nil {
+		return nil, err
+	}
+
+	ro.Body = bytes.NewReader(body)
+	ro.BodyLength = int64(len(body))
+
+	return c.Request(verb, p, ro)
+}
+
+// RawRequest accepts a verb, URL, and RequestOptions struct and returns the
+// constructed http.Request and any errors that occurred
+func (c *Client) RawRequest(verb, p string, ro *RequestOptions) (*http.Request, error) {
+	// Ensure we have request options.
+	if ro == nil {
+		ro = new(RequestOptions)
+	}
+
+	// Append the path to the URL.
+	u := *c.url
+	u.Path = strings.TrimRight(c.url.Path, "/") + "/" + strings.TrimLeft(p, "/")
+
+	// Add the token and other params.
+	var params = make(url.Values)
+	for k, v := range ro.Params {
+		if c.service.SplitParam == nil {
+			params.Add(k, v)
+			continue
+		}
+		for _, v := range c.service.SplitParam(v) {
+			params.Add(k, v)
+		}
+	}
+	u.RawQuery = params.Encode()
+
+	// Create the request object.
+	request, err := http.NewRequestWithContext(c.Context(), verb, u.String(), ro.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	// Set the Access Token
+	if c.Config.AccessToken != "" {
+		request.Header.Set(AccessTokenHeader, c.service.AuthScheme+" "+c.Config.AccessToken)
+	}
+
+	// Set the headers of the service, Ex: Kraken's Accept header.
+	for k, v := range c.service.Headers {
+		request.Header.Set(k, v)
+	}
+
+	// Set the Client Id key.
+	if c.Config.ClientId != "" {
+		request.Header.Set(ClientIdHeader, c.Config.ClientId)
+	}
+
+	// Set the User-Agent.
+	request.Header.Set("User-Agent", UserAgent)
+
+	// Add any custom headers.
+	for k, v := range ro.Headers {
+		request.Header.Add(k, v)
+	}
+
+	// Add Content-Length if we have it.
+	if ro.BodyLength > 0 {
+		request.ContentLength = ro.BodyLength
+	}
+
+	return request, nil
+}
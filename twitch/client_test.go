@@ -0,0 +1,107 @@
+package twitch
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+func TestNewClient_noAccessToken(t *testing.T) {
+	if _, err := NewClient(&Config{}, &Service{Name: "helix"}); err == nil {
+		t.Fatal("Expected an error without an access token")
+	}
+}
+
+func TestClient_RawRequest(t *testing.T) {
+	cases := []struct {
+		Label   string
+		Service *Service
+		Auth    string
+		Accept  string
+		Ids     []string
+	}{
+		{
+			Label:   "helix",
+			Service: &Service{Name: "helix", AuthScheme: "Bearer", SplitParam: SplitCommas},
+			Auth:    "Bearer token",
+			Ids:     []string{"1", "2"},
+		},
+		{
+			Label: "kraken",
+			Service: &Service{
+				Name:       "kraken",
+				AuthScheme: "OAuth",
+				Headers:    map[string]string{"Accept": "application/vnd.twitchtv.v5+json"},
+			},
+			Auth:   "OAuth token",
+			Accept: "application/vnd.twitchtv.v5+json",
+			Ids:    []string{"1,2"},
+		},
+	}
+
+	for _, c := range cases {
+		client, err := NewClient(&Config{
+			AccessToken: "token",
+			ClientId:    "client",
+			Endpoint:    "https://api.twitch.tv/" + c.Label + "/",
+		}, c.Service)
+		if err != nil {
+			t.Fatalf("%s: Error creating client: %s", c.Label, err)
+		}
+
+		req, err := client.RawRequest("GET", "/games", &RequestOptions{
+			Params:  map[string]string{"id": "1,2"},
+			Headers: map[string]string{"X-Test": "1"},
+		})
+		if err != nil {
+			t.Fatalf("%s: Error building request: %s", c.Label, err)
+		}
+
+		if req.URL.Path != "/"+c.Label+"/games" {
+			t.Errorf("%s: Unexpected path: %s", c.Label, req.URL.Path)
+		}
+		if ids := req.URL.Query()["id"]; !reflect.DeepEqual(ids, c.Ids) {
+			t.Errorf("%s: Expected ids %q, got: %q", c.Label, c.Ids, ids)
+		}
+		if v := req.Header.Get(AccessTokenHeader); v != c.Auth {
+			t.Errorf("%s: Expected Authorization %q, got: %q", c.Label, c.Auth, v)
+		}
+		if v := req.Header.Get("Accept"); v != c.Accept {
+			t.Errorf("%s: Expected Accept %q, got: %q", c.Label, c.Accept, v)
+		}
+		if v := req.Header.Get(ClientIdHeader); v != "client" {
+			t.Errorf("%s: Expected the client id, got: %q", c.Label, v)
+		}
+		if v := req.Header.Get("X-Test"); v != "1" {
+			t.Errorf("%s: Expected the custom header, got: %q", c.Label, v)
+		}
+	}
+}
+
+func TestClient_Request_wrapError(t *testing.T) {
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(404)
+		w.Write([]byte(`{"message": "no such thing"}`))
+	}))
+	defer s.Close()
+
+	notFound := errors.New("not found")
+	client, err := NewClient(&Config{AccessToken: "token", Endpoint: s.URL}, &Service{
+		Name: "helix",
+		WrapError: func(e *HTTPError) error {
+			if e.IsNotFound() {
+				return notFound
+			}
+			return e
+		},
+	})
+	if err != nil {
+		t.Fatalf("Error creating client: %s", err)
+	}
+
+	if _, err := client.Get("/things", nil); err != notFound {
+		t.Fatalf("Expected the wrapped error, got: %v", err)
+	}
+}
@@ -4,6 +4,7 @@ package twitch
 
 import (
 	"fmt"
+	"log/slog"
 	"net/http"
 	"runtime"
 )
@@ -21,6 +22,9 @@ const KrakenEndpoint = "https://api.twitch.tv/kraken/"
 const HelixEndpoint = "https://api.twitch.tv/helix/"
 const DefaultEndpoint = KrakenEndpoint
 
+// PubSubEndpoint is the WebSocket endpoint for Twitch PubSub.
+const PubSubEndpoint = "wss://pubsub-edge.twitch.tv"
+
 // ProjectURL is the url for this library.
 var ProjectURL = "github.com/catsby/go-twitch"
 
@@ -51,6 +55,18 @@ type Config struct {
 
 	// this is probably used?
 	ClientSecret string
+
+	// Middlewares wrap the HTTPClient's transport for every request the
+	// client sends. The first middleware sees a request first, Ex: put
+	// LogRequests before Retry to log each call once, or after it to log
+	// every attempt. See Middleware.
+	Middlewares []Middleware
+
+	// Logger, if set, receives a debug record for every request, with its
+	// method, path, status, latency, remaining rate limit and request id,
+	// and the warnings of the clients. Access tokens are never logged.
+	// Nothing is logged without a Logger.
+	Logger *slog.Logger
 }
 
 // DefaultClient instantiates a new Twitch API client for talking to the new
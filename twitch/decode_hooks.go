@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"net/http"
 	"reflect"
+	"strconv"
 	"time"
 
 	"github.com/mitchellh/mapstructure"
@@ -58,7 +59,79 @@ func stringToTimeHookFunc() mapstructure.DecodeHookFunc {
 			return data, nil
 		}
 
-		// Convert it by parsing
-		return time.Parse(time.RFC3339, data.(string))
+		// Twitch returns an empty string for times that are not set, such as
+		// the expiry of a permanent ban.
+		if data.(string) == "" {
+			return time.Time{}, nil
+		}
+
+		// Convert it by parsing. The teams endpoints format times the way Go
+		// prints them, Ex: 2019-02-05 05:07:16.683284 +0000 UTC.
+		parsed, err := time.Parse(time.RFC3339, data.(string))
+		if err != nil {
+			if gt, gerr := time.Parse(goTimeLayout, data.(string)); gerr == nil {
+				return gt, nil
+			}
+		}
+		return parsed, err
+	}
+}
+
+// goTimeLayout is the layout of time.Time's String method.
+const goTimeLayout = "2006-01-02 15:04:05.999999999 -0700 MST"
+
+// unixToTimeHookFunc returns a function that converts Unix timestamps to a
+// time.Time value. Some Helix endpoints, such as the ad schedule, return
+// timestamps as numbers, where zero means not set.
+func unixToTimeHookFunc() mapstructure.DecodeHookFunc {
+	return func(
+		f reflect.Type,
+		t reflect.Type,
+		data interface{}) (interface{}, error) {
+		if f.Kind() != reflect.Float64 {
+			return data, nil
+		}
+		if t != reflect.TypeOf(time.Now()) {
+			return data, nil
+		}
+
+		if data.(float64) == 0 {
+			return time.Time{}, nil
+		}
+		return time.Unix(int64(data.(float64)), 0).UTC(), nil
+	}
+}
+
+// secondsToDurationHookFunc returns a function that converts numbers of
+// seconds to a time.Duration value. Twitch returns durations, such as the
+// cooldown after running a commercial, as seconds, sometimes as a string.
+func secondsToDurationHookFunc() mapstructure.DecodeHookFunc {
+	return func(
+		f reflect.Type,
+		t reflect.Type,
+		data interface{}) (interface{}, error) {
+		if t != reflect.TypeOf(time.Duration(0)) {
+			return data, nil
+		}
+
+		var seconds float64
+		switch v := data.(type) {
+		case float64:
+			seconds = v
+		case int:
+			seconds = float64(v)
+		case string:
+			if v == "" {
+				return time.Duration(0), nil
+			}
+			var err error
+			if seconds, err = strconv.ParseFloat(v, 64); err != nil {
+				return nil, fmt.Errorf("cannot convert %q to time.Duration", v)
+			}
+		default:
+			return data, nil
+		}
+
+		return time.Duration(seconds * float64(time.Second)), nil
 	}
 }
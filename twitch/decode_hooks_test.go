@@ -0,0 +1,93 @@
+package twitch
+
+import (
+	"bytes"
+	"io/ioutil"
+	"testing"
+	"time"
+)
+
+func TestDecodeJSON_durations(t *testing.T) {
+	body := `{"retry_after": 480, "length": "60", "half": 1.5, "empty": ""}`
+
+	var out struct {
+		RetryAfter time.Duration `mapstructure:"retry_after"`
+		Length     time.Duration `mapstructure:"length"`
+		Half       time.Duration `mapstructure:"half"`
+		Empty      time.Duration `mapstructure:"empty"`
+	}
+	if err := DecodeJSON(&out, ioutil.NopCloser(bytes.NewBufferString(body))); err != nil {
+		t.Fatal(err)
+	}
+
+	if out.RetryAfter != 8*time.Minute {
+		t.Errorf("bad retry after: %s", out.RetryAfter)
+	}
+	if out.Length != time.Minute {
+		t.Errorf("bad length: %s", out.Length)
+	}
+	if out.Half != 1500*time.Millisecond {
+		t.Errorf("bad half: %s", out.Half)
+	}
+	if out.Empty != 0 {
+		t.Errorf("bad empty: %s", out.Empty)
+	}
+
+	var bad struct {
+		Length time.Duration `mapstructure:"length"`
+	}
+	err := DecodeJSON(&bad, ioutil.NopCloser(bytes.NewBufferString(`{"length": "soon"}`)))
+	if err == nil {
+		t.Errorf("expected an error for a duration that is not a number")
+	}
+}
+
+func TestDecodeJSON_unixTimes(t *testing.T) {
+	body := `{"next_ad_at": 1690931298, "last_ad_at": 0, "refresh_at": "2023-08-01T23:08:18+00:00"}`
+
+	var out struct {
+		NextAdAt  time.Time `mapstructure:"next_ad_at"`
+		LastAdAt  time.Time `mapstructure:"last_ad_at"`
+		RefreshAt time.Time `mapstructure:"refresh_at"`
+	}
+	if err := DecodeJSON(&out, ioutil.NopCloser(bytes.NewBufferString(body))); err != nil {
+		t.Fatal(err)
+	}
+
+	if !out.NextAdAt.Equal(time.Date(2023, 8, 1, 23, 8, 18, 0, time.UTC)) {
+		t.Errorf("bad next ad at: %s", out.NextAdAt)
+	}
+	if !out.LastAdAt.IsZero() {
+		t.Errorf("bad last ad at: %s", out.LastAdAt)
+	}
+	if !out.RefreshAt.Equal(out.NextAdAt) {
+		t.Errorf("bad refresh at: %s", out.RefreshAt)
+	}
+}
+
+func TestDecodeJSON_goTimes(t *testing.T) {
+	body := `{"created_at": "2019-02-05 05:07:16.683284 +0000 UTC", "updated_at": "2020-11-18 15:56:41 +0000 UTC"}`
+
+	var out struct {
+		CreatedAt time.Time `mapstructure:"created_at"`
+		UpdatedAt time.Time `mapstructure:"updated_at"`
+	}
+	if err := DecodeJSON(&out, ioutil.NopCloser(bytes.NewBufferString(body))); err != nil {
+		t.Fatal(err)
+	}
+
+	if !out.CreatedAt.Equal(time.Date(2019, 2, 5, 5, 7, 16, 683284000, time.UTC)) {
+		t.Errorf("bad created at: %s", out.CreatedAt)
+	}
+	if !out.UpdatedAt.Equal(time.Date(2020, 11, 18, 15, 56, 41, 0, time.UTC)) {
+		t.Errorf("bad updated at: %s", out.UpdatedAt)
+	}
+
+	var bad struct {
+		CreatedAt time.Time `mapstructure:"created_at"`
+	}
+	err := DecodeJSON(&bad, ioutil.NopCloser(bytes.NewBufferString(`{"created_at": "yesterday"}`)))
+	if err == nil {
+		t.Errorf("expected an error for a time that cannot be parsed")
+	}
+}
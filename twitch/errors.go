@@ -72,7 +72,7 @@ type HTTPError struct {
 	// StatusCode is the HTTP status code (2xx-5xx).
 	StatusCode int
 
-	// Message and Detail are information returned by the Fastly API.
+	// Message and Detail are information returned by the Twitch API.
 	Message string `mapstructure:"msg"`
 	Detail  string `mapstructure:"detail"`
 }
@@ -81,7 +81,16 @@ type HTTPError struct {
 func NewHTTPError(resp *http.Response) *HTTPError {
 	var e HTTPError
 	if resp.Body != nil {
-		DecodeJSON(&e, resp.Body)
+		// Helix returns the message in a "message" field rather than "msg".
+		var body struct {
+			HTTPError    `mapstructure:",squash"`
+			HelixMessage string `mapstructure:"message"`
+		}
+		DecodeJSON(&body, resp.Body)
+		e = body.HTTPError
+		if e.Message == "" {
+			e.Message = body.HelixMessage
+		}
 	}
 	e.StatusCode = resp.StatusCode
 	return &e
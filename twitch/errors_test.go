@@ -39,3 +39,19 @@ func TestNewHTTPError(t *testing.T) {
 		t.Error("not not found")
 	}
 }
+
+func TestNewHTTPError_helix(t *testing.T) {
+	resp := &http.Response{
+		StatusCode: 400,
+		Body:       ioutil.NopCloser(bytes.NewBufferString(`{"error": "Bad Request", "status": 400, "message": "The user specified in the user_id field is already banned."}`)),
+	}
+	e := NewHTTPError(resp)
+
+	if e.StatusCode != 400 {
+		t.Errorf("bad status code: %d", e.StatusCode)
+	}
+
+	if e.Message != "The user specified in the user_id field is already banned." {
+		t.Errorf("bad message: %q", e.Message)
+	}
+}
@@ -0,0 +1,25 @@
+package twitch
+
+import "time"
+
+// Bool returns a pointer to the given bool. Pointers are used by inputs that
+// only send the fields that are set, so a zero value is never sent by
+// accident.
+func Bool(v bool) *bool {
+	return &v
+}
+
+// Int returns a pointer to the given int.
+func Int(v int) *int {
+	return &v
+}
+
+// String returns a pointer to the given string.
+func String(v string) *string {
+	return &v
+}
+
+// Duration returns a pointer to the given time.Duration.
+func Duration(v time.Duration) *time.Duration {
+	return &v
+}
@@ -0,0 +1,80 @@
+package twitch
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// requestIdHeaders are the response headers a request id is read from, in
+// order.
+var requestIdHeaders = []string{"X-Request-Id", "Twitch-Trace-Id"}
+
+// discardHandler is a slog.Handler dropping every record, used when a Config
+// has no Logger so nothing is ever written to the global logger.
+type discardHandler struct{}
+
+func (discardHandler) Enabled(context.Context, slog.Level) bool  { return false }
+func (discardHandler) Handle(context.Context, slog.Record) error { return nil }
+func (h discardHandler) WithAttrs([]slog.Attr) slog.Handler      { return h }
+func (h discardHandler) WithGroup(string) slog.Handler           { return h }
+
+// loggerOrDiscard returns l, or a logger discarding every record if l is nil.
+func loggerOrDiscard(l *slog.Logger) *slog.Logger {
+	if l == nil {
+		return slog.New(discardHandler{})
+	}
+	return l
+}
+
+// Logger returns the logger of the client's Config, or one discarding every
+// record if it has none. Service clients send their warnings through it.
+func (c *Client) Logger() *slog.Logger {
+	return c.sender.logger
+}
+
+// logRequest writes a debug record of a request sent by a client.
+func (s *Sender) logRequest(req *http.Request, call *Call, resp *http.Response, err error, latency time.Duration) {
+	ctx := req.Context()
+	if !s.logger.Enabled(ctx, slog.LevelDebug) {
+		return
+	}
+	s.logger.LogAttrs(ctx, slog.LevelDebug, "twitch request", requestAttrs(req, call, resp, err, latency)...)
+}
+
+// requestAttrs returns the attributes of the record of a request. Only the
+// path of the request is logged: query parameters and headers may hold
+// credentials.
+func requestAttrs(req *http.Request, call *Call, resp *http.Response, err error, latency time.Duration) []slog.Attr {
+	attrs := []slog.Attr{
+		slog.String("call", call.Name()),
+		slog.String("method", req.Method),
+		slog.String("path", req.URL.Path),
+		slog.Duration("latency", latency),
+	}
+	if err != nil {
+		// The *url.Error of http.Client.Do includes the full URL, query and
+		// all, so only the error it wraps is logged.
+		var uerr *url.Error
+		if errors.As(err, &uerr) {
+			err = uerr.Err
+		}
+		attrs = append(attrs, slog.String("error", err.Error()))
+	}
+	if resp != nil {
+		attrs = append(attrs, slog.Int("status", resp.StatusCode))
+		if v := resp.Header.Get("Ratelimit-Remaining"); v != "" {
+			attrs = append(attrs, slog.String("ratelimit_remaining", v))
+		}
+		for _, h := range requestIdHeaders {
+			if v := resp.Header.Get(h); v != "" {
+				attrs = append(attrs, slog.String("request_id", v))
+				break
+			}
+		}
+	}
+	return attrs
+}
@@ -0,0 +1,126 @@
+package twitch
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestClient_logging(t *testing.T) {
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Ratelimit-Remaining", "799")
+		w.Header().Set("X-Request-Id", "req-1")
+		w.Write([]byte(`{}`))
+	}))
+	defer s.Close()
+
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+	client, err := NewClient(&Config{
+		AccessToken: "secret-token",
+		ClientId:    "client",
+		Endpoint:    s.URL + "/helix",
+		Logger:      logger,
+	}, &Service{Name: "helix", AuthScheme: "Bearer"})
+	if err != nil {
+		t.Fatalf("Error creating client: %s", err)
+	}
+
+	_, err = client.Get("/games", &RequestOptions{
+		Params: map[string]string{"access_token": "secret-token"},
+	})
+	if err != nil {
+		t.Fatalf("Error making request: %s", err)
+	}
+
+	if strings.Contains(buf.String(), "secret-token") {
+		t.Fatalf("Expected the token not to be logged, got: %s", buf.String())
+	}
+
+	var record map[string]interface{}
+	if err := json.Unmarshal(buf.Bytes(), &record); err != nil {
+		t.Fatalf("Error decoding record %q: %s", buf.String(), err)
+	}
+
+	expected := map[string]interface{}{
+		"level":               "DEBUG",
+		"call":                "helix",
+		"method":              "GET",
+		"path":                "/helix/games",
+		"status":              float64(200),
+		"ratelimit_remaining": "799",
+		"request_id":          "req-1",
+	}
+	for k, v := range expected {
+		if record[k] != v {
+			t.Errorf("Expected %s to be %v, got: %v", k, v, record[k])
+		}
+	}
+	if _, ok := record["latency"]; !ok {
+		t.Errorf("Expected a latency, got: %v", record)
+	}
+}
+
+func TestClient_Logger_discard(t *testing.T) {
+	client, err := NewClient(&Config{AccessToken: "token"}, &Service{Name: "helix"})
+	if err != nil {
+		t.Fatalf("Error creating client: %s", err)
+	}
+
+	// Without a Logger nothing is logged, and warnings go nowhere rather
+	// than to the global logger.
+	var buf bytes.Buffer
+	defer slog.SetDefault(slog.Default())
+	slog.SetDefault(slog.New(slog.NewTextHandler(&buf, nil)))
+
+	client.Logger().Warn("warning")
+	if buf.Len() != 0 {
+		t.Fatalf("Expected nothing to be logged, got: %s", buf.String())
+	}
+}
+
+func TestClient_logging_error(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+	client, err := NewClient(&Config{
+		AccessToken: "token",
+		Endpoint:    "https://api.twitch.tv/helix",
+		Logger:      logger,
+		Middlewares: []Middleware{
+			func(http.RoundTripper) http.RoundTripper {
+				return RoundTripperFunc(func(*http.Request) (*http.Response, error) {
+					return nil, errors.New("connection refused")
+				})
+			},
+		},
+	}, &Service{Name: "helix", AuthScheme: "Bearer"})
+	if err != nil {
+		t.Fatalf("Error creating client: %s", err)
+	}
+
+	_, err = client.Get("/games", &RequestOptions{
+		Params: map[string]string{"client_secret": "secret-value"},
+	})
+	if err == nil {
+		t.Fatal("Expected the request to fail")
+	}
+
+	if strings.Contains(buf.String(), "secret-value") {
+		t.Fatalf("Expected the query not to be logged, got: %s", buf.String())
+	}
+
+	var record map[string]interface{}
+	if err := json.Unmarshal(buf.Bytes(), &record); err != nil {
+		t.Fatalf("Error decoding record %q: %s", buf.String(), err)
+	}
+	if record["error"] != "connection refused" {
+		t.Fatalf("Expected the transport error, got: %v", record["error"])
+	}
+}
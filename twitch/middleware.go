@@ -0,0 +1,152 @@
+package twitch
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"time"
+)
+
+// Middleware wraps the http.RoundTripper that sends a client's requests, so
+// behaviour such as logging or retries can be added without replacing the
+// HTTPClient's transport. The API call a request is made for is available
+// through CallFromRequest.
+type Middleware func(next http.RoundTripper) http.RoundTripper
+
+// RoundTripperFunc adapts a function to an http.RoundTripper.
+type RoundTripperFunc func(*http.Request) (*http.Response, error)
+
+// RoundTrip calls f(req).
+func (f RoundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// Chain wraps rt in the middlewares. The first middleware is the outermost,
+// and sees a request first and its response last.
+func Chain(rt http.RoundTripper, middlewares ...Middleware) http.RoundTripper {
+	if rt == nil {
+		rt = http.DefaultTransport
+	}
+	for n := len(middlewares) - 1; n >= 0; n-- {
+		rt = middlewares[n](rt)
+	}
+	return rt
+}
+
+// Call describes the API call a request is made for.
+type Call struct {
+	// Service is the client making the call, Ex: "helix" or "kraken".
+	Service string
+
+	// Endpoint is the client method called, Ex: "GetGames", as set in the
+	// RequestOptions of its request. It is empty for requests made directly
+	// with the client's Request functions.
+	Endpoint string
+
+	// Input is the method's input, Ex: *helix.GetGamesInput. It is nil for
+	// methods without one.
+	Input interface{}
+
+	// Retries is the number of times the Retry middleware resent the
+	// request, read by middlewares placed before it once it returns.
+	Retries int
+}
+
+// Name returns the service and endpoint of the call, Ex: "helix.GetGames".
+func (c *Call) Name() string {
+	if c.Endpoint == "" {
+		return c.Service
+	}
+	return c.Service + "." + c.Endpoint
+}
+
+type callKey struct{}
+
+// WithCall returns a copy of ctx carrying call.
+func WithCall(ctx context.Context, call *Call) context.Context {
+	return context.WithValue(ctx, callKey{}, call)
+}
+
+// CallFromContext returns the call carried by ctx, or nil.
+func CallFromContext(ctx context.Context) *Call {
+	call, _ := ctx.Value(callKey{}).(*Call)
+	return call
+}
+
+// CallFromRequest returns the API call req is made for. It never returns nil,
+// so middlewares can be used with requests made outside of a client.
+func CallFromRequest(req *http.Request) *Call {
+	if call := CallFromContext(req.Context()); call != nil {
+		return call
+	}
+	return &Call{}
+}
+
+// SetHeaders returns a middleware setting the given headers on every request,
+// replacing any already set.
+func SetHeaders(headers http.Header) Middleware {
+	return func(next http.RoundTripper) http.RoundTripper {
+		return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			// A RoundTripper must not modify the request it is given.
+			req = req.Clone(req.Context())
+			for k, v := range headers {
+				req.Header[http.CanonicalHeaderKey(k)] = append([]string(nil), v...)
+			}
+			return next.RoundTrip(req)
+		})
+	}
+}
+
+// LogRequests returns a middleware writing an info record of every request
+// to logger, with the same attributes as the debug records of the Config's
+// Logger. Query parameters and headers are not logged, so credentials never
+// are. A nil logger logs nothing.
+func LogRequests(logger *slog.Logger) Middleware {
+	return func(next http.RoundTripper) http.RoundTripper {
+		if logger == nil {
+			return next
+		}
+		return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			start := time.Now()
+			resp, err := next.RoundTrip(req)
+			attrs := requestAttrs(req, CallFromRequest(req), resp, err, time.Since(start))
+			logger.LogAttrs(req.Context(), slog.LevelInfo, "twitch request", attrs...)
+			return resp, err
+		})
+	}
+}
+
+// RequestMetrics is told about every request sent through the Metrics
+// middleware.
+type RequestMetrics interface {
+	// ObserveRequest is called when a request completes. The status is zero
+	// if no response was received, and err is the transport error, not an
+	// *HTTPError.
+	ObserveRequest(call *Call, status int, latency time.Duration, err error)
+}
+
+// RequestMetricsFunc adapts a function to RequestMetrics.
+type RequestMetricsFunc func(call *Call, status int, latency time.Duration, err error)
+
+// ObserveRequest calls f.
+func (f RequestMetricsFunc) ObserveRequest(call *Call, status int, latency time.Duration, err error) {
+	f(call, status, latency, err)
+}
+
+// Metrics returns a middleware reporting every request to m. Placed after
+// Retry, every attempt is reported; placed before it, every call.
+func Metrics(m RequestMetrics) Middleware {
+	return func(next http.RoundTripper) http.RoundTripper {
+		return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			start := time.Now()
+			resp, err := next.RoundTrip(req)
+
+			status := 0
+			if resp != nil {
+				status = resp.StatusCode
+			}
+			m.ObserveRequest(CallFromRequest(req), status, time.Since(start), err)
+			return resp, err
+		})
+	}
+}
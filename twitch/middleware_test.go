@@ -0,0 +1,160 @@
+package twitch
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// respond returns a RoundTripper answering every request with the status.
+func respond(status int) http.RoundTripper {
+	return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		return &http.Response{
+			StatusCode: status,
+			Header:     make(http.Header),
+			Body:       ioutil.NopCloser(strings.NewReader("{}")),
+			Request:    req,
+		}, nil
+	})
+}
+
+func TestChain_order(t *testing.T) {
+	var order []string
+	mark := func(name string) Middleware {
+		return func(next http.RoundTripper) http.RoundTripper {
+			return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+				order = append(order, name+" in")
+				resp, err := next.RoundTrip(req)
+				order = append(order, name+" out")
+				return resp, err
+			})
+		}
+	}
+
+	rt := Chain(respond(200), mark("first"), mark("second"))
+	req := httptest.NewRequest("GET", "https://api.twitch.tv/helix/games", nil)
+	if _, err := rt.RoundTrip(req); err != nil {
+		t.Fatalf("Expected no error, got: %s", err)
+	}
+
+	expected := "first in,second in,second out,first out"
+	if got := strings.Join(order, ","); got != expected {
+		t.Fatalf("Expected %q, got: %q", expected, got)
+	}
+}
+
+func TestCallFromRequest(t *testing.T) {
+	req := httptest.NewRequest("GET", "https://api.twitch.tv/helix/games", nil)
+	if call := CallFromRequest(req); call == nil || call.Name() != "" {
+		t.Fatalf("Expected an empty call, got: %#v", call)
+	}
+
+	call := &Call{Service: "helix", Endpoint: "GetGames", Input: 1}
+	req = req.WithContext(WithCall(context.Background(), call))
+	if got := CallFromRequest(req); got != call {
+		t.Fatalf("Expected the call, got: %#v", got)
+	}
+	if call.Name() != "helix.GetGames" {
+		t.Fatalf("Expected helix.GetGames, got: %s", call.Name())
+	}
+}
+
+func TestSetHeaders(t *testing.T) {
+	var got http.Header
+	rt := Chain(RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		got = req.Header
+		return respond(200).RoundTrip(req)
+	}), SetHeaders(http.Header{"x-request-source": {"bot"}}))
+
+	req := httptest.NewRequest("GET", "https://api.twitch.tv/helix/games", nil)
+	req.Header.Set("X-Request-Source", "user")
+	if _, err := rt.RoundTrip(req); err != nil {
+		t.Fatalf("Expected no error, got: %s", err)
+	}
+
+	if v := got.Get("X-Request-Source"); v != "bot" {
+		t.Fatalf("Expected the header to be replaced, got: %q", v)
+	}
+	if v := req.Header.Get("X-Request-Source"); v != "user" {
+		t.Fatalf("Expected the original request to be unchanged, got: %q", v)
+	}
+}
+
+func TestLogRequests(t *testing.T) {
+	var buf bytes.Buffer
+	rt := Chain(respond(404), LogRequests(slog.New(slog.NewJSONHandler(&buf, nil))))
+
+	req := httptest.NewRequest("GET", "https://api.twitch.tv/helix/games?access_token=secret", nil)
+	req = req.WithContext(WithCall(req.Context(), &Call{Service: "helix", Endpoint: "GetGames"}))
+	if _, err := rt.RoundTrip(req); err != nil {
+		t.Fatalf("Expected no error, got: %s", err)
+	}
+
+	if strings.Contains(buf.String(), "secret") {
+		t.Fatalf("Expected the query not to be logged, got: %q", buf.String())
+	}
+	var record map[string]interface{}
+	if err := json.Unmarshal(buf.Bytes(), &record); err != nil {
+		t.Fatalf("Error decoding record %q: %s", buf.String(), err)
+	}
+	expected := map[string]interface{}{
+		"level":  "INFO",
+		"call":   "helix.GetGames",
+		"method": "GET",
+		"path":   "/helix/games",
+		"status": float64(404),
+	}
+	for k, v := range expected {
+		if record[k] != v {
+			t.Errorf("Expected %s to be %v, got: %v", k, v, record[k])
+		}
+	}
+}
+
+func TestLogRequests_nil(t *testing.T) {
+	rt := Chain(respond(200), LogRequests(nil))
+	req := httptest.NewRequest("GET", "https://api.twitch.tv/helix/games", nil)
+	if _, err := rt.RoundTrip(req); err != nil {
+		t.Fatalf("Expected no error, got: %s", err)
+	}
+}
+
+func TestMetrics(t *testing.T) {
+	type observation struct {
+		name   string
+		status int
+		err    error
+	}
+	var seen []observation
+	m := RequestMetricsFunc(func(call *Call, status int, latency time.Duration, err error) {
+		seen = append(seen, observation{call.Name(), status, err})
+	})
+
+	boom := errors.New("boom")
+	fail := RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		return nil, boom
+	})
+
+	req := httptest.NewRequest("GET", "https://api.twitch.tv/helix/games", nil)
+	req = req.WithContext(WithCall(req.Context(), &Call{Service: "helix", Endpoint: "GetGames"}))
+	Chain(respond(200), Metrics(m)).RoundTrip(req)
+	Chain(fail, Metrics(m)).RoundTrip(req)
+
+	if len(seen) != 2 {
+		t.Fatalf("Expected 2 observations, got: %d", len(seen))
+	}
+	if seen[0] != (observation{"helix.GetGames", 200, nil}) {
+		t.Errorf("Unexpected first observation: %#v", seen[0])
+	}
+	if seen[1] != (observation{"helix.GetGames", 0, boom}) {
+		t.Errorf("Unexpected second observation: %#v", seen[1])
+	}
+}
@@ -0,0 +1,112 @@
+package twitch
+
+import (
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// RateLimiter tracks the Helix rate limit bucket from the Ratelimit-Limit,
+// Ratelimit-Remaining and Ratelimit-Reset headers of responses, and holds
+// requests while the bucket is empty instead of sending them to be rejected
+// with a 429. Share one RateLimiter between the clients using the same token,
+// as Twitch does.
+//
+// See:
+//  - https://dev.twitch.tv/docs/api/guide#twitch-rate-limits
+type RateLimiter struct {
+	mu        sync.Mutex
+	limit     int
+	remaining int
+	reset     time.Time
+}
+
+// NewRateLimiter returns a RateLimiter that lets requests through until a
+// response tells it the state of the bucket.
+func NewRateLimiter() *RateLimiter {
+	return &RateLimiter{remaining: -1}
+}
+
+// RateLimit returns a middleware holding requests while the rate limit bucket
+// is empty, using its own RateLimiter.
+func RateLimit() Middleware {
+	return NewRateLimiter().Middleware
+}
+
+// Middleware holds a request until the bucket has a point left or has reset,
+// or the request's context is done.
+func (l *RateLimiter) Middleware(next http.RoundTripper) http.RoundTripper {
+	return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		for {
+			wait := l.take(time.Now())
+			if wait <= 0 {
+				break
+			}
+
+			timer := time.NewTimer(wait)
+			select {
+			case <-req.Context().Done():
+				timer.Stop()
+				return nil, req.Context().Err()
+			case <-timer.C:
+			}
+		}
+
+		resp, err := next.RoundTrip(req)
+		if resp != nil {
+			l.update(resp.Header)
+		}
+		return resp, err
+	})
+}
+
+// State returns the last known size of the bucket, the points remaining and
+// when it refills. The limit and remaining points are -1 when unknown.
+func (l *RateLimiter) State() (limit, remaining int, reset time.Time) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.limit == 0 {
+		return -1, l.remaining, l.reset
+	}
+	return l.limit, l.remaining, l.reset
+}
+
+// take spends a point of the bucket, or returns how long to wait for one.
+func (l *RateLimiter) take(now time.Time) time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.remaining < 0 {
+		return 0
+	}
+	if l.remaining == 0 {
+		if now.Before(l.reset) {
+			return l.reset.Sub(now)
+		}
+		// The bucket has refilled, but by how much is only known from the
+		// next response.
+		l.remaining = -1
+		return 0
+	}
+	l.remaining--
+	return 0
+}
+
+// update records the state of the bucket sent with a response.
+func (l *RateLimiter) update(h http.Header) {
+	remaining, err := strconv.Atoi(h.Get("Ratelimit-Remaining"))
+	if err != nil {
+		return
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.remaining = remaining
+	if limit, err := strconv.Atoi(h.Get("Ratelimit-Limit")); err == nil {
+		l.limit = limit
+	}
+	if reset, err := strconv.ParseInt(h.Get("Ratelimit-Reset"), 10, 64); err == nil {
+		l.reset = time.Unix(reset, 0)
+	}
+}
@@ -0,0 +1,65 @@
+package twitch
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestRateLimiter(t *testing.T) {
+	now := time.Now()
+	reset := now.Add(time.Hour)
+
+	l := NewRateLimiter()
+	if limit, remaining, _ := l.State(); limit != -1 || remaining != -1 {
+		t.Fatalf("Expected an unknown state, got: %d, %d", limit, remaining)
+	}
+	if wait := l.take(now); wait != 0 {
+		t.Fatalf("Expected no wait for an unknown bucket, got: %s", wait)
+	}
+
+	l.update(http.Header{
+		"Ratelimit-Limit":     {"800"},
+		"Ratelimit-Remaining": {"1"},
+		"Ratelimit-Reset":     {strconv.FormatInt(reset.Unix(), 10)},
+	})
+	if limit, remaining, r := l.State(); limit != 800 || remaining != 1 || r.Unix() != reset.Unix() {
+		t.Fatalf("Unexpected state: %d, %d, %s", limit, remaining, r)
+	}
+
+	if wait := l.take(now); wait != 0 {
+		t.Fatalf("Expected the last point to be taken, got a wait of %s", wait)
+	}
+	if wait := l.take(now); wait <= 0 || wait > time.Hour {
+		t.Fatalf("Expected a wait until the reset, got: %s", wait)
+	}
+	if wait := l.take(reset.Add(time.Second)); wait != 0 {
+		t.Fatalf("Expected no wait after the reset, got: %s", wait)
+	}
+}
+
+func TestRateLimiter_Middleware(t *testing.T) {
+	reset := time.Now().Add(time.Hour).Unix()
+	rt := Chain(RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		resp, _ := respond(200).RoundTrip(req)
+		resp.Header.Set("Ratelimit-Remaining", "0")
+		resp.Header.Set("Ratelimit-Reset", strconv.FormatInt(reset, 10))
+		return resp, nil
+	}), RateLimit())
+
+	req := httptest.NewRequest("GET", "https://api.twitch.tv/helix/games", nil)
+	if _, err := rt.RoundTrip(req); err != nil {
+		t.Fatalf("Expected no error, got: %s", err)
+	}
+
+	// The bucket is empty until the reset, so the next request waits until
+	// its context is done.
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, err := rt.RoundTrip(req.WithContext(ctx)); err != context.DeadlineExceeded {
+		t.Fatalf("Expected the context's error, got: %v", err)
+	}
+}
@@ -19,6 +19,12 @@ type RequestOptions struct {
 	// Request. BodyLength is the final size of the Body.
 	Body       io.Reader
 	BodyLength int64
+
+	// Endpoint is the name of the client method making the request, Ex:
+	// "GetGames", and Input its input. Both are passed to middlewares in the
+	// request's Call.
+	Endpoint string
+	Input    interface{}
 }
 
 // decodeJSON is used to decode an HTTP response body into an interface as JSON.
@@ -35,6 +41,8 @@ func DecodeJSON(out interface{}, body io.ReadCloser) error {
 		DecodeHook: mapstructure.ComposeDecodeHookFunc(
 			mapToHTTPHeaderHookFunc(),
 			stringToTimeHookFunc(),
+			unixToTimeHookFunc(),
+			secondsToDurationHookFunc(),
 		),
 		WeaklyTypedInput: true,
 		Result:           out,
@@ -43,7 +51,5 @@ func DecodeJSON(out interface{}, body io.ReadCloser) error {
 		return err
 	}
 
-	// log.Printf("\nWhat is parsed: \n\n%s\n---\n", spew.Sdump(parsed))
-	// log.Printf("\nWhat is out: \n\n%s\n---\n", spew.Sdump(out))
 	return decoder.Decode(parsed.(map[string]interface{}))
 }
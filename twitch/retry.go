@@ -0,0 +1,147 @@
+package twitch
+
+import (
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// Defaults of RetryOptions.
+const (
+	DefaultRetryAttempts = 3
+	DefaultRetryBackoff  = 500 * time.Millisecond
+	DefaultRetryMaxWait  = 30 * time.Second
+)
+
+// RetryOptions configures the Retry middleware.
+type RetryOptions struct {
+	// Attempts is the most times a request is sent, including the first.
+	// Default: DefaultRetryAttempts.
+	Attempts int
+
+	// Backoff is the wait before the first retry, doubled for each retry
+	// after it. Default: DefaultRetryBackoff.
+	Backoff time.Duration
+
+	// MaxWait caps the wait before a retry, including waits until a rate
+	// limit resets. Requests that would wait longer are not retried.
+	// Default: DefaultRetryMaxWait.
+	MaxWait time.Duration
+
+	// ShouldRetry reports whether a request is retried. Default: RetryableResponse.
+	ShouldRetry func(req *http.Request, resp *http.Response, err error) bool
+}
+
+// RetryableResponse reports whether a request should be retried: when it was
+// rate limited, or when it is idempotent and failed to send or got a 5xx
+// response.
+func RetryableResponse(req *http.Request, resp *http.Response, err error) bool {
+	if resp != nil && resp.StatusCode == http.StatusTooManyRequests {
+		return true
+	}
+
+	switch req.Method {
+	case "GET", "HEAD", "PUT", "DELETE", "OPTIONS":
+	default:
+		return false
+	}
+	return err != nil || resp.StatusCode >= 500
+}
+
+// Retry returns a middleware resending requests that fail with a rate limit,
+// a server error or a network error, as decided by o.ShouldRetry. Rate limited
+// requests wait for the Ratelimit-Reset or Retry-After header, others back off
+// exponentially. Waits end early when the request's context is done.
+func Retry(o *RetryOptions) Middleware {
+	var opts RetryOptions
+	if o != nil {
+		opts = *o
+	}
+	if opts.Attempts <= 0 {
+		opts.Attempts = DefaultRetryAttempts
+	}
+	if opts.Backoff <= 0 {
+		opts.Backoff = DefaultRetryBackoff
+	}
+	if opts.MaxWait <= 0 {
+		opts.MaxWait = DefaultRetryMaxWait
+	}
+	if opts.ShouldRetry == nil {
+		opts.ShouldRetry = RetryableResponse
+	}
+
+	return func(next http.RoundTripper) http.RoundTripper {
+		return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			backoff := opts.Backoff
+			for attempt := 1; ; attempt++ {
+				resp, err := next.RoundTrip(req)
+				if attempt == opts.Attempts || !opts.ShouldRetry(req, resp, err) {
+					return resp, err
+				}
+
+				// The body has been sent, and can only be sent again if it
+				// can be rewound.
+				if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+					return resp, err
+				}
+
+				wait := backoff
+				if reset, ok := retryAfter(resp); ok {
+					wait = reset
+				}
+				if wait > opts.MaxWait {
+					return resp, err
+				}
+				backoff *= 2
+
+				if resp != nil {
+					io.Copy(ioutil.Discard, resp.Body)
+					resp.Body.Close()
+				}
+
+				timer := time.NewTimer(wait)
+				select {
+				case <-req.Context().Done():
+					timer.Stop()
+					return nil, req.Context().Err()
+				case <-timer.C:
+				}
+
+				if call := CallFromContext(req.Context()); call != nil {
+					call.Retries++
+				}
+
+				if req.GetBody != nil {
+					body, err := req.GetBody()
+					if err != nil {
+						return nil, err
+					}
+					req = req.Clone(req.Context())
+					req.Body = body
+				}
+			}
+		})
+	}
+}
+
+// retryAfter returns how long a rate limited response asks to wait, from
+// Helix's Ratelimit-Reset header or a standard Retry-After header.
+func retryAfter(resp *http.Response) (time.Duration, bool) {
+	if resp == nil || resp.StatusCode != http.StatusTooManyRequests {
+		return 0, false
+	}
+
+	if reset, err := strconv.ParseInt(resp.Header.Get("Ratelimit-Reset"), 10, 64); err == nil {
+		wait := time.Until(time.Unix(reset, 0))
+		if wait < 0 {
+			wait = 0
+		}
+		return wait, true
+	}
+	if seconds, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil {
+		return time.Duration(seconds) * time.Second, true
+	}
+	return 0, false
+}
@@ -0,0 +1,107 @@
+package twitch
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+// sequence returns a RoundTripper answering with the statuses in turn, and
+// recording the bodies it was sent.
+func sequence(bodies *[]string, statuses ...int) http.RoundTripper {
+	n := 0
+	return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		if req.Body != nil {
+			b, _ := ioutil.ReadAll(req.Body)
+			*bodies = append(*bodies, string(b))
+		}
+		status := statuses[n]
+		if n < len(statuses)-1 {
+			n++
+		}
+		return respond(status).RoundTrip(req)
+	})
+}
+
+func TestRetry(t *testing.T) {
+	cases := []struct {
+		Label    string
+		Method   string
+		Statuses []int
+		Status   int
+		Attempts int
+	}{
+		{"success", "GET", []int{200}, 200, 1},
+		{"server error", "GET", []int{503, 502, 200}, 200, 3},
+		{"gives up", "GET", []int{500}, 500, 3},
+		{"rate limited post", "POST", []int{429, 200}, 200, 2},
+		{"server error post", "POST", []int{500, 200}, 500, 1},
+		{"client error", "GET", []int{400, 200}, 400, 1},
+	}
+
+	for _, c := range cases {
+		var bodies []string
+		rt := Chain(sequence(&bodies, c.Statuses...), Retry(&RetryOptions{Backoff: time.Millisecond}))
+
+		req, _ := http.NewRequest(c.Method, "https://api.twitch.tv/helix/raids", strings.NewReader("body"))
+		resp, err := rt.RoundTrip(req)
+		if err != nil {
+			t.Fatalf("%s: Expected no error, got: %s", c.Label, err)
+		}
+		if resp.StatusCode != c.Status {
+			t.Errorf("%s: Expected status %d, got: %d", c.Label, c.Status, resp.StatusCode)
+		}
+		if len(bodies) != c.Attempts {
+			t.Errorf("%s: Expected %d attempts, got: %d", c.Label, c.Attempts, len(bodies))
+		}
+		for _, b := range bodies {
+			if b != "body" {
+				t.Errorf("%s: Expected the body to be resent, got: %q", c.Label, b)
+			}
+		}
+	}
+}
+
+func TestRetry_rateLimitReset(t *testing.T) {
+	reset := time.Now().Add(time.Hour).Unix()
+	limited := RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		resp, _ := respond(429).RoundTrip(req)
+		resp.Header.Set("Ratelimit-Reset", strconv.FormatInt(reset, 10))
+		return resp, nil
+	})
+
+	// The wait until the reset is longer than MaxWait, so the rate limited
+	// response is returned rather than waited on.
+	rt := Chain(limited, Retry(&RetryOptions{MaxWait: time.Second}))
+	req := httptest.NewRequest("GET", "https://api.twitch.tv/helix/games", nil)
+
+	start := time.Now()
+	resp, err := rt.RoundTrip(req)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %s", err)
+	}
+	if resp.StatusCode != 429 {
+		t.Fatalf("Expected 429, got: %d", resp.StatusCode)
+	}
+	if time.Since(start) > 500*time.Millisecond {
+		t.Fatalf("Expected no wait, waited %s", time.Since(start))
+	}
+}
+
+func TestRetry_contextCanceled(t *testing.T) {
+	var bodies []string
+	rt := Chain(sequence(&bodies, 503), Retry(&RetryOptions{Backoff: time.Hour, MaxWait: 2 * time.Hour}))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	req := httptest.NewRequest("GET", "https://api.twitch.tv/helix/games", nil).WithContext(ctx)
+
+	if _, err := rt.RoundTrip(req); err != context.DeadlineExceeded {
+		t.Fatalf("Expected the context's error, got: %v", err)
+	}
+}
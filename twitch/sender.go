@@ -0,0 +1,85 @@
+package twitch
+
+import (
+	"log/slog"
+	"net/http"
+	"time"
+
+	cleanhttp "github.com/hashicorp/go-cleanhttp"
+)
+
+// Sender sends the requests built by a service client through the
+// middlewares of its Config, and checks their responses. Every request is
+// logged at the debug level to the Config's Logger. The helix and kraken
+// clients share it.
+type Sender struct {
+	// Service names the client in the Call given to middlewares, and is the
+	// last element of the client's package path, Ex: "helix".
+	Service string
+
+	// WrapError, if set, is given the *HTTPError of a failed response and
+	// returns the error to return instead, Ex: to match known errors.
+	WrapError func(*HTTPError) error
+
+	httpClient *http.Client
+	logger     *slog.Logger
+}
+
+// NewSender returns a Sender for the service client configured by config.
+// The config's HTTPClient is not modified; a copy using the middlewares is
+// made when there are any.
+func NewSender(service string, config *Config) *Sender {
+	client := config.HTTPClient
+	if client == nil {
+		client = cleanhttp.DefaultClient()
+	}
+	if len(config.Middlewares) > 0 {
+		wrapped := *client
+		wrapped.Transport = Chain(client.Transport, config.Middlewares...)
+		client = &wrapped
+	}
+
+	return &Sender{
+		Service:    service,
+		httpClient: client,
+		logger:     loggerOrDiscard(config.Logger),
+	}
+}
+
+// Do sends req, made for a call to ro's Endpoint with its Input, and returns
+// an error for a non-2xx response.
+func (s *Sender) Do(req *http.Request, ro *RequestOptions) (*http.Response, error) {
+	call := &Call{Service: s.Service}
+	if ro != nil {
+		call.Endpoint = ro.Endpoint
+		call.Input = ro.Input
+	}
+
+	req = req.WithContext(WithCall(req.Context(), call))
+	start := time.Now()
+	resp, err := s.httpClient.Do(req)
+	s.logRequest(req, call, resp, err, time.Since(start))
+
+	resp, err = CheckResponse(resp, err)
+	if herr, ok := err.(*HTTPError); ok && s.WrapError != nil {
+		err = s.WrapError(herr)
+	}
+	return resp, err
+}
+
+// CheckResponse verifies that a request was successful. A non-2xx response
+// returns an *HTTPError with the message Twitch returned.
+func CheckResponse(resp *http.Response, err error) (*http.Response, error) {
+	// If the err is already there, there was an error higher up the chain, so
+	// just return that.
+	if err != nil {
+		return resp, err
+	}
+
+	switch resp.StatusCode {
+	case 200, 201, 202, 204, 205, 206:
+		return resp, nil
+	default:
+		return resp, NewHTTPError(resp)
+	}
+}
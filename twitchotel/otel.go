@@ -0,0 +1,170 @@
+// Package twitchotel instruments the helix and kraken clients with
+// OpenTelemetry. Its middleware starts a client span for every API call,
+// named after the endpoint, Ex: helix.GetGames, and records the latency,
+// errors and retries of calls as metrics:
+//
+//	mw, err := twitchotel.Middleware(nil)
+//	...
+//	client, err := helix.NewClient(&twitch.Config{
+//		AccessToken: token,
+//		Middlewares: []twitch.Middleware{mw, twitch.Retry(nil)},
+//	})
+//	games, err := client.WithContext(ctx).GetGames(input)
+//
+// Spans are children of the span in the context given to WithContext.
+package twitchotel
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/catsby/go-twitch/twitch"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// ScopeName is the instrumentation scope of the tracer and meter.
+const ScopeName = "github.com/catsby/go-twitch/twitchotel"
+
+// Names of the metrics recorded.
+const (
+	DurationMetric = "twitch.client.request.duration"
+	ErrorsMetric   = "twitch.client.errors"
+	RetriesMetric  = "twitch.client.retries"
+)
+
+// Attribute keys of the spans and metrics, besides the OpenTelemetry HTTP
+// semantic conventions.
+const (
+	ServiceKey            = attribute.Key("twitch.service")
+	EndpointKey           = attribute.Key("twitch.endpoint")
+	RetriesKey            = attribute.Key("twitch.retries")
+	RateLimitLimitKey     = attribute.Key("twitch.ratelimit.limit")
+	RateLimitRemainingKey = attribute.Key("twitch.ratelimit.remaining")
+	RateLimitResetKey     = attribute.Key("twitch.ratelimit.reset")
+)
+
+// Options configures the Middleware.
+type Options struct {
+	// TracerProvider creates the tracer. Default: the global provider.
+	TracerProvider trace.TracerProvider
+
+	// MeterProvider creates the meter. Default: the global provider.
+	MeterProvider metric.MeterProvider
+}
+
+// Middleware returns a middleware tracing every API call and recording its
+// metrics. Put it first in the Config's Middlewares, so a call's span and
+// latency cover its retries, and its retries are counted.
+func Middleware(o *Options) (twitch.Middleware, error) {
+	var opts Options
+	if o != nil {
+		opts = *o
+	}
+	if opts.TracerProvider == nil {
+		opts.TracerProvider = otel.GetTracerProvider()
+	}
+	if opts.MeterProvider == nil {
+		opts.MeterProvider = otel.GetMeterProvider()
+	}
+
+	tracer := opts.TracerProvider.Tracer(ScopeName, trace.WithInstrumentationVersion(twitch.ProjectVersion))
+	meter := opts.MeterProvider.Meter(ScopeName, metric.WithInstrumentationVersion(twitch.ProjectVersion))
+
+	duration, err := meter.Float64Histogram(DurationMetric,
+		metric.WithUnit("s"),
+		metric.WithDescription("Duration of Twitch API calls, including retries."))
+	if err != nil {
+		return nil, err
+	}
+	failures, err := meter.Int64Counter(ErrorsMetric,
+		metric.WithUnit("{call}"),
+		metric.WithDescription("Twitch API calls that failed to send or got an error status."))
+	if err != nil {
+		return nil, err
+	}
+	retries, err := meter.Int64Counter(RetriesMetric,
+		metric.WithUnit("{retry}"),
+		metric.WithDescription("Twitch API requests resent by the Retry middleware."))
+	if err != nil {
+		return nil, err
+	}
+
+	return func(next http.RoundTripper) http.RoundTripper {
+		return twitch.RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			call := twitch.CallFromRequest(req)
+
+			name := call.Name()
+			if call.Endpoint == "" {
+				name = fmt.Sprintf("%s %s", call.Name(), req.Method)
+			}
+			attrs := []attribute.KeyValue{
+				ServiceKey.String(call.Service),
+				EndpointKey.String(call.Endpoint),
+				attribute.String("http.request.method", req.Method),
+			}
+
+			ctx, span := tracer.Start(req.Context(), name,
+				trace.WithSpanKind(trace.SpanKindClient),
+				trace.WithAttributes(attrs...),
+				trace.WithAttributes(
+					attribute.String("server.address", req.URL.Hostname()),
+					attribute.String("url.path", req.URL.Path),
+				))
+			defer span.End()
+
+			start := time.Now()
+			resp, err := next.RoundTrip(req.WithContext(ctx))
+			elapsed := time.Since(start)
+
+			if call.Retries > 0 {
+				span.SetAttributes(RetriesKey.Int(call.Retries))
+				retries.Add(ctx, int64(call.Retries), metric.WithAttributes(attrs...))
+			}
+
+			errorType := ""
+			if err != nil {
+				errorType = fmt.Sprintf("%T", err)
+				span.RecordError(err)
+				span.SetStatus(codes.Error, err.Error())
+			} else {
+				status := attribute.Int("http.response.status_code", resp.StatusCode)
+				attrs = append(attrs, status)
+				span.SetAttributes(status)
+				span.SetAttributes(rateLimitAttributes(resp.Header)...)
+				if resp.StatusCode >= 400 {
+					errorType = strconv.Itoa(resp.StatusCode)
+					span.SetStatus(codes.Error, http.StatusText(resp.StatusCode))
+				}
+			}
+
+			duration.Record(ctx, elapsed.Seconds(), metric.WithAttributes(attrs...))
+			if errorType != "" {
+				failures.Add(ctx, 1, metric.WithAttributes(append(attrs, attribute.String("error.type", errorType))...))
+			}
+
+			return resp, err
+		})
+	}, nil
+}
+
+// rateLimitAttributes returns the state of the Helix rate limit bucket sent
+// with a response.
+func rateLimitAttributes(h http.Header) []attribute.KeyValue {
+	var attrs []attribute.KeyValue
+	for key, header := range map[attribute.Key]string{
+		RateLimitLimitKey:     "Ratelimit-Limit",
+		RateLimitRemainingKey: "Ratelimit-Remaining",
+		RateLimitResetKey:     "Ratelimit-Reset",
+	} {
+		if v, err := strconv.ParseInt(h.Get(header), 10, 64); err == nil {
+			attrs = append(attrs, key.Int64(v))
+		}
+	}
+	return attrs
+}
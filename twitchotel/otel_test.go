@@ -0,0 +1,157 @@
+package twitchotel
+
+import (
+	"context"
+	"testing"
+
+	"github.com/catsby/go-twitch/service/helix"
+	"github.com/catsby/go-twitch/twitch"
+	"github.com/catsby/go-twitch/twitchtest"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// instrumented returns a helix client for s traced and measured in memory.
+func instrumented(t *testing.T, s *twitchtest.Server) (*helix.Client, *tracetest.SpanRecorder, *sdkmetric.ManualReader, trace.Tracer) {
+	spans := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(spans))
+	reader := sdkmetric.NewManualReader()
+	mp := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+
+	mw, err := Middleware(&Options{TracerProvider: tp, MeterProvider: mp})
+	if err != nil {
+		t.Fatalf("Error creating middleware: %s", err)
+	}
+
+	config := s.HelixConfig()
+	config.Middlewares = []twitch.Middleware{mw, twitch.Retry(&twitch.RetryOptions{Backoff: 1})}
+	c, err := helix.NewClient(config)
+	if err != nil {
+		t.Fatalf("Error creating client: %s", err)
+	}
+
+	return c, spans, reader, tp.Tracer("test")
+}
+
+func TestMiddleware_spans(t *testing.T) {
+	s := twitchtest.NewServer(&twitchtest.State{
+		Games: []*twitchtest.Game{{Id: "504461", Name: "Celeste"}},
+	})
+	defer s.Close()
+	c, spans, _, tracer := instrumented(t, s)
+
+	ctx, parent := tracer.Start(context.Background(), "parent")
+	s.FailNext(503)
+	if _, err := c.WithContext(ctx).GetGames(&helix.GetGamesInput{Names: []string{"Celeste"}}); err != nil {
+		t.Fatalf("Error getting games: %s", err)
+	}
+	parent.End()
+
+	ended := spans.Ended()
+	if len(ended) != 2 {
+		t.Fatalf("Expected 2 spans, got: %d", len(ended))
+	}
+	span := ended[0]
+
+	if span.Name() != "helix.GetGames" {
+		t.Errorf("Expected span helix.GetGames, got: %s", span.Name())
+	}
+	if span.SpanKind() != trace.SpanKindClient {
+		t.Errorf("Expected a client span, got: %s", span.SpanKind())
+	}
+	if span.Parent().SpanID() != parent.SpanContext().SpanID() {
+		t.Errorf("Expected the span to be a child of the context's span")
+	}
+	if span.Status().Code == codes.Error {
+		t.Errorf("Expected the retried call not to be an error, got: %v", span.Status())
+	}
+
+	attrs := make(map[attribute.Key]attribute.Value)
+	for _, kv := range span.Attributes() {
+		attrs[kv.Key] = kv.Value
+	}
+	expected := map[attribute.Key]int64{
+		"http.response.status_code": 200,
+		RetriesKey:                  1,
+		RateLimitLimitKey:           twitchtest.RateLimit,
+		RateLimitRemainingKey:       twitchtest.RateLimit - 1,
+	}
+	for k, v := range expected {
+		if attrs[k].AsInt64() != v {
+			t.Errorf("Expected %s to be %d, got: %v", k, v, attrs[k].Emit())
+		}
+	}
+	if attrs[EndpointKey].AsString() != "GetGames" {
+		t.Errorf("Expected the endpoint attribute, got: %s", attrs[EndpointKey].Emit())
+	}
+}
+
+func TestMiddleware_metrics(t *testing.T) {
+	s := twitchtest.NewServer(&twitchtest.State{
+		Games: []*twitchtest.Game{{Id: "504461", Name: "Celeste"}},
+	})
+	defer s.Close()
+	c, _, reader, _ := instrumented(t, s)
+
+	input := &helix.GetGamesInput{Names: []string{"Celeste"}}
+	s.FailNext(503)
+	c.GetGames(input)
+	s.FailNext(400)
+	c.GetGames(input)
+
+	var rm metricdata.ResourceMetrics
+	if err := reader.Collect(context.Background(), &rm); err != nil {
+		t.Fatalf("Error collecting metrics: %s", err)
+	}
+
+	found := make(map[string]metricdata.Aggregation)
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			found[m.Name] = m.Data
+		}
+	}
+
+	hist, ok := found[DurationMetric].(metricdata.Histogram[float64])
+	if !ok {
+		t.Fatalf("Expected a %s histogram, got: %T", DurationMetric, found[DurationMetric])
+	}
+	var calls uint64
+	for _, dp := range hist.DataPoints {
+		calls += dp.Count
+	}
+	if calls != 2 {
+		t.Errorf("Expected 2 calls measured, got: %d", calls)
+	}
+
+	sum := func(name string) (total int64, attrs []attribute.Set) {
+		data, ok := found[name].(metricdata.Sum[int64])
+		if !ok {
+			t.Fatalf("Expected a %s counter, got: %T", name, found[name])
+		}
+		for _, dp := range data.DataPoints {
+			total += dp.Value
+			attrs = append(attrs, dp.Attributes)
+		}
+		return total, attrs
+	}
+
+	if retries, _ := sum(RetriesMetric); retries != 1 {
+		t.Errorf("Expected 1 retry, got: %d", retries)
+	}
+
+	errors, attrs := sum(ErrorsMetric)
+	if errors != 1 {
+		t.Fatalf("Expected 1 error, got: %d", errors)
+	}
+	if v, _ := attrs[0].Value("error.type"); v.AsString() != "400" {
+		t.Errorf("Expected error.type 400, got: %s", v.Emit())
+	}
+	if v, _ := attrs[0].Value(EndpointKey); v.AsString() != "GetGames" {
+		t.Errorf("Expected the endpoint attribute, got: %s", v.Emit())
+	}
+}
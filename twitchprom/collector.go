@@ -0,0 +1,239 @@
+// Package twitchprom exposes the usage of the helix and kraken clients as
+// Prometheus metrics. Its Collector counts requests by endpoint and status,
+// measures their latency, and reports the state of the Helix rate limit
+// bucket, token refreshes and PubSub connections:
+//
+//	metrics := twitchprom.NewCollector(nil)
+//	prometheus.MustRegister(metrics)
+//
+//	client, err := helix.NewClient(&twitch.Config{
+//		AccessToken: token,
+//		Middlewares: []twitch.Middleware{twitch.Retry(nil), metrics.Middleware},
+//	})
+//
+// The chat package has no connection of its own to report; its Sender's
+// queue is reported by Sender.Stats.
+package twitchprom
+
+import (
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/catsby/go-twitch/service/pubsub"
+	"github.com/catsby/go-twitch/twitch"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Namespace prefixes the names of the metrics, Ex: twitch_requests_total.
+const Namespace = "twitch"
+
+// Options configures a Collector.
+type Options struct {
+	// Buckets of the request duration histogram, in seconds. Default:
+	// prometheus.DefBuckets.
+	Buckets []float64
+
+	// ConstLabels are added to every metric, Ex: to tell apart collectors
+	// registered for clients using different tokens.
+	ConstLabels prometheus.Labels
+}
+
+// Collector is a prometheus.Collector of the requests sent through its
+// Middleware, and of the PubSub clients it watches. Use one Collector per
+// access token, as the rate limit bucket it reports is the token's.
+type Collector struct {
+	requests  *prometheus.CounterVec
+	duration  *prometheus.HistogramVec
+	refreshes *prometheus.CounterVec
+
+	rateLimitLimit     *prometheus.Desc
+	rateLimitRemaining *prometheus.Desc
+	rateLimitReset     *prometheus.Desc
+	pubsubConns        *prometheus.Desc
+	pubsubTopics       *prometheus.Desc
+	pubsubReconnects   *prometheus.Desc
+
+	mu     sync.Mutex
+	bucket *bucket
+	pubsub map[string]*pubsub.Client
+}
+
+// bucket is the state of the Helix rate limit bucket sent with the last
+// response.
+type bucket struct {
+	limit     int
+	remaining int
+	reset     time.Time
+}
+
+// NewCollector returns a Collector with the given options. A nil o uses the
+// defaults.
+func NewCollector(o *Options) *Collector {
+	var opts Options
+	if o != nil {
+		opts = *o
+	}
+	if opts.Buckets == nil {
+		opts.Buckets = prometheus.DefBuckets
+	}
+
+	desc := func(name, help string, labels ...string) *prometheus.Desc {
+		return prometheus.NewDesc(prometheus.BuildFQName(Namespace, "", name), help, labels, opts.ConstLabels)
+	}
+
+	c := &Collector{
+		requests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace:   Namespace,
+			Name:        "requests_total",
+			Help:        "Twitch API requests sent, by endpoint and status code. The code is \"error\" when no response was received.",
+			ConstLabels: opts.ConstLabels,
+		}, []string{"service", "endpoint", "code"}),
+		duration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace:   Namespace,
+			Name:        "request_duration_seconds",
+			Help:        "Latency of Twitch API requests, by endpoint.",
+			Buckets:     opts.Buckets,
+			ConstLabels: opts.ConstLabels,
+		}, []string{"service", "endpoint"}),
+		refreshes: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace:   Namespace,
+			Name:        "token_refreshes_total",
+			Help:        "Access token refreshes, by result.",
+			ConstLabels: opts.ConstLabels,
+		}, []string{"result"}),
+
+		rateLimitLimit:     desc("helix_ratelimit_limit", "Size of the Helix rate limit bucket."),
+		rateLimitRemaining: desc("helix_ratelimit_remaining", "Points left in the Helix rate limit bucket."),
+		rateLimitReset:     desc("helix_ratelimit_reset_timestamp_seconds", "When the Helix rate limit bucket refills, as a Unix timestamp."),
+		pubsubConns:        desc("pubsub_connections", "PubSub connections, by state.", "client", "state"),
+		pubsubTopics:       desc("pubsub_topics", "PubSub topics listened to.", "client"),
+		pubsubReconnects:   desc("pubsub_reconnects_total", "PubSub connections replaced after failing or being asked to reconnect.", "client"),
+
+		pubsub: make(map[string]*pubsub.Client),
+	}
+
+	// Report both results from the start, so rates can be taken of them
+	// before the first refresh.
+	c.refreshes.WithLabelValues("success")
+	c.refreshes.WithLabelValues("error")
+
+	return c
+}
+
+// Middleware counts and times every request, and records the rate limit
+// bucket sent with its response. Placed after twitch.Retry in the Config's
+// Middlewares, every attempt is counted; placed before it, every call.
+func (c *Collector) Middleware(next http.RoundTripper) http.RoundTripper {
+	return twitch.RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		start := time.Now()
+		resp, err := next.RoundTrip(req)
+		elapsed := time.Since(start)
+
+		call := twitch.CallFromRequest(req)
+		code := "error"
+		if err == nil {
+			code = strconv.Itoa(resp.StatusCode)
+			c.update(resp.Header)
+		}
+		c.requests.WithLabelValues(call.Service, call.Endpoint, code).Inc()
+		c.duration.WithLabelValues(call.Service, call.Endpoint).Observe(elapsed.Seconds())
+
+		return resp, err
+	})
+}
+
+// ObserveTokenRefresh counts a refresh of the access token, which failed if
+// err is not nil. Tokens are refreshed by the application, so it reports
+// them.
+func (c *Collector) ObserveTokenRefresh(err error) {
+	if err != nil {
+		c.refreshes.WithLabelValues("error").Inc()
+		return
+	}
+	c.refreshes.WithLabelValues("success").Inc()
+}
+
+// WatchPubSub reports the connections of a PubSub client, labelled with name,
+// until Unwatch is called with the same name.
+func (c *Collector) WatchPubSub(name string, client *pubsub.Client) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.pubsub[name] = client
+}
+
+// Unwatch stops reporting the PubSub client watched as name.
+func (c *Collector) Unwatch(name string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.pubsub, name)
+}
+
+// Describe implements prometheus.Collector.
+func (c *Collector) Describe(ch chan<- *prometheus.Desc) {
+	c.requests.Describe(ch)
+	c.duration.Describe(ch)
+	c.refreshes.Describe(ch)
+	ch <- c.rateLimitLimit
+	ch <- c.rateLimitRemaining
+	ch <- c.rateLimitReset
+	ch <- c.pubsubConns
+	ch <- c.pubsubTopics
+	ch <- c.pubsubReconnects
+}
+
+// Collect implements prometheus.Collector. The rate limit bucket is only
+// reported once a response has told its state.
+func (c *Collector) Collect(ch chan<- prometheus.Metric) {
+	c.requests.Collect(ch)
+	c.duration.Collect(ch)
+	c.refreshes.Collect(ch)
+
+	c.mu.Lock()
+	b := c.bucket
+	clients := make(map[string]*pubsub.Client, len(c.pubsub))
+	for name, client := range c.pubsub {
+		clients[name] = client
+	}
+	c.mu.Unlock()
+
+	if b != nil {
+		if b.limit >= 0 {
+			ch <- prometheus.MustNewConstMetric(c.rateLimitLimit, prometheus.GaugeValue, float64(b.limit))
+		}
+		ch <- prometheus.MustNewConstMetric(c.rateLimitRemaining, prometheus.GaugeValue, float64(b.remaining))
+		if !b.reset.IsZero() {
+			ch <- prometheus.MustNewConstMetric(c.rateLimitReset, prometheus.GaugeValue, float64(b.reset.Unix()))
+		}
+	}
+
+	for name, client := range clients {
+		stats := client.Stats()
+		ch <- prometheus.MustNewConstMetric(c.pubsubConns, prometheus.GaugeValue, float64(stats.Connected), name, "connected")
+		ch <- prometheus.MustNewConstMetric(c.pubsubConns, prometheus.GaugeValue, float64(stats.Connections-stats.Connected), name, "reconnecting")
+		ch <- prometheus.MustNewConstMetric(c.pubsubTopics, prometheus.GaugeValue, float64(stats.Topics), name)
+		ch <- prometheus.MustNewConstMetric(c.pubsubReconnects, prometheus.CounterValue, float64(stats.Reconnects), name)
+	}
+}
+
+// update records the state of the rate limit bucket sent with a response.
+// Kraken responses have none, and are ignored.
+func (c *Collector) update(h http.Header) {
+	remaining, err := strconv.Atoi(h.Get("Ratelimit-Remaining"))
+	if err != nil {
+		return
+	}
+
+	b := &bucket{limit: -1, remaining: remaining}
+	if limit, err := strconv.Atoi(h.Get("Ratelimit-Limit")); err == nil {
+		b.limit = limit
+	}
+	if reset, err := strconv.ParseInt(h.Get("Ratelimit-Reset"), 10, 64); err == nil {
+		b.reset = time.Unix(reset, 0)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.bucket = b
+}
@@ -0,0 +1,171 @@
+package twitchprom
+
+import (
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/catsby/go-twitch/service/helix"
+	"github.com/catsby/go-twitch/service/pubsub"
+	"github.com/catsby/go-twitch/twitch"
+	"github.com/catsby/go-twitch/twitchtest"
+	"github.com/gorilla/websocket"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestCollector_requests(t *testing.T) {
+	s := twitchtest.NewServer(&twitchtest.State{
+		Games: []*twitchtest.Game{{Id: "504461", Name: "Celeste"}},
+	})
+	defer s.Close()
+
+	metrics := NewCollector(nil)
+	config := s.HelixConfig()
+	config.Middlewares = []twitch.Middleware{twitch.Retry(&twitch.RetryOptions{Backoff: 1}), metrics.Middleware}
+	c, err := helix.NewClient(config)
+	if err != nil {
+		t.Fatalf("Error creating client: %s", err)
+	}
+
+	s.FailNext(503)
+	if _, err := c.GetGames(&helix.GetGamesInput{Names: []string{"Celeste"}}); err != nil {
+		t.Fatalf("Error getting games: %s", err)
+	}
+	metrics.ObserveTokenRefresh(nil)
+	metrics.ObserveTokenRefresh(errors.New("invalid refresh token"))
+
+	expected := `
+# HELP twitch_requests_total Twitch API requests sent, by endpoint and status code. The code is "error" when no response was received.
+# TYPE twitch_requests_total counter
+twitch_requests_total{code="200",endpoint="GetGames",service="helix"} 1
+twitch_requests_total{code="503",endpoint="GetGames",service="helix"} 1
+# HELP twitch_token_refreshes_total Access token refreshes, by result.
+# TYPE twitch_token_refreshes_total counter
+twitch_token_refreshes_total{result="error"} 1
+twitch_token_refreshes_total{result="success"} 1
+`
+	if err := testutil.CollectAndCompare(metrics, strings.NewReader(expected),
+		"twitch_requests_total", "twitch_token_refreshes_total"); err != nil {
+		t.Fatal(err)
+	}
+
+	if n := testutil.CollectAndCount(metrics, "twitch_request_duration_seconds"); n != 1 {
+		t.Fatalf("Expected 1 duration histogram, got: %d", n)
+	}
+	if n := testutil.CollectAndCount(metrics, "twitch_helix_ratelimit_remaining"); n != 1 {
+		t.Fatalf("Expected the rate limit to be reported, got: %d", n)
+	}
+}
+
+func TestCollector_rateLimit(t *testing.T) {
+	metrics := NewCollector(nil)
+	if n := testutil.CollectAndCount(metrics, "twitch_helix_ratelimit_remaining"); n != 0 {
+		t.Fatalf("Expected no rate limit before a response, got: %d", n)
+	}
+
+	rt := metrics.Middleware(twitch.RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		return &http.Response{
+			StatusCode: 200,
+			Header: http.Header{
+				"Ratelimit-Limit":     []string{"800"},
+				"Ratelimit-Remaining": []string{"799"},
+				"Ratelimit-Reset":     []string{"1600000000"},
+			},
+			Body: ioutil.NopCloser(strings.NewReader("{}")),
+		}, nil
+	}))
+	req := httptest.NewRequest("GET", "https://api.twitch.tv/helix/games", nil)
+	if _, err := rt.RoundTrip(req); err != nil {
+		t.Fatalf("Error sending request: %s", err)
+	}
+
+	expected := `
+# HELP twitch_helix_ratelimit_limit Size of the Helix rate limit bucket.
+# TYPE twitch_helix_ratelimit_limit gauge
+twitch_helix_ratelimit_limit 800
+# HELP twitch_helix_ratelimit_remaining Points left in the Helix rate limit bucket.
+# TYPE twitch_helix_ratelimit_remaining gauge
+twitch_helix_ratelimit_remaining 799
+# HELP twitch_helix_ratelimit_reset_timestamp_seconds When the Helix rate limit bucket refills, as a Unix timestamp.
+# TYPE twitch_helix_ratelimit_reset_timestamp_seconds gauge
+twitch_helix_ratelimit_reset_timestamp_seconds 1.6e+09
+# HELP twitch_requests_total Twitch API requests sent, by endpoint and status code. The code is "error" when no response was received.
+# TYPE twitch_requests_total counter
+twitch_requests_total{code="200",endpoint="",service=""} 1
+`
+	if err := testutil.CollectAndCompare(metrics, strings.NewReader(expected),
+		"twitch_helix_ratelimit_limit", "twitch_helix_ratelimit_remaining",
+		"twitch_helix_ratelimit_reset_timestamp_seconds", "twitch_requests_total"); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// pubsubServer is a PubSub server accepting every LISTEN.
+func pubsubServer(t *testing.T) *httptest.Server {
+	upgrader := websocket.Upgrader{}
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ws, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			t.Errorf("Failed to upgrade: %s", err)
+			return
+		}
+		defer ws.Close()
+		for {
+			var f struct {
+				Type  string `json:"type"`
+				Nonce string `json:"nonce"`
+			}
+			if err := ws.ReadJSON(&f); err != nil {
+				return
+			}
+			if f.Type == "LISTEN" {
+				ws.WriteJSON(map[string]string{"type": "RESPONSE", "nonce": f.Nonce})
+			}
+		}
+	}))
+}
+
+func TestCollector_pubsub(t *testing.T) {
+	s := pubsubServer(t)
+	defer s.Close()
+
+	client, err := pubsub.NewClient(&pubsub.Config{
+		Endpoint:    "ws" + strings.TrimPrefix(s.URL, "http"),
+		AccessToken: "xxxxxxxxxxxxx",
+	})
+	if err != nil {
+		t.Fatalf("Error creating client: %s", err)
+	}
+	defer client.Close()
+	if err := client.Listen(pubsub.WhispersTopic("1337"), pubsub.WhispersTopic("1338")); err != nil {
+		t.Fatalf("Error listening: %s", err)
+	}
+
+	metrics := NewCollector(nil)
+	metrics.WatchPubSub("bot", client)
+
+	expected := `
+# HELP twitch_pubsub_connections PubSub connections, by state.
+# TYPE twitch_pubsub_connections gauge
+twitch_pubsub_connections{client="bot",state="connected"} 1
+twitch_pubsub_connections{client="bot",state="reconnecting"} 0
+# HELP twitch_pubsub_reconnects_total PubSub connections replaced after failing or being asked to reconnect.
+# TYPE twitch_pubsub_reconnects_total counter
+twitch_pubsub_reconnects_total{client="bot"} 0
+# HELP twitch_pubsub_topics PubSub topics listened to.
+# TYPE twitch_pubsub_topics gauge
+twitch_pubsub_topics{client="bot"} 2
+`
+	if err := testutil.CollectAndCompare(metrics, strings.NewReader(expected),
+		"twitch_pubsub_connections", "twitch_pubsub_reconnects_total", "twitch_pubsub_topics"); err != nil {
+		t.Fatal(err)
+	}
+
+	metrics.Unwatch("bot")
+	if n := testutil.CollectAndCount(metrics, "twitch_pubsub_topics"); n != 0 {
+		t.Fatalf("Expected no PubSub metrics after Unwatch, got: %d", n)
+	}
+}
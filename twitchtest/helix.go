@@ -0,0 +1,373 @@
+package twitchtest
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+)
+
+// Helix pages default to 20 objects, and allow at most 100.
+const (
+	defaultFirst = 20
+	maxFirst     = 100
+)
+
+func (s *Server) helixRoutes() {
+	s.route("GET", "/helix/users", s.helixUsers)
+	s.route("GET", "/helix/streams", s.helixStreams)
+	s.route("GET", "/helix/games", s.helixGames)
+
+	s.route("POST", "/helix/moderation/bans", s.helixBanUser)
+	s.route("DELETE", "/helix/moderation/bans", s.helixUnbanUser)
+	s.route("GET", "/helix/moderation/banned", s.helixBannedUsers)
+	s.route("GET", "/helix/channels/vips", s.helixVIPs)
+	s.route("POST", "/helix/channels/vips", s.helixAddVIP)
+	s.route("DELETE", "/helix/channels/vips", s.helixRemoveVIP)
+	s.route("GET", "/helix/moderation/blocked_terms", s.helixBlockedTerms)
+	s.route("POST", "/helix/moderation/blocked_terms", s.helixAddBlockedTerm)
+	s.route("DELETE", "/helix/moderation/blocked_terms", s.helixRemoveBlockedTerm)
+	s.route("GET", "/helix/moderation/moderators", s.helixModerators)
+	s.route("POST", "/helix/moderation/moderators", s.helixAddModerator)
+	s.route("DELETE", "/helix/moderation/moderators", s.helixRemoveModerator)
+	s.route("POST", "/helix/moderation/enforcements/status", s.helixCheckAutoMod)
+	s.route("POST", "/helix/moderation/automod/message", s.helixManageHeldMessage)
+	s.route("GET", "/helix/moderation/automod/settings", s.helixGetAutoModSettings)
+	s.route("PUT", "/helix/moderation/automod/settings", s.helixUpdateAutoModSettings)
+
+	s.route("GET", "/helix/chat/settings", s.helixChatSettings)
+	s.route("PATCH", "/helix/chat/settings", s.helixUpdateChatSettings)
+	s.route("POST", "/helix/chat/announcements", s.helixSendAnnouncement)
+	s.route("POST", "/helix/chat/shoutouts", s.helixSendShoutout)
+	s.route("POST", "/helix/chat/messages", s.helixSendChatMessage)
+	s.route("GET", "/helix/chat/chatters", s.helixChatters)
+	s.route("GET", "/helix/chat/color", s.helixChatColors)
+	s.route("PUT", "/helix/chat/color", s.helixUpdateChatColor)
+	s.route("GET", "/helix/chat/emotes", s.helixChannelEmotes)
+	s.route("GET", "/helix/chat/emotes/global", s.helixGlobalEmotes)
+	s.route("GET", "/helix/chat/emotes/set", s.helixEmoteSets)
+	s.route("GET", "/helix/chat/emotes/user", s.helixUserEmotes)
+	s.route("GET", "/helix/chat/badges", s.helixChannelBadges)
+	s.route("GET", "/helix/chat/badges/global", s.helixGlobalBadges)
+	s.route("GET", "/helix/bits/cheermotes", s.helixCheermotes)
+
+	s.route("GET", "/helix/subscriptions", s.helixSubscriptions)
+	s.route("GET", "/helix/subscriptions/user", s.helixUserSubscription)
+	s.route("GET", "/helix/bits/leaderboard", s.helixBitsLeaderboard)
+	s.route("GET", "/helix/channels/editors", s.helixEditors)
+	s.route("GET", "/helix/teams", s.helixTeams)
+	s.route("GET", "/helix/teams/channel", s.helixChannelTeams)
+	s.route("GET", "/helix/hypetrain/events", s.helixHypeTrainEvents)
+
+	s.route("GET", "/helix/streams/markers", s.helixMarkers)
+	s.route("POST", "/helix/streams/markers", s.helixCreateMarker)
+	s.route("POST", "/helix/channels/commercial", s.helixStartCommercial)
+	s.route("GET", "/helix/channels/ads", s.helixAdSchedule)
+	s.route("POST", "/helix/channels/ads/schedule/snooze", s.helixSnoozeNextAd)
+	s.route("POST", "/helix/raids", s.helixStartRaid)
+	s.route("DELETE", "/helix/raids", s.helixCancelRaid)
+
+	s.route("GET", "/helix/polls", s.helixPolls)
+	s.route("POST", "/helix/polls", s.helixCreatePoll)
+	s.route("PATCH", "/helix/polls", s.helixEndPoll)
+	s.route("GET", "/helix/predictions", s.helixPredictions)
+	s.route("POST", "/helix/predictions", s.helixCreatePrediction)
+	s.route("PATCH", "/helix/predictions", s.helixEndPrediction)
+
+	s.route("GET", "/helix/channel_points/custom_rewards", s.helixRewards)
+	s.route("POST", "/helix/channel_points/custom_rewards", s.helixCreateReward)
+	s.route("PATCH", "/helix/channel_points/custom_rewards", s.helixUpdateReward)
+	s.route("DELETE", "/helix/channel_points/custom_rewards", s.helixDeleteReward)
+	s.route("GET", "/helix/channel_points/custom_rewards/redemptions", s.helixRedemptions)
+	s.route("PATCH", "/helix/channel_points/custom_rewards/redemptions", s.helixUpdateRedemptions)
+
+	s.route("GET", "/helix/schedule", s.helixSchedule)
+	s.route("POST", "/helix/schedule/segment", s.helixCreateSegment)
+	s.route("PATCH", "/helix/schedule/segment", s.helixUpdateSegment)
+	s.route("DELETE", "/helix/schedule/segment", s.helixDeleteSegment)
+	s.route("PATCH", "/helix/schedule/settings", s.helixScheduleSettings)
+}
+
+// helixData writes a Helix response, with a pagination cursor when one is
+// given.
+func helixData(w http.ResponseWriter, data []map[string]interface{}, cursor *string) {
+	if data == nil {
+		data = []map[string]interface{}{}
+	}
+	body := map[string]interface{}{"data": data}
+	if cursor != nil {
+		p := map[string]string{}
+		if *cursor != "" {
+			p["cursor"] = *cursor
+		}
+		body["pagination"] = p
+	}
+	WriteJSON(w, http.StatusOK, body)
+}
+
+// helixPage returns the bounds of the requested page of n objects, and the
+// cursor of the next one, writing a 400 and returning false if the page
+// parameters are invalid. Pages hold at most max objects, and default to 20
+// or max if it is less.
+func helixPage(w http.ResponseWriter, r *http.Request, n, max int) (int, int, string, bool) {
+	q := r.URL.Query()
+
+	first := defaultFirst
+	if first > max {
+		first = max
+	}
+	if v := q.Get("first"); v != "" {
+		var err error
+		first, err = strconv.Atoi(v)
+		if err != nil || first < 1 || first > max {
+			badRequest(w, "The parameter \"first\" was malformed: the value must be between 1 and %d", max)
+			return 0, 0, "", false
+		}
+	}
+
+	start := 0
+	if v := q.Get("after"); v != "" {
+		offset, ok := decodeCursor(v)
+		if !ok {
+			badRequest(w, "The parameter \"after\" was malformed")
+			return 0, 0, "", false
+		}
+		start = offset
+	} else if v := q.Get("before"); v != "" {
+		offset, ok := decodeCursor(v)
+		if !ok {
+			badRequest(w, "The parameter \"before\" was malformed")
+			return 0, 0, "", false
+		}
+		start = offset - first
+		if start < 0 {
+			start = 0
+		}
+	}
+	if start > n {
+		start = n
+	}
+
+	end := start + first
+	if end > n {
+		end = n
+	}
+
+	var cursor string
+	if end < n {
+		cursor = encodeCursor(end)
+	}
+	return start, end, cursor, true
+}
+
+// Cursors are opaque to clients; the server uses the offset of the next
+// object.
+func encodeCursor(offset int) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(strconv.Itoa(offset)))
+}
+
+func decodeCursor(cursor string) (int, bool) {
+	b, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return 0, false
+	}
+	offset, err := strconv.Atoi(string(b))
+	return offset, err == nil && offset >= 0
+}
+
+// required returns the value of each of the query parameters, writing a 400
+// and returning false if one is missing.
+func required(w http.ResponseWriter, r *http.Request, names ...string) ([]string, bool) {
+	q := r.URL.Query()
+	values := make([]string, len(names))
+	for n, name := range names {
+		values[n] = q.Get(name)
+		if values[n] == "" {
+			badRequest(w, "Missing required parameter \"%s\"", name)
+			return nil, false
+		}
+	}
+	return values, true
+}
+
+// decodeBody decodes the JSON body of a request into v, writing a 400 and
+// returning false if it is malformed.
+func decodeBody(w http.ResponseWriter, r *http.Request, v interface{}) bool {
+	if err := json.NewDecoder(r.Body).Decode(v); err != nil {
+		badRequest(w, "Malformed request body: %s", err)
+		return false
+	}
+	return true
+}
+
+// noContent writes the 204 of a successful request with nothing to return.
+func noContent(w http.ResponseWriter) {
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// newId returns a random ID in the UUID format Twitch uses for most objects.
+func newId() string {
+	b := make([]byte, 16)
+	rand.Read(b)
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:])
+}
+
+// userNames returns the login and display name of the user with the ID, or
+// empty strings if it is not in the State.
+func (s *Server) userNames(id string) (string, string) {
+	if u := s.state.user(id); u != nil {
+		return u.Login, u.DisplayName
+	}
+	return "", ""
+}
+
+// set returns the values of a query parameter as a set.
+func set(values []string) map[string]bool {
+	if len(values) == 0 {
+		return nil
+	}
+	m := make(map[string]bool)
+	for _, v := range values {
+		m[v] = true
+	}
+	return m
+}
+
+func (s *Server) helixUser(u *User) map[string]interface{} {
+	return map[string]interface{}{
+		"id":                u.Id,
+		"login":             u.Login,
+		"display_name":      u.DisplayName,
+		"type":              u.Type,
+		"broadcaster_type":  u.BroadcasterType,
+		"description":       u.Description,
+		"profile_image_url": u.ProfileImageURL,
+		"view_count":        s.state.channel(u.Id).Views,
+		"created_at":        timeString(u.CreatedAt),
+	}
+}
+
+func (s *Server) helixUsers(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+	q := r.URL.Query()
+	ids, logins := q["id"], q["login"]
+	if len(ids)+len(logins) > maxFirst {
+		badRequest(w, "The number of id and login parameters must not exceed %d", maxFirst)
+		return
+	}
+	if len(ids)+len(logins) == 0 {
+		if s.state.AuthUserId == "" {
+			badRequest(w, "Must provide an ID, Login or OAuth Token")
+			return
+		}
+		ids = []string{s.state.AuthUserId}
+	}
+
+	var data []map[string]interface{}
+	for _, id := range ids {
+		if u := s.state.user(id); u != nil {
+			data = append(data, s.helixUser(u))
+		}
+	}
+	for _, login := range logins {
+		if u := s.state.userByLogin(login); u != nil {
+			data = append(data, s.helixUser(u))
+		}
+	}
+	helixData(w, data, nil)
+}
+
+func (s *Server) helixStreams(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+	q := r.URL.Query()
+	if len(q["user_id"])+len(q["user_login"]) > maxFirst {
+		badRequest(w, "The number of user_id and user_login parameters must not exceed %d", maxFirst)
+		return
+	}
+
+	userIds := set(q["user_id"])
+	for _, login := range q["user_login"] {
+		if userIds == nil {
+			userIds = map[string]bool{}
+		}
+		if u := s.state.userByLogin(login); u != nil {
+			userIds[u.Id] = true
+		}
+	}
+	gameIds := set(q["game_id"])
+	languages := set(q["language"])
+
+	var streams []*Stream
+	for _, st := range s.state.liveStreams() {
+		if userIds != nil && !userIds[st.UserId] {
+			continue
+		}
+		if gameIds != nil && !gameIds[st.GameId] {
+			continue
+		}
+		if languages != nil && !languages[st.Language] {
+			continue
+		}
+		streams = append(streams, st)
+	}
+
+	start, end, cursor, ok := helixPage(w, r, len(streams), maxFirst)
+	if !ok {
+		return
+	}
+
+	var data []map[string]interface{}
+	for _, st := range streams[start:end] {
+		u := s.state.user(st.UserId)
+		if u == nil {
+			u = &User{Id: st.UserId}
+		}
+		tags := st.Tags
+		if tags == nil {
+			tags = []string{}
+		}
+		data = append(data, map[string]interface{}{
+			"id":            st.Id,
+			"user_id":       st.UserId,
+			"user_login":    u.Login,
+			"user_name":     u.DisplayName,
+			"game_id":       st.GameId,
+			"game_name":     s.state.gameName(st.GameId),
+			"type":          "live",
+			"title":         st.Title,
+			"tags":          tags,
+			"viewer_count":  st.ViewerCount,
+			"started_at":    timeString(st.StartedAt),
+			"language":      st.Language,
+			"thumbnail_url": "https://static-cdn.jtvnw.net/previews-ttv/live_user_" + u.Login + "-{width}x{height}.jpg",
+			"is_mature":     st.IsMature,
+		})
+	}
+	helixData(w, data, &cursor)
+}
+
+func (s *Server) helixGames(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+	q := r.URL.Query()
+	if len(q["id"])+len(q["name"]) == 0 {
+		badRequest(w, "Must provide at least one id or name")
+		return
+	}
+
+	var data []map[string]interface{}
+	add := func(g *Game) {
+		if g != nil {
+			data = append(data, map[string]interface{}{
+				"id":          g.Id,
+				"name":        g.Name,
+				"box_art_url": g.BoxArtURL,
+			})
+		}
+	}
+	for _, id := range q["id"] {
+		add(s.state.game(id))
+	}
+	for _, name := range q["name"] {
+		add(s.state.gameByName(name))
+	}
+	helixData(w, data, nil)
+}
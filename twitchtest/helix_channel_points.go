@@ -0,0 +1,376 @@
+package twitchtest
+
+import (
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Redemptions are listed at most 50 to a page.
+const maxRedemptionsFirst = 50
+
+func (s *Server) helixReward(rw *Reward) map[string]interface{} {
+	login, name := s.userNames(rw.BroadcasterId)
+	return map[string]interface{}{
+		"broadcaster_id":    rw.BroadcasterId,
+		"broadcaster_login": login,
+		"broadcaster_name":  name,
+		"id":                rw.Id,
+		"title":             rw.Title,
+		"prompt":            rw.Prompt,
+		"cost":              rw.Cost,
+		"image":             nil,
+		"default_image": map[string]string{
+			"url_1x": "https://static-cdn.jtvnw.net/custom-reward-images/default-1.png",
+			"url_2x": "https://static-cdn.jtvnw.net/custom-reward-images/default-2.png",
+			"url_4x": "https://static-cdn.jtvnw.net/custom-reward-images/default-4.png",
+		},
+		"background_color":       rw.BackgroundColor,
+		"is_enabled":             !rw.IsDisabled,
+		"is_user_input_required": rw.IsUserInputRequired,
+		"max_per_stream_setting": map[string]interface{}{
+			"is_enabled":     rw.MaxPerStream > 0,
+			"max_per_stream": rw.MaxPerStream,
+		},
+		"max_per_user_per_stream_setting": map[string]interface{}{
+			"is_enabled":              rw.MaxPerUserPerStream > 0,
+			"max_per_user_per_stream": rw.MaxPerUserPerStream,
+		},
+		"global_cooldown_setting": map[string]interface{}{
+			"is_enabled":              rw.GlobalCooldown > 0,
+			"global_cooldown_seconds": int(rw.GlobalCooldown / time.Second),
+		},
+		"is_paused":                             rw.IsPaused,
+		"is_in_stock":                           true,
+		"should_redemptions_skip_request_queue": rw.ShouldRedemptionsSkipRequestQueue,
+		"redemptions_redeemed_current_stream":   nil,
+		"cooldown_expires_at":                   nil,
+	}
+}
+
+// rewardBody is the body of creating and updating a reward. Fields that are
+// not sent are nil.
+type rewardBody struct {
+	Title                             *string `json:"title"`
+	Prompt                            *string `json:"prompt"`
+	Cost                              *int    `json:"cost"`
+	BackgroundColor                   *string `json:"background_color"`
+	IsEnabled                         *bool   `json:"is_enabled"`
+	IsUserInputRequired               *bool   `json:"is_user_input_required"`
+	IsMaxPerStreamEnabled             *bool   `json:"is_max_per_stream_enabled"`
+	MaxPerStream                      *int    `json:"max_per_stream"`
+	IsMaxPerUserPerStreamEnabled      *bool   `json:"is_max_per_user_per_stream_enabled"`
+	MaxPerUserPerStream               *int    `json:"max_per_user_per_stream"`
+	IsGlobalCooldownEnabled           *bool   `json:"is_global_cooldown_enabled"`
+	GlobalCooldownSeconds             *int    `json:"global_cooldown_seconds"`
+	IsPaused                          *bool   `json:"is_paused"`
+	ShouldRedemptionsSkipRequestQueue *bool   `json:"should_redemptions_skip_request_queue"`
+}
+
+// apply sets the fields of the reward that are in the body.
+func (b *rewardBody) apply(rw *Reward) {
+	if b.Title != nil {
+		rw.Title = *b.Title
+	}
+	if b.Prompt != nil {
+		rw.Prompt = *b.Prompt
+	}
+	if b.Cost != nil {
+		rw.Cost = *b.Cost
+	}
+	if b.BackgroundColor != nil {
+		rw.BackgroundColor = *b.BackgroundColor
+	}
+	if b.IsEnabled != nil {
+		rw.IsDisabled = !*b.IsEnabled
+	}
+	if b.IsUserInputRequired != nil {
+		rw.IsUserInputRequired = *b.IsUserInputRequired
+	}
+	if b.IsPaused != nil {
+		rw.IsPaused = *b.IsPaused
+	}
+	if b.ShouldRedemptionsSkipRequestQueue != nil {
+		rw.ShouldRedemptionsSkipRequestQueue = *b.ShouldRedemptionsSkipRequestQueue
+	}
+
+	if b.MaxPerStream != nil {
+		rw.MaxPerStream = *b.MaxPerStream
+	}
+	if b.IsMaxPerStreamEnabled != nil && !*b.IsMaxPerStreamEnabled {
+		rw.MaxPerStream = 0
+	}
+	if b.MaxPerUserPerStream != nil {
+		rw.MaxPerUserPerStream = *b.MaxPerUserPerStream
+	}
+	if b.IsMaxPerUserPerStreamEnabled != nil && !*b.IsMaxPerUserPerStreamEnabled {
+		rw.MaxPerUserPerStream = 0
+	}
+	if b.GlobalCooldownSeconds != nil {
+		rw.GlobalCooldown = time.Duration(*b.GlobalCooldownSeconds) * time.Second
+	}
+	if b.IsGlobalCooldownEnabled != nil && !*b.IsGlobalCooldownEnabled {
+		rw.GlobalCooldown = 0
+	}
+}
+
+// reward returns the reward of a channel, or nil.
+func (st *State) reward(broadcasterId, id string) *Reward {
+	for _, rw := range st.Rewards {
+		if rw.BroadcasterId == broadcasterId && rw.Id == id {
+			return rw
+		}
+	}
+	return nil
+}
+
+// manageableReward returns the reward of a channel, writing a 404 if it does
+// not exist or a 403 if it was created by another client.
+func (s *Server) manageableReward(w http.ResponseWriter, broadcasterId, id string) *Reward {
+	rw := s.state.reward(broadcasterId, id)
+	if rw == nil {
+		WriteError(w, http.StatusNotFound, "The custom reward was not found")
+		return nil
+	}
+	if rw.ClientId != s.ClientId {
+		WriteError(w, http.StatusForbidden, "The ID in header Client-Id must match the client ID used to create the custom reward.")
+		return nil
+	}
+	return rw
+}
+
+// duplicateReward reports whether another reward of the channel has the
+// title.
+func (st *State) duplicateReward(rw *Reward, title string) bool {
+	for _, o := range st.Rewards {
+		if o != rw && o.BroadcasterId == rw.BroadcasterId && strings.EqualFold(o.Title, title) {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *Server) helixRewards(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+	p, ok := required(w, r, "broadcaster_id")
+	if !ok {
+		return
+	}
+	q := r.URL.Query()
+	ids := set(q["id"])
+	manageable := q.Get("only_manageable_rewards") == "true"
+
+	var data []map[string]interface{}
+	for _, rw := range s.state.Rewards {
+		if rw.BroadcasterId != p[0] || (ids != nil && !ids[rw.Id]) {
+			continue
+		}
+		if manageable && rw.ClientId != s.ClientId {
+			continue
+		}
+		data = append(data, s.helixReward(rw))
+	}
+	helixData(w, data, nil)
+}
+
+func (s *Server) helixCreateReward(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+	p, ok := required(w, r, "broadcaster_id")
+	if !ok {
+		return
+	}
+	var body rewardBody
+	if !decodeBody(w, r, &body) {
+		return
+	}
+	if body.Title == nil || *body.Title == "" || body.Cost == nil {
+		badRequest(w, "Missing required field \"title\" or \"cost\"")
+		return
+	}
+	if *body.Cost < 1 {
+		badRequest(w, "The cost field must be at least 1")
+		return
+	}
+
+	rw := &Reward{
+		Id:            newId(),
+		BroadcasterId: p[0],
+		ClientId:      s.ClientId,
+	}
+	if s.state.duplicateReward(rw, *body.Title) {
+		badRequest(w, "CREATE_CUSTOM_REWARD_DUPLICATE_REWARD")
+		return
+	}
+	body.apply(rw)
+	s.state.Rewards = append(s.state.Rewards, rw)
+
+	helixData(w, []map[string]interface{}{s.helixReward(rw)}, nil)
+}
+
+func (s *Server) helixUpdateReward(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+	p, ok := required(w, r, "broadcaster_id", "id")
+	if !ok {
+		return
+	}
+	var body rewardBody
+	if !decodeBody(w, r, &body) {
+		return
+	}
+	rw := s.manageableReward(w, p[0], p[1])
+	if rw == nil {
+		return
+	}
+	if body.Title != nil && s.state.duplicateReward(rw, *body.Title) {
+		badRequest(w, "UPDATE_CUSTOM_REWARD_DUPLICATE_REWARD")
+		return
+	}
+	if body.Cost != nil && *body.Cost < 1 {
+		badRequest(w, "The cost field must be at least 1")
+		return
+	}
+	body.apply(rw)
+
+	helixData(w, []map[string]interface{}{s.helixReward(rw)}, nil)
+}
+
+func (s *Server) helixDeleteReward(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+	p, ok := required(w, r, "broadcaster_id", "id")
+	if !ok {
+		return
+	}
+	rw := s.manageableReward(w, p[0], p[1])
+	if rw == nil {
+		return
+	}
+
+	for n, o := range s.state.Rewards {
+		if o == rw {
+			s.state.Rewards = append(s.state.Rewards[:n], s.state.Rewards[n+1:]...)
+			break
+		}
+	}
+	// Deleting a reward fulfills its redemptions that were not.
+	for _, rd := range s.state.Redemptions {
+		if rd.RewardId == rw.Id && redemptionStatus(rd) == "UNFULFILLED" {
+			rd.Status = "FULFILLED"
+		}
+	}
+	noContent(w)
+}
+
+// redemptionStatus returns the status of a redemption, UNFULFILLED if it was
+// seeded without one.
+func redemptionStatus(rd *Redemption) string {
+	if rd.Status == "" {
+		return "UNFULFILLED"
+	}
+	return rd.Status
+}
+
+func (s *Server) helixRedemption(rd *Redemption, rw *Reward) map[string]interface{} {
+	login, name := s.userNames(rd.BroadcasterId)
+	userLogin, userName := s.userNames(rd.UserId)
+	return map[string]interface{}{
+		"broadcaster_id":    rd.BroadcasterId,
+		"broadcaster_login": login,
+		"broadcaster_name":  name,
+		"id":                rd.Id,
+		"user_id":           rd.UserId,
+		"user_login":        userLogin,
+		"user_name":         userName,
+		"user_input":        rd.UserInput,
+		"status":            redemptionStatus(rd),
+		"redeemed_at":       timeString(rd.RedeemedAt),
+		"reward": map[string]interface{}{
+			"id":     rw.Id,
+			"title":  rw.Title,
+			"prompt": rw.Prompt,
+			"cost":   rw.Cost,
+		},
+	}
+}
+
+func (s *Server) helixRedemptions(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+	p, ok := required(w, r, "broadcaster_id", "reward_id")
+	if !ok {
+		return
+	}
+	q := r.URL.Query()
+	ids, status := set(q["id"]), q.Get("status")
+	if ids == nil && status == "" {
+		badRequest(w, "Must provide either status or id")
+		return
+	}
+	rw := s.manageableReward(w, p[0], p[1])
+	if rw == nil {
+		return
+	}
+
+	var redemptions []*Redemption
+	for _, rd := range s.state.Redemptions {
+		if rd.BroadcasterId != p[0] || rd.RewardId != rw.Id {
+			continue
+		}
+		if (ids != nil && !ids[rd.Id]) || (status != "" && redemptionStatus(rd) != status) {
+			continue
+		}
+		redemptions = append(redemptions, rd)
+	}
+	newest := q.Get("sort") == "NEWEST"
+	sort.SliceStable(redemptions, func(i, j int) bool {
+		if newest {
+			return redemptions[i].RedeemedAt.After(redemptions[j].RedeemedAt)
+		}
+		return redemptions[i].RedeemedAt.Before(redemptions[j].RedeemedAt)
+	})
+
+	start, end, cursor, ok := helixPage(w, r, len(redemptions), maxRedemptionsFirst)
+	if !ok {
+		return
+	}
+
+	var data []map[string]interface{}
+	for _, rd := range redemptions[start:end] {
+		data = append(data, s.helixRedemption(rd, rw))
+	}
+	helixData(w, data, &cursor)
+}
+
+func (s *Server) helixUpdateRedemptions(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+	p, ok := required(w, r, "broadcaster_id", "reward_id", "id")
+	if !ok {
+		return
+	}
+	ids := set(r.URL.Query()["id"])
+	var body struct {
+		Status string `json:"status"`
+	}
+	if !decodeBody(w, r, &body) {
+		return
+	}
+	if body.Status != "FULFILLED" && body.Status != "CANCELED" {
+		badRequest(w, "The status field must be FULFILLED or CANCELED")
+		return
+	}
+	rw := s.manageableReward(w, p[0], p[1])
+	if rw == nil {
+		return
+	}
+
+	// Only unfulfilled redemptions can be updated; the others are left out.
+	var data []map[string]interface{}
+	for _, rd := range s.state.Redemptions {
+		if rd.BroadcasterId != p[0] || rd.RewardId != rw.Id || !ids[rd.Id] {
+			continue
+		}
+		if redemptionStatus(rd) != "UNFULFILLED" {
+			continue
+		}
+		rd.Status = body.Status
+		data = append(data, s.helixRedemption(rd, rw))
+	}
+	if len(data) == 0 {
+		WriteError(w, http.StatusNotFound, "No unfulfilled redemptions were found")
+		return
+	}
+	helixData(w, data, nil)
+}
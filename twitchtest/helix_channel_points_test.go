@@ -0,0 +1,182 @@
+package twitchtest_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/catsby/go-twitch/service/helix"
+	"github.com/catsby/go-twitch/twitch"
+	"github.com/catsby/go-twitch/twitchtest"
+)
+
+func TestHelix_customRewards(t *testing.T) {
+	t.Parallel()
+
+	st := seed()
+	st.Rewards = []*twitchtest.Reward{
+		{Id: "other", BroadcasterId: "1", Title: "Hydrate", Cost: 100, ClientId: "another-client"},
+	}
+	s := twitchtest.NewServer(st)
+	defer s.Close()
+
+	c, err := helix.NewClient(s.HelixConfig())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	o, err := c.CreateCustomReward(&helix.CreateCustomRewardInput{
+		BroadcasterId:  "1",
+		Title:          "Song request",
+		Cost:           500,
+		MaxPerStream:   10,
+		GlobalCooldown: time.Minute,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	rw := o.Rewards[0]
+	if rw.Cost != 500 || !rw.IsEnabled || !rw.MaxPerStreamSetting.IsEnabled || rw.GlobalCooldownSetting.Cooldown() != time.Minute {
+		t.Fatalf("Bad reward: %#v", rw)
+	}
+	if _, err := c.CreateCustomReward(&helix.CreateCustomRewardInput{BroadcasterId: "1", Title: "HYDRATE", Cost: 1}); err == nil {
+		t.Fatalf("Expected an error for a duplicate title")
+	}
+
+	o, err = c.UpdateCustomReward(&helix.UpdateCustomRewardInput{
+		BroadcasterId: "1",
+		Id:            rw.Id,
+		IsPaused:      twitch.Bool(true),
+		MaxPerStream:  twitch.Int(0),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !o.Rewards[0].IsPaused || o.Rewards[0].MaxPerStreamSetting.IsEnabled || o.Rewards[0].Cost != 500 {
+		t.Fatalf("Bad updated reward: %#v", o.Rewards[0])
+	}
+
+	// Rewards created by another client can be read but not changed.
+	_, err = c.UpdateCustomReward(&helix.UpdateCustomRewardInput{BroadcasterId: "1", Id: "other", IsPaused: twitch.Bool(true)})
+	if err == nil {
+		t.Fatalf("Expected an error updating another client's reward")
+	}
+	o, err = c.GetCustomReward(&helix.GetCustomRewardInput{BroadcasterId: "1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(o.Rewards) != 2 {
+		t.Fatalf("Expected 2 rewards, got %d", len(o.Rewards))
+	}
+	o, err = c.GetCustomReward(&helix.GetCustomRewardInput{BroadcasterId: "1", OnlyManageableRewards: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(o.Rewards) != 1 || o.Rewards[0].Id != rw.Id {
+		t.Fatalf("Expected only the created reward, got %#v", o.Rewards)
+	}
+
+	if err := c.DeleteCustomReward(&helix.DeleteCustomRewardInput{BroadcasterId: "1", Id: "other"}); err == nil {
+		t.Fatalf("Expected an error deleting another client's reward")
+	}
+	if err := c.DeleteCustomReward(&helix.DeleteCustomRewardInput{BroadcasterId: "1", Id: rw.Id}); err != nil {
+		t.Fatal(err)
+	}
+	o, err = c.GetCustomReward(&helix.GetCustomRewardInput{BroadcasterId: "1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(o.Rewards) != 1 || o.Rewards[0].Id != "other" {
+		t.Fatalf("Expected the reward to be deleted, got %#v", o.Rewards)
+	}
+}
+
+func TestHelix_redemptions(t *testing.T) {
+	t.Parallel()
+
+	redeemed := time.Date(2021, 6, 1, 12, 0, 0, 0, time.UTC)
+	st := seed()
+	st.Rewards = []*twitchtest.Reward{
+		{Id: "song", BroadcasterId: "1", Title: "Song request", Cost: 500, ClientId: twitchtest.DefaultClientId},
+	}
+	for n, id := range []string{"r1", "r2", "r3"} {
+		st.Redemptions = append(st.Redemptions, &twitchtest.Redemption{
+			Id:            id,
+			BroadcasterId: "1",
+			RewardId:      "song",
+			UserId:        "2",
+			UserInput:     "never gonna give you up",
+			RedeemedAt:    redeemed.Add(time.Duration(n) * time.Minute),
+		})
+	}
+	st.Redemptions[2].Status = "FULFILLED"
+	s := twitchtest.NewServer(st)
+	defer s.Close()
+
+	c, err := helix.NewClient(s.HelixConfig())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var ids []string
+	in := &helix.GetCustomRewardRedemptionInput{
+		BroadcasterId: "1",
+		RewardId:      "song",
+		Status:        helix.RedemptionStatusUnfulfilled,
+		Sort:          "NEWEST",
+		First:         1,
+	}
+	for {
+		o, err := c.GetCustomRewardRedemption(in)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, rd := range o.Redemptions {
+			if rd.UserLogin != "afro" || rd.Reward.Title != "Song request" || rd.RedeemedAt.IsZero() {
+				t.Fatalf("Bad redemption: %#v", rd)
+			}
+			ids = append(ids, rd.Id)
+		}
+		if o.Pagination.Cursor == "" {
+			break
+		}
+		in.After = o.Pagination.Cursor
+	}
+	if len(ids) != 2 || ids[0] != "r2" || ids[1] != "r1" {
+		t.Fatalf("Expected the unfulfilled redemptions newest first, got %v", ids)
+	}
+
+	// Redemptions that are not unfulfilled are left out.
+	o, err := c.UpdateRedemptionStatus(&helix.UpdateRedemptionStatusInput{
+		BroadcasterId: "1",
+		RewardId:      "song",
+		Ids:           []string{"r1", "r3"},
+		Status:        helix.RedemptionStatusCanceled,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(o.Redemptions) != 1 || o.Redemptions[0].Id != "r1" || o.Redemptions[0].Status != helix.RedemptionStatusCanceled {
+		t.Fatalf("Bad updated redemptions: %#v", o.Redemptions)
+	}
+	_, err = c.UpdateRedemptionStatus(&helix.UpdateRedemptionStatusInput{
+		BroadcasterId: "1",
+		RewardId:      "song",
+		Ids:           []string{"r3"},
+		Status:        helix.RedemptionStatusCanceled,
+	})
+	if err == nil {
+		t.Fatalf("Expected an error with no unfulfilled redemptions")
+	}
+
+	g, err := c.GetCustomRewardRedemption(&helix.GetCustomRewardRedemptionInput{
+		BroadcasterId: "1",
+		RewardId:      "song",
+		Status:        helix.RedemptionStatusCanceled,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(g.Redemptions) != 1 || g.Redemptions[0].Id != "r1" {
+		t.Fatalf("Expected r1 to be canceled, got %#v", g.Redemptions)
+	}
+}
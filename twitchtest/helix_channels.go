@@ -0,0 +1,404 @@
+package twitchtest
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// subscriptionPoints are the points each subscription tier is worth.
+var subscriptionPoints = map[string]int{
+	"1000": 1,
+	"2000": 2,
+	"3000": 6,
+}
+
+func subscriptionTier(sub *Subscription) string {
+	if sub.Tier == "" {
+		return "1000"
+	}
+	return sub.Tier
+}
+
+func (s *Server) helixSubscription(sub *Subscription) map[string]interface{} {
+	login, name := s.userNames(sub.UserId)
+	broadcasterLogin, broadcasterName := s.userNames(sub.BroadcasterId)
+	gifterLogin, gifterName := s.userNames(sub.GifterId)
+	return map[string]interface{}{
+		"broadcaster_id":    sub.BroadcasterId,
+		"broadcaster_login": broadcasterLogin,
+		"broadcaster_name":  broadcasterName,
+		"is_gift":           sub.GifterId != "",
+		"gifter_id":         sub.GifterId,
+		"gifter_login":      gifterLogin,
+		"gifter_name":       gifterName,
+		"tier":              subscriptionTier(sub),
+		"plan_name":         sub.PlanName,
+		"user_id":           sub.UserId,
+		"user_login":        login,
+		"user_name":         name,
+	}
+}
+
+// helixSubscriptions counts the total and points over every subscription of
+// the channel, not only the users asked for.
+func (s *Server) helixSubscriptions(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+	p, ok := required(w, r, "broadcaster_id")
+	if !ok {
+		return
+	}
+	userIds := set(r.URL.Query()["user_id"])
+	if len(userIds) > maxFirst {
+		badRequest(w, "The number of user_id parameters must not exceed %d", maxFirst)
+		return
+	}
+
+	var subs []*Subscription
+	total, points := 0, 0
+	for _, sub := range s.state.Subscriptions {
+		if sub.BroadcasterId != p[0] {
+			continue
+		}
+		total++
+		points += subscriptionPoints[subscriptionTier(sub)]
+		if userIds == nil || userIds[sub.UserId] {
+			subs = append(subs, sub)
+		}
+	}
+
+	start, end, cursor, ok := helixPage(w, r, len(subs), maxFirst)
+	if !ok {
+		return
+	}
+
+	data := []map[string]interface{}{}
+	for _, sub := range subs[start:end] {
+		data = append(data, s.helixSubscription(sub))
+	}
+	pagination := map[string]string{}
+	if cursor != "" {
+		pagination["cursor"] = cursor
+	}
+	WriteJSON(w, http.StatusOK, map[string]interface{}{
+		"data":       data,
+		"pagination": pagination,
+		"total":      total,
+		"points":     points,
+	})
+}
+
+func (s *Server) helixUserSubscription(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+	p, ok := required(w, r, "broadcaster_id", "user_id")
+	if !ok {
+		return
+	}
+	for _, sub := range s.state.Subscriptions {
+		if sub.BroadcasterId == p[0] && sub.UserId == p[1] {
+			helixData(w, []map[string]interface{}{s.helixSubscription(sub)}, nil)
+			return
+		}
+	}
+	login, _ := s.userNames(p[1])
+	broadcasterLogin, _ := s.userNames(p[0])
+	WriteError(w, http.StatusNotFound, fmt.Sprintf("%s has no subscription to %s", login, broadcasterLogin))
+}
+
+// leaderboardPeriod returns the bounds of the leaderboard period holding t,
+// in UTC. The all period has none.
+func leaderboardPeriod(period string, t time.Time) (time.Time, time.Time) {
+	t = t.UTC()
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	switch period {
+	case "day":
+		return day, day.AddDate(0, 0, 1)
+	case "week":
+		start := day.AddDate(0, 0, -(int(day.Weekday())+6)%7)
+		return start, start.AddDate(0, 0, 7)
+	case "month":
+		start := time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+		return start, start.AddDate(0, 1, 0)
+	case "year":
+		start := time.Date(t.Year(), 1, 1, 0, 0, 0, 0, time.UTC)
+		return start, start.AddDate(1, 0, 0)
+	}
+	return time.Time{}, time.Time{}
+}
+
+// helixBitsLeaderboard ranks the cheers in the channel of the AuthUserId.
+// With a user_id, the page of count leaders is centered on that user.
+func (s *Server) helixBitsLeaderboard(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+	q := r.URL.Query()
+	count := 10
+	if v := q.Get("count"); v != "" {
+		var err error
+		count, err = strconv.Atoi(v)
+		if err != nil || count < 1 || count > maxFirst {
+			badRequest(w, "The parameter \"count\" was malformed: the value must be between 1 and %d", maxFirst)
+			return
+		}
+	}
+	period := q.Get("period")
+	switch period {
+	case "":
+		period = "all"
+	case "day", "week", "month", "year", "all":
+	default:
+		badRequest(w, "The parameter \"period\" must be day, week, month, year or all")
+		return
+	}
+	at := time.Now()
+	if v := q.Get("started_at"); v != "" {
+		var err error
+		at, err = time.Parse(time.RFC3339, v)
+		if err != nil {
+			badRequest(w, "The parameter \"started_at\" was malformed")
+			return
+		}
+	}
+	start, end := leaderboardPeriod(period, at)
+
+	scores := make(map[string]int)
+	for _, c := range s.state.Cheers {
+		if c.BroadcasterId != s.state.AuthUserId {
+			continue
+		}
+		if period != "all" && (c.CheeredAt.Before(start) || !c.CheeredAt.Before(end)) {
+			continue
+		}
+		scores[c.UserId] += c.Bits
+	}
+	var leaders []string
+	for id := range scores {
+		leaders = append(leaders, id)
+	}
+	sort.Slice(leaders, func(i, j int) bool {
+		if scores[leaders[i]] != scores[leaders[j]] {
+			return scores[leaders[i]] > scores[leaders[j]]
+		}
+		return leaders[i] < leaders[j]
+	})
+
+	from := 0
+	if userId := q.Get("user_id"); userId != "" {
+		n := -1
+		for i, id := range leaders {
+			if id == userId {
+				n = i
+				break
+			}
+		}
+		if n < 0 {
+			leaders = nil
+		} else {
+			from = n - (count-1)/2
+			if from+count > len(leaders) {
+				from = len(leaders) - count
+			}
+			if from < 0 {
+				from = 0
+			}
+		}
+	}
+	to := from + count
+	if to > len(leaders) {
+		to = len(leaders)
+	}
+
+	data := []map[string]interface{}{}
+	for n, id := range leaders[from:to] {
+		login, name := s.userNames(id)
+		data = append(data, map[string]interface{}{
+			"user_id":    id,
+			"user_login": login,
+			"user_name":  name,
+			"rank":       from + n + 1,
+			"score":      scores[id],
+		})
+	}
+	WriteJSON(w, http.StatusOK, map[string]interface{}{
+		"data": data,
+		"date_range": map[string]string{
+			"started_at": timeString(start),
+			"ended_at":   timeString(end),
+		},
+		"total": len(data),
+	})
+}
+
+func (s *Server) helixEditors(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+	p, ok := required(w, r, "broadcaster_id")
+	if !ok {
+		return
+	}
+	var data []map[string]interface{}
+	for _, e := range s.state.Editors {
+		if e.BroadcasterId == p[0] {
+			_, name := s.userNames(e.UserId)
+			data = append(data, map[string]interface{}{
+				"user_id":    e.UserId,
+				"user_name":  name,
+				"created_at": timeString(e.CreatedAt),
+			})
+		}
+	}
+	helixData(w, data, nil)
+}
+
+func helixTeam(t *Team) map[string]interface{} {
+	return map[string]interface{}{
+		"id":                   t.Id,
+		"team_name":            t.Name,
+		"team_display_name":    t.DisplayName,
+		"info":                 t.Info,
+		"thumbnail_url":        t.ThumbnailURL,
+		"background_image_url": t.BackgroundImageURL,
+		"banner":               t.Banner,
+		"created_at":           timeString(t.CreatedAt),
+		"updated_at":           timeString(t.UpdatedAt),
+	}
+}
+
+func (s *Server) helixTeams(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+	q := r.URL.Query()
+	name, id := q.Get("name"), q.Get("id")
+	if (name == "") == (id == "") {
+		badRequest(w, "Must provide exactly one of name or id")
+		return
+	}
+
+	var data []map[string]interface{}
+	for _, t := range s.state.Teams {
+		if (name != "" && !strings.EqualFold(t.Name, name)) || (id != "" && t.Id != id) {
+			continue
+		}
+		users := []map[string]interface{}{}
+		for _, userId := range t.UserIds {
+			login, userName := s.userNames(userId)
+			users = append(users, map[string]interface{}{
+				"user_id":    userId,
+				"user_name":  userName,
+				"user_login": login,
+			})
+		}
+		team := helixTeam(t)
+		team["users"] = users
+		data = append(data, team)
+	}
+	helixData(w, data, nil)
+}
+
+func (s *Server) helixChannelTeams(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+	p, ok := required(w, r, "broadcaster_id")
+	if !ok {
+		return
+	}
+	login, name := s.userNames(p[0])
+
+	var data []map[string]interface{}
+	for _, t := range s.state.Teams {
+		for _, userId := range t.UserIds {
+			if userId != p[0] {
+				continue
+			}
+			team := helixTeam(t)
+			team["broadcaster_id"] = p[0]
+			team["broadcaster_login"] = login
+			team["broadcaster_name"] = name
+			data = append(data, team)
+			break
+		}
+	}
+	helixData(w, data, nil)
+}
+
+// helixHypeTrainContributions returns the last contribution to a Hype Train,
+// and the top contributor of Bits and of subscriptions.
+func helixHypeTrainContributions(t *HypeTrain) (interface{}, []map[string]interface{}) {
+	contribution := func(userId, typ string, total int) map[string]interface{} {
+		return map[string]interface{}{
+			"user":  userId,
+			"type":  typ,
+			"total": total,
+		}
+	}
+
+	var last interface{}
+	if n := len(t.Contributions); n > 0 {
+		c := t.Contributions[n-1]
+		last = contribution(c.UserId, c.Type, c.Total)
+	}
+
+	top := []map[string]interface{}{}
+	for _, typ := range []string{"BITS", "SUBS"} {
+		totals := make(map[string]int)
+		best := ""
+		for _, c := range t.Contributions {
+			if c.Type != typ {
+				continue
+			}
+			totals[c.UserId] += c.Total
+			if best == "" || totals[c.UserId] > totals[best] {
+				best = c.UserId
+			}
+		}
+		if best != "" {
+			top = append(top, contribution(best, typ, totals[best]))
+		}
+	}
+	return last, top
+}
+
+// helixHypeTrainEvents lists the channel's Hype Trains newest first, one
+// progression event each.
+func (s *Server) helixHypeTrainEvents(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+	p, ok := required(w, r, "broadcaster_id")
+	if !ok {
+		return
+	}
+
+	var trains []*HypeTrain
+	for _, t := range s.state.HypeTrains {
+		if t.BroadcasterId == p[0] {
+			trains = append(trains, t)
+		}
+	}
+	sort.SliceStable(trains, func(i, j int) bool {
+		return trains[i].StartedAt.After(trains[j].StartedAt)
+	})
+
+	start, end, cursor, ok := helixPage(w, r, len(trains), maxFirst)
+	if !ok {
+		return
+	}
+
+	var data []map[string]interface{}
+	for _, t := range trains[start:end] {
+		total := 0
+		for _, c := range t.Contributions {
+			total += c.Total
+		}
+		last, top := helixHypeTrainContributions(t)
+		data = append(data, map[string]interface{}{
+			"id":              fmt.Sprintf("%s-%d", t.Id, len(t.Contributions)),
+			"event_type":      "hypetrain.progression",
+			"event_timestamp": timeString(t.StartedAt),
+			"version":         "1.0",
+			"event_data": map[string]interface{}{
+				"id":                t.Id,
+				"broadcaster_id":    t.BroadcasterId,
+				"level":             t.Level,
+				"goal":              t.Goal,
+				"total":             total,
+				"last_contribution": last,
+				"top_contributions": top,
+				"started_at":        timeString(t.StartedAt),
+				"expires_at":        timeString(t.ExpiresAt),
+				"cooldown_end_time": timeString(t.CooldownEndTime),
+			},
+		})
+	}
+	helixData(w, data, &cursor)
+}
@@ -0,0 +1,224 @@
+package twitchtest_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/catsby/go-twitch/service/helix"
+	"github.com/catsby/go-twitch/twitchtest"
+)
+
+func TestHelix_subscriptions(t *testing.T) {
+	t.Parallel()
+
+	st := seed()
+	st.Subscriptions = []*twitchtest.Subscription{
+		{BroadcasterId: "1", UserId: "2", PlanName: "Channel Subscription (catsby)"},
+		{BroadcasterId: "1", UserId: "3", Tier: "3000", GifterId: "2"},
+		{BroadcasterId: "1", UserId: "4", Tier: "2000"},
+		{BroadcasterId: "2", UserId: "1"},
+	}
+	s := twitchtest.NewServer(st)
+	defer s.Close()
+
+	c, err := helix.NewClient(s.HelixConfig())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var subs []*helix.Subscription
+	in := &helix.GetBroadcasterSubscriptionsInput{BroadcasterId: "1", First: 2}
+	for {
+		o, err := c.GetBroadcasterSubscriptions(in)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if o.Total != 3 || o.Points != 9 {
+			t.Fatalf("Bad total and points: %d %d", o.Total, o.Points)
+		}
+		subs = append(subs, o.Subscriptions...)
+		if o.Pagination.Cursor == "" {
+			break
+		}
+		in.After = o.Pagination.Cursor
+	}
+	if len(subs) != 3 || subs[0].Tier != helix.SubscriptionTier1 || subs[0].BroadcasterLogin != "catsby" {
+		t.Fatalf("Bad subscriptions: %#v", subs)
+	}
+	if !subs[1].IsGift || subs[1].GifterLogin != "afro" || subs[1].UserName != "LIRIK" {
+		t.Fatalf("Bad gift: %#v", subs[1])
+	}
+
+	o, err := c.GetBroadcasterSubscriptions(&helix.GetBroadcasterSubscriptionsInput{BroadcasterId: "1", UserIds: []string{"4"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(o.Subscriptions) != 1 || o.Subscriptions[0].Tier != helix.SubscriptionTier2 {
+		t.Fatalf("Bad filtered subscriptions: %#v", o.Subscriptions)
+	}
+
+	u, err := c.CheckUserSubscription(&helix.CheckUserSubscriptionInput{BroadcasterId: "2", UserId: "1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(u.Subscriptions) != 1 || u.Subscriptions[0].BroadcasterName != "Afro" {
+		t.Fatalf("Bad subscription: %#v", u.Subscriptions)
+	}
+	_, err = c.CheckUserSubscription(&helix.CheckUserSubscriptionInput{BroadcasterId: "3", UserId: "1"})
+	if !errors.Is(err, helix.ErrNotSubscribed) {
+		t.Fatalf("Expected ErrNotSubscribed, got %v", err)
+	}
+}
+
+func TestHelix_bitsLeaderboard(t *testing.T) {
+	t.Parallel()
+
+	// Wednesday, June 16 2021.
+	day := time.Date(2021, 6, 16, 12, 0, 0, 0, time.UTC)
+	st := seed()
+	st.Cheers = []*twitchtest.Cheer{
+		{BroadcasterId: "1", UserId: "2", Bits: 100, CheeredAt: day},
+		{BroadcasterId: "1", UserId: "3", Bits: 500, CheeredAt: day.AddDate(0, 0, -1)},
+		{BroadcasterId: "1", UserId: "2", Bits: 300, CheeredAt: day.AddDate(0, 0, -3)},
+		{BroadcasterId: "1", UserId: "4", Bits: 50, CheeredAt: day.AddDate(0, -1, 0)},
+		{BroadcasterId: "2", UserId: "3", Bits: 10000, CheeredAt: day},
+	}
+	s := twitchtest.NewServer(st)
+	defer s.Close()
+
+	c, err := helix.NewClient(s.HelixConfig())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	o, err := c.GetBitsLeaderboard(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if o.Total != 3 || o.Leaders[0].UserLogin != "lirik" || o.Leaders[0].Score != 500 || o.Leaders[1].Score != 400 || o.Leaders[2].Rank != 3 {
+		t.Fatalf("Bad leaderboard: %#v", o.Leaders)
+	}
+
+	// The week starts on Monday, June 14, so the cheer on Sunday is left out.
+	o, err = c.GetBitsLeaderboard(&helix.GetBitsLeaderboardInput{Period: helix.BitsLeaderboardWeek, StartedAt: day})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(o.Leaders) != 2 || o.Leaders[0].Score != 500 || o.Leaders[1].Score != 100 {
+		t.Fatalf("Bad weekly leaderboard: %#v", o.Leaders)
+	}
+	if want := time.Date(2021, 6, 14, 0, 0, 0, 0, time.UTC); !o.DateRange.StartedAt.Equal(want) || !o.DateRange.EndedAt.Equal(want.AddDate(0, 0, 7)) {
+		t.Fatalf("Bad date range: %#v", o.DateRange)
+	}
+
+	o, err = c.GetBitsLeaderboard(&helix.GetBitsLeaderboardInput{Count: 1, UserId: "4"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(o.Leaders) != 1 || o.Leaders[0].UserId != "4" || o.Leaders[0].Rank != 3 {
+		t.Fatalf("Expected the leaderboard around offline, got %#v", o.Leaders)
+	}
+}
+
+func TestHelix_teams(t *testing.T) {
+	t.Parallel()
+
+	created := time.Date(2019, 2, 5, 0, 0, 0, 0, time.UTC)
+	st := seed()
+	st.Teams = []*twitchtest.Team{
+		{Id: "6358", Name: "livecoders", DisplayName: "Live Coders", CreatedAt: created, UserIds: []string{"1", "2", "4"}},
+		{Id: "7000", Name: "gta", DisplayName: "GTA", UserIds: []string{"2"}},
+	}
+	st.Editors = []*twitchtest.Editor{{BroadcasterId: "1", UserId: "2", CreatedAt: created}}
+	s := twitchtest.NewServer(st)
+	defer s.Close()
+
+	c, err := helix.NewClient(s.HelixConfig())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	m, err := c.GetTeamMembers(&helix.GetTeamMembersInput{Name: "LiveCoders"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if m.Team.TeamDisplayName != "Live Coders" || !m.Team.CreatedAt.Equal(created) || len(m.Members) != 3 {
+		t.Fatalf("Bad team: %#v", m)
+	}
+	if !m.Members[0].Live() || !m.Members[1].Live() || m.Members[2].Live() || m.Members[1].UserLogin != "afro" {
+		t.Fatalf("Expected catsby and afro to be live, got %#v", m.Members)
+	}
+
+	o, err := c.GetChannelTeams(&helix.GetChannelTeamsInput{BroadcasterId: "2"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(o.Teams) != 2 || o.Teams[1].TeamName != "gta" || o.Teams[1].BroadcasterName != "Afro" {
+		t.Fatalf("Bad channel teams: %#v", o.Teams)
+	}
+
+	e, err := c.GetChannelEditors(&helix.GetChannelEditorsInput{BroadcasterId: "1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(e.Editors) != 1 || e.Editors[0].UserName != "Afro" || !e.Editors[0].CreatedAt.Equal(created) {
+		t.Fatalf("Bad editors: %#v", e.Editors)
+	}
+}
+
+func TestHelix_hypeTrainEvents(t *testing.T) {
+	t.Parallel()
+
+	started := time.Date(2020, 4, 24, 20, 0, 0, 0, time.UTC)
+	st := seed()
+	st.HypeTrains = []*twitchtest.HypeTrain{
+		{Id: "old", BroadcasterId: "1", Level: 1, Goal: 1600, StartedAt: started.AddDate(0, 0, -7)},
+		{
+			Id:            "new",
+			BroadcasterId: "1",
+			Level:         2,
+			Goal:          1800,
+			StartedAt:     started,
+			Contributions: []*twitchtest.HypeTrainContribution{
+				{UserId: "2", Type: "BITS", Total: 300},
+				{UserId: "3", Type: "BITS", Total: 500},
+				{UserId: "2", Type: "BITS", Total: 300},
+				{UserId: "4", Type: "SUBS", Total: 500},
+			},
+		},
+	}
+	s := twitchtest.NewServer(st)
+	defer s.Close()
+
+	c, err := helix.NewClient(s.HelixConfig())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	o, err := c.GetHypeTrainEvents(&helix.GetHypeTrainEventsInput{BroadcasterId: "1", First: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(o.Events) != 1 || o.Pagination.Cursor == "" {
+		t.Fatalf("Bad first page: %#v", o)
+	}
+	train := o.Events[0].EventData
+	if train.Id != "new" || train.Total != 1600 || !train.StartedAt.Equal(started) {
+		t.Fatalf("Bad train: %#v", train)
+	}
+	if train.LastContribution == nil || train.LastContribution.Type != helix.HypeTrainContributionSubs {
+		t.Fatalf("Bad last contribution: %#v", train.LastContribution)
+	}
+	if len(train.TopContributions) != 2 || train.TopContributions[0].User != "2" || train.TopContributions[0].Total != 600 {
+		t.Fatalf("Expected afro to be the top Bits contributor, got %#v", train.TopContributions)
+	}
+
+	o, err = c.GetHypeTrainEvents(&helix.GetHypeTrainEventsInput{BroadcasterId: "1", After: o.Pagination.Cursor})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(o.Events) != 1 || o.Events[0].EventData.Id != "old" || o.Events[0].EventData.LastContribution != nil {
+		t.Fatalf("Bad last page: %#v", o.Events)
+	}
+}
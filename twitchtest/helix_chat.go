@@ -0,0 +1,372 @@
+package twitchtest
+
+import (
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// Shoutouts are limited to one every 2 minutes, and one an hour to the same
+// channel.
+const (
+	shoutoutCooldown       = 2 * time.Minute
+	shoutoutTargetCooldown = time.Hour
+)
+
+// maxChatters is the largest page of chatters.
+const maxChatters = 1000
+
+// chatColors are the hex values of the named chat colors.
+var chatColors = map[string]string{
+	"blue":         "#0000FF",
+	"blue_violet":  "#8A2BE2",
+	"cadet_blue":   "#5F9EA0",
+	"chocolate":    "#D2691E",
+	"coral":        "#FF7F50",
+	"dodger_blue":  "#1E90FF",
+	"firebrick":    "#B22222",
+	"golden_rod":   "#DAA520",
+	"green":        "#008000",
+	"hot_pink":     "#FF69B4",
+	"orange_red":   "#FF4500",
+	"red":          "#FF0000",
+	"sea_green":    "#2E8B57",
+	"spring_green": "#00FF7F",
+	"yellow_green": "#9ACD32",
+}
+
+var hexColorRe = regexp.MustCompile(`^#[0-9a-fA-F]{6}$`)
+
+// chatSettings returns the chat settings of a channel, or new ones with
+// every mode off.
+func (st *State) chatSettings(broadcasterId string) *ChatSettings {
+	for _, c := range st.ChatSettings {
+		if c.BroadcasterId == broadcasterId {
+			return c
+		}
+	}
+	return &ChatSettings{BroadcasterId: broadcasterId}
+}
+
+// modeDuration returns the duration of a chat mode in whole units, or nil
+// when the mode is off.
+func modeDuration(on bool, d, unit time.Duration) interface{} {
+	if !on {
+		return nil
+	}
+	return int(d / unit)
+}
+
+// helixChatSettings leaves out the non-moderator chat delay unless a
+// moderator asks, as Twitch does.
+func helixChatSettings(c *ChatSettings, moderatorId string) map[string]interface{} {
+	m := map[string]interface{}{
+		"broadcaster_id":         c.BroadcasterId,
+		"slow_mode":              c.SlowMode,
+		"slow_mode_wait_time":    modeDuration(c.SlowMode, c.SlowModeWaitTime, time.Second),
+		"follower_mode":          c.FollowerMode,
+		"follower_mode_duration": modeDuration(c.FollowerMode, c.FollowerModeDuration, time.Minute),
+		"subscriber_mode":        c.SubscriberMode,
+		"emote_mode":             c.EmoteMode,
+		"unique_chat_mode":       c.UniqueChatMode,
+	}
+	if moderatorId != "" {
+		m["moderator_id"] = moderatorId
+		m["non_moderator_chat_delay"] = c.NonModeratorChatDelay
+		m["non_moderator_chat_delay_duration"] = modeDuration(c.NonModeratorChatDelay, c.NonModeratorChatDelayDuration, time.Second)
+	}
+	return m
+}
+
+func (s *Server) helixChatSettings(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+	p, ok := required(w, r, "broadcaster_id")
+	if !ok {
+		return
+	}
+	c := s.state.chatSettings(p[0])
+	helixData(w, []map[string]interface{}{helixChatSettings(c, r.URL.Query().Get("moderator_id"))}, nil)
+}
+
+// helixUpdateChatSettings turns a mode on when its duration is set, and
+// defaults the slow mode wait time to 30 seconds.
+func (s *Server) helixUpdateChatSettings(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+	p, ok := required(w, r, "broadcaster_id", "moderator_id")
+	if !ok {
+		return
+	}
+	var body struct {
+		SlowMode                      *bool `json:"slow_mode"`
+		SlowModeWaitTime              *int  `json:"slow_mode_wait_time"`
+		FollowerMode                  *bool `json:"follower_mode"`
+		FollowerModeDuration          *int  `json:"follower_mode_duration"`
+		SubscriberMode                *bool `json:"subscriber_mode"`
+		EmoteMode                     *bool `json:"emote_mode"`
+		UniqueChatMode                *bool `json:"unique_chat_mode"`
+		NonModeratorChatDelay         *bool `json:"non_moderator_chat_delay"`
+		NonModeratorChatDelayDuration *int  `json:"non_moderator_chat_delay_duration"`
+	}
+	if !decodeBody(w, r, &body) {
+		return
+	}
+	if v := body.SlowModeWaitTime; v != nil && (*v < 3 || *v > 120) {
+		badRequest(w, "The slow_mode_wait_time field must be between 3 and 120")
+		return
+	}
+	if v := body.FollowerModeDuration; v != nil && (*v < 0 || *v > 129600) {
+		badRequest(w, "The follower_mode_duration field must be between 0 and 129600")
+		return
+	}
+	if v := body.NonModeratorChatDelayDuration; v != nil && *v != 2 && *v != 4 && *v != 6 {
+		badRequest(w, "The non_moderator_chat_delay_duration field must be 2, 4 or 6")
+		return
+	}
+
+	c := s.state.chatSettings(p[0])
+	if body.NonModeratorChatDelay != nil && *body.NonModeratorChatDelay &&
+		body.NonModeratorChatDelayDuration == nil && c.NonModeratorChatDelayDuration == 0 {
+		badRequest(w, "The non_moderator_chat_delay_duration field is required to turn on the chat delay")
+		return
+	}
+	flags := []struct {
+		v *bool
+		f *bool
+	}{
+		{body.SlowMode, &c.SlowMode},
+		{body.FollowerMode, &c.FollowerMode},
+		{body.SubscriberMode, &c.SubscriberMode},
+		{body.EmoteMode, &c.EmoteMode},
+		{body.UniqueChatMode, &c.UniqueChatMode},
+		{body.NonModeratorChatDelay, &c.NonModeratorChatDelay},
+	}
+	for _, fl := range flags {
+		if fl.v != nil {
+			*fl.f = *fl.v
+		}
+	}
+	if v := body.SlowModeWaitTime; v != nil {
+		c.SlowMode = true
+		c.SlowModeWaitTime = time.Duration(*v) * time.Second
+	} else if c.SlowMode && c.SlowModeWaitTime == 0 {
+		c.SlowModeWaitTime = 30 * time.Second
+	}
+	if v := body.FollowerModeDuration; v != nil {
+		c.FollowerMode = true
+		c.FollowerModeDuration = time.Duration(*v) * time.Minute
+	}
+	if v := body.NonModeratorChatDelayDuration; v != nil {
+		c.NonModeratorChatDelay = true
+		c.NonModeratorChatDelayDuration = time.Duration(*v) * time.Second
+	}
+
+	if s.state.chatSettings(p[0]) != c {
+		s.state.ChatSettings = append(s.state.ChatSettings, c)
+	}
+	helixData(w, []map[string]interface{}{helixChatSettings(c, p[1])}, nil)
+}
+
+func (s *Server) helixSendAnnouncement(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+	p, ok := required(w, r, "broadcaster_id", "moderator_id")
+	if !ok {
+		return
+	}
+	var body struct {
+		Message string `json:"message"`
+		Color   string `json:"color"`
+	}
+	if !decodeBody(w, r, &body) {
+		return
+	}
+	if n := len([]rune(body.Message)); n == 0 || n > 500 {
+		badRequest(w, "The message field must be between 1 and 500 characters")
+		return
+	}
+	switch body.Color {
+	case "":
+		body.Color = "primary"
+	case "primary", "blue", "green", "orange", "purple":
+	default:
+		badRequest(w, "The color field must be blue, green, orange, purple or primary")
+		return
+	}
+
+	s.state.Announcements = append(s.state.Announcements, &Announcement{
+		BroadcasterId: p[0],
+		ModeratorId:   p[1],
+		Message:       body.Message,
+		Color:         body.Color,
+	})
+	noContent(w)
+}
+
+// helixSendShoutout requires the channel giving the Shoutout to be live, and
+// enforces Twitch's cooldowns with a 429.
+func (s *Server) helixSendShoutout(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+	p, ok := required(w, r, "from_broadcaster_id", "to_broadcaster_id", "moderator_id")
+	if !ok {
+		return
+	}
+	if p[0] == p[1] {
+		badRequest(w, "The broadcaster may not give themselves a Shoutout.")
+		return
+	}
+	if s.state.stream(p[0]) == nil {
+		badRequest(w, "The broadcaster is not streaming live or does not have one or more viewers.")
+		return
+	}
+
+	now := time.Now().UTC().Truncate(time.Second)
+	for _, so := range s.state.Shoutouts {
+		if so.FromBroadcasterId != p[0] {
+			continue
+		}
+		if now.Sub(so.CreatedAt) < shoutoutCooldown ||
+			(so.ToBroadcasterId == p[1] && now.Sub(so.CreatedAt) < shoutoutTargetCooldown) {
+			WriteError(w, http.StatusTooManyRequests, "The broadcaster may not send a Shoutout to this channel yet.")
+			return
+		}
+	}
+
+	s.state.Shoutouts = append(s.state.Shoutouts, &Shoutout{
+		FromBroadcasterId: p[0],
+		ToBroadcasterId:   p[1],
+		ModeratorId:       p[2],
+		CreatedAt:         now,
+	})
+	noContent(w)
+}
+
+// helixSendChatMessage drops the messages of banned and timed out users.
+func (s *Server) helixSendChatMessage(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+	var body struct {
+		BroadcasterId        string `json:"broadcaster_id"`
+		SenderId             string `json:"sender_id"`
+		Message              string `json:"message"`
+		ReplyParentMessageId string `json:"reply_parent_message_id"`
+	}
+	if !decodeBody(w, r, &body) {
+		return
+	}
+	if body.BroadcasterId == "" || body.SenderId == "" || body.Message == "" {
+		badRequest(w, "Missing required field \"broadcaster_id\", \"sender_id\" or \"message\"")
+		return
+	}
+	if len([]rune(body.Message)) > 500 {
+		badRequest(w, "The message field must be at most 500 characters")
+		return
+	}
+
+	now := time.Now().UTC().Truncate(time.Second)
+	if s.state.activeBan(body.BroadcasterId, body.SenderId, now) >= 0 {
+		helixData(w, []map[string]interface{}{{
+			"message_id": "",
+			"is_sent":    false,
+			"drop_reason": map[string]interface{}{
+				"code":    "msg_banned",
+				"message": "You are banned from talking in this channel.",
+			},
+		}}, nil)
+		return
+	}
+
+	m := &ChatMessage{
+		Id:                   newId(),
+		BroadcasterId:        body.BroadcasterId,
+		SenderId:             body.SenderId,
+		Message:              body.Message,
+		ReplyParentMessageId: body.ReplyParentMessageId,
+		SentAt:               now,
+	}
+	s.state.ChatMessages = append(s.state.ChatMessages, m)
+	helixData(w, []map[string]interface{}{{
+		"message_id":  m.Id,
+		"is_sent":     true,
+		"drop_reason": nil,
+	}}, nil)
+}
+
+func (s *Server) helixChatters(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+	p, ok := required(w, r, "broadcaster_id", "moderator_id")
+	if !ok {
+		return
+	}
+
+	var chatters []*Chatter
+	for _, c := range s.state.Chatters {
+		if c.BroadcasterId == p[0] {
+			chatters = append(chatters, c)
+		}
+	}
+
+	start, end, cursor, ok := helixPage(w, r, len(chatters), maxChatters)
+	if !ok {
+		return
+	}
+
+	var data []map[string]interface{}
+	for _, c := range chatters[start:end] {
+		login, name := s.userNames(c.UserId)
+		data = append(data, map[string]interface{}{
+			"user_id":    c.UserId,
+			"user_login": login,
+			"user_name":  name,
+		})
+	}
+	if data == nil {
+		data = []map[string]interface{}{}
+	}
+	pagination := map[string]string{}
+	if cursor != "" {
+		pagination["cursor"] = cursor
+	}
+	WriteJSON(w, http.StatusOK, map[string]interface{}{
+		"data":       data,
+		"pagination": pagination,
+		"total":      len(chatters),
+	})
+}
+
+func (s *Server) helixChatColors(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+	ids := r.URL.Query()["user_id"]
+	if len(ids) == 0 || len(ids) > maxFirst {
+		badRequest(w, "The number of user_id parameters must be between 1 and %d", maxFirst)
+		return
+	}
+
+	var data []map[string]interface{}
+	for _, id := range ids {
+		if u := s.state.user(id); u != nil {
+			data = append(data, map[string]interface{}{
+				"user_id":    u.Id,
+				"user_login": u.Login,
+				"user_name":  u.DisplayName,
+				"color":      u.ChatColor,
+			})
+		}
+	}
+	helixData(w, data, nil)
+}
+
+// helixUpdateChatColor stores named colors as their hex value, as Twitch
+// returns them.
+func (s *Server) helixUpdateChatColor(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+	p, ok := required(w, r, "user_id", "color")
+	if !ok {
+		return
+	}
+	color, named := chatColors[p[1]]
+	if !named {
+		if !hexColorRe.MatchString(p[1]) {
+			badRequest(w, "The color query parameter is not valid: %q", p[1])
+			return
+		}
+		color = strings.ToUpper(p[1])
+	}
+	u := s.state.user(p[0])
+	if u == nil {
+		badRequest(w, "The user in the user_id query parameter was not found")
+		return
+	}
+	u.ChatColor = color
+	noContent(w)
+}
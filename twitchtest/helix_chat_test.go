@@ -0,0 +1,213 @@
+package twitchtest_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/catsby/go-twitch/service/helix"
+	"github.com/catsby/go-twitch/twitch"
+	"github.com/catsby/go-twitch/twitchtest"
+)
+
+func TestHelix_chatSettings(t *testing.T) {
+	t.Parallel()
+
+	s := twitchtest.NewServer(seed())
+	defer s.Close()
+
+	c, err := helix.NewClient(s.HelixConfig())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = c.UpdateChatSettings(&helix.UpdateChatSettingsInput{
+		BroadcasterId:         "1",
+		ModeratorId:           "1",
+		SlowMode:              twitch.Bool(true),
+		FollowerModeDuration:  twitch.Int(10),
+		NonModeratorChatDelay: twitch.Bool(true),
+	})
+	if err == nil {
+		t.Fatalf("Expected an error turning on the chat delay without a duration")
+	}
+
+	o, err := c.UpdateChatSettings(&helix.UpdateChatSettingsInput{
+		BroadcasterId:                 "1",
+		ModeratorId:                   "1",
+		SlowMode:                      twitch.Bool(true),
+		FollowerModeDuration:          twitch.Int(10),
+		NonModeratorChatDelayDuration: twitch.Int(4),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	cs := o.Settings[0]
+	if !cs.SlowMode || cs.SlowModeWaitTime == nil || *cs.SlowModeWaitTime != 30 {
+		t.Fatalf("Expected the default slow mode wait time, got %#v", cs)
+	}
+	if !cs.FollowerMode || *cs.FollowerModeDuration != 10 || !cs.NonModeratorChatDelay || *cs.NonModeratorChatDelayDuration != 4 {
+		t.Fatalf("Bad settings: %#v", cs)
+	}
+
+	if _, err := c.UpdateChatSettings(&helix.UpdateChatSettingsInput{BroadcasterId: "1", ModeratorId: "1", SlowMode: twitch.Bool(false)}); err != nil {
+		t.Fatal(err)
+	}
+
+	// The chat delay is only returned to moderators.
+	g, err := c.GetChatSettings(&helix.GetChatSettingsInput{BroadcasterId: "1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	cs = g.Settings[0]
+	if cs.SlowMode || cs.SlowModeWaitTime != nil || !cs.FollowerMode || cs.NonModeratorChatDelay || cs.NonModeratorChatDelayDuration != nil {
+		t.Fatalf("Bad settings: %#v", cs)
+	}
+	g, err = c.GetChatSettings(&helix.GetChatSettingsInput{BroadcasterId: "1", ModeratorId: "1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cs := g.Settings[0]; !cs.NonModeratorChatDelay || cs.ModeratorId != "1" {
+		t.Fatalf("Expected the chat delay, got %#v", cs)
+	}
+}
+
+func TestHelix_chatMessages(t *testing.T) {
+	t.Parallel()
+
+	st := seed()
+	st.Bans = []*twitchtest.Ban{{BroadcasterId: "1", UserId: "4"}}
+	s := twitchtest.NewServer(st)
+	defer s.Close()
+
+	c, err := helix.NewClient(s.HelixConfig())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	o, err := c.SendChatMessage(&helix.SendChatMessageInput{BroadcasterId: "1", SenderId: "2", Message: "hello"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if m := o.Messages[0]; !m.IsSent || m.MessageId == "" || m.DropReason != nil {
+		t.Fatalf("Bad message: %#v", m)
+	}
+	o, err = c.SendChatMessage(&helix.SendChatMessageInput{BroadcasterId: "1", SenderId: "4", Message: "hello"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if m := o.Messages[0]; m.IsSent || m.DropReason == nil || m.DropReason.Code == "" {
+		t.Fatalf("Expected the message of a banned user to be dropped, got %#v", m)
+	}
+	if n := len(s.State().ChatMessages); n != 1 {
+		t.Fatalf("Expected 1 message in the state, got %d", n)
+	}
+
+	err = c.SendChatAnnouncement(&helix.SendChatAnnouncementInput{BroadcasterId: "1", ModeratorId: "1", Message: "Giveaway!"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if a := s.State().Announcements; len(a) != 1 || a[0].Color != "primary" {
+		t.Fatalf("Bad announcements: %#v", a)
+	}
+}
+
+func TestHelix_shoutouts(t *testing.T) {
+	t.Parallel()
+
+	st := seed()
+	st.Shoutouts = []*twitchtest.Shoutout{{FromBroadcasterId: "2", ToBroadcasterId: "3", CreatedAt: time.Now().Add(-5 * time.Minute)}}
+	s := twitchtest.NewServer(st)
+	defer s.Close()
+
+	c, err := helix.NewClient(s.HelixConfig())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	send := func(from, to string) error {
+		return c.SendShoutout(&helix.SendShoutoutInput{FromBroadcasterId: from, ToBroadcasterId: to, ModeratorId: from})
+	}
+	if err := send("2", "2"); err == nil {
+		t.Fatalf("Expected an error for a Shoutout to themselves")
+	}
+	if err := send("4", "2"); err == nil {
+		t.Fatalf("Expected an error for a Shoutout from an offline channel")
+	}
+	if err := send("2", "3"); err == nil {
+		t.Fatalf("Expected an error for a second Shoutout to the same channel within an hour")
+	}
+	if err := send("2", "1"); err != nil {
+		t.Fatal(err)
+	}
+	if err := send("2", "4"); err == nil {
+		t.Fatalf("Expected an error for a second Shoutout within 2 minutes")
+	}
+}
+
+func TestHelix_chatters(t *testing.T) {
+	t.Parallel()
+
+	st := seed()
+	for _, id := range []string{"2", "3", "4"} {
+		st.Chatters = append(st.Chatters, &twitchtest.Chatter{BroadcasterId: "1", UserId: id})
+	}
+	s := twitchtest.NewServer(st)
+	defer s.Close()
+
+	c, err := helix.NewClient(s.HelixConfig())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var logins []string
+	in := &helix.GetChattersInput{BroadcasterId: "1", ModeratorId: "1", First: 2}
+	for {
+		o, err := c.GetChatters(in)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if o.Total != 3 {
+			t.Fatalf("Bad total: %d", o.Total)
+		}
+		for _, ch := range o.Chatters {
+			logins = append(logins, ch.UserLogin)
+		}
+		if o.Pagination.Cursor == "" {
+			break
+		}
+		in.After = o.Pagination.Cursor
+	}
+	if len(logins) != 3 || logins[0] != "afro" || logins[2] != "offline" {
+		t.Fatalf("Bad chatters: %v", logins)
+	}
+}
+
+func TestHelix_chatColor(t *testing.T) {
+	t.Parallel()
+
+	s := twitchtest.NewServer(seed())
+	defer s.Close()
+
+	c, err := helix.NewClient(s.HelixConfig())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := c.UpdateUserChatColor(&helix.UpdateUserChatColorInput{UserId: "1", Color: helix.ChatColorBlueViolet}); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.UpdateUserChatColor(&helix.UpdateUserChatColorInput{UserId: "2", Color: "#9146ff"}); err != nil {
+		t.Fatal(err)
+	}
+
+	o, err := c.GetUserChatColor(&helix.GetUserChatColorInput{UserIds: []string{"1", "2", "3"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(o.Colors) != 3 || o.Colors[0].Color != "#8A2BE2" || o.Colors[1].Color != "#9146FF" || o.Colors[2].Color != "" {
+		t.Fatalf("Bad colors: %#v", o.Colors)
+	}
+	if o.Colors[0].UserLogin != "catsby" {
+		t.Fatalf("Bad user: %#v", o.Colors[0])
+	}
+}
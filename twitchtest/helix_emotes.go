@@ -0,0 +1,309 @@
+package twitchtest
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// emoteTemplate is the template of emote URLs in Helix responses.
+const emoteTemplate = "https://static-cdn.jtvnw.net/emoticons/v2/{{id}}/{{format}}/{{theme_mode}}/{{scale}}"
+
+// maxEmoteSets is the most emote_set_id parameters of a request.
+const maxEmoteSets = 25
+
+// defaultCheermoteTiers are the tiers of a Cheermote without Tiers, and
+// their colors.
+var (
+	defaultCheermoteTiers = []int{1, 100, 1000, 5000, 10000}
+	cheermoteColors       = map[int]string{
+		1:     "#979797",
+		100:   "#9c3ee8",
+		1000:  "#1db2a5",
+		5000:  "#0099fe",
+		10000: "#f43021",
+	}
+)
+
+func emoteType(e *Emote) string {
+	switch {
+	case e.EmoteType != "":
+		return e.EmoteType
+	case e.OwnerId == "":
+		return "globals"
+	}
+	return "subscriptions"
+}
+
+func emoteTier(e *Emote) string {
+	if emoteType(e) != "subscriptions" {
+		return ""
+	}
+	if e.Tier == "" {
+		return "1000"
+	}
+	return e.Tier
+}
+
+func helixEmote(e *Emote) map[string]interface{} {
+	url := func(scale string) string {
+		return "https://static-cdn.jtvnw.net/emoticons/v2/" + e.Id + "/static/light/" + scale
+	}
+	format := []string{"static"}
+	if e.Animated {
+		format = append(format, "animated")
+	}
+	return map[string]interface{}{
+		"id":   e.Id,
+		"name": e.Name,
+		"images": map[string]string{
+			"url_1x": url("1.0"),
+			"url_2x": url("2.0"),
+			"url_4x": url("3.0"),
+		},
+		"tier":         emoteTier(e),
+		"emote_type":   emoteType(e),
+		"emote_set_id": e.EmoteSetId,
+		"owner_id":     e.OwnerId,
+		"format":       format,
+		"scale":        []string{"1.0", "2.0", "3.0"},
+		"theme_mode":   []string{"light", "dark"},
+	}
+}
+
+// writeEmotes writes a page of emotes with the template of their URLs.
+func writeEmotes(w http.ResponseWriter, emotes []*Emote, cursor *string) {
+	data := []map[string]interface{}{}
+	for _, e := range emotes {
+		data = append(data, helixEmote(e))
+	}
+	body := map[string]interface{}{
+		"data":     data,
+		"template": emoteTemplate,
+	}
+	if cursor != nil {
+		p := map[string]string{}
+		if *cursor != "" {
+			p["cursor"] = *cursor
+		}
+		body["pagination"] = p
+	}
+	WriteJSON(w, http.StatusOK, body)
+}
+
+func (s *Server) helixChannelEmotes(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+	p, ok := required(w, r, "broadcaster_id")
+	if !ok {
+		return
+	}
+	var emotes []*Emote
+	for _, e := range s.state.Emotes {
+		if e.OwnerId == p[0] {
+			emotes = append(emotes, e)
+		}
+	}
+	writeEmotes(w, emotes, nil)
+}
+
+func (s *Server) helixGlobalEmotes(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+	var emotes []*Emote
+	for _, e := range s.state.Emotes {
+		if e.OwnerId == "" {
+			emotes = append(emotes, e)
+		}
+	}
+	writeEmotes(w, emotes, nil)
+}
+
+func (s *Server) helixEmoteSets(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+	ids := r.URL.Query()["emote_set_id"]
+	if len(ids) == 0 || len(ids) > maxEmoteSets {
+		badRequest(w, "The number of emote_set_id parameters must be between 1 and %d", maxEmoteSets)
+		return
+	}
+	sets := set(ids)
+	var emotes []*Emote
+	for _, e := range s.state.Emotes {
+		if sets[e.EmoteSetId] {
+			emotes = append(emotes, e)
+		}
+	}
+	writeEmotes(w, emotes, nil)
+}
+
+// helixUserEmotes returns the emotes a user can use: the global ones, their
+// own, the subscriptions emotes of the channels they subscribe to up to their
+// tier, and the follower emotes of the channels they follow. A broadcaster_id
+// adds the follower emotes of that channel, as they can be used in its chat.
+func (s *Server) helixUserEmotes(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+	p, ok := required(w, r, "user_id")
+	if !ok {
+		return
+	}
+	broadcasterId := r.URL.Query().Get("broadcaster_id")
+
+	tiers := make(map[string]string)
+	for _, sub := range s.state.Subscriptions {
+		if sub.UserId == p[0] {
+			tiers[sub.BroadcasterId] = subscriptionTier(sub)
+		}
+	}
+	follows := map[string]bool{broadcasterId: broadcasterId != ""}
+	for _, f := range s.state.following(p[0]) {
+		follows[f.BroadcasterId] = true
+	}
+
+	var emotes []*Emote
+	for _, e := range s.state.Emotes {
+		usable := false
+		switch emoteType(e) {
+		case "globals":
+			usable = true
+		case "follower":
+			usable = e.OwnerId == p[0] || follows[e.OwnerId]
+		case "subscriptions":
+			tier, ok := tiers[e.OwnerId]
+			usable = e.OwnerId == p[0] || (ok && tier >= emoteTier(e))
+		default:
+			usable = e.OwnerId == p[0]
+		}
+		if usable {
+			emotes = append(emotes, e)
+		}
+	}
+
+	start, end, cursor, ok := helixPage(w, r, len(emotes), maxFirst)
+	if !ok {
+		return
+	}
+	writeEmotes(w, emotes[start:end], &cursor)
+}
+
+// writeBadges writes badges grouped into their sets, in the order the sets
+// first appear.
+func writeBadges(w http.ResponseWriter, badges []*Badge) {
+	var setIds []string
+	versions := make(map[string][]map[string]interface{})
+	for _, b := range badges {
+		if versions[b.SetId] == nil {
+			setIds = append(setIds, b.SetId)
+		}
+		url := func(scale int) string {
+			return fmt.Sprintf("https://static-cdn.jtvnw.net/badges/v1/%s-%s/%d", b.SetId, b.Id, scale)
+		}
+		versions[b.SetId] = append(versions[b.SetId], map[string]interface{}{
+			"id":           b.Id,
+			"image_url_1x": url(1),
+			"image_url_2x": url(2),
+			"image_url_4x": url(3),
+			"title":        b.Title,
+			"description":  b.Description,
+			"click_action": b.ClickAction,
+			"click_url":    b.ClickURL,
+		})
+	}
+
+	var data []map[string]interface{}
+	for _, id := range setIds {
+		data = append(data, map[string]interface{}{
+			"set_id":   id,
+			"versions": versions[id],
+		})
+	}
+	helixData(w, data, nil)
+}
+
+func (s *Server) helixChannelBadges(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+	p, ok := required(w, r, "broadcaster_id")
+	if !ok {
+		return
+	}
+	var badges []*Badge
+	for _, b := range s.state.Badges {
+		if b.BroadcasterId == p[0] {
+			badges = append(badges, b)
+		}
+	}
+	writeBadges(w, badges)
+}
+
+func (s *Server) helixGlobalBadges(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+	var badges []*Badge
+	for _, b := range s.state.Badges {
+		if b.BroadcasterId == "" {
+			badges = append(badges, b)
+		}
+	}
+	writeBadges(w, badges)
+}
+
+func helixCheermote(c *Cheermote) map[string]interface{} {
+	tiers := c.Tiers
+	if len(tiers) == 0 {
+		tiers = defaultCheermoteTiers
+	}
+	prefix := strings.ToLower(c.Prefix)
+
+	var data []map[string]interface{}
+	for _, bits := range tiers {
+		images := make(map[string]interface{})
+		for _, theme := range []string{"dark", "light"} {
+			urls := make(map[string]map[string]string)
+			for format, ext := range map[string]string{"animated": "gif", "static": "png"} {
+				urls[format] = make(map[string]string)
+				for _, scale := range []string{"1", "1.5", "2", "3", "4"} {
+					urls[format][scale] = fmt.Sprintf("https://d3aqoihi2n8ty8.cloudfront.net/actions/%s/%s/%s/%d/%s.%s", prefix, theme, format, bits, scale, ext)
+				}
+			}
+			images[theme] = urls
+		}
+		color, ok := cheermoteColors[bits]
+		if !ok {
+			color = cheermoteColors[1]
+		}
+		data = append(data, map[string]interface{}{
+			"id":                strconv.Itoa(bits),
+			"min_bits":          bits,
+			"color":             color,
+			"images":            images,
+			"can_cheer":         true,
+			"show_in_bits_card": true,
+		})
+	}
+
+	typ := "global_first_party"
+	if c.BroadcasterId != "" {
+		typ = "channel_custom"
+	}
+	return map[string]interface{}{
+		"prefix":        c.Prefix,
+		"tiers":         data,
+		"type":          typ,
+		"order":         c.Order,
+		"last_updated":  timeString(c.LastUpdated),
+		"is_charitable": c.IsCharitable,
+	}
+}
+
+// helixCheermotes returns the global Cheermotes, and the custom ones of the
+// broadcaster_id, sorted by Order.
+func (s *Server) helixCheermotes(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+	broadcasterId := r.URL.Query().Get("broadcaster_id")
+	var cheermotes []*Cheermote
+	for _, c := range s.state.Cheermotes {
+		if c.BroadcasterId == "" || (broadcasterId != "" && c.BroadcasterId == broadcasterId) {
+			cheermotes = append(cheermotes, c)
+		}
+	}
+	sort.SliceStable(cheermotes, func(i, j int) bool {
+		return cheermotes[i].Order < cheermotes[j].Order
+	})
+
+	var data []map[string]interface{}
+	for _, c := range cheermotes {
+		data = append(data, helixCheermote(c))
+	}
+	helixData(w, data, nil)
+}
@@ -0,0 +1,158 @@
+package twitchtest_test
+
+import (
+	"testing"
+
+	"github.com/catsby/go-twitch/service/helix"
+	"github.com/catsby/go-twitch/twitchtest"
+)
+
+func TestHelix_emotes(t *testing.T) {
+	t.Parallel()
+
+	st := seed()
+	st.Emotes = []*twitchtest.Emote{
+		{Id: "25", Name: "Kappa", EmoteSetId: "0"},
+		{Id: "e1", Name: "afroHi", EmoteSetId: "s1", OwnerId: "2", Animated: true},
+		{Id: "e2", Name: "afroHype", EmoteSetId: "s2", OwnerId: "2", Tier: "2000"},
+		{Id: "e3", Name: "offlineWave", EmoteSetId: "s3", OwnerId: "4", EmoteType: "follower"},
+		{Id: "e4", Name: "lirikHi", EmoteSetId: "s4", OwnerId: "3", EmoteType: "follower"},
+	}
+	st.Subscriptions = []*twitchtest.Subscription{{BroadcasterId: "2", UserId: "1"}}
+	s := twitchtest.NewServer(st)
+	defer s.Close()
+
+	c, err := helix.NewClient(s.HelixConfig())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	o, err := c.GetGlobalEmotes()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(o.Emotes) != 1 || o.Emotes[0].EmoteType != "globals" || o.Template != helix.DefaultEmoteTemplate {
+		t.Fatalf("Bad global emotes: %#v", o)
+	}
+
+	o, err = c.GetChannelEmotes(&helix.GetChannelEmotesInput{BroadcasterId: "2"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(o.Emotes) != 2 || o.Emotes[0].Tier != "1000" || o.Emotes[1].Tier != "2000" {
+		t.Fatalf("Bad channel emotes: %#v", o.Emotes)
+	}
+	if got := o.Emotes[0].URL(helix.EmoteFormatAnimated, helix.EmoteThemeDark, helix.EmoteScale2x); got != "https://static-cdn.jtvnw.net/emoticons/v2/e1/animated/dark/2.0" {
+		t.Fatalf("Bad URL: %s", got)
+	}
+
+	o, err = c.GetEmoteSets(&helix.GetEmoteSetsInput{EmoteSetIds: []string{"0", "s4"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(o.Emotes) != 2 || o.Emotes[1].Name != "lirikHi" {
+		t.Fatalf("Bad emote sets: %#v", o.Emotes)
+	}
+
+	// catsby subscribes to afro at tier 1 and follows lirik, so gets the
+	// global emote, afro's tier 1 emote and lirik's follower emote. Asking
+	// in the chat of offline adds its follower emote.
+	names := func(in *helix.GetUserEmotesInput) []string {
+		o, err := c.GetUserEmotes(in)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var names []string
+		for _, e := range o.Emotes {
+			names = append(names, e.Name)
+		}
+		return names
+	}
+	if got := names(&helix.GetUserEmotesInput{UserId: "1"}); len(got) != 3 || got[1] != "afroHi" || got[2] != "lirikHi" {
+		t.Fatalf("Bad user emotes: %v", got)
+	}
+	if got := names(&helix.GetUserEmotesInput{UserId: "1", BroadcasterId: "4"}); len(got) != 4 || got[2] != "offlineWave" {
+		t.Fatalf("Bad user emotes in the chat of offline: %v", got)
+	}
+}
+
+func TestHelix_badges(t *testing.T) {
+	t.Parallel()
+
+	st := seed()
+	st.Badges = []*twitchtest.Badge{
+		{SetId: "subscriber", Id: "0", Title: "Subscriber"},
+		{SetId: "moderator", Id: "1", Title: "Moderator"},
+		{SetId: "subscriber", Id: "0", BroadcasterId: "2", Title: "afro sub"},
+		{SetId: "subscriber", Id: "3", BroadcasterId: "2", Title: "3-Month afro sub"},
+	}
+	s := twitchtest.NewServer(st)
+	defer s.Close()
+
+	c, err := helix.NewClient(s.HelixConfig())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	o, err := c.GetGlobalChatBadges()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(o.BadgeSets) != 2 || o.BadgeSets[0].SetId != "subscriber" {
+		t.Fatalf("Bad global badges: %#v", o.BadgeSets)
+	}
+
+	// Channel badges override the global ones of the same set and version.
+	bc := helix.NewBadgeCache(c, "2")
+	b, err := bc.Badge("subscriber", "0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if b == nil || b.Title != "afro sub" || b.ImageUrl1x == "" {
+		t.Fatalf("Bad badge: %#v", b)
+	}
+	if b, _ := bc.Badge("subscriber", "3"); b == nil || b.Title != "3-Month afro sub" {
+		t.Fatalf("Bad badge: %#v", b)
+	}
+	if b, _ := bc.Badge("moderator", "1"); b == nil {
+		t.Fatalf("Expected the global moderator badge")
+	}
+}
+
+func TestHelix_cheermotes(t *testing.T) {
+	t.Parallel()
+
+	st := seed()
+	st.Cheermotes = []*twitchtest.Cheermote{
+		{Prefix: "afroCheer", BroadcasterId: "2", Tiers: []int{1, 500}},
+		{Prefix: "Cheer", Order: 1},
+		{Prefix: "lirikCheer", BroadcasterId: "3"},
+	}
+	s := twitchtest.NewServer(st)
+	defer s.Close()
+
+	c, err := helix.NewClient(s.HelixConfig())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	o, err := c.GetCheermotes(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(o.Cheermotes) != 1 || o.Cheermotes[0].Prefix != "Cheer" || len(o.Cheermotes[0].Tiers) != 5 {
+		t.Fatalf("Bad global cheermotes: %#v", o.Cheermotes)
+	}
+	tier := o.Cheermotes[0].Tier(250)
+	if tier == nil || tier.MinBits != 100 || tier.Image(helix.EmoteThemeDark, true, "2") == "" {
+		t.Fatalf("Bad tier: %#v", tier)
+	}
+
+	o, err = c.GetCheermotes(&helix.GetCheermotesInput{BroadcasterId: "2"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(o.Cheermotes) != 2 || o.Cheermotes[0].Type != "channel_custom" || o.Cheermotes[0].Tier(999).MinBits != 500 {
+		t.Fatalf("Expected afro's custom cheermote first, got %#v", o.Cheermotes)
+	}
+}
@@ -0,0 +1,526 @@
+package twitchtest
+
+import (
+	"net/http"
+	"strings"
+	"time"
+)
+
+// activeBan returns the index of the ban or unexpired timeout of a user in a
+// channel, or -1.
+func (st *State) activeBan(broadcasterId, userId string, now time.Time) int {
+	for n, b := range st.Bans {
+		if b.BroadcasterId != broadcasterId || b.UserId != userId {
+			continue
+		}
+		if b.ExpiresAt.IsZero() || b.ExpiresAt.After(now) {
+			return n
+		}
+	}
+	return -1
+}
+
+func (s *Server) helixBanUser(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+	p, ok := required(w, r, "broadcaster_id", "moderator_id")
+	if !ok {
+		return
+	}
+	var body struct {
+		Data struct {
+			UserId   string `json:"user_id"`
+			Duration int    `json:"duration"`
+			Reason   string `json:"reason"`
+		} `json:"data"`
+	}
+	if !decodeBody(w, r, &body) {
+		return
+	}
+	d := body.Data
+	if d.UserId == "" {
+		badRequest(w, "Missing required field \"user_id\"")
+		return
+	}
+	if d.UserId == p[0] {
+		badRequest(w, "The user specified in the user_id field may not be banned.")
+		return
+	}
+
+	now := time.Now().UTC().Truncate(time.Second)
+	ban := &Ban{
+		BroadcasterId: p[0],
+		UserId:        d.UserId,
+		ModeratorId:   p[1],
+		Reason:        d.Reason,
+		CreatedAt:     now,
+	}
+	if d.Duration > 0 {
+		ban.ExpiresAt = now.Add(time.Duration(d.Duration) * time.Second)
+	}
+
+	// A timeout replaces an earlier one, but a banned user stays banned.
+	if n := s.state.activeBan(p[0], d.UserId, now); n >= 0 {
+		if s.state.Bans[n].ExpiresAt.IsZero() {
+			badRequest(w, "The user specified in the user_id field is already banned.")
+			return
+		}
+		s.state.Bans = append(s.state.Bans[:n], s.state.Bans[n+1:]...)
+	}
+	s.state.Bans = append(s.state.Bans, ban)
+
+	helixData(w, []map[string]interface{}{{
+		"broadcaster_id": ban.BroadcasterId,
+		"moderator_id":   ban.ModeratorId,
+		"user_id":        ban.UserId,
+		"created_at":     timeString(ban.CreatedAt),
+		"end_time":       timeString(ban.ExpiresAt),
+	}}, nil)
+}
+
+func (s *Server) helixUnbanUser(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+	p, ok := required(w, r, "broadcaster_id", "moderator_id", "user_id")
+	if !ok {
+		return
+	}
+	n := s.state.activeBan(p[0], p[2], time.Now())
+	if n < 0 {
+		badRequest(w, "The user specified in the user_id field is not banned.")
+		return
+	}
+	s.state.Bans = append(s.state.Bans[:n], s.state.Bans[n+1:]...)
+	noContent(w)
+}
+
+func (s *Server) helixBannedUsers(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+	p, ok := required(w, r, "broadcaster_id")
+	if !ok {
+		return
+	}
+	userIds := set(r.URL.Query()["user_id"])
+
+	now := time.Now()
+	var bans []*Ban
+	for _, b := range s.state.Bans {
+		if b.BroadcasterId != p[0] || (userIds != nil && !userIds[b.UserId]) {
+			continue
+		}
+		if !b.ExpiresAt.IsZero() && !b.ExpiresAt.After(now) {
+			continue
+		}
+		bans = append(bans, b)
+	}
+
+	start, end, cursor, ok := helixPage(w, r, len(bans), maxFirst)
+	if !ok {
+		return
+	}
+
+	var data []map[string]interface{}
+	for _, b := range bans[start:end] {
+		login, name := s.userNames(b.UserId)
+		modLogin, modName := s.userNames(b.ModeratorId)
+		data = append(data, map[string]interface{}{
+			"user_id":         b.UserId,
+			"user_login":      login,
+			"user_name":       name,
+			"expires_at":      timeString(b.ExpiresAt),
+			"created_at":      timeString(b.CreatedAt),
+			"reason":          b.Reason,
+			"moderator_id":    b.ModeratorId,
+			"moderator_login": modLogin,
+			"moderator_name":  modName,
+		})
+	}
+	helixData(w, data, &cursor)
+}
+
+// vip returns the index of a VIP of a channel, or -1.
+func (st *State) vip(broadcasterId, userId string) int {
+	for n, v := range st.VIPs {
+		if v.BroadcasterId == broadcasterId && v.UserId == userId {
+			return n
+		}
+	}
+	return -1
+}
+
+func (s *Server) helixVIPs(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+	p, ok := required(w, r, "broadcaster_id")
+	if !ok {
+		return
+	}
+	userIds := set(r.URL.Query()["user_id"])
+
+	var vips []*VIP
+	for _, v := range s.state.VIPs {
+		if v.BroadcasterId == p[0] && (userIds == nil || userIds[v.UserId]) {
+			vips = append(vips, v)
+		}
+	}
+
+	start, end, cursor, ok := helixPage(w, r, len(vips), maxFirst)
+	if !ok {
+		return
+	}
+
+	var data []map[string]interface{}
+	for _, v := range vips[start:end] {
+		login, name := s.userNames(v.UserId)
+		data = append(data, map[string]interface{}{
+			"user_id":    v.UserId,
+			"user_login": login,
+			"user_name":  name,
+		})
+	}
+	helixData(w, data, &cursor)
+}
+
+func (s *Server) helixAddVIP(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+	p, ok := required(w, r, "broadcaster_id", "user_id")
+	if !ok {
+		return
+	}
+	if s.state.vip(p[0], p[1]) >= 0 {
+		WriteError(w, http.StatusUnprocessableEntity, "The user in the user_id query parameter is already a VIP.")
+		return
+	}
+	if s.state.moderator(p[0], p[1]) >= 0 {
+		WriteError(w, http.StatusUnprocessableEntity, "The user in the user_id query parameter is a moderator. To make them a VIP, you must first remove them as a moderator.")
+		return
+	}
+	s.state.VIPs = append(s.state.VIPs, &VIP{BroadcasterId: p[0], UserId: p[1]})
+	noContent(w)
+}
+
+func (s *Server) helixRemoveVIP(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+	p, ok := required(w, r, "broadcaster_id", "user_id")
+	if !ok {
+		return
+	}
+	n := s.state.vip(p[0], p[1])
+	if n < 0 {
+		WriteError(w, http.StatusUnprocessableEntity, "The user in the user_id query parameter is not a VIP.")
+		return
+	}
+	s.state.VIPs = append(s.state.VIPs[:n], s.state.VIPs[n+1:]...)
+	noContent(w)
+}
+
+func helixBlockedTerm(t *BlockedTerm) map[string]interface{} {
+	return map[string]interface{}{
+		"broadcaster_id": t.BroadcasterId,
+		"moderator_id":   t.ModeratorId,
+		"id":             t.Id,
+		"text":           t.Text,
+		"created_at":     timeString(t.CreatedAt),
+		"updated_at":     timeString(t.CreatedAt),
+		"expires_at":     nil,
+	}
+}
+
+func (s *Server) helixBlockedTerms(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+	p, ok := required(w, r, "broadcaster_id", "moderator_id")
+	if !ok {
+		return
+	}
+
+	var terms []*BlockedTerm
+	for _, t := range s.state.BlockedTerms {
+		if t.BroadcasterId == p[0] {
+			terms = append(terms, t)
+		}
+	}
+
+	start, end, cursor, ok := helixPage(w, r, len(terms), maxFirst)
+	if !ok {
+		return
+	}
+
+	var data []map[string]interface{}
+	for _, t := range terms[start:end] {
+		data = append(data, helixBlockedTerm(t))
+	}
+	helixData(w, data, &cursor)
+}
+
+func (s *Server) helixAddBlockedTerm(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+	p, ok := required(w, r, "broadcaster_id", "moderator_id")
+	if !ok {
+		return
+	}
+	var body struct {
+		Text string `json:"text"`
+	}
+	if !decodeBody(w, r, &body) {
+		return
+	}
+	if n := len([]rune(body.Text)); n < 2 || n > 500 {
+		badRequest(w, "The text field must be between 2 and 500 characters")
+		return
+	}
+
+	// Blocking a term that is already blocked returns the existing one.
+	for _, t := range s.state.BlockedTerms {
+		if t.BroadcasterId == p[0] && strings.EqualFold(t.Text, body.Text) {
+			helixData(w, []map[string]interface{}{helixBlockedTerm(t)}, nil)
+			return
+		}
+	}
+
+	t := &BlockedTerm{
+		Id:            newId(),
+		BroadcasterId: p[0],
+		ModeratorId:   p[1],
+		Text:          body.Text,
+		CreatedAt:     time.Now().UTC().Truncate(time.Second),
+	}
+	s.state.BlockedTerms = append(s.state.BlockedTerms, t)
+	helixData(w, []map[string]interface{}{helixBlockedTerm(t)}, nil)
+}
+
+func (s *Server) helixRemoveBlockedTerm(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+	p, ok := required(w, r, "broadcaster_id", "moderator_id", "id")
+	if !ok {
+		return
+	}
+	// Removing a term that is not blocked succeeds, as it does on Twitch.
+	for n, t := range s.state.BlockedTerms {
+		if t.BroadcasterId == p[0] && t.Id == p[2] {
+			s.state.BlockedTerms = append(s.state.BlockedTerms[:n], s.state.BlockedTerms[n+1:]...)
+			break
+		}
+	}
+	noContent(w)
+}
+
+// moderator returns the index of a moderator of a channel, or -1.
+func (st *State) moderator(broadcasterId, userId string) int {
+	for n, m := range st.Moderators {
+		if m.BroadcasterId == broadcasterId && m.UserId == userId {
+			return n
+		}
+	}
+	return -1
+}
+
+func (s *Server) helixModerators(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+	p, ok := required(w, r, "broadcaster_id")
+	if !ok {
+		return
+	}
+	userIds := set(r.URL.Query()["user_id"])
+
+	var mods []*Moderator
+	for _, m := range s.state.Moderators {
+		if m.BroadcasterId == p[0] && (userIds == nil || userIds[m.UserId]) {
+			mods = append(mods, m)
+		}
+	}
+
+	start, end, cursor, ok := helixPage(w, r, len(mods), maxFirst)
+	if !ok {
+		return
+	}
+
+	var data []map[string]interface{}
+	for _, m := range mods[start:end] {
+		login, name := s.userNames(m.UserId)
+		data = append(data, map[string]interface{}{
+			"user_id":    m.UserId,
+			"user_login": login,
+			"user_name":  name,
+		})
+	}
+	helixData(w, data, &cursor)
+}
+
+func (s *Server) helixAddModerator(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+	p, ok := required(w, r, "broadcaster_id", "user_id")
+	if !ok {
+		return
+	}
+	if s.state.moderator(p[0], p[1]) >= 0 {
+		badRequest(w, "The user in the user_id query parameter is already a moderator.")
+		return
+	}
+	if s.state.vip(p[0], p[1]) >= 0 {
+		WriteError(w, http.StatusUnprocessableEntity, "The user in the user_id query parameter is a VIP. To make them a moderator, you must first remove them as a VIP.")
+		return
+	}
+	s.state.Moderators = append(s.state.Moderators, &Moderator{BroadcasterId: p[0], UserId: p[1]})
+	noContent(w)
+}
+
+func (s *Server) helixRemoveModerator(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+	p, ok := required(w, r, "broadcaster_id", "user_id")
+	if !ok {
+		return
+	}
+	n := s.state.moderator(p[0], p[1])
+	if n < 0 {
+		badRequest(w, "The user in the user_id query parameter is not a moderator.")
+		return
+	}
+	s.state.Moderators = append(s.state.Moderators[:n], s.state.Moderators[n+1:]...)
+	noContent(w)
+}
+
+// helixCheckAutoMod permits the messages that contain none of the channel's
+// blocked terms.
+func (s *Server) helixCheckAutoMod(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+	p, ok := required(w, r, "broadcaster_id")
+	if !ok {
+		return
+	}
+	var body struct {
+		Data []struct {
+			MsgId   string `json:"msg_id"`
+			MsgText string `json:"msg_text"`
+		} `json:"data"`
+	}
+	if !decodeBody(w, r, &body) {
+		return
+	}
+	if len(body.Data) == 0 || len(body.Data) > maxFirst {
+		badRequest(w, "The data field must contain between 1 and %d messages", maxFirst)
+		return
+	}
+
+	var data []map[string]interface{}
+	for _, m := range body.Data {
+		permitted := true
+		for _, t := range s.state.BlockedTerms {
+			if t.BroadcasterId == p[0] && strings.Contains(strings.ToLower(m.MsgText), strings.ToLower(t.Text)) {
+				permitted = false
+				break
+			}
+		}
+		data = append(data, map[string]interface{}{
+			"msg_id":       m.MsgId,
+			"is_permitted": permitted,
+		})
+	}
+	helixData(w, data, nil)
+}
+
+func (s *Server) helixManageHeldMessage(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+	var body struct {
+		UserId string `json:"user_id"`
+		MsgId  string `json:"msg_id"`
+		Action string `json:"action"`
+	}
+	if !decodeBody(w, r, &body) {
+		return
+	}
+	if body.UserId == "" || body.MsgId == "" {
+		badRequest(w, "Missing required field \"user_id\" or \"msg_id\"")
+		return
+	}
+	if body.Action != "ALLOW" && body.Action != "DENY" {
+		badRequest(w, "The action field must be ALLOW or DENY")
+		return
+	}
+	for _, m := range s.state.HeldMessages {
+		if m.Id == body.MsgId && m.Status == "" {
+			m.Status = body.Action
+			noContent(w)
+			return
+		}
+	}
+	WriteError(w, http.StatusNotFound, "The message specified in the msg_id field was not found.")
+}
+
+// autoModSettings returns the AutoMod settings of a channel, or new ones
+// with every level at 0.
+func (st *State) autoModSettings(broadcasterId string) *AutoModSettings {
+	for _, a := range st.AutoModSettings {
+		if a.BroadcasterId == broadcasterId {
+			return a
+		}
+	}
+	return &AutoModSettings{BroadcasterId: broadcasterId}
+}
+
+func helixAutoModSettings(a *AutoModSettings, moderatorId string) map[string]interface{} {
+	var overall interface{}
+	if a.OverallLevel != nil {
+		overall = *a.OverallLevel
+	}
+	return map[string]interface{}{
+		"broadcaster_id":             a.BroadcasterId,
+		"moderator_id":               moderatorId,
+		"overall_level":              overall,
+		"disability":                 a.Disability,
+		"aggression":                 a.Aggression,
+		"sexuality_sex_or_gender":    a.SexualitySexOrGender,
+		"misogyny":                   a.Misogyny,
+		"bullying":                   a.Bullying,
+		"swearing":                   a.Swearing,
+		"race_ethnicity_or_religion": a.RaceEthnicityOrReligion,
+		"sex_based_terms":            a.SexBasedTerms,
+	}
+}
+
+func (s *Server) helixGetAutoModSettings(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+	p, ok := required(w, r, "broadcaster_id", "moderator_id")
+	if !ok {
+		return
+	}
+	a := s.state.autoModSettings(p[0])
+	helixData(w, []map[string]interface{}{helixAutoModSettings(a, p[1])}, nil)
+}
+
+// helixUpdateAutoModSettings sets every level to the overall_level, or each
+// level on its own, as Twitch does not allow both in one request.
+func (s *Server) helixUpdateAutoModSettings(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+	p, ok := required(w, r, "broadcaster_id", "moderator_id")
+	if !ok {
+		return
+	}
+	var body map[string]int
+	if !decodeBody(w, r, &body) {
+		return
+	}
+	for name, level := range body {
+		if level < 0 || level > 4 {
+			badRequest(w, "The %s field must be between 0 and 4", name)
+			return
+		}
+	}
+
+	a := s.state.autoModSettings(p[0])
+	levels := map[string]*int{
+		"disability":                 &a.Disability,
+		"aggression":                 &a.Aggression,
+		"sexuality_sex_or_gender":    &a.SexualitySexOrGender,
+		"misogyny":                   &a.Misogyny,
+		"bullying":                   &a.Bullying,
+		"swearing":                   &a.Swearing,
+		"race_ethnicity_or_religion": &a.RaceEthnicityOrReligion,
+		"sex_based_terms":            &a.SexBasedTerms,
+	}
+	if overall, ok := body["overall_level"]; ok {
+		if len(body) > 1 {
+			badRequest(w, "The overall_level field may not be set with the individual levels")
+			return
+		}
+		a.OverallLevel = &overall
+		for _, l := range levels {
+			*l = overall
+		}
+	} else {
+		for name := range body {
+			if levels[name] == nil {
+				badRequest(w, "Unknown AutoMod setting \"%s\"", name)
+				return
+			}
+		}
+		for name, level := range body {
+			*levels[name] = level
+		}
+		a.OverallLevel = nil
+	}
+	if s.state.autoModSettings(p[0]) != a {
+		s.state.AutoModSettings = append(s.state.AutoModSettings, a)
+	}
+	helixData(w, []map[string]interface{}{helixAutoModSettings(a, p[1])}, nil)
+}
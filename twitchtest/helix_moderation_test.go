@@ -0,0 +1,297 @@
+package twitchtest_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/catsby/go-twitch/service/helix"
+	"github.com/catsby/go-twitch/twitch"
+	"github.com/catsby/go-twitch/twitchtest"
+)
+
+func TestHelix_bans(t *testing.T) {
+	t.Parallel()
+
+	s := twitchtest.NewServer(seed())
+	defer s.Close()
+
+	c, err := helix.NewClient(s.HelixConfig())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	o, err := c.BanUser(&helix.BanUserInput{
+		BroadcasterId: "1",
+		ModeratorId:   "1",
+		UserId:        "2",
+		Reason:        "spam",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(o.Bans) != 1 || o.Bans[0].UserId != "2" || !o.Bans[0].EndTime.IsZero() {
+		t.Fatalf("Bad ban: %#v", o.Bans)
+	}
+
+	_, err = c.BanUser(&helix.BanUserInput{BroadcasterId: "1", ModeratorId: "1", UserId: "2"})
+	if !errors.Is(err, helix.ErrUserAlreadyBanned) {
+		t.Fatalf("Expected ErrUserAlreadyBanned, got %v", err)
+	}
+	_, err = c.BanUser(&helix.BanUserInput{BroadcasterId: "1", ModeratorId: "1", UserId: "1"})
+	if !errors.Is(err, helix.ErrUserMayNotBeBanned) {
+		t.Fatalf("Expected ErrUserMayNotBeBanned, got %v", err)
+	}
+
+	// A timeout replaces an earlier one.
+	for _, d := range []time.Duration{time.Minute, time.Hour} {
+		_, err := c.BanUser(&helix.BanUserInput{BroadcasterId: "1", ModeratorId: "1", UserId: "3", Duration: d})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var banned []*helix.BannedUser
+	in := &helix.GetBannedUsersInput{BroadcasterId: "1", First: 1}
+	for {
+		o, err := c.GetBannedUsers(in)
+		if err != nil {
+			t.Fatal(err)
+		}
+		banned = append(banned, o.BannedUsers...)
+		if o.Pagination.Cursor == "" {
+			break
+		}
+		in.After = o.Pagination.Cursor
+	}
+	if len(banned) != 2 || banned[0].UserLogin != "afro" || banned[0].Reason != "spam" {
+		t.Fatalf("Bad banned users: %#v", banned)
+	}
+	if banned[1].UserLogin != "lirik" || banned[1].ExpiresAt.Sub(banned[1].CreatedAt) != time.Hour {
+		t.Fatalf("Expected the second timeout to replace the first, got %#v", banned[1])
+	}
+
+	if err := c.UnbanUser(&helix.UnbanUserInput{BroadcasterId: "1", ModeratorId: "1", UserId: "2"}); err != nil {
+		t.Fatal(err)
+	}
+	err = c.UnbanUser(&helix.UnbanUserInput{BroadcasterId: "1", ModeratorId: "1", UserId: "2"})
+	if !errors.Is(err, helix.ErrUserNotBanned) {
+		t.Fatalf("Expected ErrUserNotBanned, got %v", err)
+	}
+
+	gb, err := c.GetBannedUsers(&helix.GetBannedUsersInput{BroadcasterId: "1", UserIds: []string{"2", "3"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(gb.BannedUsers) != 1 || gb.BannedUsers[0].UserId != "3" {
+		t.Fatalf("Expected only lirik to be banned, got %#v", gb.BannedUsers)
+	}
+	s.AssertRequested(t, "DELETE", "/helix/moderation/bans", 2)
+}
+
+func TestHelix_VIPs(t *testing.T) {
+	t.Parallel()
+
+	s := twitchtest.NewServer(seed())
+	defer s.Close()
+
+	c, err := helix.NewClient(s.HelixConfig())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, id := range []string{"2", "3", "4"} {
+		if err := c.AddChannelVIP(&helix.AddChannelVIPInput{BroadcasterId: "1", UserId: id}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	err = c.AddChannelVIP(&helix.AddChannelVIPInput{BroadcasterId: "1", UserId: "2"})
+	if !errors.Is(err, helix.ErrUserAlreadyVIP) {
+		t.Fatalf("Expected ErrUserAlreadyVIP, got %v", err)
+	}
+
+	if err := c.RemoveChannelVIP(&helix.RemoveChannelVIPInput{BroadcasterId: "1", UserId: "3"}); err != nil {
+		t.Fatal(err)
+	}
+	err = c.RemoveChannelVIP(&helix.RemoveChannelVIPInput{BroadcasterId: "1", UserId: "3"})
+	if !errors.Is(err, helix.ErrUserNotVIP) {
+		t.Fatalf("Expected ErrUserNotVIP, got %v", err)
+	}
+
+	o, err := c.GetVIPs(&helix.GetVIPsInput{BroadcasterId: "1", First: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(o.VIPs) != 1 || o.VIPs[0].UserLogin != "afro" || o.Pagination.Cursor == "" {
+		t.Fatalf("Bad first page: %#v %#v", o.VIPs, o.Pagination)
+	}
+	o, err = c.GetVIPs(&helix.GetVIPsInput{BroadcasterId: "1", After: o.Pagination.Cursor})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(o.VIPs) != 1 || o.VIPs[0].UserName != "Offline" || o.Pagination.Cursor != "" {
+		t.Fatalf("Bad last page: %#v %#v", o.VIPs, o.Pagination)
+	}
+}
+
+func TestHelix_blockedTerms(t *testing.T) {
+	t.Parallel()
+
+	s := twitchtest.NewServer(seed())
+	defer s.Close()
+
+	c, err := helix.NewClient(s.HelixConfig())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var ids []string
+	for _, text := range []string{"kappa", "KAPPA", "pogchamp"} {
+		o, err := c.AddBlockedTerm(&helix.AddBlockedTermInput{BroadcasterId: "1", ModeratorId: "1", Text: text})
+		if err != nil {
+			t.Fatal(err)
+		}
+		ids = append(ids, o.BlockedTerms[0].Id)
+	}
+	if ids[0] != ids[1] || ids[0] == ids[2] {
+		t.Fatalf("Expected a duplicate term to return the existing one, got %v", ids)
+	}
+	if _, err := c.AddBlockedTerm(&helix.AddBlockedTermInput{BroadcasterId: "1", ModeratorId: "1", Text: "x"}); err == nil {
+		t.Fatalf("Expected an error for a one character term")
+	}
+
+	if err := c.RemoveBlockedTerm(&helix.RemoveBlockedTermInput{BroadcasterId: "1", ModeratorId: "1", Id: ids[0]}); err != nil {
+		t.Fatal(err)
+	}
+
+	o, err := c.GetBlockedTerms(&helix.GetBlockedTermsInput{BroadcasterId: "1", ModeratorId: "1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(o.BlockedTerms) != 1 || o.BlockedTerms[0].Text != "pogchamp" || o.BlockedTerms[0].CreatedAt.IsZero() {
+		t.Fatalf("Bad blocked terms: %#v", o.BlockedTerms)
+	}
+}
+
+func TestHelix_moderators(t *testing.T) {
+	t.Parallel()
+
+	st := seed()
+	st.VIPs = []*twitchtest.VIP{{BroadcasterId: "1", UserId: "4"}}
+	s := twitchtest.NewServer(st)
+	defer s.Close()
+
+	c, err := helix.NewClient(s.HelixConfig())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, id := range []string{"2", "3"} {
+		if err := c.AddChannelModerator(&helix.AddChannelModeratorInput{BroadcasterId: "1", UserId: id}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	err = c.AddChannelModerator(&helix.AddChannelModeratorInput{BroadcasterId: "1", UserId: "2"})
+	if !errors.Is(err, helix.ErrUserAlreadyModerator) {
+		t.Fatalf("Expected ErrUserAlreadyModerator, got %v", err)
+	}
+	err = c.AddChannelModerator(&helix.AddChannelModeratorInput{BroadcasterId: "1", UserId: "4"})
+	if !errors.Is(err, helix.ErrUserIsVIP) {
+		t.Fatalf("Expected ErrUserIsVIP, got %v", err)
+	}
+	err = c.AddChannelVIP(&helix.AddChannelVIPInput{BroadcasterId: "1", UserId: "2"})
+	if !errors.Is(err, helix.ErrUserIsModerator) {
+		t.Fatalf("Expected ErrUserIsModerator, got %v", err)
+	}
+
+	if err := c.RemoveChannelModerator(&helix.RemoveChannelModeratorInput{BroadcasterId: "1", UserId: "3"}); err != nil {
+		t.Fatal(err)
+	}
+	err = c.RemoveChannelModerator(&helix.RemoveChannelModeratorInput{BroadcasterId: "1", UserId: "3"})
+	if !errors.Is(err, helix.ErrUserNotModerator) {
+		t.Fatalf("Expected ErrUserNotModerator, got %v", err)
+	}
+
+	o, err := c.GetModerators(&helix.GetModeratorsInput{BroadcasterId: "1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(o.Moderators) != 1 || o.Moderators[0].UserLogin != "afro" {
+		t.Fatalf("Bad moderators: %#v", o.Moderators)
+	}
+	o, err = c.GetModerators(&helix.GetModeratorsInput{BroadcasterId: "1", UserIds: []string{"3", "4"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(o.Moderators) != 0 {
+		t.Fatalf("Expected no moderators, got %#v", o.Moderators)
+	}
+}
+
+func TestHelix_autoMod(t *testing.T) {
+	t.Parallel()
+
+	st := seed()
+	st.BlockedTerms = []*twitchtest.BlockedTerm{{Id: "t", BroadcasterId: "1", Text: "kappa"}}
+	st.HeldMessages = []*twitchtest.HeldMessage{{Id: "held", BroadcasterId: "1", UserId: "2", Text: "Kappa"}}
+	s := twitchtest.NewServer(st)
+	defer s.Close()
+
+	c, err := helix.NewClient(s.HelixConfig())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	o, err := c.CheckAutoModStatus(&helix.CheckAutoModStatusInput{
+		BroadcasterId: "1",
+		Messages: []*helix.AutoModMessage{
+			{MsgId: "a", MsgText: "hello"},
+			{MsgId: "b", MsgText: "KAPPA 123"},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(o.Statuses) != 2 || !o.Statuses[0].IsPermitted || o.Statuses[1].IsPermitted {
+		t.Fatalf("Expected only the message without a blocked term to be permitted, got %#v", o.Statuses)
+	}
+
+	in := &helix.ManageHeldAutoModMessageInput{UserId: "1", MsgId: "held", Action: helix.AutoModActionDeny}
+	if err := c.ManageHeldAutoModMessage(in); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.ManageHeldAutoModMessage(in); err == nil {
+		t.Fatalf("Expected an error for a message that is no longer held")
+	}
+	if status := s.State().HeldMessages[0].Status; status != "DENY" {
+		t.Fatalf("Bad status: %s", status)
+	}
+
+	u, err := c.UpdateAutoModSettings(&helix.UpdateAutoModSettingsInput{
+		BroadcasterId: "1",
+		ModeratorId:   "1",
+		OverallLevel:  twitch.Int(3),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if a := u.Settings[0]; a.OverallLevel == nil || *a.OverallLevel != 3 || a.Swearing != 3 {
+		t.Fatalf("Bad settings: %#v", a)
+	}
+
+	_, err = c.UpdateAutoModSettings(&helix.UpdateAutoModSettingsInput{
+		BroadcasterId: "1",
+		ModeratorId:   "1",
+		Swearing:      4,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	g, err := c.GetAutoModSettings(&helix.GetAutoModSettingsInput{BroadcasterId: "1", ModeratorId: "2"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if a := g.Settings[0]; a.OverallLevel != nil || a.Swearing != 4 || a.Bullying != 0 || a.ModeratorId != "2" {
+		t.Fatalf("Expected the levels to be set one by one, got %#v", a)
+	}
+}
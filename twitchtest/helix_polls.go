@@ -0,0 +1,339 @@
+package twitchtest
+
+import (
+	"net/http"
+	"sort"
+	"time"
+)
+
+// Polls and predictions are listed at most 20 and 25 to a page.
+const (
+	maxPollsFirst       = 20
+	maxPredictionsFirst = 25
+)
+
+// timeOrNil formats a time the way Twitch does, or returns nil, a JSON null,
+// for the zero time.
+func timeOrNil(t time.Time) interface{} {
+	if t.IsZero() {
+		return nil
+	}
+	return timeString(t)
+}
+
+// expire completes the polls and locks the predictions whose time has run
+// out.
+func (st *State) expire(now time.Time) {
+	for _, p := range st.Polls {
+		if p.Status == "" {
+			p.Status = "ACTIVE"
+		}
+		if end := p.StartedAt.Add(p.Duration); p.Status == "ACTIVE" && !end.After(now) {
+			p.Status = "COMPLETED"
+			p.EndedAt = end
+		}
+	}
+	for _, p := range st.Predictions {
+		if p.Status == "" {
+			p.Status = "ACTIVE"
+		}
+		if end := p.CreatedAt.Add(p.PredictionWindow); p.Status == "ACTIVE" && !end.After(now) {
+			p.Status = "LOCKED"
+			p.LockedAt = end
+		}
+	}
+}
+
+func (s *Server) helixPoll(p *Poll) map[string]interface{} {
+	login, name := s.userNames(p.BroadcasterId)
+	choices := []map[string]interface{}{}
+	for _, c := range p.Choices {
+		choices = append(choices, map[string]interface{}{
+			"id":                   c.Id,
+			"title":                c.Title,
+			"votes":                c.Votes,
+			"channel_points_votes": 0,
+			"bits_votes":           0,
+		})
+	}
+	return map[string]interface{}{
+		"id":                            p.Id,
+		"broadcaster_id":                p.BroadcasterId,
+		"broadcaster_login":             login,
+		"broadcaster_name":              name,
+		"title":                         p.Title,
+		"choices":                       choices,
+		"bits_voting_enabled":           false,
+		"bits_per_vote":                 0,
+		"channel_points_voting_enabled": p.ChannelPointsPerVote > 0,
+		"channel_points_per_vote":       p.ChannelPointsPerVote,
+		"status":                        p.Status,
+		"duration":                      int(p.Duration / time.Second),
+		"started_at":                    timeString(p.StartedAt),
+		"ended_at":                      timeOrNil(p.EndedAt),
+	}
+}
+
+func (s *Server) helixPolls(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+	p, ok := required(w, r, "broadcaster_id")
+	if !ok {
+		return
+	}
+	ids := set(r.URL.Query()["id"])
+	s.state.expire(time.Now())
+
+	var polls []*Poll
+	for _, poll := range s.state.Polls {
+		if poll.BroadcasterId == p[0] && (ids == nil || ids[poll.Id]) {
+			polls = append(polls, poll)
+		}
+	}
+	sort.SliceStable(polls, func(i, j int) bool {
+		return polls[i].StartedAt.After(polls[j].StartedAt)
+	})
+
+	start, end, cursor, ok := helixPage(w, r, len(polls), maxPollsFirst)
+	if !ok {
+		return
+	}
+
+	var data []map[string]interface{}
+	for _, poll := range polls[start:end] {
+		data = append(data, s.helixPoll(poll))
+	}
+	helixData(w, data, &cursor)
+}
+
+func (s *Server) helixCreatePoll(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+	var body struct {
+		BroadcasterId string `json:"broadcaster_id"`
+		Title         string `json:"title"`
+		Choices       []struct {
+			Title string `json:"title"`
+		} `json:"choices"`
+		Duration             int  `json:"duration"`
+		ChannelPointsEnabled bool `json:"channel_points_voting_enabled"`
+		ChannelPointsPerVote int  `json:"channel_points_per_vote"`
+	}
+	if !decodeBody(w, r, &body) {
+		return
+	}
+	if body.BroadcasterId == "" || body.Title == "" {
+		badRequest(w, "Missing required field \"broadcaster_id\" or \"title\"")
+		return
+	}
+	if len(body.Choices) < 2 || len(body.Choices) > 5 {
+		badRequest(w, "The choices field must contain between 2 and 5 choices")
+		return
+	}
+	if body.Duration < 15 || body.Duration > 1800 {
+		badRequest(w, "The duration field must be between 15 and 1800 seconds")
+		return
+	}
+
+	poll := &Poll{
+		Id:            newId(),
+		BroadcasterId: body.BroadcasterId,
+		Title:         body.Title,
+		Status:        "ACTIVE",
+		Duration:      time.Duration(body.Duration) * time.Second,
+		StartedAt:     time.Now().UTC().Truncate(time.Second),
+	}
+	if body.ChannelPointsEnabled {
+		poll.ChannelPointsPerVote = body.ChannelPointsPerVote
+	}
+	for _, c := range body.Choices {
+		poll.Choices = append(poll.Choices, &PollChoice{Id: newId(), Title: c.Title})
+	}
+	s.state.Polls = append(s.state.Polls, poll)
+
+	helixData(w, []map[string]interface{}{s.helixPoll(poll)}, nil)
+}
+
+func (s *Server) helixEndPoll(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+	var body struct {
+		BroadcasterId string `json:"broadcaster_id"`
+		Id            string `json:"id"`
+		Status        string `json:"status"`
+	}
+	if !decodeBody(w, r, &body) {
+		return
+	}
+	if body.Status != "TERMINATED" && body.Status != "ARCHIVED" {
+		badRequest(w, "The status field must be TERMINATED or ARCHIVED")
+		return
+	}
+	s.state.expire(time.Now())
+
+	for _, poll := range s.state.Polls {
+		if poll.BroadcasterId != body.BroadcasterId || poll.Id != body.Id {
+			continue
+		}
+		if poll.Status != "ACTIVE" {
+			badRequest(w, "The poll must be active to end it")
+			return
+		}
+		poll.Status = body.Status
+		poll.EndedAt = time.Now().UTC().Truncate(time.Second)
+		helixData(w, []map[string]interface{}{s.helixPoll(poll)}, nil)
+		return
+	}
+	WriteError(w, http.StatusNotFound, "The poll was not found")
+}
+
+func (s *Server) helixPrediction(p *Prediction) map[string]interface{} {
+	login, name := s.userNames(p.BroadcasterId)
+	outcomes := []map[string]interface{}{}
+	for n, o := range p.Outcomes {
+		color := "BLUE"
+		if n == 1 && len(p.Outcomes) == 2 {
+			color = "PINK"
+		}
+		outcomes = append(outcomes, map[string]interface{}{
+			"id":             o.Id,
+			"title":          o.Title,
+			"users":          o.Users,
+			"channel_points": o.ChannelPoints,
+			"top_predictors": nil,
+			"color":          color,
+		})
+	}
+	var winner interface{}
+	if p.WinningOutcomeId != "" {
+		winner = p.WinningOutcomeId
+	}
+	return map[string]interface{}{
+		"id":                 p.Id,
+		"broadcaster_id":     p.BroadcasterId,
+		"broadcaster_login":  login,
+		"broadcaster_name":   name,
+		"title":              p.Title,
+		"winning_outcome_id": winner,
+		"outcomes":           outcomes,
+		"prediction_window":  int(p.PredictionWindow / time.Second),
+		"status":             p.Status,
+		"created_at":         timeString(p.CreatedAt),
+		"ended_at":           timeOrNil(p.EndedAt),
+		"locked_at":          timeOrNil(p.LockedAt),
+	}
+}
+
+func (s *Server) helixPredictions(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+	p, ok := required(w, r, "broadcaster_id")
+	if !ok {
+		return
+	}
+	ids := set(r.URL.Query()["id"])
+	s.state.expire(time.Now())
+
+	var predictions []*Prediction
+	for _, pr := range s.state.Predictions {
+		if pr.BroadcasterId == p[0] && (ids == nil || ids[pr.Id]) {
+			predictions = append(predictions, pr)
+		}
+	}
+	sort.SliceStable(predictions, func(i, j int) bool {
+		return predictions[i].CreatedAt.After(predictions[j].CreatedAt)
+	})
+
+	start, end, cursor, ok := helixPage(w, r, len(predictions), maxPredictionsFirst)
+	if !ok {
+		return
+	}
+
+	var data []map[string]interface{}
+	for _, pr := range predictions[start:end] {
+		data = append(data, s.helixPrediction(pr))
+	}
+	helixData(w, data, &cursor)
+}
+
+func (s *Server) helixCreatePrediction(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+	var body struct {
+		BroadcasterId string `json:"broadcaster_id"`
+		Title         string `json:"title"`
+		Outcomes      []struct {
+			Title string `json:"title"`
+		} `json:"outcomes"`
+		PredictionWindow int `json:"prediction_window"`
+	}
+	if !decodeBody(w, r, &body) {
+		return
+	}
+	if body.BroadcasterId == "" || body.Title == "" {
+		badRequest(w, "Missing required field \"broadcaster_id\" or \"title\"")
+		return
+	}
+	if len(body.Outcomes) < 2 || len(body.Outcomes) > 10 {
+		badRequest(w, "The outcomes field must contain between 2 and 10 outcomes")
+		return
+	}
+	if body.PredictionWindow < 30 || body.PredictionWindow > 1800 {
+		badRequest(w, "The prediction_window field must be between 30 and 1800 seconds")
+		return
+	}
+
+	pr := &Prediction{
+		Id:               newId(),
+		BroadcasterId:    body.BroadcasterId,
+		Title:            body.Title,
+		Status:           "ACTIVE",
+		PredictionWindow: time.Duration(body.PredictionWindow) * time.Second,
+		CreatedAt:        time.Now().UTC().Truncate(time.Second),
+	}
+	for _, o := range body.Outcomes {
+		pr.Outcomes = append(pr.Outcomes, &PredictionOutcome{Id: newId(), Title: o.Title})
+	}
+	s.state.Predictions = append(s.state.Predictions, pr)
+
+	helixData(w, []map[string]interface{}{s.helixPrediction(pr)}, nil)
+}
+
+func (s *Server) helixEndPrediction(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+	var body struct {
+		BroadcasterId    string `json:"broadcaster_id"`
+		Id               string `json:"id"`
+		Status           string `json:"status"`
+		WinningOutcomeId string `json:"winning_outcome_id"`
+	}
+	if !decodeBody(w, r, &body) {
+		return
+	}
+	s.state.expire(time.Now())
+
+	var pr *Prediction
+	for _, p := range s.state.Predictions {
+		if p.BroadcasterId == body.BroadcasterId && p.Id == body.Id {
+			pr = p
+		}
+	}
+	if pr == nil {
+		WriteError(w, http.StatusNotFound, "The prediction was not found")
+		return
+	}
+
+	now := time.Now().UTC().Truncate(time.Second)
+	switch {
+	case body.Status == "LOCKED" && pr.Status == "ACTIVE":
+		pr.LockedAt = now
+	case body.Status == "CANCELED" && (pr.Status == "ACTIVE" || pr.Status == "LOCKED"):
+		pr.EndedAt = now
+	case body.Status == "RESOLVED" && (pr.Status == "ACTIVE" || pr.Status == "LOCKED"):
+		found := false
+		for _, o := range pr.Outcomes {
+			found = found || o.Id == body.WinningOutcomeId
+		}
+		if !found {
+			badRequest(w, "The winning_outcome_id field must be the ID of one of the outcomes")
+			return
+		}
+		pr.WinningOutcomeId = body.WinningOutcomeId
+		pr.EndedAt = now
+	default:
+		badRequest(w, "The prediction may not be changed from %s to %s", pr.Status, body.Status)
+		return
+	}
+	pr.Status = body.Status
+
+	helixData(w, []map[string]interface{}{s.helixPrediction(pr)}, nil)
+}
@@ -0,0 +1,171 @@
+package twitchtest_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/catsby/go-twitch/service/helix"
+	"github.com/catsby/go-twitch/twitchtest"
+)
+
+func TestHelix_polls(t *testing.T) {
+	t.Parallel()
+
+	st := seed()
+	st.Polls = []*twitchtest.Poll{{
+		Id:            "old",
+		BroadcasterId: "1",
+		Title:         "Old",
+		Choices:       []*twitchtest.PollChoice{{Id: "a", Title: "A", Votes: 3}, {Id: "b", Title: "B"}},
+		Duration:      time.Minute,
+		StartedAt:     time.Now().Add(-time.Hour),
+	}}
+	s := twitchtest.NewServer(st)
+	defer s.Close()
+
+	c, err := helix.NewClient(s.HelixConfig())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	o, err := c.CreatePoll(&helix.CreatePollInput{
+		BroadcasterId:        "1",
+		Title:                "Next game?",
+		Choices:              []string{"Fortnite", "GTA V"},
+		Duration:             time.Minute,
+		ChannelPointsPerVote: 100,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	poll := o.Polls[0]
+	if poll.Status != helix.PollStatusActive || len(poll.Choices) != 2 || poll.Duration != 60 || !poll.ChannelPointsVotingEnabled {
+		t.Fatalf("Bad poll: %#v", poll)
+	}
+
+	// Polls are listed newest first, and the old one has run out of time.
+	o, err = c.GetPolls(&helix.GetPollsInput{BroadcasterId: "1", First: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(o.Polls) != 1 || o.Polls[0].Id != poll.Id || o.Pagination.Cursor == "" {
+		t.Fatalf("Bad first page: %#v", o)
+	}
+	o, err = c.GetPolls(&helix.GetPollsInput{BroadcasterId: "1", After: o.Pagination.Cursor})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(o.Polls) != 1 || o.Polls[0].Status != helix.PollStatusCompleted || o.Polls[0].EndedAt.IsZero() {
+		t.Fatalf("Expected the old poll to be completed, got %#v", o.Polls)
+	}
+	if o.Polls[0].Choices[0].Votes != 3 {
+		t.Fatalf("Bad choices: %#v", o.Polls[0].Choices)
+	}
+
+	o, err = c.EndPoll(&helix.EndPollInput{BroadcasterId: "1", Id: poll.Id, Status: helix.PollStatusTerminated})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if o.Polls[0].Status != helix.PollStatusTerminated || o.Polls[0].EndedAt.IsZero() {
+		t.Fatalf("Bad ended poll: %#v", o.Polls[0])
+	}
+	if _, err := c.EndPoll(&helix.EndPollInput{BroadcasterId: "1", Id: poll.Id, Status: helix.PollStatusArchived}); err == nil {
+		t.Fatalf("Expected an error ending a poll twice")
+	}
+	if _, err := c.EndPoll(&helix.EndPollInput{BroadcasterId: "1", Id: "unknown", Status: helix.PollStatusArchived}); err == nil {
+		t.Fatalf("Expected an error ending an unknown poll")
+	}
+
+	done, err := c.WaitPoll(&helix.WaitPollInput{BroadcasterId: "1", Id: poll.Id, Timeout: time.Second})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if done.Status != helix.PollStatusTerminated {
+		t.Fatalf("Bad status: %s", done.Status)
+	}
+}
+
+func TestHelix_predictions(t *testing.T) {
+	t.Parallel()
+
+	st := seed()
+	st.Predictions = []*twitchtest.Prediction{{
+		Id:               "old",
+		BroadcasterId:    "1",
+		Title:            "Old",
+		Outcomes:         []*twitchtest.PredictionOutcome{{Id: "a", Title: "Yes"}, {Id: "b", Title: "No"}},
+		PredictionWindow: time.Minute,
+		CreatedAt:        time.Now().Add(-time.Hour),
+	}}
+	s := twitchtest.NewServer(st)
+	defer s.Close()
+
+	c, err := helix.NewClient(s.HelixConfig())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	o, err := c.CreatePrediction(&helix.CreatePredictionInput{
+		BroadcasterId:    "1",
+		Title:            "Win?",
+		Outcomes:         []string{"Yes", "No"},
+		PredictionWindow: time.Minute,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	pr := o.Predictions[0]
+	if pr.Status != helix.PredictionStatusActive || len(pr.Outcomes) != 2 || pr.Outcomes[1].Color != "PINK" || pr.WinningOutcomeId != "" {
+		t.Fatalf("Bad prediction: %#v", pr)
+	}
+
+	o, err = c.GetPredictions(&helix.GetPredictionsInput{BroadcasterId: "1", Ids: []string{"old"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(o.Predictions) != 1 || o.Predictions[0].Status != helix.PredictionStatusLocked || o.Predictions[0].LockedAt.IsZero() {
+		t.Fatalf("Expected the old prediction to be locked, got %#v", o.Predictions)
+	}
+
+	o, err = c.EndPrediction(&helix.EndPredictionInput{BroadcasterId: "1", Id: pr.Id, Status: helix.PredictionStatusLocked})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if o.Predictions[0].Status != helix.PredictionStatusLocked {
+		t.Fatalf("Bad status: %s", o.Predictions[0].Status)
+	}
+	if _, err := c.EndPrediction(&helix.EndPredictionInput{BroadcasterId: "1", Id: pr.Id, Status: helix.PredictionStatusLocked}); err == nil {
+		t.Fatalf("Expected an error locking a prediction twice")
+	}
+	_, err = c.EndPrediction(&helix.EndPredictionInput{
+		BroadcasterId:    "1",
+		Id:               pr.Id,
+		Status:           helix.PredictionStatusResolved,
+		WinningOutcomeId: "unknown",
+	})
+	if err == nil {
+		t.Fatalf("Expected an error for an unknown winning outcome")
+	}
+
+	winner := pr.Outcomes[0].Id
+	o, err = c.EndPrediction(&helix.EndPredictionInput{
+		BroadcasterId:    "1",
+		Id:               pr.Id,
+		Status:           helix.PredictionStatusResolved,
+		WinningOutcomeId: winner,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if o.Predictions[0].WinningOutcomeId != winner || o.Predictions[0].EndedAt.IsZero() {
+		t.Fatalf("Bad resolved prediction: %#v", o.Predictions[0])
+	}
+
+	o, err = c.GetPredictions(&helix.GetPredictionsInput{BroadcasterId: "1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(o.Predictions) != 2 || o.Predictions[0].Status != helix.PredictionStatusResolved {
+		t.Fatalf("Expected the resolved prediction first, got %#v", o.Predictions)
+	}
+}
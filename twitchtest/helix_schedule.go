@@ -0,0 +1,430 @@
+package twitchtest
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// ScheduleWeeks is the number of weeks a recurring segment is listed for,
+// from the start_time of the request.
+const ScheduleWeeks = 4
+
+// Schedule segments are listed at most 25 to a page.
+const maxScheduleFirst = 25
+
+// Segments last 240 minutes unless the request sets a duration, which must
+// be between 30 and 1380 minutes.
+const (
+	defaultSegmentMinutes = 240
+	minSegmentMinutes     = 30
+	maxSegmentMinutes     = 1380
+)
+
+// occurrence is a single broadcast of a segment.
+type occurrence struct {
+	seg   *Segment
+	start time.Time
+}
+
+// id returns the ID of the occurrence. Occurrences of recurring segments
+// encode the segment's ID with the ISO week they are in, as Twitch does.
+func (o occurrence) id() string {
+	if !o.seg.IsRecurring {
+		return o.seg.Id
+	}
+	year, week := o.start.ISOWeek()
+	b, _ := json.Marshal(map[string]interface{}{
+		"segmentID": o.seg.Id,
+		"isoYear":   year,
+		"isoWeek":   week,
+	})
+	return base64.StdEncoding.EncodeToString(b)
+}
+
+func (o occurrence) canceled() bool {
+	for _, t := range o.seg.Canceled {
+		if t.Equal(o.start) {
+			return true
+		}
+	}
+	return false
+}
+
+// location returns the time zone of a segment, or UTC.
+func (seg *Segment) location() *time.Location {
+	if loc, err := time.LoadLocation(seg.Timezone); err == nil && seg.Timezone != "" {
+		return loc
+	}
+	return time.UTC
+}
+
+// occurrences returns the occurrences of a segment starting on or after
+// from, ScheduleWeeks of them if it is recurring.
+func (seg *Segment) occurrences(from time.Time) []occurrence {
+	if !seg.IsRecurring {
+		if seg.StartTime.Before(from) {
+			return nil
+		}
+		return []occurrence{{seg, seg.StartTime}}
+	}
+
+	// Weeks are added in the segment's time zone, so the occurrences keep
+	// their local time across daylight saving changes.
+	local := seg.StartTime.In(seg.location())
+	k := 0
+	if from.After(local) {
+		k = int(from.Sub(local)/(7*24*time.Hour)) - 1
+		if k < 0 {
+			k = 0
+		}
+	}
+	var out []occurrence
+	for ; len(out) < ScheduleWeeks; k++ {
+		start := local.AddDate(0, 0, 7*k)
+		if !start.Before(from) {
+			out = append(out, occurrence{seg, start})
+		}
+	}
+	return out
+}
+
+// occurrence returns the occurrence of a segment, or of one of its weeks,
+// with the ID.
+func (st *State) occurrence(broadcasterId, id string) (occurrence, bool) {
+	segmentId, year, week := id, 0, 0
+	if b, err := base64.StdEncoding.DecodeString(id); err == nil {
+		var v struct {
+			SegmentId string `json:"segmentID"`
+			IsoYear   int    `json:"isoYear"`
+			IsoWeek   int    `json:"isoWeek"`
+		}
+		if json.Unmarshal(b, &v) == nil && v.SegmentId != "" {
+			segmentId, year, week = v.SegmentId, v.IsoYear, v.IsoWeek
+		}
+	}
+
+	for _, seg := range st.Segments {
+		if seg.BroadcasterId != broadcasterId || seg.Id != segmentId {
+			continue
+		}
+		if !seg.IsRecurring || week == 0 {
+			return occurrence{seg, seg.StartTime}, true
+		}
+		local := seg.StartTime.In(seg.location())
+		for k := 0; k < 53*10; k++ {
+			start := local.AddDate(0, 0, 7*k)
+			if y, w := start.ISOWeek(); y == year && w == week {
+				return occurrence{seg, start}, true
+			}
+		}
+	}
+	return occurrence{}, false
+}
+
+func (s *Server) helixSegment(o occurrence) map[string]interface{} {
+	end := o.start.Add(o.seg.Duration)
+	var canceledUntil, category interface{}
+	if o.canceled() {
+		canceledUntil = timeString(end)
+	}
+	if o.seg.CategoryId != "" {
+		category = map[string]string{
+			"id":   o.seg.CategoryId,
+			"name": s.state.gameName(o.seg.CategoryId),
+		}
+	}
+	return map[string]interface{}{
+		"id":             o.id(),
+		"start_time":     timeString(o.start),
+		"end_time":       timeString(end),
+		"title":          o.seg.Title,
+		"canceled_until": canceledUntil,
+		"category":       category,
+		"is_recurring":   o.seg.IsRecurring,
+	}
+}
+
+// helixScheduleData returns the schedule of a channel with the segments.
+func (s *Server) helixScheduleData(broadcasterId string, segments []map[string]interface{}) map[string]interface{} {
+	login, name := s.userNames(broadcasterId)
+	var vacation interface{}
+	for _, v := range s.state.Vacations {
+		if v.BroadcasterId == broadcasterId && v.EndTime.After(time.Now()) {
+			vacation = map[string]string{
+				"start_time": timeString(v.StartTime),
+				"end_time":   timeString(v.EndTime),
+			}
+		}
+	}
+	if segments == nil {
+		segments = []map[string]interface{}{}
+	}
+	return map[string]interface{}{
+		"segments":          segments,
+		"broadcaster_id":    broadcasterId,
+		"broadcaster_name":  name,
+		"broadcaster_login": login,
+		"vacation":          vacation,
+	}
+}
+
+func (s *Server) helixSchedule(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+	p, ok := required(w, r, "broadcaster_id")
+	if !ok {
+		return
+	}
+	q := r.URL.Query()
+	ids := set(q["id"])
+	from := time.Now()
+	if v := q.Get("start_time"); v != "" {
+		t, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			badRequest(w, "The parameter \"start_time\" was malformed")
+			return
+		}
+		from = t
+	}
+
+	var occurrences []occurrence
+	for _, seg := range s.state.Segments {
+		if seg.BroadcasterId != p[0] {
+			continue
+		}
+		for _, o := range seg.occurrences(from) {
+			if ids == nil || ids[o.id()] || ids[seg.Id] {
+				occurrences = append(occurrences, o)
+			}
+		}
+	}
+	if len(occurrences) == 0 {
+		WriteError(w, http.StatusNotFound, "Segments were either not found or have been cancelled")
+		return
+	}
+	sort.SliceStable(occurrences, func(i, j int) bool {
+		return occurrences[i].start.Before(occurrences[j].start)
+	})
+
+	start, end, cursor, ok := helixPage(w, r, len(occurrences), maxScheduleFirst)
+	if !ok {
+		return
+	}
+
+	var segments []map[string]interface{}
+	for _, o := range occurrences[start:end] {
+		segments = append(segments, s.helixSegment(o))
+	}
+	body := map[string]interface{}{
+		"data":       s.helixScheduleData(p[0], segments),
+		"pagination": map[string]string{},
+	}
+	if cursor != "" {
+		body["pagination"] = map[string]string{"cursor": cursor}
+	}
+	WriteJSON(w, http.StatusOK, body)
+}
+
+// segmentMinutes parses the duration of a segment, sent as a string of
+// minutes, writing a 400 and returning false if it is invalid.
+func segmentMinutes(w http.ResponseWriter, v string) (time.Duration, bool) {
+	m, err := strconv.Atoi(v)
+	if err != nil || m < minSegmentMinutes || m > maxSegmentMinutes {
+		badRequest(w, "The duration field must be between %d and %d minutes", minSegmentMinutes, maxSegmentMinutes)
+		return 0, false
+	}
+	return time.Duration(m) * time.Minute, true
+}
+
+// segmentStart parses the start time and time zone of a segment, writing a
+// 400 and returning false if either is invalid.
+func segmentStart(w http.ResponseWriter, v, timezone string) (time.Time, bool) {
+	t, err := time.Parse(time.RFC3339, v)
+	if err != nil {
+		badRequest(w, "The start_time field was malformed")
+		return time.Time{}, false
+	}
+	if _, err := time.LoadLocation(timezone); err != nil || timezone == "" {
+		badRequest(w, "The timezone field must be an IANA time zone")
+		return time.Time{}, false
+	}
+	return t, true
+}
+
+func (s *Server) helixCreateSegment(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+	p, ok := required(w, r, "broadcaster_id")
+	if !ok {
+		return
+	}
+	var body struct {
+		StartTime   string `json:"start_time"`
+		Timezone    string `json:"timezone"`
+		Duration    string `json:"duration"`
+		IsRecurring bool   `json:"is_recurring"`
+		CategoryId  string `json:"category_id"`
+		Title       string `json:"title"`
+	}
+	if !decodeBody(w, r, &body) {
+		return
+	}
+	start, ok := segmentStart(w, body.StartTime, body.Timezone)
+	if !ok {
+		return
+	}
+	d := defaultSegmentMinutes * time.Minute
+	if body.Duration != "" {
+		if d, ok = segmentMinutes(w, body.Duration); !ok {
+			return
+		}
+	}
+
+	seg := &Segment{
+		Id:            newId(),
+		BroadcasterId: p[0],
+		StartTime:     start,
+		Duration:      d,
+		Title:         body.Title,
+		CategoryId:    body.CategoryId,
+		IsRecurring:   body.IsRecurring,
+		Timezone:      body.Timezone,
+	}
+	s.state.Segments = append(s.state.Segments, seg)
+
+	segments := []map[string]interface{}{s.helixSegment(occurrence{seg, seg.StartTime})}
+	WriteJSON(w, http.StatusOK, map[string]interface{}{
+		"data": s.helixScheduleData(p[0], segments),
+	})
+}
+
+func (s *Server) helixUpdateSegment(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+	p, ok := required(w, r, "broadcaster_id", "id")
+	if !ok {
+		return
+	}
+	var body struct {
+		StartTime  *string `json:"start_time"`
+		Timezone   *string `json:"timezone"`
+		Duration   *string `json:"duration"`
+		CategoryId *string `json:"category_id"`
+		Title      *string `json:"title"`
+		IsCanceled *bool   `json:"is_canceled"`
+	}
+	if !decodeBody(w, r, &body) {
+		return
+	}
+	o, found := s.state.occurrence(p[0], p[1])
+	if !found {
+		WriteError(w, http.StatusNotFound, "The segment was not found")
+		return
+	}
+	seg := o.seg
+
+	// Validate every field before changing any.
+	start, d := seg.StartTime, seg.Duration
+	timezone := seg.Timezone
+	if body.Timezone != nil {
+		timezone = *body.Timezone
+	}
+	if body.StartTime != nil {
+		if seg.IsRecurring && body.Timezone == nil {
+			badRequest(w, "The timezone field is required to change the start_time of a recurring segment")
+			return
+		}
+		if start, ok = segmentStart(w, *body.StartTime, timezone); !ok {
+			return
+		}
+	}
+	if body.Duration != nil {
+		if d, ok = segmentMinutes(w, *body.Duration); !ok {
+			return
+		}
+	}
+
+	if body.IsCanceled != nil {
+		var canceled []time.Time
+		for _, t := range seg.Canceled {
+			if !t.Equal(o.start) {
+				canceled = append(canceled, t)
+			}
+		}
+		if *body.IsCanceled {
+			canceled = append(canceled, o.start)
+		}
+		seg.Canceled = canceled
+	}
+	if body.StartTime != nil {
+		// The cancellations were of the old occurrences.
+		o.start = o.start.Add(start.Sub(seg.StartTime))
+		seg.StartTime, seg.Timezone, seg.Canceled = start, timezone, nil
+	}
+	seg.Duration = d
+	if body.CategoryId != nil {
+		seg.CategoryId = *body.CategoryId
+	}
+	if body.Title != nil {
+		seg.Title = *body.Title
+	}
+
+	WriteJSON(w, http.StatusOK, map[string]interface{}{
+		"data": s.helixScheduleData(p[0], []map[string]interface{}{s.helixSegment(o)}),
+	})
+}
+
+func (s *Server) helixDeleteSegment(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+	p, ok := required(w, r, "broadcaster_id", "id")
+	if !ok {
+		return
+	}
+	// Deleting an occurrence deletes every occurrence of its segment, and
+	// deleting a segment that does not exist succeeds, as it does on Twitch.
+	if o, found := s.state.occurrence(p[0], p[1]); found {
+		for n, seg := range s.state.Segments {
+			if seg == o.seg {
+				s.state.Segments = append(s.state.Segments[:n], s.state.Segments[n+1:]...)
+				break
+			}
+		}
+	}
+	noContent(w)
+}
+
+func (s *Server) helixScheduleSettings(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+	p, ok := required(w, r, "broadcaster_id")
+	if !ok {
+		return
+	}
+	q := r.URL.Query()
+
+	var vacations []*Vacation
+	for _, v := range s.state.Vacations {
+		if v.BroadcasterId != p[0] {
+			vacations = append(vacations, v)
+		}
+	}
+
+	if q.Get("is_vacation_enabled") == "true" {
+		v, ok := required(w, r, "vacation_start_time", "vacation_end_time", "timezone")
+		if !ok {
+			return
+		}
+		start, err := time.Parse(time.RFC3339, v[0])
+		end, err2 := time.Parse(time.RFC3339, v[1])
+		if err != nil || err2 != nil || !end.After(start) {
+			badRequest(w, "The vacation_start_time and vacation_end_time parameters must be times, in order")
+			return
+		}
+		if _, err := time.LoadLocation(v[2]); err != nil {
+			badRequest(w, "The timezone parameter must be an IANA time zone")
+			return
+		}
+		vacations = append(vacations, &Vacation{
+			BroadcasterId: p[0],
+			StartTime:     start,
+			EndTime:       end,
+		})
+	}
+	s.state.Vacations = vacations
+	noContent(w)
+}
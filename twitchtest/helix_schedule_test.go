@@ -0,0 +1,164 @@
+package twitchtest_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/catsby/go-twitch/service/helix"
+	"github.com/catsby/go-twitch/twitch"
+	"github.com/catsby/go-twitch/twitchtest"
+)
+
+func TestHelix_schedule(t *testing.T) {
+	t.Parallel()
+
+	s := twitchtest.NewServer(seed())
+	defer s.Close()
+
+	c, err := helix.NewClient(s.HelixConfig())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ny, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skip(err)
+	}
+	start := time.Date(2021, 10, 25, 18, 0, 0, 0, ny)
+
+	o, err := c.CreateScheduleSegment(&helix.CreateScheduleSegmentInput{
+		BroadcasterId: "1",
+		StartTime:     start,
+		Timezone:      "America/New_York",
+		Duration:      time.Hour,
+		IsRecurring:   true,
+		CategoryId:    "33214",
+		Title:         "Weekly",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	seg := o.Schedule.Segments[0]
+	if !seg.IsRecurring || seg.Category == nil || seg.Category.Name != "Fortnite" || o.Schedule.BroadcasterLogin != "catsby" {
+		t.Fatalf("Bad segment: %#v", seg)
+	}
+
+	var segments []*helix.ScheduleSegment
+	in := &helix.GetChannelStreamScheduleInput{BroadcasterId: "1", StartTime: start, First: 3}
+	for {
+		o, err := c.GetChannelStreamSchedule(in)
+		if err != nil {
+			t.Fatal(err)
+		}
+		segments = append(segments, o.Schedule.Segments...)
+		if o.Pagination.Cursor == "" {
+			break
+		}
+		in.After = o.Pagination.Cursor
+	}
+	if len(segments) != twitchtest.ScheduleWeeks {
+		t.Fatalf("Expected %d weeks, got %d", twitchtest.ScheduleWeeks, len(segments))
+	}
+	// Each week has its own ID, and keeps the local time across the end of
+	// daylight saving time on November 7.
+	for n, seg := range segments {
+		if got := seg.StartTime.In(ny); got.Hour() != 18 || got.Day() != start.AddDate(0, 0, 7*n).Day() {
+			t.Fatalf("Bad start of week %d: %s", n, got)
+		}
+		if n > 0 && seg.Id == segments[n-1].Id {
+			t.Fatalf("Expected each week to have its own ID, got %s twice", seg.Id)
+		}
+	}
+
+	// Canceling an occurrence cancels that week only.
+	u, err := c.UpdateScheduleSegment(&helix.UpdateScheduleSegmentInput{
+		BroadcasterId: "1",
+		Id:            segments[1].Id,
+		IsCanceled:    twitch.Bool(true),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !u.Schedule.Segments[0].Canceled() || u.Schedule.Segments[0].Id != segments[1].Id {
+		t.Fatalf("Bad canceled segment: %#v", u.Schedule.Segments[0])
+	}
+
+	g, err := c.GetChannelStreamSchedule(&helix.GetChannelStreamScheduleInput{BroadcasterId: "1", StartTime: start})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if g.Schedule.Segments[0].Canceled() || !g.Schedule.Segments[1].Canceled() {
+		t.Fatalf("Expected only the second week to be canceled")
+	}
+
+	// The weeks are grouped into a single recurring event.
+	var buf bytes.Buffer
+	if err := g.Schedule.WriteICalendar(&buf, ny); err != nil {
+		t.Fatal(err)
+	}
+	if n := strings.Count(buf.String(), "BEGIN:VEVENT"); n != 1 || !strings.Contains(buf.String(), "EXDATE") {
+		t.Fatalf("Expected one event with an exception, got:\n%s", buf.String())
+	}
+
+	if err := c.DeleteScheduleSegment(&helix.DeleteScheduleSegmentInput{BroadcasterId: "1", Id: segments[2].Id}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.GetChannelStreamSchedule(&helix.GetChannelStreamScheduleInput{BroadcasterId: "1", StartTime: start}); err == nil {
+		t.Fatalf("Expected an error for an empty schedule")
+	}
+}
+
+func TestHelix_scheduleVacation(t *testing.T) {
+	t.Parallel()
+
+	st := seed()
+	st.Segments = []*twitchtest.Segment{{
+		Id:            "single",
+		BroadcasterId: "1",
+		StartTime:     time.Now().Add(24 * time.Hour).UTC().Truncate(time.Second),
+		Duration:      time.Hour,
+	}}
+	s := twitchtest.NewServer(st)
+	defer s.Close()
+
+	c, err := helix.NewClient(s.HelixConfig())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	vacation := time.Now().UTC().Truncate(time.Second)
+	err = c.UpdateScheduleSettings(&helix.UpdateScheduleSettingsInput{
+		BroadcasterId:     "1",
+		IsVacationEnabled: true,
+		VacationStartTime: vacation,
+		VacationEndTime:   vacation.Add(7 * 24 * time.Hour),
+		Timezone:          "UTC",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	o, err := c.GetChannelStreamSchedule(&helix.GetChannelStreamScheduleInput{BroadcasterId: "1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v := o.Schedule.Vacation; v == nil || !v.StartTime.Equal(vacation) {
+		t.Fatalf("Bad vacation: %#v", v)
+	}
+	if len(o.Schedule.Segments) != 1 || o.Schedule.Segments[0].Id != "single" || o.Schedule.Segments[0].IsRecurring {
+		t.Fatalf("Bad segments: %#v", o.Schedule.Segments)
+	}
+
+	if err := c.UpdateScheduleSettings(&helix.UpdateScheduleSettingsInput{BroadcasterId: "1"}); err != nil {
+		t.Fatal(err)
+	}
+	o, err = c.GetChannelStreamSchedule(&helix.GetChannelStreamScheduleInput{BroadcasterId: "1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if o.Schedule.Vacation != nil {
+		t.Fatalf("Expected the vacation to end, got %#v", o.Schedule.Vacation)
+	}
+}
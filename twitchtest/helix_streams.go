@@ -0,0 +1,275 @@
+package twitchtest
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// A commercial can be started once every 8 minutes. Snoozing pushes the next
+// ad back by 5 minutes, and a used snooze comes back after an hour.
+const (
+	commercialCooldown = 8 * time.Minute
+	snoozeDelay        = 5 * time.Minute
+	snoozeRefresh      = time.Hour
+)
+
+// maxMarkerDescription is the longest description of a stream marker.
+const maxMarkerDescription = 140
+
+func (s *Server) helixMarker(m *Marker) map[string]interface{} {
+	login, _ := s.userNames(m.UserId)
+	pos := int(m.Position / time.Second)
+	return map[string]interface{}{
+		"id":               m.Id,
+		"created_at":       timeString(m.CreatedAt),
+		"description":      m.Description,
+		"position_seconds": pos,
+		"url":              fmt.Sprintf("https://twitch.tv/%s/manager/highlighter/%s?t=%dh%dm%ds", login, m.VideoId, pos/3600, pos/60%60, pos%60),
+	}
+}
+
+// helixCreateMarker marks the live stream of the user at how long it has
+// been live.
+func (s *Server) helixCreateMarker(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+	var body struct {
+		UserId      string `json:"user_id"`
+		Description string `json:"description"`
+	}
+	if !decodeBody(w, r, &body) {
+		return
+	}
+	if body.UserId == "" {
+		badRequest(w, "Missing required field \"user_id\"")
+		return
+	}
+	if len([]rune(body.Description)) > maxMarkerDescription {
+		badRequest(w, "The description field must be at most %d characters", maxMarkerDescription)
+		return
+	}
+	st := s.state.stream(body.UserId)
+	if st == nil {
+		WriteError(w, http.StatusNotFound, "The user is not streaming live")
+		return
+	}
+
+	now := time.Now().UTC().Truncate(time.Second)
+	m := &Marker{
+		Id:          newId(),
+		UserId:      body.UserId,
+		VideoId:     st.Id,
+		Description: body.Description,
+		CreatedAt:   now,
+	}
+	if !st.StartedAt.IsZero() {
+		m.Position = now.Sub(st.StartedAt).Truncate(time.Second)
+	}
+	s.state.Markers = append(s.state.Markers, m)
+	helixData(w, []map[string]interface{}{s.helixMarker(m)}, nil)
+}
+
+// helixMarkers returns the markers of a video, or of the user's most recent
+// one.
+func (s *Server) helixMarkers(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+	q := r.URL.Query()
+	userId, videoId := q.Get("user_id"), q.Get("video_id")
+	if (userId == "") == (videoId == "") {
+		badRequest(w, "Must provide exactly one of user_id or video_id")
+		return
+	}
+	if videoId == "" {
+		var latest time.Time
+		for _, m := range s.state.Markers {
+			if m.UserId == userId && !m.CreatedAt.Before(latest) {
+				latest, videoId = m.CreatedAt, m.VideoId
+			}
+		}
+	}
+
+	var markers []*Marker
+	for _, m := range s.state.Markers {
+		if videoId != "" && m.VideoId == videoId {
+			markers = append(markers, m)
+		}
+	}
+
+	start, end, cursor, ok := helixPage(w, r, len(markers), maxFirst)
+	if !ok {
+		return
+	}
+	if len(markers) == 0 {
+		helixData(w, nil, &cursor)
+		return
+	}
+
+	var data []map[string]interface{}
+	for _, m := range markers[start:end] {
+		data = append(data, s.helixMarker(m))
+	}
+	owner := markers[0].UserId
+	login, name := s.userNames(owner)
+	helixData(w, []map[string]interface{}{{
+		"user_id":    owner,
+		"user_name":  name,
+		"user_login": login,
+		"videos": []map[string]interface{}{{
+			"video_id": videoId,
+			"markers":  data,
+		}},
+	}}, &cursor)
+}
+
+// adSchedule returns the ad schedule of a channel, or a new empty one.
+func (st *State) adSchedule(broadcasterId string) *AdSchedule {
+	for _, a := range st.AdSchedules {
+		if a.BroadcasterId == broadcasterId {
+			return a
+		}
+	}
+	return &AdSchedule{BroadcasterId: broadcasterId}
+}
+
+// helixStartCommercial answers a 429 until commercialCooldown has passed
+// since the last ad.
+func (s *Server) helixStartCommercial(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+	var body struct {
+		BroadcasterId string `json:"broadcaster_id"`
+		Length        int    `json:"length"`
+	}
+	if !decodeBody(w, r, &body) {
+		return
+	}
+	if body.BroadcasterId == "" {
+		badRequest(w, "Missing required field \"broadcaster_id\"")
+		return
+	}
+	if body.Length < 1 || body.Length > 180 {
+		badRequest(w, "The length field must be between 1 and 180")
+		return
+	}
+	if s.state.stream(body.BroadcasterId) == nil {
+		badRequest(w, "To start a commercial, the broadcaster must be streaming live.")
+		return
+	}
+
+	now := time.Now().UTC().Truncate(time.Second)
+	a := s.state.adSchedule(body.BroadcasterId)
+	if wait := a.LastAdAt.Add(commercialCooldown).Sub(now); wait > 0 {
+		w.Header().Set("Retry-After", strconv.Itoa(int(wait/time.Second)))
+		WriteError(w, http.StatusTooManyRequests, fmt.Sprintf("The broadcaster may not run another commercial for %d seconds.", int(wait/time.Second)))
+		return
+	}
+	a.LastAdAt = now
+	if s.state.adSchedule(body.BroadcasterId) != a {
+		s.state.AdSchedules = append(s.state.AdSchedules, a)
+	}
+
+	helixData(w, []map[string]interface{}{{
+		"length":      body.Length,
+		"message":     "",
+		"retry_after": int(commercialCooldown / time.Second),
+	}}, nil)
+}
+
+// helixAdSchedule leaves out the next ad of a channel that is not live.
+func (s *Server) helixAdSchedule(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+	p, ok := required(w, r, "broadcaster_id")
+	if !ok {
+		return
+	}
+	a := s.state.adSchedule(p[0])
+	next := a.NextAdAt
+	if s.state.stream(p[0]) == nil {
+		next = time.Time{}
+	}
+	helixData(w, []map[string]interface{}{{
+		"next_ad_at":        timeString(next),
+		"last_ad_at":        timeString(a.LastAdAt),
+		"duration":          int(a.Duration / time.Second),
+		"preroll_free_time": int(a.PrerollFreeTime / time.Second),
+		"snooze_count":      a.SnoozeCount,
+		"snooze_refresh_at": timeString(a.SnoozeRefreshAt),
+	}}, nil)
+}
+
+func (s *Server) helixSnoozeNextAd(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+	p, ok := required(w, r, "broadcaster_id")
+	if !ok {
+		return
+	}
+	a := s.state.adSchedule(p[0])
+	if s.state.stream(p[0]) == nil || a.NextAdAt.IsZero() {
+		badRequest(w, "The channel is not live or has no ad scheduled.")
+		return
+	}
+	if a.SnoozeCount == 0 {
+		WriteError(w, http.StatusTooManyRequests, "The channel has no snoozes left.")
+		return
+	}
+
+	a.NextAdAt = a.NextAdAt.Add(snoozeDelay)
+	a.SnoozeCount--
+	if a.SnoozeRefreshAt.IsZero() {
+		a.SnoozeRefreshAt = time.Now().UTC().Truncate(time.Second).Add(snoozeRefresh)
+	}
+	helixData(w, []map[string]interface{}{{
+		"snooze_count":      a.SnoozeCount,
+		"snooze_refresh_at": timeString(a.SnoozeRefreshAt),
+		"next_ad_at":        timeString(a.NextAdAt),
+	}}, nil)
+}
+
+// raid returns the index of the pending raid of a channel, or -1.
+func (st *State) raid(broadcasterId string) int {
+	for n, rd := range st.Raids {
+		if rd.FromBroadcasterId == broadcasterId {
+			return n
+		}
+	}
+	return -1
+}
+
+func (s *Server) helixStartRaid(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+	p, ok := required(w, r, "from_broadcaster_id", "to_broadcaster_id")
+	if !ok {
+		return
+	}
+	if p[0] == p[1] {
+		badRequest(w, "The broadcaster may not raid themselves.")
+		return
+	}
+	if s.state.user(p[1]) == nil {
+		WriteError(w, http.StatusNotFound, "The targeted channel was not found.")
+		return
+	}
+	if s.state.raid(p[0]) >= 0 {
+		WriteError(w, http.StatusConflict, "The broadcaster is already in the process of raiding another channel.")
+		return
+	}
+
+	rd := &Raid{
+		FromBroadcasterId: p[0],
+		ToBroadcasterId:   p[1],
+		CreatedAt:         time.Now().UTC().Truncate(time.Second),
+	}
+	s.state.Raids = append(s.state.Raids, rd)
+	helixData(w, []map[string]interface{}{{
+		"created_at": timeString(rd.CreatedAt),
+		"is_mature":  s.state.channel(p[1]).Mature,
+	}}, nil)
+}
+
+func (s *Server) helixCancelRaid(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+	p, ok := required(w, r, "broadcaster_id")
+	if !ok {
+		return
+	}
+	n := s.state.raid(p[0])
+	if n < 0 {
+		WriteError(w, http.StatusNotFound, "The broadcaster doesn't have a pending raid to cancel.")
+		return
+	}
+	s.state.Raids = append(s.state.Raids[:n], s.state.Raids[n+1:]...)
+	noContent(w)
+}
@@ -0,0 +1,171 @@
+package twitchtest_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/catsby/go-twitch/service/helix"
+	"github.com/catsby/go-twitch/twitchtest"
+)
+
+func TestHelix_markers(t *testing.T) {
+	t.Parallel()
+
+	s := twitchtest.NewServer(seed())
+	defer s.Close()
+
+	c, err := helix.NewClient(s.HelixConfig())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := c.CreateStreamMarker(&helix.CreateStreamMarkerInput{UserId: "4"}); err == nil {
+		t.Fatalf("Expected an error marking a stream that is not live")
+	}
+	for _, d := range []string{"first", "second", "third"} {
+		if _, err := c.CreateStreamMarker(&helix.CreateStreamMarkerInput{UserId: "2", Description: d}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	m := s.State().Markers
+	if len(m) != 3 || m[0].VideoId != "10" || m[0].Position < 24*time.Hour {
+		t.Fatalf("Bad markers in the state: %#v", m)
+	}
+
+	var descriptions []string
+	in := &helix.GetStreamMarkersInput{UserId: "2", First: 2}
+	for {
+		o, err := c.GetStreamMarkers(in)
+		if err != nil {
+			t.Fatal(err)
+		}
+		u := o.Users[0]
+		if u.UserLogin != "afro" || u.Videos[0].VideoId != "10" {
+			t.Fatalf("Bad user markers: %#v", u)
+		}
+		for _, m := range u.Videos[0].Markers {
+			descriptions = append(descriptions, m.Description)
+		}
+		if o.Pagination.Cursor == "" {
+			break
+		}
+		in.After = o.Pagination.Cursor
+	}
+	if len(descriptions) != 3 || descriptions[2] != "third" {
+		t.Fatalf("Bad markers: %v", descriptions)
+	}
+
+	o, err := c.GetStreamMarkers(&helix.GetStreamMarkersInput{VideoId: "10"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ms := o.Users[0].Videos[0].Markers; len(ms) != 3 || ms[0].Url == "" {
+		t.Fatalf("Bad video markers: %#v", ms)
+	}
+}
+
+func TestHelix_ads(t *testing.T) {
+	t.Parallel()
+
+	next := time.Now().UTC().Truncate(time.Second).Add(time.Hour)
+	st := seed()
+	st.AdSchedules = []*twitchtest.AdSchedule{
+		{BroadcasterId: "1", NextAdAt: next, Duration: time.Minute, SnoozeCount: 1},
+		{BroadcasterId: "4", NextAdAt: next, SnoozeCount: 3},
+	}
+	s := twitchtest.NewServer(st)
+	defer s.Close()
+
+	c, err := helix.NewClient(s.HelixConfig())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := c.StartCommercial(&helix.StartCommercialInput{BroadcasterId: "4", Length: time.Minute}); err == nil {
+		t.Fatalf("Expected an error starting a commercial on a channel that is not live")
+	}
+	o, err := c.StartCommercial(&helix.StartCommercialInput{BroadcasterId: "1", Length: time.Minute})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cm := o.Commercials[0]; cm.Length != time.Minute || cm.RetryAfter != 8*time.Minute {
+		t.Fatalf("Bad commercial: %#v", cm)
+	}
+	if _, err := c.StartCommercial(&helix.StartCommercialInput{BroadcasterId: "1", Length: time.Minute}); err == nil {
+		t.Fatalf("Expected an error starting a second commercial within 8 minutes")
+	}
+
+	a, err := c.GetAdSchedule(&helix.GetAdScheduleInput{BroadcasterId: "1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sc := a.Schedules[0]; !sc.NextAdAt.Equal(next) || sc.LastAdAt.IsZero() || sc.Duration != time.Minute {
+		t.Fatalf("Bad ad schedule: %#v", sc)
+	}
+	a, err = c.GetAdSchedule(&helix.GetAdScheduleInput{BroadcasterId: "4"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sc := a.Schedules[0]; !sc.NextAdAt.IsZero() || sc.SnoozeCount != 3 {
+		t.Fatalf("Expected no next ad while offline, got %#v", sc)
+	}
+
+	sn, err := c.SnoozeNextAd(&helix.SnoozeNextAdInput{BroadcasterId: "1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if z := sn.Snoozes[0]; z.SnoozeCount != 0 || !z.NextAdAt.Equal(next.Add(5*time.Minute)) || z.SnoozeRefreshAt.IsZero() {
+		t.Fatalf("Bad snooze: %#v", z)
+	}
+	if _, err := c.SnoozeNextAd(&helix.SnoozeNextAdInput{BroadcasterId: "1"}); err == nil {
+		t.Fatalf("Expected an error snoozing without snoozes left")
+	}
+	if _, err := c.SnoozeNextAd(&helix.SnoozeNextAdInput{BroadcasterId: "4"}); err == nil {
+		t.Fatalf("Expected an error snoozing while offline")
+	}
+}
+
+func TestHelix_raids(t *testing.T) {
+	t.Parallel()
+
+	st := seed()
+	st.Channels = append(st.Channels, &twitchtest.Channel{BroadcasterId: "3", Mature: true})
+	s := twitchtest.NewServer(st)
+	defer s.Close()
+
+	c, err := helix.NewClient(s.HelixConfig())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	raid := func(from, to string) (*helix.StartRaidOutput, error) {
+		return c.StartRaid(&helix.StartRaidInput{FromBroadcasterId: from, ToBroadcasterId: to})
+	}
+	if _, err := raid("1", "1"); !errors.Is(err, helix.ErrRaidSelf) {
+		t.Fatalf("Expected ErrRaidSelf, got %v", err)
+	}
+	if _, err := raid("1", "404"); !errors.Is(err, helix.ErrRaidTargetNotFound) {
+		t.Fatalf("Expected ErrRaidTargetNotFound, got %v", err)
+	}
+	o, err := raid("1", "3")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r := o.Raids[0]; !r.IsMature || r.CreatedAt.IsZero() {
+		t.Fatalf("Bad raid: %#v", r)
+	}
+	if _, err := raid("1", "2"); !errors.Is(err, helix.ErrRaidInProgress) {
+		t.Fatalf("Expected ErrRaidInProgress, got %v", err)
+	}
+
+	if err := c.CancelRaid(&helix.CancelRaidInput{BroadcasterId: "1"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.CancelRaid(&helix.CancelRaidInput{BroadcasterId: "1"}); !errors.Is(err, helix.ErrNoPendingRaid) {
+		t.Fatalf("Expected ErrNoPendingRaid, got %v", err)
+	}
+	if n := len(s.State().Raids); n != 0 {
+		t.Fatalf("Expected no pending raids, got %d", n)
+	}
+}
@@ -0,0 +1,124 @@
+package twitchtest_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/catsby/go-twitch/service/helix"
+	"github.com/catsby/go-twitch/twitchtest"
+)
+
+// seed returns a small State shared by the tests.
+func seed() *twitchtest.State {
+	created := time.Date(2016, 12, 14, 20, 32, 28, 0, time.UTC)
+	return &twitchtest.State{
+		AuthUserId: "1",
+		Users: []*twitchtest.User{
+			{Id: "1", Login: "catsby", DisplayName: "catsby", CreatedAt: created},
+			{Id: "2", Login: "afro", DisplayName: "Afro", Description: "Variety"},
+			{Id: "3", Login: "lirik", DisplayName: "LIRIK"},
+			{Id: "4", Login: "offline", DisplayName: "Offline"},
+		},
+		Channels: []*twitchtest.Channel{
+			{BroadcasterId: "2", Title: "GTA RP", GameId: "32982", Language: "en", Views: 100},
+		},
+		Streams: []*twitchtest.Stream{
+			{Id: "10", UserId: "2", GameId: "32982", Title: "GTA RP", ViewerCount: 1490, Language: "en", StartedAt: created},
+			{Id: "11", UserId: "3", GameId: "33214", Title: "Fortnite", ViewerCount: 25000, Language: "en"},
+			{Id: "12", UserId: "1", GameId: "33214", Title: "Coding", ViewerCount: 3, Language: "de"},
+		},
+		Clips: []*twitchtest.Clip{
+			{Id: "AwkwardHelplessSalamanderSwiftRage", BroadcasterId: "2", CreatorId: "1", GameId: "32982", Title: "lol", ViewCount: 10, Language: "en", Duration: 30},
+			{Id: "BoringSlimyPorcupineKappa", BroadcasterId: "3", CreatorId: "1", GameId: "33214", Title: "clutch", ViewCount: 500, Language: "en", Duration: 12.5, VideoId: "205586603"},
+		},
+		Games: []*twitchtest.Game{
+			{Id: "32982", Name: "Grand Theft Auto V"},
+			{Id: "33214", Name: "Fortnite"},
+		},
+		Follows: []*twitchtest.Follow{
+			{UserId: "1", BroadcasterId: "2", FollowedAt: created},
+			{UserId: "1", BroadcasterId: "3", FollowedAt: created},
+			{UserId: "4", BroadcasterId: "2", FollowedAt: created},
+		},
+	}
+}
+
+func TestHelix_GetStreams(t *testing.T) {
+	t.Parallel()
+
+	s := twitchtest.NewServer(seed())
+	defer s.Close()
+
+	c, err := helix.NewClient(s.HelixConfig())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var logins []string
+	in := &helix.GetStreamsInput{First: 2}
+	for {
+		o, err := c.GetStreams(in)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, st := range o.Streams {
+			logins = append(logins, st.UserLogin)
+		}
+		if o.Pagination.Cursor == "" {
+			break
+		}
+		in.After = o.Pagination.Cursor
+	}
+	if len(logins) != 3 || logins[0] != "lirik" || logins[2] != "catsby" {
+		t.Fatalf("Expected streams by viewers, got %v", logins)
+	}
+	s.AssertRequested(t, "GET", "/helix/streams", 2)
+
+	o, err := c.GetStreams(&helix.GetStreamsInput{
+		UserLogins: []string{"afro", "offline"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(o.Streams) != 1 || o.Streams[0].GameName != "Grand Theft Auto V" || o.Streams[0].StartedAt.IsZero() {
+		t.Fatalf("Bad streams: %#v", o.Streams)
+	}
+
+	s.Update(func(st *twitchtest.State) {
+		st.Streams = st.Streams[1:]
+	})
+	o, err = c.GetStreams(&helix.GetStreamsInput{UserIds: []string{"2"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(o.Streams) != 0 {
+		t.Fatalf("Expected afro to be offline, got %#v", o.Streams)
+	}
+
+	if _, err := c.GetStreams(&helix.GetStreamsInput{First: 101}); err == nil {
+		t.Fatalf("Expected an error for First 101")
+	}
+}
+
+func TestHelix_GetGames(t *testing.T) {
+	t.Parallel()
+
+	s := twitchtest.NewServer(seed())
+	defer s.Close()
+
+	c, err := helix.NewClient(s.HelixConfig())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	o, err := c.GetGames(&helix.GetGamesInput{
+		Ids:   []int{32982},
+		Names: []string{"fortnite", "Unknown"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(o.Games) != 2 || o.Games[1].Name != "Fortnite" {
+		t.Fatalf("Bad games: %#v", o.Games)
+	}
+}
@@ -0,0 +1,508 @@
+package twitchtest
+
+import (
+	"hash/crc32"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Kraken lists default to 25 objects, and allow at most 100. Clips default to
+// 10.
+const (
+	defaultLimit     = 25
+	defaultClipLimit = 10
+	maxLimit         = 100
+)
+
+func (s *Server) krakenRoutes() {
+	s.route("GET", "/kraken/user", s.krakenAuthUser)
+	s.route("GET", "/kraken/users/:id", s.krakenUser)
+	s.route("GET", "/kraken/users/:id/follows/channels", s.krakenUserFollows)
+	s.route("GET", "/kraken/channel", s.krakenAuthChannel)
+	s.route("GET", "/kraken/channels/:id", s.krakenChannel)
+	s.route("GET", "/kraken/channels/:id/follows", s.krakenChannelFollows)
+	s.route("GET", "/kraken/channels/:id/videos", s.krakenChannelVideos)
+	s.route("GET", "/kraken/streams", s.krakenStreams)
+	s.route("GET", "/kraken/streams/summary", s.krakenStreamSummary)
+	s.route("GET", "/kraken/streams/featured", s.krakenFeaturedStreams)
+	s.route("GET", "/kraken/streams/followed", s.krakenFollowedStreams)
+	s.route("GET", "/kraken/streams/:id", s.krakenStream)
+	s.route("GET", "/kraken/clips/top", s.krakenTopClips)
+	s.route("GET", "/kraken/clips/followed", s.krakenFollowedClips)
+	s.route("GET", "/kraken/clips/:slug", s.krakenClip)
+	s.route("GET", "/kraken/ingests", s.krakenIngests)
+}
+
+// krakenPage returns the bounds of the requested page of n objects, writing a
+// 400 and returning false if the page parameters are invalid.
+func krakenPage(w http.ResponseWriter, r *http.Request, n, limit int) (int, int, bool) {
+	q := r.URL.Query()
+
+	if v := q.Get("limit"); v != "" {
+		var err error
+		limit, err = strconv.Atoi(v)
+		if err != nil || limit < 1 || limit > maxLimit {
+			badRequest(w, "Invalid limit parameter, must be between 1 and %d", maxLimit)
+			return 0, 0, false
+		}
+	}
+
+	offset := 0
+	if v := q.Get("offset"); v != "" {
+		var err error
+		offset, err = strconv.Atoi(v)
+		if err != nil || offset < 0 {
+			badRequest(w, "Invalid offset parameter")
+			return 0, 0, false
+		}
+	}
+
+	if offset > n {
+		offset = n
+	}
+	end := offset + limit
+	if end > n {
+		end = n
+	}
+	return offset, end, true
+}
+
+func (s *Server) krakenUserJSON(u *User) map[string]interface{} {
+	userType := u.Type
+	if userType == "" {
+		userType = "user"
+	}
+	return map[string]interface{}{
+		"_id":          krakenId(u.Id),
+		"name":         u.Login,
+		"display_name": u.DisplayName,
+		"type":         userType,
+		"bio":          u.Description,
+		"logo":         u.ProfileImageURL,
+		"created_at":   timeString(u.CreatedAt),
+	}
+}
+
+func (s *Server) krakenChannelJSON(u *User) map[string]interface{} {
+	c := s.state.channel(u.Id)
+	return map[string]interface{}{
+		"_id":                  krakenId(u.Id),
+		"name":                 u.Login,
+		"display_name":         u.DisplayName,
+		"broadcaster_language": c.Language,
+		"language":             c.Language,
+		"description":          u.Description,
+		"followers":            len(s.state.followers(u.Id)),
+		"game":                 s.state.gameName(c.GameId),
+		"url":                  "https://www.twitch.tv/" + u.Login,
+		"logo":                 u.ProfileImageURL,
+		"mature":               c.Mature,
+		"status":               c.Title,
+		"views":                c.Views,
+		"created_at":           timeString(u.CreatedAt),
+		"updated_at":           timeString(c.UpdatedAt),
+	}
+}
+
+func (s *Server) krakenStreamJSON(st *Stream) map[string]interface{} {
+	u := s.state.user(st.UserId)
+	if u == nil {
+		u = &User{Id: st.UserId}
+	}
+	return map[string]interface{}{
+		"_id":          krakenId(st.Id),
+		"game":         s.state.gameName(st.GameId),
+		"viewers":      st.ViewerCount,
+		"average_fps":  60,
+		"delay":        0,
+		"is_playlist":  false,
+		"stream_type":  "live",
+		"created_at":   timeString(st.StartedAt),
+		"channel":      s.krakenChannelJSON(u),
+		"video_height": 1080,
+	}
+}
+
+// userParam returns the user with the ID in the route, writing a 404 and
+// returning nil if there is none.
+func (s *Server) userParam(w http.ResponseWriter, id string) *User {
+	u := s.state.user(id)
+	if u == nil {
+		WriteError(w, http.StatusNotFound, "User does not exist")
+	}
+	return u
+}
+
+func (s *Server) authUser(w http.ResponseWriter) *User {
+	u := s.state.user(s.state.AuthUserId)
+	if u == nil {
+		WriteError(w, http.StatusUnauthorized, "Token invalid or missing required scope")
+	}
+	return u
+}
+
+func (s *Server) krakenAuthUser(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+	if u := s.authUser(w); u != nil {
+		WriteJSON(w, http.StatusOK, s.krakenUserJSON(u))
+	}
+}
+
+func (s *Server) krakenUser(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	if u := s.userParam(w, params["id"]); u != nil {
+		WriteJSON(w, http.StatusOK, s.krakenUserJSON(u))
+	}
+}
+
+func (s *Server) krakenUserFollows(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	u := s.userParam(w, params["id"])
+	if u == nil {
+		return
+	}
+
+	follows := s.state.following(u.Id)
+	start, end, ok := krakenPage(w, r, len(follows), defaultLimit)
+	if !ok {
+		return
+	}
+
+	out := []map[string]interface{}{}
+	for _, f := range follows[start:end] {
+		c := s.state.user(f.BroadcasterId)
+		if c == nil {
+			continue
+		}
+		out = append(out, map[string]interface{}{
+			"created_at":    timeString(f.FollowedAt),
+			"notifications": false,
+			"channel":       s.krakenChannelJSON(c),
+		})
+	}
+	WriteJSON(w, http.StatusOK, map[string]interface{}{
+		"_total":  len(follows),
+		"follows": out,
+	})
+}
+
+func (s *Server) krakenAuthChannel(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+	if u := s.authUser(w); u != nil {
+		WriteJSON(w, http.StatusOK, s.krakenChannelJSON(u))
+	}
+}
+
+func (s *Server) krakenChannel(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	if u := s.userParam(w, params["id"]); u != nil {
+		WriteJSON(w, http.StatusOK, s.krakenChannelJSON(u))
+	}
+}
+
+func (s *Server) krakenChannelFollows(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	u := s.userParam(w, params["id"])
+	if u == nil {
+		return
+	}
+
+	follows := s.state.followers(u.Id)
+	start, end, ok := krakenPage(w, r, len(follows), defaultLimit)
+	if !ok {
+		return
+	}
+
+	out := []map[string]interface{}{}
+	for _, f := range follows[start:end] {
+		follower := s.state.user(f.UserId)
+		if follower == nil {
+			continue
+		}
+		out = append(out, map[string]interface{}{
+			"created_at":    timeString(f.FollowedAt),
+			"notifications": false,
+			"user":          s.krakenUserJSON(follower),
+		})
+	}
+	WriteJSON(w, http.StatusOK, map[string]interface{}{
+		"_total":  len(follows),
+		"follows": out,
+	})
+}
+
+// krakenChannelVideos answers with no videos, since the State has none.
+func (s *Server) krakenChannelVideos(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	if u := s.userParam(w, params["id"]); u != nil {
+		WriteJSON(w, http.StatusOK, map[string]interface{}{
+			"_total": 0,
+			"videos": []interface{}{},
+		})
+	}
+}
+
+func (s *Server) writeKrakenStreams(w http.ResponseWriter, r *http.Request, streams []*Stream) {
+	start, end, ok := krakenPage(w, r, len(streams), defaultLimit)
+	if !ok {
+		return
+	}
+
+	out := []map[string]interface{}{}
+	for _, st := range streams[start:end] {
+		out = append(out, s.krakenStreamJSON(st))
+	}
+	WriteJSON(w, http.StatusOK, map[string]interface{}{
+		"_total":  len(streams),
+		"streams": out,
+	})
+}
+
+// filterStreams returns the live streams matching the channel, game and
+// language query parameters.
+func (s *Server) filterStreams(r *http.Request) []*Stream {
+	q := r.URL.Query()
+
+	var channels map[string]bool
+	if v := q.Get("channel"); v != "" {
+		channels = set(strings.Split(v, ","))
+	}
+	game, language := q.Get("game"), q.Get("language")
+
+	var out []*Stream
+	for _, st := range s.state.liveStreams() {
+		if channels != nil && !channels[st.UserId] {
+			continue
+		}
+		if game != "" && !strings.EqualFold(s.state.gameName(st.GameId), game) {
+			continue
+		}
+		if language != "" && st.Language != language {
+			continue
+		}
+		out = append(out, st)
+	}
+	return out
+}
+
+func (s *Server) krakenStreams(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+	s.writeKrakenStreams(w, r, s.filterStreams(r))
+}
+
+func (s *Server) krakenStreamSummary(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+	streams := s.filterStreams(r)
+	viewers := 0
+	for _, st := range streams {
+		viewers += st.ViewerCount
+	}
+	WriteJSON(w, http.StatusOK, map[string]interface{}{
+		"channels": len(streams),
+		"viewers":  viewers,
+	})
+}
+
+// krakenFeaturedStreams answers with no featured streams, since the State has
+// none.
+func (s *Server) krakenFeaturedStreams(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+	WriteJSON(w, http.StatusOK, map[string]interface{}{
+		"featured": []interface{}{},
+	})
+}
+
+func (s *Server) krakenFollowedStreams(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+	u := s.authUser(w)
+	if u == nil {
+		return
+	}
+
+	followed := make(map[string]bool)
+	for _, f := range s.state.following(u.Id) {
+		followed[f.BroadcasterId] = true
+	}
+
+	var streams []*Stream
+	for _, st := range s.state.liveStreams() {
+		if followed[st.UserId] {
+			streams = append(streams, st)
+		}
+	}
+	s.writeKrakenStreams(w, r, streams)
+}
+
+func (s *Server) krakenStream(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	u := s.userParam(w, params["id"])
+	if u == nil {
+		return
+	}
+
+	var stream interface{}
+	if st := s.state.stream(u.Id); st != nil {
+		stream = s.krakenStreamJSON(st)
+	}
+	WriteJSON(w, http.StatusOK, map[string]interface{}{
+		"stream": stream,
+	})
+}
+
+func (s *Server) krakenClipJSON(c *Clip) map[string]interface{} {
+	person := func(id string) map[string]interface{} {
+		u := s.state.user(id)
+		if u == nil {
+			u = &User{Id: id}
+		}
+		return map[string]interface{}{
+			"id":           u.Id,
+			"name":         u.Login,
+			"display_name": u.DisplayName,
+			"channel_url":  "https://www.twitch.tv/" + u.Login,
+			"logo":         u.ProfileImageURL,
+		}
+	}
+
+	var vod interface{}
+	if c.VideoId != "" {
+		vod = map[string]interface{}{
+			"id":  c.VideoId,
+			"url": "https://www.twitch.tv/videos/" + c.VideoId,
+		}
+	}
+
+	thumbnail := "https://clips-media-assets.twitch.tv/" + c.Id + "-preview"
+	return map[string]interface{}{
+		"slug":        c.Id,
+		"tracking_id": strconv.FormatUint(uint64(crc32.ChecksumIEEE([]byte(c.Id))), 10),
+		"url":         "https://clips.twitch.tv/" + c.Id,
+		"embed_url":   "https://clips.twitch.tv/embed?clip=" + c.Id,
+		"embed_html":  "<iframe src='https://clips.twitch.tv/embed?clip=" + c.Id + "'></iframe>",
+		"broadcaster": person(c.BroadcasterId),
+		"curator":     person(c.CreatorId),
+		"vod":         vod,
+		"game":        s.state.gameName(c.GameId),
+		"language":    c.Language,
+		"title":       c.Title,
+		"views":       c.ViewCount,
+		"duration":    c.Duration,
+		"created_at":  timeString(c.CreatedAt),
+		"thumbnails": map[string]string{
+			"medium": thumbnail + "-480x272.jpg",
+			"small":  thumbnail + "-260x147.jpg",
+			"tiny":   thumbnail + "-86x45.jpg",
+		},
+	}
+}
+
+func (s *Server) krakenClip(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	for _, c := range s.state.Clips {
+		if c.Id == params["slug"] {
+			WriteJSON(w, http.StatusOK, s.krakenClipJSON(c))
+			return
+		}
+	}
+	WriteError(w, http.StatusNotFound, "Clip does not exist")
+}
+
+// writeKrakenClips writes a page of clips, most viewed first. The cursor is
+// the offset of the next page.
+func (s *Server) writeKrakenClips(w http.ResponseWriter, r *http.Request, clips []*Clip) {
+	q := r.URL.Query()
+	offset := 0
+	if v := q.Get("cursor"); v != "" {
+		var ok bool
+		if offset, ok = decodeCursor(v); !ok {
+			badRequest(w, "Invalid cursor parameter")
+			return
+		}
+		q.Set("offset", strconv.Itoa(offset))
+		r.URL.RawQuery = q.Encode()
+	}
+
+	sorted := make([]*Clip, len(clips))
+	copy(sorted, clips)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].ViewCount > sorted[j].ViewCount
+	})
+
+	start, end, ok := krakenPage(w, r, len(sorted), defaultClipLimit)
+	if !ok {
+		return
+	}
+
+	out := []map[string]interface{}{}
+	for _, c := range sorted[start:end] {
+		out = append(out, s.krakenClipJSON(c))
+	}
+
+	var cursor string
+	if end < len(sorted) {
+		cursor = encodeCursor(end)
+	}
+	WriteJSON(w, http.StatusOK, map[string]interface{}{
+		"clips":   out,
+		"_cursor": cursor,
+	})
+}
+
+func (s *Server) krakenTopClips(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+	q := r.URL.Query()
+
+	var channels map[string]bool
+	if v := q.Get("channel"); v != "" {
+		channels = set(strings.Split(strings.ToLower(v), ","))
+	}
+	game, language := q.Get("game"), q.Get("language")
+
+	var clips []*Clip
+	for _, c := range s.state.Clips {
+		if channels != nil {
+			u := s.state.user(c.BroadcasterId)
+			if u == nil || !channels[strings.ToLower(u.Login)] {
+				continue
+			}
+		}
+		if game != "" && !strings.EqualFold(s.state.gameName(c.GameId), game) {
+			continue
+		}
+		if language != "" && c.Language != language {
+			continue
+		}
+		clips = append(clips, c)
+	}
+	s.writeKrakenClips(w, r, clips)
+}
+
+func (s *Server) krakenFollowedClips(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+	u := s.authUser(w)
+	if u == nil {
+		return
+	}
+
+	followed := make(map[string]bool)
+	for _, f := range s.state.following(u.Id) {
+		followed[f.BroadcasterId] = true
+	}
+
+	var clips []*Clip
+	for _, c := range s.state.Clips {
+		if followed[c.BroadcasterId] {
+			clips = append(clips, c)
+		}
+	}
+	s.writeKrakenClips(w, r, clips)
+}
+
+// krakenIngests answers with a fixed list of ingest servers.
+func (s *Server) krakenIngests(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+	WriteJSON(w, http.StatusOK, map[string]interface{}{
+		"ingests": []map[string]interface{}{
+			{
+				"_id":          24,
+				"availability": 1.0,
+				"default":      true,
+				"name":         "US West: San Francisco, CA",
+				"url_template": "rtmp://live-sjc.twitch.tv/app/{stream_key}",
+			},
+			{
+				"_id":          25,
+				"availability": 1.0,
+				"default":      false,
+				"name":         "EU: Amsterdam, NL",
+				"url_template": "rtmp://live-ams.twitch.tv/app/{stream_key}",
+			},
+		},
+	})
+}
@@ -0,0 +1,170 @@
+package twitchtest_test
+
+import (
+	"testing"
+
+	"github.com/catsby/go-twitch/service/kraken"
+	"github.com/catsby/go-twitch/twitchtest"
+)
+
+func TestKraken_users(t *testing.T) {
+	t.Parallel()
+
+	s := twitchtest.NewServer(seed())
+	defer s.Close()
+
+	c, err := kraken.NewClient(s.KrakenConfig())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	me, err := c.GetUser(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if me.Id != 1 || me.Name != "catsby" {
+		t.Fatalf("Bad user: %#v", me)
+	}
+
+	follows, err := c.GetUserFollows(&kraken.GetUserFollowsInput{Id: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if follows.Total != 2 || follows.Follows[0].Channel.Name != "afro" {
+		t.Fatalf("Bad follows: %#v", follows)
+	}
+
+	if _, err := c.GetUser(&kraken.GetUserInput{Id: 99}); err == nil {
+		t.Fatalf("Expected an error for a missing user")
+	}
+
+	r := s.AssertRequested(t, "GET", "/kraken/user", 1)
+	if r.Header.Get("Accept") != "application/vnd.twitchtv.v5+json" {
+		t.Fatalf("Bad Accept header: %q", r.Header.Get("Accept"))
+	}
+}
+
+func TestKraken_channels(t *testing.T) {
+	t.Parallel()
+
+	s := twitchtest.NewServer(seed())
+	defer s.Close()
+
+	c, err := kraken.NewClient(s.KrakenConfig())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	o, err := c.GetChannel(&kraken.GetChannelInput{Id: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ch := o.Channel
+	if ch.Name != "afro" || ch.Status != "GTA RP" || ch.Game != "Grand Theft Auto V" || ch.Followers != 2 {
+		t.Fatalf("Bad channel: %#v", ch)
+	}
+
+	followers, err := c.GetChannelFollowers(&kraken.GetChannelFollowersInput{Id: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if followers.Total != 2 {
+		t.Fatalf("Expected (2) followers, got (%d)", followers.Total)
+	}
+}
+
+func TestKraken_streams(t *testing.T) {
+	t.Parallel()
+
+	s := twitchtest.NewServer(seed())
+	defer s.Close()
+
+	c, err := kraken.NewClient(s.KrakenConfig())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	o, _, err := c.GetStream(&kraken.GetStreamInput{ChannelId: 3})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if o.Stream == nil || o.Stream.Channel.Name != "lirik" || o.Stream.Game != "Fortnite" {
+		t.Fatalf("Bad stream: %#v", o.Stream)
+	}
+
+	o, _, err = c.GetStream(&kraken.GetStreamInput{ChannelId: 4})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if o.Stream != nil {
+		t.Fatalf("Expected no stream, got %#v", o.Stream)
+	}
+
+	live, err := c.GetLiveStreams(&kraken.GetLiveStreamsInput{Game: "Fortnite"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if live.Total != 2 || live.Streams[0].Channel.Name != "lirik" {
+		t.Fatalf("Bad live streams: %#v", live)
+	}
+
+	summary, err := c.GetStreamSummary(&kraken.GetStreamSummaryInput{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if summary.Channels != 3 || summary.Viewers != 26493 {
+		t.Fatalf("Bad summary: %#v", summary)
+	}
+
+	followed, err := c.GetFollowedStreams(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if followed.Total != 2 {
+		t.Fatalf("Expected (2) followed streams, got (%d)", followed.Total)
+	}
+}
+
+func TestKraken_clips(t *testing.T) {
+	t.Parallel()
+
+	s := twitchtest.NewServer(seed())
+	defer s.Close()
+
+	c, err := kraken.NewClient(s.KrakenConfig())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	clip, err := c.GetClip(&kraken.GetClipInput{Slug: "BoringSlimyPorcupineKappa"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if clip.Title != "clutch" || clip.Curator.Name != "catsby" || clip.Duration != 12.5 {
+		t.Fatalf("Bad clip: %#v", clip)
+	}
+
+	top, err := c.GetTopClips(&kraken.GetTopClipsInput{Limit: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(top.Clips) != 1 || top.Clips[0].Views != 500 || top.Cursor == "" {
+		t.Fatalf("Bad top clips: %#v", top)
+	}
+
+	top, err = c.GetTopClips(&kraken.GetTopClipsInput{Limit: 1, Cursor: top.Cursor})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(top.Clips) != 1 || top.Clips[0].Title != "lol" || top.Cursor != "" {
+		t.Fatalf("Bad second page: %#v", top)
+	}
+
+	ingests, err := c.GetIngestServerList(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ingests.IngestList) == 0 {
+		t.Fatalf("Expected ingest servers")
+	}
+}
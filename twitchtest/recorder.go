@@ -0,0 +1,344 @@
+package twitchtest
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/dnaeon/go-vcr/cassette"
+	"github.com/dnaeon/go-vcr/recorder"
+)
+
+// Redacted replaces secrets in recorded fixtures.
+const Redacted = "xxxxxxxxxxxxx"
+
+// Environment variables that choose the recorder mode: RECORD=true records new
+// fixtures against the real API, and RECORD_DISABLE=true skips the fixtures
+// entirely. Fixtures are replayed otherwise.
+const (
+	RecordEnvVar        = "RECORD"
+	RecordDisableEnvVar = "RECORD_DISABLE"
+)
+
+// redactedHeaders are always redacted. Authorization keeps its scheme, Ex:
+// "Bearer xxxxxxxxxxxxx", so fixtures show which API was used.
+var redactedHeaders = []string{
+	"Authorization",
+	"Client-Id",
+	"Cookie",
+	"Set-Cookie",
+}
+
+// redactedFields are the JSON fields, form fields and query parameters that
+// are always redacted, covering the OAuth token endpoints.
+var redactedFields = []string{
+	"access_token",
+	"refresh_token",
+	"client_secret",
+	"client_id",
+	"code",
+	"id_token",
+}
+
+// RecorderOptions configures NewRecorder.
+type RecorderOptions struct {
+	// Mode defaults to RecorderMode().
+	Mode *recorder.Mode
+
+	// Transport is used to make real requests. Default:
+	// http.DefaultTransport.
+	Transport http.RoundTripper
+
+	// RedactHeaders and RedactFields are redacted along with the defaults,
+	// Ex: "stream_key" or "email". Fields are matched in JSON bodies at any
+	// depth, and in form bodies and query parameters.
+	RedactHeaders []string
+	RedactFields  []string
+}
+
+// RecorderMode returns the recorder mode chosen by the RECORD and
+// RECORD_DISABLE environment variables.
+func RecorderMode() recorder.Mode {
+	switch {
+	case os.Getenv(RecordDisableEnvVar) == "true":
+		return recorder.ModeDisabled
+	case os.Getenv(RecordEnvVar) == "true":
+		return recorder.ModeRecording
+	default:
+		return recorder.ModeReplaying
+	}
+}
+
+// NewRecorder returns a go-vcr recorder for the fixture, Ex:
+// "fixtures/users/get", that redacts secrets before interactions are written
+// and matches requests on their method, host, path and query parameters in any
+// order. The caller must call Stop to save a recording.
+func NewRecorder(fixture string, o *RecorderOptions) (*recorder.Recorder, error) {
+	if o == nil {
+		o = new(RecorderOptions)
+	}
+	mode := RecorderMode()
+	if o.Mode != nil {
+		mode = *o.Mode
+	}
+
+	r, err := recorder.NewAsMode(fixture, mode, o.Transport)
+	if err != nil {
+		return nil, err
+	}
+
+	headers := append(append([]string{}, redactedHeaders...), o.RedactHeaders...)
+	fields := make(map[string]bool)
+	for _, f := range append(append([]string{}, redactedFields...), o.RedactFields...) {
+		fields[strings.ToLower(f)] = true
+	}
+
+	r.SetMatcher(matchRequest)
+	r.AddSaveFilter(func(i *cassette.Interaction) error {
+		redactHeaders(i.Request.Headers, headers)
+		redactHeaders(i.Response.Headers, headers)
+		i.Request.URL = redactURL(i.Request.URL, fields)
+		i.Request.Form = redactValues(i.Request.Form, fields)
+		i.Request.Body = redactBody(i.Request.Body, fields)
+		i.Response.Body = redactBody(i.Response.Body, fields)
+		return nil
+	})
+
+	return r, nil
+}
+
+// matchRequest matches requests on their method, host, path, query
+// parameters and body, ignoring the order of the parameters and of the fields
+// of JSON bodies. A redacted parameter or field matches any value, and a
+// fixture with no recorded body matches any body.
+func matchRequest(r *http.Request, i cassette.Request) bool {
+	if r.Method != i.Method {
+		return false
+	}
+
+	u, err := url.Parse(i.URL)
+	if err != nil {
+		return false
+	}
+	if r.URL.Host != u.Host || r.URL.Path != u.Path {
+		return false
+	}
+
+	if !matchValues(r.URL.Query(), u.Query()) {
+		return false
+	}
+	return matchBody(r, i.Body)
+}
+
+// matchValues reports whether got has the values of want, in any order.
+func matchValues(got, want url.Values) bool {
+	if len(got) != len(want) {
+		return false
+	}
+	for k, wv := range want {
+		gv := got[k]
+		if len(gv) != len(wv) {
+			return false
+		}
+		if len(wv) == 1 && wv[0] == Redacted {
+			continue
+		}
+		sort.Strings(gv)
+		sort.Strings(wv)
+		for n := range wv {
+			if gv[n] != wv[n] {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// matchBody compares the body of r to a recorded one: JSON bodies by value,
+// form bodies by their values, and other bodies as they are. The body of r is
+// left to be read again.
+func matchBody(r *http.Request, recorded string) bool {
+	want := strings.TrimSpace(recorded)
+	if want == "" {
+		return true
+	}
+
+	var got []byte
+	if r.Body != nil {
+		b, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			return false
+		}
+		r.Body.Close()
+		r.Body = ioutil.NopCloser(bytes.NewReader(b))
+		got = bytes.TrimSpace(b)
+	}
+
+	if want[0] == '{' || want[0] == '[' {
+		var wv, gv interface{}
+		if json.Unmarshal([]byte(want), &wv) != nil || json.Unmarshal(got, &gv) != nil {
+			return false
+		}
+		return matchJSON(gv, wv)
+	}
+
+	if strings.Contains(want, "=") {
+		wv, werr := url.ParseQuery(want)
+		gv, gerr := url.ParseQuery(string(got))
+		if werr == nil && gerr == nil {
+			return matchValues(gv, wv)
+		}
+	}
+	return string(got) == want
+}
+
+// matchJSON reports whether the decoded JSON value got equals want, where a
+// redacted string in want matches any value.
+func matchJSON(got, want interface{}) bool {
+	switch w := want.(type) {
+	case string:
+		if w == Redacted {
+			return true
+		}
+	case map[string]interface{}:
+		g, ok := got.(map[string]interface{})
+		if !ok || len(g) != len(w) {
+			return false
+		}
+		for k, wv := range w {
+			gv, ok := g[k]
+			if !ok || !matchJSON(gv, wv) {
+				return false
+			}
+		}
+		return true
+	case []interface{}:
+		g, ok := got.([]interface{})
+		if !ok || len(g) != len(w) {
+			return false
+		}
+		for n := range w {
+			if !matchJSON(g[n], w[n]) {
+				return false
+			}
+		}
+		return true
+	}
+	return got == want
+}
+
+func redactHeaders(h http.Header, names []string) {
+	for _, name := range names {
+		values := h[http.CanonicalHeaderKey(name)]
+		for n, v := range values {
+			if sp := strings.IndexByte(v, ' '); sp > 0 && strings.EqualFold(name, "Authorization") {
+				values[n] = v[:sp+1] + Redacted
+			} else {
+				values[n] = Redacted
+			}
+		}
+	}
+}
+
+func redactValues(v url.Values, fields map[string]bool) url.Values {
+	for k, values := range v {
+		if fields[strings.ToLower(k)] {
+			for n := range values {
+				values[n] = Redacted
+			}
+		}
+	}
+	return v
+}
+
+func redactURL(raw string, fields map[string]bool) string {
+	u, err := url.Parse(raw)
+	if err != nil || u.RawQuery == "" {
+		return raw
+	}
+
+	q := u.Query()
+	redacted := false
+	for k := range q {
+		if fields[strings.ToLower(k)] {
+			redacted = true
+		}
+	}
+	if !redacted {
+		return raw
+	}
+
+	u.RawQuery = redactValues(q, fields).Encode()
+	return u.String()
+}
+
+// redactBody redacts a JSON or form encoded body, leaving other bodies as
+// they are.
+func redactBody(body string, fields map[string]bool) string {
+	trimmed := strings.TrimSpace(body)
+	if trimmed == "" {
+		return body
+	}
+
+	if trimmed[0] == '{' || trimmed[0] == '[' {
+		var v interface{}
+		if err := json.Unmarshal([]byte(trimmed), &v); err != nil {
+			return body
+		}
+		if !redactJSON(v, fields) {
+			return body
+		}
+		b, err := json.Marshal(v)
+		if err != nil {
+			return body
+		}
+		return string(b)
+	}
+
+	if !strings.Contains(trimmed, "=") {
+		return body
+	}
+	q, err := url.ParseQuery(trimmed)
+	if err != nil {
+		return body
+	}
+	for k := range q {
+		if fields[strings.ToLower(k)] {
+			return redactValues(q, fields).Encode()
+		}
+	}
+	return body
+}
+
+// redactJSON replaces the values of the fields in a decoded JSON value, and
+// reports whether any were found.
+func redactJSON(v interface{}, fields map[string]bool) bool {
+	found := false
+	switch t := v.(type) {
+	case map[string]interface{}:
+		for k, child := range t {
+			if fields[strings.ToLower(k)] {
+				if child != nil {
+					t[k] = Redacted
+				}
+				found = true
+				continue
+			}
+			if redactJSON(child, fields) {
+				found = true
+			}
+		}
+	case []interface{}:
+		for _, child := range t {
+			if redactJSON(child, fields) {
+				found = true
+			}
+		}
+	}
+	return found
+}
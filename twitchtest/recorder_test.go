@@ -0,0 +1,160 @@
+package twitchtest
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/dnaeon/go-vcr/cassette"
+	"github.com/dnaeon/go-vcr/recorder"
+)
+
+func TestRecorder_redacts(t *testing.T) {
+	t.Parallel()
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.SetCookie(w, &http.Cookie{Name: "session", Value: "s3cr3t-cookie"})
+		if r.URL.Path == "/oauth2/token" {
+			w.Write([]byte(`{"access_token":"s3cr3t-access","refresh_token":"s3cr3t-refresh","expires_in":14124,"token_type":"bearer"}`))
+			return
+		}
+		w.Write([]byte(`{"data":[{"id":"1","login":"catsby","email":"catsby@example.com"}]}`))
+	}))
+	defer ts.Close()
+
+	dir, err := ioutil.TempDir("", "twitchtest")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	fixture := filepath.Join(dir, "fixture")
+
+	mode := recorder.ModeRecording
+	r, err := NewRecorder(fixture, &RecorderOptions{
+		Mode:         &mode,
+		RedactFields: []string{"email"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := &http.Client{Transport: r}
+
+	resp, err := c.PostForm(ts.URL+"/oauth2/token", map[string][]string{
+		"client_id":     {"s3cr3t-client"},
+		"client_secret": {"s3cr3t-secret"},
+		"grant_type":    {"refresh_token"},
+		"refresh_token": {"s3cr3t-refresh"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	body, _ := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	if !strings.Contains(string(body), "s3cr3t-access") {
+		t.Fatalf("Expected the live response to be unchanged, got %s", body)
+	}
+
+	req, _ := http.NewRequest("GET", ts.URL+"/helix/users?login=catsby&id=1", nil)
+	req.Header.Set("Authorization", "Bearer s3cr3t-token")
+	req.Header.Set("Client-Id", "s3cr3t-client")
+	resp, err = c.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+
+	if err := r.Stop(); err != nil {
+		t.Fatal(err)
+	}
+
+	b, err := ioutil.ReadFile(fixture + ".yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	saved := string(b)
+	if strings.Contains(saved, "s3cr3t") || strings.Contains(saved, "catsby@example.com") {
+		t.Fatalf("Expected secrets to be redacted:\n%s", saved)
+	}
+	if !strings.Contains(saved, "Bearer "+Redacted) || !strings.Contains(saved, "grant_type=refresh_token") {
+		t.Fatalf("Expected redacted values in place:\n%s", saved)
+	}
+
+	// Replay with the query parameters in another order.
+	mode = recorder.ModeReplaying
+	r, err = NewRecorder(fixture, &RecorderOptions{Mode: &mode})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Stop()
+	c = &http.Client{Transport: r}
+
+	ts.Close()
+	resp, err = c.Get(ts.URL + "/helix/users?id=1&login=catsby")
+	if err != nil {
+		t.Fatal(err)
+	}
+	body, _ = ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	if !strings.Contains(string(body), `"login":"catsby"`) {
+		t.Fatalf("Bad replayed body: %s", body)
+	}
+
+	if _, err := c.Get(ts.URL + "/helix/users?id=2&login=catsby"); err == nil {
+		t.Fatalf("Expected no interaction for other parameters")
+	}
+}
+
+func TestMatchRequest_redactedParams(t *testing.T) {
+	t.Parallel()
+
+	req, _ := http.NewRequest("POST", "https://id.twitch.tv/oauth2/token?client_secret=real&grant_type=client_credentials", nil)
+	recorded := "https://id.twitch.tv/oauth2/token?client_secret=" + Redacted + "&grant_type=client_credentials"
+	if !matchRequest(req, cassetteRequest("POST", recorded)) {
+		t.Fatalf("Expected a redacted parameter to match any value")
+	}
+	if matchRequest(req, cassetteRequest("GET", recorded)) {
+		t.Fatalf("Expected the method to be matched")
+	}
+}
+
+func TestMatchRequest_body(t *testing.T) {
+	t.Parallel()
+
+	const u = "https://api.twitch.tv/helix/polls"
+	cases := []struct {
+		body     string
+		recorded string
+		match    bool
+	}{
+		{`{"title":"Poll","duration":60}`, `{"duration":60,"title":"Poll"}`, true},
+		{`{"title":"Poll","duration":60}`, `{"duration":61,"title":"Poll"}`, false},
+		{`{"title":"Poll"}`, `{"duration":60,"title":"Poll"}`, false},
+		{`{"choices":[{"title":"a"},{"title":"b"}]}`, `{"choices":[{"title":"b"},{"title":"a"}]}`, false},
+		{`{"client_secret":"real"}`, `{"client_secret":"` + Redacted + `"}`, true},
+		{`grant_type=refresh_token&client_secret=real`, `client_secret=` + Redacted + `&grant_type=refresh_token`, true},
+		{`grant_type=client_credentials`, `grant_type=refresh_token`, false},
+		{`{"title":"Poll"}`, ``, true},
+	}
+
+	for _, tc := range cases {
+		req, _ := http.NewRequest("POST", u, strings.NewReader(tc.body))
+		i := cassetteRequest("POST", u)
+		i.Body = tc.recorded
+		if matchRequest(req, i) != tc.match {
+			t.Errorf("Expected %s matching %s to be %t", tc.body, tc.recorded, tc.match)
+		}
+
+		// The body is left for the next matcher, or the real request.
+		if b, _ := ioutil.ReadAll(req.Body); string(b) != tc.body {
+			t.Errorf("Expected the body to be readable again, got: %s", b)
+		}
+	}
+}
+
+func cassetteRequest(method, url string) cassette.Request {
+	return cassette.Request{Method: method, URL: url}
+}
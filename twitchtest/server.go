@@ -0,0 +1,378 @@
+// Package twitchtest provides an in-memory fake of the Twitch API for tests,
+// in the spirit of net/http/httptest. A Server answers the Helix and Kraken
+// endpoints backed by users, channels, streams, clips and games from a
+// seedable State, and records every request so tests can assert on them.
+//
+// The rest of the Helix endpoints the library wraps are backed by the State
+// as well: moderators, bans, VIPs, blocked terms and AutoMod; chat settings,
+// messages, announcements, Shoutouts, chatters and colors; emotes, badges,
+// cheermotes, subscriptions and Bits; custom rewards and their redemptions,
+// polls and predictions; markers, commercials, ads and raids; editors, teams
+// and Hype Trains; and schedule segments and vacations. Writes change the
+// State, so a test can call a write endpoint and read the result back, or
+// check Server.State.
+//
+//	s := twitchtest.NewServer(&twitchtest.State{
+//		Users:   []*twitchtest.User{{Id: "1", Login: "catsby"}},
+//		Streams: []*twitchtest.Stream{{Id: "10", UserId: "1"}},
+//	})
+//	defer s.Close()
+//
+//	client, err := helix.NewClient(s.HelixConfig())
+//
+// Handle overrides the response of an endpoint. Faults make the server
+// answer with an error, such as a 429 when rate limited.
+//
+// NewRecorder records and replays go-vcr fixtures of the real API, with
+// secrets redacted.
+package twitchtest
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/catsby/go-twitch/twitch"
+)
+
+// Default credentials of a Server.
+const (
+	DefaultAccessToken = "twitchtest-token"
+	DefaultClientId    = "twitchtest-client"
+)
+
+// RateLimit is the Ratelimit-Limit header of Helix responses.
+const RateLimit = 800
+
+// Server is a fake Twitch API server.
+type Server struct {
+	// URL is the base URL of the server, Ex: http://127.0.0.1:54321.
+	URL string
+
+	// AccessToken and ClientId are the credentials the server accepts.
+	// Requests with another access token get a 401.
+	AccessToken string
+	ClientId    string
+
+	server *httptest.Server
+
+	mu       sync.Mutex
+	state    *State
+	routes   []*route
+	faults   []*Fault
+	requests []*Request
+}
+
+// NewServer starts a Server answering from the given State, which may be nil.
+// The caller should call Close when finished.
+func NewServer(st *State) *Server {
+	if st == nil {
+		st = new(State)
+	}
+
+	s := &Server{
+		AccessToken: DefaultAccessToken,
+		ClientId:    DefaultClientId,
+		state:       st,
+	}
+	s.helixRoutes()
+	s.krakenRoutes()
+
+	s.server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	s.URL = s.server.URL
+
+	return s
+}
+
+// Close shuts down the server.
+func (s *Server) Close() {
+	s.server.Close()
+}
+
+// HelixConfig returns a config for helix.NewClient that talks to the server.
+func (s *Server) HelixConfig() *twitch.Config {
+	return s.config("/helix/")
+}
+
+// KrakenConfig returns a config for kraken.NewClient that talks to the server.
+func (s *Server) KrakenConfig() *twitch.Config {
+	return s.config("/kraken/")
+}
+
+func (s *Server) config(path string) *twitch.Config {
+	return &twitch.Config{
+		Endpoint:    s.URL + path,
+		AccessToken: s.AccessToken,
+		ClientId:    s.ClientId,
+		HTTPClient:  s.server.Client(),
+	}
+}
+
+// Update calls f with the server's State, so it can be changed between
+// requests, Ex: to take a stream offline.
+func (s *Server) Update(f func(st *State)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	f(s.state)
+}
+
+// HandlerFunc answers a request to a route added with Handle. Params holds the
+// values of the route's :name segments.
+type HandlerFunc func(w http.ResponseWriter, r *http.Request, params map[string]string)
+
+// Handle answers requests matching method and pattern with h, taking
+// precedence over the built in endpoints. Patterns are full paths where a
+// segment starting with a colon matches any value, Ex: /kraken/channels/:id.
+// The State is locked while h runs; use State to read it.
+func (s *Server) Handle(method, pattern string, h HandlerFunc) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.routes = append([]*route{{method, split(pattern), h}}, s.routes...)
+}
+
+// State returns the server's State. It must only be used inside a HandlerFunc,
+// or another time the server is not answering requests.
+func (s *Server) State() *State {
+	return s.state
+}
+
+// Fault makes the server answer matching requests with an error instead.
+type Fault struct {
+	// Method and Path limit the fault to matching requests, Ex: "GET" and
+	// "/helix/streams". Empty values match every request.
+	Method string
+	Path   string
+
+	// Status is the HTTP status code, Ex: 429, 500 or 401.
+	Status int
+
+	// Message defaults to a message like Twitch's for the Status.
+	Message string
+
+	// Times is the number of requests that fail before the fault is
+	// removed. Zero fails every request until ClearFaults is called.
+	Times int
+}
+
+// Inject adds a fault. Faults are checked in the order they were added.
+func (s *Server) Inject(f *Fault) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.faults = append(s.faults, f)
+}
+
+// FailNext makes the next request fail with the given status.
+func (s *Server) FailNext(status int) {
+	s.Inject(&Fault{Status: status, Times: 1})
+}
+
+// ClearFaults removes every fault.
+func (s *Server) ClearFaults() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.faults = nil
+}
+
+// Request is a request the server received.
+type Request struct {
+	Method string
+	Path   string
+	Query  url.Values
+	Header http.Header
+	Body   []byte
+
+	// Status is the status code the server answered with.
+	Status int
+}
+
+// Requests returns every request received, oldest first.
+func (s *Server) Requests() []*Request {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	out := make([]*Request, len(s.requests))
+	copy(out, s.requests)
+	return out
+}
+
+// Requested returns the requests received with the given method and path,
+// oldest first.
+func (s *Server) Requested(method, path string) []*Request {
+	var out []*Request
+	for _, r := range s.Requests() {
+		if r.Method == method && r.Path == path {
+			out = append(out, r)
+		}
+	}
+	return out
+}
+
+// ClearRequests forgets the requests received so far.
+func (s *Server) ClearRequests() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.requests = nil
+}
+
+// AssertRequested fails the test unless the server received n requests with
+// the given method and path, and returns the last one, if any.
+func (s *Server) AssertRequested(t testing.TB, method, path string, n int) *Request {
+	t.Helper()
+	reqs := s.Requested(method, path)
+	if len(reqs) != n {
+		t.Errorf("twitchtest: expected %d %s %s requests, got %d", n, method, path, len(reqs))
+	}
+	if len(reqs) == 0 {
+		return nil
+	}
+	return reqs[len(reqs)-1]
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	body, _ := ioutil.ReadAll(r.Body)
+	rw := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+
+	s.mu.Lock()
+	req := &Request{
+		Method: r.Method,
+		Path:   r.URL.Path,
+		Query:  r.URL.Query(),
+		Header: r.Header,
+		Body:   body,
+	}
+	s.requests = append(s.requests, req)
+	defer func() {
+		req.Status = rw.status
+		s.mu.Unlock()
+	}()
+
+	r.Body = ioutil.NopCloser(bytes.NewReader(body))
+
+	helix := strings.HasPrefix(r.URL.Path, "/helix/")
+	if helix {
+		rw.Header().Set("Ratelimit-Limit", strconv.Itoa(RateLimit))
+		rw.Header().Set("Ratelimit-Remaining", strconv.Itoa(RateLimit-1))
+		rw.Header().Set("Ratelimit-Reset", strconv.FormatInt(time.Now().Add(time.Minute).Unix(), 10))
+	}
+
+	if f := s.fault(r); f != nil {
+		if f.Status == http.StatusTooManyRequests {
+			rw.Header().Set("Ratelimit-Remaining", "0")
+		}
+		WriteError(rw, f.Status, f.Message)
+		return
+	}
+
+	scheme := "OAuth "
+	if helix {
+		scheme = "Bearer "
+	}
+	if r.Header.Get("Authorization") != scheme+s.AccessToken {
+		WriteError(rw, http.StatusUnauthorized, "Invalid OAuth token")
+		return
+	}
+
+	segments := split(r.URL.Path)
+	for _, rt := range s.routes {
+		if params, ok := rt.match(r.Method, segments); ok {
+			rt.handler(rw, r, params)
+			return
+		}
+	}
+	WriteError(rw, http.StatusNotFound, "")
+}
+
+// fault returns the first fault matching the request, using up one of its
+// Times.
+func (s *Server) fault(r *http.Request) *Fault {
+	for i, f := range s.faults {
+		if f.Method != "" && f.Method != r.Method {
+			continue
+		}
+		if f.Path != "" && f.Path != r.URL.Path {
+			continue
+		}
+
+		if f.Times > 0 {
+			f.Times--
+			if f.Times == 0 {
+				s.faults = append(s.faults[:i:i], s.faults[i+1:]...)
+			}
+		}
+		return f
+	}
+	return nil
+}
+
+// statusRecorder keeps the status code written to a response.
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+func (r *statusRecorder) WriteHeader(status int) {
+	r.status = status
+	r.ResponseWriter.WriteHeader(status)
+}
+
+type route struct {
+	method   string
+	segments []string
+	handler  HandlerFunc
+}
+
+func (rt *route) match(method string, segments []string) (map[string]string, bool) {
+	if rt.method != method || len(rt.segments) != len(segments) {
+		return nil, false
+	}
+	params := make(map[string]string)
+	for i, seg := range rt.segments {
+		if strings.HasPrefix(seg, ":") {
+			params[seg[1:]] = segments[i]
+		} else if seg != segments[i] {
+			return nil, false
+		}
+	}
+	return params, true
+}
+
+func (s *Server) route(method, pattern string, h HandlerFunc) {
+	s.routes = append(s.routes, &route{method, split(pattern), h})
+}
+
+func split(path string) []string {
+	return strings.Split(strings.Trim(path, "/"), "/")
+}
+
+// WriteJSON writes v as a JSON response with the given status.
+func WriteJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+// WriteError writes an error response the way Twitch does. An empty message
+// uses the status text.
+func WriteError(w http.ResponseWriter, status int, message string) {
+	if message == "" {
+		message = http.StatusText(status)
+	}
+	WriteJSON(w, status, map[string]interface{}{
+		"error":   http.StatusText(status),
+		"status":  status,
+		"message": message,
+	})
+}
+
+// badRequest writes a 400 with a formatted message.
+func badRequest(w http.ResponseWriter, format string, a ...interface{}) {
+	WriteError(w, http.StatusBadRequest, fmt.Sprintf(format, a...))
+}
@@ -0,0 +1,122 @@
+package twitchtest_test
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/catsby/go-twitch/service/helix"
+	"github.com/catsby/go-twitch/twitch"
+	"github.com/catsby/go-twitch/twitchtest"
+)
+
+func TestServer_faults(t *testing.T) {
+	t.Parallel()
+
+	s := twitchtest.NewServer(seed())
+	defer s.Close()
+
+	c, err := helix.NewClient(s.HelixConfig())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, status := range []int{429, 500, 401} {
+		s.FailNext(status)
+		_, err = c.GetStreams(nil)
+
+		var httpErr *twitch.HTTPError
+		if !errors.As(err, &httpErr) || httpErr.StatusCode != status {
+			t.Fatalf("Expected a %d, got (%v)", status, err)
+		}
+	}
+
+	if _, err := c.GetStreams(nil); err != nil {
+		t.Fatalf("Expected the faults to be used up, got (%v)", err)
+	}
+
+	s.Inject(&twitchtest.Fault{
+		Method:  "GET",
+		Path:    "/helix/games",
+		Status:  http.StatusServiceUnavailable,
+		Message: "Try again later",
+	})
+	for n := 0; n < 2; n++ {
+		_, err = c.GetGames(&helix.GetGamesInput{Ids: []int{33214}})
+		var httpErr *twitch.HTTPError
+		if !errors.As(err, &httpErr) || httpErr.Message != "Try again later" {
+			t.Fatalf("Expected the injected error, got (%v)", err)
+		}
+	}
+	if _, err := c.GetStreams(nil); err != nil {
+		t.Fatalf("Expected only games to fail, got (%v)", err)
+	}
+
+	s.ClearFaults()
+	if _, err = c.GetGames(&helix.GetGamesInput{Ids: []int{33214}}); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestServer_unauthorized(t *testing.T) {
+	t.Parallel()
+
+	s := twitchtest.NewServer(nil)
+	defer s.Close()
+
+	config := s.HelixConfig()
+	config.AccessToken = "wrong"
+	c, err := helix.NewClient(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = c.GetStreams(nil)
+	var httpErr *twitch.HTTPError
+	if !errors.As(err, &httpErr) || httpErr.StatusCode != 401 {
+		t.Fatalf("Expected a 401, got (%v)", err)
+	}
+}
+
+func TestServer_Handle(t *testing.T) {
+	t.Parallel()
+
+	s := twitchtest.NewServer(seed())
+	defer s.Close()
+
+	s.Handle("POST", "/helix/raids", func(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+		if r.URL.Query().Get("to_broadcaster_id") == "2" {
+			twitchtest.WriteError(w, 400, "The targeted channel's settings prevent you from raiding them.")
+			return
+		}
+		twitchtest.WriteJSON(w, 200, map[string]interface{}{
+			"data": []map[string]interface{}{{"created_at": "2022-02-18T07:20:50.52Z", "is_mature": false}},
+		})
+	})
+
+	c, err := helix.NewClient(s.HelixConfig())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := c.StartRaid(&helix.StartRaidInput{FromBroadcasterId: "1", ToBroadcasterId: "3"}); err != nil {
+		t.Fatal(err)
+	}
+	_, err = c.StartRaid(&helix.StartRaidInput{FromBroadcasterId: "1", ToBroadcasterId: "2"})
+	if !errors.Is(err, helix.ErrRaidNotAllowed) {
+		t.Fatalf("Expected ErrRaidNotAllowed, got (%v)", err)
+	}
+
+	r := s.AssertRequested(t, "POST", "/helix/raids", 2)
+	if r.Status != 400 || r.Query.Get("from_broadcaster_id") != "1" {
+		t.Fatalf("Bad request: %#v", r)
+	}
+	if r.Header.Get("Client-Id") != twitchtest.DefaultClientId {
+		t.Fatalf("Bad Client-Id: %q", r.Header.Get("Client-Id"))
+	}
+
+	s.ClearRequests()
+	if n := len(s.Requests()); n != 0 {
+		t.Fatalf("Expected no requests, got (%d)", n)
+	}
+}
@@ -0,0 +1,628 @@
+package twitchtest
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// State is the data the fake server answers from. Seed it before passing it
+// to NewServer, or change it later through Server.Update. IDs are strings, as
+// in Helix; Kraken responses use them as numbers, so seed numeric IDs for
+// endpoints served through the Kraken client.
+type State struct {
+	// AuthUserId is the ID of the user the access token belongs to, used by
+	// endpoints such as Kraken's /user and /streams/followed.
+	AuthUserId string
+
+	Users    []*User
+	Channels []*Channel
+	Streams  []*Stream
+	Clips    []*Clip
+	Games    []*Game
+	Follows  []*Follow
+
+	// The moderation, Channel Points, poll, prediction and schedule data of
+	// the Helix endpoints. The write endpoints change them, so tests can
+	// check the State after a call, or seed it to check the reads.
+	Bans         []*Ban
+	VIPs         []*VIP
+	BlockedTerms []*BlockedTerm
+	Polls        []*Poll
+	Predictions  []*Prediction
+	Rewards      []*Reward
+	Redemptions  []*Redemption
+	Segments     []*Segment
+	Vacations    []*Vacation
+
+	// The moderator, AutoMod and chat data of the Helix endpoints.
+	Moderators      []*Moderator
+	AutoModSettings []*AutoModSettings
+	HeldMessages    []*HeldMessage
+	ChatSettings    []*ChatSettings
+	ChatMessages    []*ChatMessage
+	Announcements   []*Announcement
+	Shoutouts       []*Shoutout
+	Chatters        []*Chatter
+	Emotes          []*Emote
+	Badges          []*Badge
+	Cheermotes      []*Cheermote
+
+	// The subscription, Bits, stream marker, ad, raid, editor, team and
+	// Hype Train data of the Helix endpoints.
+	Subscriptions []*Subscription
+	Cheers        []*Cheer
+	Markers       []*Marker
+	AdSchedules   []*AdSchedule
+	Raids         []*Raid
+	Editors       []*Editor
+	Teams         []*Team
+	HypeTrains    []*HypeTrain
+}
+
+// User is a Twitch user.
+type User struct {
+	Id          string
+	Login       string
+	DisplayName string
+
+	// Type is "staff", "admin", "global_mod" or empty.
+	Type            string
+	BroadcasterType string
+	Description     string
+	ProfileImageURL string
+	CreatedAt       time.Time
+
+	// ChatColor is the user's name color in chat, as #RRGGBB, or empty if
+	// they never chose one.
+	ChatColor string
+}
+
+// Channel is the channel of the user with the ID BroadcasterId. A user
+// without a Channel has an empty one.
+type Channel struct {
+	BroadcasterId string
+	Title         string
+	GameId        string
+	Language      string
+	Mature        bool
+	Views         int
+	UpdatedAt     time.Time
+}
+
+// Stream is a live stream of the user with the ID UserId.
+type Stream struct {
+	Id          string
+	UserId      string
+	GameId      string
+	Title       string
+	ViewerCount int
+	StartedAt   time.Time
+	Language    string
+	Tags        []string
+	IsMature    bool
+}
+
+// Clip is a clip of the channel with the ID BroadcasterId. Id is the slug.
+type Clip struct {
+	Id            string
+	BroadcasterId string
+	CreatorId     string
+	VideoId       string
+	GameId        string
+	Title         string
+	Language      string
+	ViewCount     int
+	CreatedAt     time.Time
+
+	// Duration is in seconds.
+	Duration float64
+}
+
+// Game is a game or category.
+type Game struct {
+	Id        string
+	Name      string
+	BoxArtURL string
+}
+
+// Follow is a user following a channel.
+type Follow struct {
+	UserId        string
+	BroadcasterId string
+	FollowedAt    time.Time
+}
+
+// Ban is a user banned or timed out in the channel with the ID
+// BroadcasterId.
+type Ban struct {
+	BroadcasterId string
+	UserId        string
+	ModeratorId   string
+	Reason        string
+	CreatedAt     time.Time
+
+	// ExpiresAt is when a timeout ends, and the zero time for a ban. Expired
+	// timeouts are ignored.
+	ExpiresAt time.Time
+}
+
+// VIP is a VIP of the channel with the ID BroadcasterId.
+type VIP struct {
+	BroadcasterId string
+	UserId        string
+}
+
+// BlockedTerm is a term blocked in the chat of the channel with the ID
+// BroadcasterId.
+type BlockedTerm struct {
+	Id            string
+	BroadcasterId string
+	ModeratorId   string
+	Text          string
+	CreatedAt     time.Time
+}
+
+// Poll is a poll in the channel with the ID BroadcasterId. An ACTIVE poll is
+// COMPLETED once its Duration has passed.
+type Poll struct {
+	Id            string
+	BroadcasterId string
+	Title         string
+	Choices       []*PollChoice
+
+	// Status defaults to ACTIVE.
+	Status               string
+	Duration             time.Duration
+	ChannelPointsPerVote int
+	StartedAt            time.Time
+	EndedAt              time.Time
+}
+
+// PollChoice is a choice of a Poll.
+type PollChoice struct {
+	Id    string
+	Title string
+	Votes int
+}
+
+// Prediction is a prediction in the channel with the ID BroadcasterId. An
+// ACTIVE prediction is LOCKED once its PredictionWindow has passed.
+type Prediction struct {
+	Id               string
+	BroadcasterId    string
+	Title            string
+	Outcomes         []*PredictionOutcome
+	WinningOutcomeId string
+
+	// Status defaults to ACTIVE.
+	Status           string
+	PredictionWindow time.Duration
+	CreatedAt        time.Time
+	LockedAt         time.Time
+	EndedAt          time.Time
+}
+
+// PredictionOutcome is an outcome of a Prediction.
+type PredictionOutcome struct {
+	Id            string
+	Title         string
+	Users         int
+	ChannelPoints int
+}
+
+// Reward is a Channel Points reward of the channel with the ID
+// BroadcasterId.
+type Reward struct {
+	Id              string
+	BroadcasterId   string
+	Title           string
+	Prompt          string
+	Cost            int
+	BackgroundColor string
+
+	// IsDisabled is the inverse of the API's is_enabled, so a seeded
+	// Reward is enabled.
+	IsDisabled          bool
+	IsPaused            bool
+	IsUserInputRequired bool
+
+	// Limits are disabled when zero.
+	MaxPerStream        int
+	MaxPerUserPerStream int
+	GlobalCooldown      time.Duration
+
+	ShouldRedemptionsSkipRequestQueue bool
+
+	// ClientId is the client that created the reward. Only rewards of the
+	// server's ClientId can be updated, deleted or have their redemptions
+	// managed; rewards created through the server get it.
+	ClientId string
+}
+
+// Redemption is a redemption of the Reward with the ID RewardId.
+type Redemption struct {
+	Id            string
+	BroadcasterId string
+	RewardId      string
+	UserId        string
+	UserInput     string
+
+	// Status defaults to UNFULFILLED.
+	Status     string
+	RedeemedAt time.Time
+}
+
+// Segment is a broadcast in the stream schedule of the channel with the ID
+// BroadcasterId. Recurring segments are listed once per week, for
+// ScheduleWeeks weeks, each occurrence with its own ID.
+type Segment struct {
+	Id            string
+	BroadcasterId string
+	StartTime     time.Time
+	Duration      time.Duration
+	Title         string
+	CategoryId    string
+	IsRecurring   bool
+
+	// Timezone is the IANA time zone that keeps the occurrences of a
+	// recurring segment at the same local time. Default: UTC.
+	Timezone string
+
+	// Canceled holds the start times of the canceled occurrences.
+	Canceled []time.Time
+}
+
+// Vacation pauses the stream schedule of the channel with the ID
+// BroadcasterId.
+type Vacation struct {
+	BroadcasterId string
+	StartTime     time.Time
+	EndTime       time.Time
+}
+
+// Moderator is a moderator of the channel with the ID BroadcasterId.
+type Moderator struct {
+	BroadcasterId string
+	UserId        string
+}
+
+// AutoModSettings are the AutoMod levels of the channel with the ID
+// BroadcasterId, from 0 to 4. A channel without AutoModSettings has every
+// level at 0.
+type AutoModSettings struct {
+	BroadcasterId string
+
+	// OverallLevel is set when the levels were set together, and nil when
+	// they were set one by one.
+	OverallLevel *int
+
+	Disability              int
+	Aggression              int
+	SexualitySexOrGender    int
+	Misogyny                int
+	Bullying                int
+	Swearing                int
+	RaceEthnicityOrReligion int
+	SexBasedTerms           int
+}
+
+// HeldMessage is a chat message AutoMod held for review in the channel with
+// the ID BroadcasterId.
+type HeldMessage struct {
+	Id            string
+	BroadcasterId string
+	UserId        string
+	Text          string
+
+	// Status is empty while the message is held, then ALLOW or DENY.
+	Status string
+}
+
+// ChatSettings are the chat modes of the channel with the ID BroadcasterId.
+// A channel without ChatSettings has every mode off.
+type ChatSettings struct {
+	BroadcasterId string
+
+	SlowMode         bool
+	SlowModeWaitTime time.Duration
+
+	FollowerMode         bool
+	FollowerModeDuration time.Duration
+
+	SubscriberMode bool
+	EmoteMode      bool
+	UniqueChatMode bool
+
+	NonModeratorChatDelay         bool
+	NonModeratorChatDelayDuration time.Duration
+}
+
+// ChatMessage is a message sent to the chat of the channel with the ID
+// BroadcasterId through the API.
+type ChatMessage struct {
+	Id                   string
+	BroadcasterId        string
+	SenderId             string
+	Message              string
+	ReplyParentMessageId string
+	SentAt               time.Time
+}
+
+// Announcement is an announcement in the chat of the channel with the ID
+// BroadcasterId.
+type Announcement struct {
+	BroadcasterId string
+	ModeratorId   string
+	Message       string
+
+	// Color defaults to primary.
+	Color string
+}
+
+// Shoutout is a Shoutout of the channel with the ID ToBroadcasterId in the
+// channel with the ID FromBroadcasterId.
+type Shoutout struct {
+	FromBroadcasterId string
+	ToBroadcasterId   string
+	ModeratorId       string
+	CreatedAt         time.Time
+}
+
+// Chatter is a user in the chat of the channel with the ID BroadcasterId.
+type Chatter struct {
+	BroadcasterId string
+	UserId        string
+}
+
+// Emote is a global emote, or an emote of the channel with the ID OwnerId.
+type Emote struct {
+	Id         string
+	Name       string
+	EmoteSetId string
+	OwnerId    string
+
+	// EmoteType is globals, follower, subscriptions or bitstier. Default:
+	// globals without an OwnerId, subscriptions with one.
+	EmoteType string
+
+	// Tier is the subscription tier that unlocks a subscriptions emote.
+	// Default: 1000.
+	Tier     string
+	Animated bool
+}
+
+// Badge is a version of a global chat badge, or of a badge of the channel
+// with the ID BroadcasterId.
+type Badge struct {
+	SetId         string
+	Id            string
+	BroadcasterId string
+	Title         string
+	Description   string
+	ClickAction   string
+	ClickURL      string
+}
+
+// Cheermote is a global Cheermote, or a custom one of the channel with the ID
+// BroadcasterId.
+type Cheermote struct {
+	Prefix        string
+	BroadcasterId string
+
+	// Tiers are the minimum Bits of each tier. Default: 1, 100, 1000, 5000
+	// and 10000.
+	Tiers        []int
+	Order        int
+	IsCharitable bool
+	LastUpdated  time.Time
+}
+
+// Subscription is a subscription of the user with the ID UserId to the
+// channel with the ID BroadcasterId.
+type Subscription struct {
+	BroadcasterId string
+	UserId        string
+
+	// Tier is 1000, 2000 or 3000. Default: 1000.
+	Tier     string
+	PlanName string
+
+	// GifterId is the user who gifted the subscription, if it is a gift.
+	GifterId string
+}
+
+// Cheer is Bits cheered by the user with the ID UserId in the channel with
+// the ID BroadcasterId. The Bits leaderboard of the channel of the State's
+// AuthUserId adds them up.
+type Cheer struct {
+	BroadcasterId string
+	UserId        string
+	Bits          int
+	CheeredAt     time.Time
+}
+
+// Marker is a stream marker of the user with the ID UserId. Markers created
+// through the server are in the video with the ID of the live Stream.
+type Marker struct {
+	Id          string
+	UserId      string
+	VideoId     string
+	Description string
+	CreatedAt   time.Time
+
+	// Position is how far into the video the marker is.
+	Position time.Duration
+}
+
+// AdSchedule is when ads run on the channel with the ID BroadcasterId. A
+// channel without an AdSchedule has no ad scheduled and no snoozes.
+type AdSchedule struct {
+	BroadcasterId   string
+	NextAdAt        time.Time
+	LastAdAt        time.Time
+	Duration        time.Duration
+	PrerollFreeTime time.Duration
+	SnoozeCount     int
+	SnoozeRefreshAt time.Time
+}
+
+// Raid is a pending raid of the channel with the ID ToBroadcasterId by the
+// channel with the ID FromBroadcasterId.
+type Raid struct {
+	FromBroadcasterId string
+	ToBroadcasterId   string
+	CreatedAt         time.Time
+}
+
+// Editor is an editor of the channel with the ID BroadcasterId.
+type Editor struct {
+	BroadcasterId string
+	UserId        string
+	CreatedAt     time.Time
+}
+
+// Team is a team of the users with the IDs UserIds.
+type Team struct {
+	Id                 string
+	Name               string
+	DisplayName        string
+	Info               string
+	ThumbnailURL       string
+	BackgroundImageURL string
+	Banner             string
+	CreatedAt          time.Time
+	UpdatedAt          time.Time
+	UserIds            []string
+}
+
+// HypeTrain is a Hype Train in the channel with the ID BroadcasterId. Its
+// event is the progression after its last contribution.
+type HypeTrain struct {
+	Id              string
+	BroadcasterId   string
+	Level           int
+	Goal            int
+	Contributions   []*HypeTrainContribution
+	StartedAt       time.Time
+	ExpiresAt       time.Time
+	CooldownEndTime time.Time
+}
+
+// HypeTrainContribution is a contribution of the user with the ID UserId to
+// a HypeTrain.
+type HypeTrainContribution struct {
+	UserId string
+
+	// Type is BITS, SUBS or OTHER.
+	Type  string
+	Total int
+}
+
+func (st *State) user(id string) *User {
+	for _, u := range st.Users {
+		if u.Id == id {
+			return u
+		}
+	}
+	return nil
+}
+
+func (st *State) userByLogin(login string) *User {
+	for _, u := range st.Users {
+		if strings.EqualFold(u.Login, login) {
+			return u
+		}
+	}
+	return nil
+}
+
+func (st *State) channel(id string) *Channel {
+	for _, c := range st.Channels {
+		if c.BroadcasterId == id {
+			return c
+		}
+	}
+	return &Channel{BroadcasterId: id}
+}
+
+func (st *State) stream(userId string) *Stream {
+	for _, s := range st.Streams {
+		if s.UserId == userId {
+			return s
+		}
+	}
+	return nil
+}
+
+func (st *State) game(id string) *Game {
+	for _, g := range st.Games {
+		if g.Id == id {
+			return g
+		}
+	}
+	return nil
+}
+
+func (st *State) gameByName(name string) *Game {
+	for _, g := range st.Games {
+		if strings.EqualFold(g.Name, name) {
+			return g
+		}
+	}
+	return nil
+}
+
+func (st *State) gameName(id string) string {
+	if g := st.game(id); g != nil {
+		return g.Name
+	}
+	return ""
+}
+
+func (st *State) followers(broadcasterId string) []*Follow {
+	var out []*Follow
+	for _, f := range st.Follows {
+		if f.BroadcasterId == broadcasterId {
+			out = append(out, f)
+		}
+	}
+	return out
+}
+
+func (st *State) following(userId string) []*Follow {
+	var out []*Follow
+	for _, f := range st.Follows {
+		if f.UserId == userId {
+			out = append(out, f)
+		}
+	}
+	return out
+}
+
+// liveStreams returns the streams sorted by viewers, most first, the order
+// both APIs list them in.
+func (st *State) liveStreams() []*Stream {
+	out := make([]*Stream, len(st.Streams))
+	copy(out, st.Streams)
+	sort.SliceStable(out, func(i, j int) bool {
+		return out[i].ViewerCount > out[j].ViewerCount
+	})
+	return out
+}
+
+// krakenId converts a string ID to the number Kraken uses, or 0.
+func krakenId(id string) int {
+	n, _ := strconv.Atoi(id)
+	return n
+}
+
+// timeString formats a time the way Twitch does, or returns an empty string
+// for the zero time.
+func timeString(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}